@@ -0,0 +1,481 @@
+// hexconv converts firmware images between hex formats, raw binaries and
+// ELF loadable segments.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/qeedquan/go-media/debug/elfutil"
+	"github.com/qeedquan/go-media/debug/extekhex"
+	"github.com/qeedquan/go-media/debug/ihex"
+	"github.com/qeedquan/go-media/debug/memmap"
+	"github.com/qeedquan/go-media/debug/srec"
+	"github.com/qeedquan/go-media/debug/ti/tagged"
+	"github.com/qeedquan/go-media/math/mathutil"
+)
+
+type Image struct {
+	Format   string
+	Map      *memmap.Map
+	Start    uint64
+	HasStart bool
+}
+
+var (
+	iformat  = flag.String("i", "", "input format: srec, ihex, extekhex, tagged, bin, elf (default: detect)")
+	oformat  = flag.String("o", "", "output format: srec, ihex, extekhex, tagged, bin (default: from output extension)")
+	info     = flag.Bool("info", false, "list the address ranges and start address")
+	base     = flag.String("base", "0", "load address of binary input")
+	vaddr    = flag.Bool("vaddr", false, "use virtual instead of physical addresses of ELF segments")
+	offset   = flag.String("offset", "", "add a signed offset to every address")
+	relocate = flag.String("relocate", "", "move the image so it starts at this address")
+	crop     = flag.String("crop", "", "keep only the address range start:end")
+	fill     = flag.String("fill", "", "fill the gaps in the range start:end")
+	fillbyte = flag.String("fillbyte", "0xff", "byte used for gaps")
+	crcaddr  = flag.String("crc", "", "insert a checksum at this address")
+	crctype  = flag.String("crctype", "crc32", "checksum type: crc32, crc32c, crc16, sum8, sum16, sum32")
+	crcrange = flag.String("crcrange", "", "range start:end covered by the checksum (default: image start to checksum address)")
+	bigend   = flag.Bool("be", false, "store the checksum big endian")
+	start    = flag.String("start", "", "override the start address")
+	reclen   = flag.Int("reclen", 0, "data bytes per record for srec, ihex and tagged output")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("hexconv: ")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() < 1 || (!*info && flag.NArg() < 2 && *oformat == "") {
+		usage()
+	}
+
+	m, err := load(flag.Arg(0))
+	ck(err)
+	ck(transform(m))
+
+	if *info {
+		printInfo(os.Stdout, m)
+		if flag.NArg() < 2 {
+			return
+		}
+	}
+
+	output := flag.Arg(1)
+	format := *oformat
+	if format == "" {
+		format = formatFromExt(output)
+		if format == "" {
+			log.Fatalf("can't determine output format of %q, use -o", output)
+		}
+	}
+	switch format {
+	case "srec", "ihex", "extekhex", "tagged", "bin":
+	default:
+		// elf is only supported as input
+		log.Fatalf("unsupported output format %q", format)
+	}
+
+	w := io.Writer(os.Stdout)
+	if output != "" && output != "-" {
+		f, err := os.Create(output)
+		ck(err)
+		defer func() {
+			ck(f.Close())
+		}()
+		w = f
+	}
+	ck(save(w, format, m))
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: hexconv [options] input [output]")
+	fmt.Fprintln(os.Stderr, "output is written to stdout when it is omitted or -")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func ck(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseAddr(s string) (uint64, error) {
+	v, err := strconv.ParseUint(s, 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid address %q", s)
+	}
+	return v, nil
+}
+
+func parseRange(s string) (memmap.Range, error) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return memmap.Range{}, fmt.Errorf("invalid range %q, expected start:end", s)
+	}
+	a, err := parseAddr(s[:i])
+	if err != nil {
+		return memmap.Range{}, err
+	}
+	b, err := parseAddr(s[i+1:])
+	if err != nil {
+		return memmap.Range{}, err
+	}
+	if b < a {
+		return memmap.Range{}, fmt.Errorf("invalid range %q, end before start", s)
+	}
+	return memmap.Range{Start: a, End: b}, nil
+}
+
+func formatFromExt(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".srec", ".s19", ".s28", ".s37", ".mot", ".mhx":
+		return "srec"
+	case ".hex", ".ihex", ".ihx", ".h86":
+		return "ihex"
+	case ".tek", ".xtek", ".tex":
+		return "extekhex"
+	case ".tag", ".tagged":
+		return "tagged"
+	case ".bin", ".img", ".raw":
+		return "bin"
+	}
+	return ""
+}
+
+func detect(b []byte) string {
+	if bytes.HasPrefix(b, []byte(elf.ELFMAG)) {
+		return "elf"
+	}
+	t := bytes.TrimLeft(b, " \t\r\n")
+	if len(t) == 0 {
+		return "bin"
+	}
+	for _, c := range t[:mathutil.Min(len(t), 256)] {
+		if c >= 0x80 || (c < 0x20 && c != '\r' && c != '\n' && c != '\t') {
+			return "bin"
+		}
+	}
+	switch t[0] {
+	case 'S':
+		return "srec"
+	case ':':
+		return "ihex"
+	case '%':
+		return "extekhex"
+	case 'K', '9', '0':
+		return "tagged"
+	}
+	return "bin"
+}
+
+func load(name string) (*Image, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	format := *iformat
+	if format == "" {
+		format = detect(b)
+	}
+
+	m := &Image{Format: format}
+	r := bytes.NewReader(b)
+	switch format {
+	case "srec":
+		var f *srec.File
+		if f, err = srec.Decode(r); err == nil {
+			m.Start, m.HasStart = f.Start, f.Start != 0
+			m.Map, err = f.Map()
+		}
+	case "ihex":
+		var f *ihex.File
+		if f, err = ihex.Decode(r); err == nil {
+			m.Start, m.HasStart = uint64(f.Start), f.HasStart
+			m.Map, err = f.Map()
+		}
+	case "extekhex":
+		var f *extekhex.File
+		if f, err = extekhex.Decode(r); err == nil {
+			m.Start, m.HasStart = f.Start, f.Start != 0
+			m.Map, err = f.Map()
+		}
+	case "tagged":
+		var f *tagged.File
+		if f, err = tagged.Decode(r); err == nil {
+			m.Map, err = f.Map()
+		}
+	case "bin":
+		var addr uint64
+		if addr, err = parseAddr(*base); err == nil {
+			m.Map = memmap.FromBinary(addr, b)
+		}
+	case "elf":
+		m.Map, m.Start, err = loadELF(r)
+		m.HasStart = true
+	default:
+		return nil, fmt.Errorf("unknown input format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return m, nil
+}
+
+func loadELF(r io.ReaderAt) (*memmap.Map, uint64, error) {
+	f, err := elfutil.NewFile(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	m := &memmap.Map{}
+	for _, p := range f.Progs {
+		if p.Type != elf.PT_LOAD || len(p.Data) == 0 {
+			continue
+		}
+		addr := p.Paddr
+		if *vaddr {
+			addr = p.Vaddr
+		}
+		if err := m.Add(addr, p.Data); err != nil {
+			return nil, 0, err
+		}
+	}
+	return m, f.Entry, nil
+}
+
+func transform(m *Image) error {
+	fb, err := parseAddr(*fillbyte)
+	if err != nil || fb > 0xff {
+		return fmt.Errorf("invalid fill byte %q", *fillbyte)
+	}
+	m.Map.Fill = byte(fb)
+
+	if *offset != "" {
+		delta, err := strconv.ParseInt(*offset, 0, 64)
+		if err != nil {
+			return fmt.Errorf("invalid offset %q", *offset)
+		}
+		if err := m.Map.Offset(delta); err != nil {
+			return err
+		}
+		if m.HasStart {
+			m.Start += uint64(delta)
+		}
+	}
+
+	if *relocate != "" {
+		addr, err := parseAddr(*relocate)
+		if err != nil {
+			return err
+		}
+		delta := int64(addr - m.Map.Start())
+		if err := m.Map.Offset(delta); err != nil {
+			return err
+		}
+		if m.HasStart {
+			m.Start += uint64(delta)
+		}
+	}
+
+	if *crop != "" {
+		r, err := parseRange(*crop)
+		if err != nil {
+			return err
+		}
+		m.Map.Crop(r.Start, r.End)
+	}
+
+	if *fill != "" {
+		r, err := parseRange(*fill)
+		if err != nil {
+			return err
+		}
+		m.Map.FillGaps(r.Start, r.End)
+	}
+
+	if *crcaddr != "" {
+		if err := insertChecksum(m.Map); err != nil {
+			return err
+		}
+	}
+
+	if *start != "" {
+		addr, err := parseAddr(*start)
+		if err != nil {
+			return err
+		}
+		m.Start, m.HasStart = addr, true
+	}
+	return nil
+}
+
+func insertChecksum(m *memmap.Map) error {
+	addr, err := parseAddr(*crcaddr)
+	if err != nil {
+		return err
+	}
+
+	r := memmap.Range{Start: m.Start(), End: addr}
+	if *crcrange != "" {
+		if r, err = parseRange(*crcrange); err != nil {
+			return err
+		}
+	} else if addr < r.Start {
+		return fmt.Errorf("checksum address %#x is before the image start %#x, use -crcrange to give the range it covers", addr, r.Start)
+	}
+	if r.Start <= addr && addr < r.End {
+		return fmt.Errorf("checksum address %#x is inside the range %v it covers", addr, r)
+	}
+
+	b := make([]byte, r.Len())
+	for i := range b {
+		b[i] = m.Fill
+	}
+	m.ReadAt(b, int64(r.Start))
+
+	var (
+		v    uint64
+		size int
+	)
+	switch *crctype {
+	case "crc32":
+		v, size = uint64(crc32.ChecksumIEEE(b)), 4
+	case "crc32c":
+		v, size = uint64(crc32.Checksum(b, crc32.MakeTable(crc32.Castagnoli))), 4
+	case "crc16":
+		v, size = uint64(crc16(b)), 2
+	case "sum8", "sum16", "sum32":
+		for _, c := range b {
+			v += uint64(c)
+		}
+		size, _ = strconv.Atoi((*crctype)[3:])
+		size /= 8
+	default:
+		return fmt.Errorf("unknown checksum type %q", *crctype)
+	}
+
+	var buf [8]byte
+	order := binary.ByteOrder(binary.LittleEndian)
+	if *bigend {
+		order = binary.BigEndian
+	}
+	order.PutUint64(buf[:], v)
+	if *bigend {
+		m.Write(addr, buf[8-size:])
+	} else {
+		m.Write(addr, buf[:size])
+	}
+	return nil
+}
+
+// crc16 is CRC-16/CCITT-FALSE.
+func crc16(b []byte) uint16 {
+	c := uint16(0xffff)
+	for _, x := range b {
+		c ^= uint16(x) << 8
+		for i := 0; i < 8; i++ {
+			if c&0x8000 != 0 {
+				c = c<<1 ^ 0x1021
+			} else {
+				c <<= 1
+			}
+		}
+	}
+	return c
+}
+
+func save(w io.Writer, format string, m *Image) error {
+	switch format {
+	case "srec":
+		f, err := srec.FromMap(m.Map)
+		if err != nil {
+			return err
+		}
+		if *reclen > 0 {
+			segs, err := m.Map.Split(uint64(*reclen))
+			if err != nil {
+				return err
+			}
+			f.Records = f.Records[:0]
+			for _, s := range segs {
+				f.Records = append(f.Records, srec.Record{Addr: s.Addr, Data: s.Data})
+			}
+		}
+		f.Start = m.Start
+		if f.Version < 3 && m.Start >= 1<<(8*(f.Version+1)) {
+			f.Version = 3
+		}
+		return srec.Encode(w, f)
+	case "ihex":
+		f, err := ihex.FromMap(m.Map)
+		if err != nil {
+			return err
+		}
+		f.Start, f.HasStart = uint32(m.Start), m.HasStart
+		f.RecordLength = *reclen
+		return ihex.Encode(w, f)
+	case "extekhex":
+		f, err := extekhex.FromMap(m.Map)
+		if err != nil {
+			return err
+		}
+		f.Start = m.Start
+		return extekhex.Encode(w, f)
+	case "tagged":
+		f, err := tagged.FromMap(m.Map)
+		if err != nil {
+			return err
+		}
+		if *reclen > 0 {
+			segs, err := m.Map.Split(uint64(*reclen))
+			if err != nil {
+				return err
+			}
+			f.Records = f.Records[:0]
+			for _, s := range segs {
+				f.Records = append(f.Records, tagged.Record{Addr: s.Addr, Data: s.Data})
+			}
+		}
+		return tagged.Encode(w, f)
+	case "bin":
+		bw := bufio.NewWriter(w)
+		bw.Write(m.Map.Binary())
+		return bw.Flush()
+	}
+	return fmt.Errorf("unknown output format %q", format)
+}
+
+func printInfo(w io.Writer, m *Image) {
+	fmt.Fprintf(w, "format: %s\n", m.Format)
+	if m.HasStart {
+		fmt.Fprintf(w, "start:  %#x\n", m.Start)
+	}
+	if len(m.Map.Segments) == 0 {
+		fmt.Fprintf(w, "empty\n")
+		return
+	}
+	fmt.Fprintf(w, "span:   %#x-%#x (%d bytes)\n", m.Map.Start(), m.Map.End(), m.Map.End()-m.Map.Start())
+	fmt.Fprintf(w, "data:   %d bytes in %d ranges\n", m.Map.Size(), len(m.Map.Segments))
+	for _, r := range m.Map.Ranges() {
+		fmt.Fprintf(w, "  %#010x-%#010x %8d\n", r.Start, r.End, r.Len())
+	}
+	if g := m.Map.Gaps(); len(g) > 0 {
+		fmt.Fprintf(w, "gaps:\n")
+		for _, r := range g {
+			fmt.Fprintf(w, "  %#010x-%#010x %8d\n", r.Start, r.End, r.Len())
+		}
+	}
+}
@@ -0,0 +1,683 @@
+package atmegaasm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/qeedquan/go-media/debug/ihex"
+	"github.com/qeedquan/go-media/debug/memmap"
+	"github.com/qeedquan/go-media/debug/srec"
+)
+
+// Program is the output of the assembler, addresses are in bytes.
+type Program struct {
+	Map     *memmap.Map
+	Symbols map[string]int64
+}
+
+func (p *Program) SREC() (*srec.File, error) {
+	return srec.FromMap(p.Map)
+}
+
+func (p *Program) IHEX() (*ihex.File, error) {
+	return ihex.FromMap(p.Map)
+}
+
+type assembler struct {
+	lines   []string
+	symbols map[string]int64
+	labels  map[string]bool
+	pc      int64
+	line    int
+	final   bool
+	mem     *memmap.Map
+}
+
+var mnemonics = map[string]Op{}
+
+func init() {
+	for op := UNK + 1; op <= XCH; op++ {
+		mnemonics[strings.ToLower(op.String())] = op
+	}
+}
+
+// Assemble assembles AVR source in the syntax printed by Inst.String. A
+// line holds an optional label followed by an instruction or one of the
+// directives .org, .db, .dw and .equ, comments start with a semicolon. The
+// data of .db is padded to a whole word.
+// Operands are expressions over numbers, symbols and the functions lo8,
+// hi8, hh8 and pm, a branch target of the form .+n is a raw offset.
+func Assemble(r io.Reader) (*Program, error) {
+	a := &assembler{
+		symbols: make(map[string]int64),
+		labels:  make(map[string]bool),
+	}
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		a.lines = append(a.lines, s.Text())
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	for pass := 0; pass < 2; pass++ {
+		a.final = pass == 1
+		a.pc = 0
+		a.mem = memmap.New(0xff)
+		for i, l := range a.lines {
+			a.line = i + 1
+			if err := a.assembleLine(l); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &Program{Map: a.mem, Symbols: a.symbols}, nil
+}
+
+func (a *assembler) errorf(format string, args ...interface{}) error {
+	pfx := fmt.Sprintf("atmegaasm: #%d: ", a.line)
+	return fmt.Errorf(pfx+format, args...)
+}
+
+func (a *assembler) assembleLine(l string) error {
+	if i := indexOutsideQuotes(l, ';'); i >= 0 {
+		l = l[:i]
+	}
+	l = strings.TrimSpace(l)
+
+	if i := strings.IndexByte(l, ':'); i > 0 && isIdent(l[:i]) {
+		name := l[:i]
+		if !a.final {
+			if a.labels[name] {
+				return a.errorf("label %q redefined", name)
+			}
+			a.labels[name] = true
+		}
+		a.symbols[name] = a.pc
+		l = strings.TrimSpace(l[i+1:])
+	}
+	if l == "" {
+		return nil
+	}
+
+	name, rest := l, ""
+	if i := strings.IndexAny(l, " \t"); i >= 0 {
+		name, rest = l[:i], strings.TrimSpace(l[i+1:])
+	}
+	args := splitArgs(rest)
+	name = strings.ToLower(name)
+
+	if strings.HasPrefix(name, ".") {
+		return a.directive(name, rest, args)
+	}
+	return a.instruction(name, args)
+}
+
+func (a *assembler) directive(name, rest string, args []string) error {
+	switch name {
+	case ".org":
+		if len(args) != 1 {
+			return a.errorf(".org expects one address")
+		}
+		// the location must be known on the first pass
+		final := a.final
+		a.final = true
+		v, err := a.eval(args[0])
+		a.final = final
+		if err != nil {
+			return a.errorf("%v", err)
+		}
+		a.pc = v
+
+	case ".equ", ".set":
+		var sym, val string
+		if i := strings.IndexAny(rest, "=,"); i >= 0 {
+			sym, val = strings.TrimSpace(rest[:i]), strings.TrimSpace(rest[i+1:])
+		}
+		if !isIdent(sym) {
+			return a.errorf("%s expects a name and a value", name)
+		}
+		v, err := a.eval(val)
+		if err != nil {
+			return a.errorf("%v", err)
+		}
+		a.symbols[sym] = v
+
+	case ".db", ".byte":
+		var b []byte
+		for _, s := range args {
+			if len(s) >= 2 && s[0] == '"' {
+				t, err := strconv.Unquote(s)
+				if err != nil {
+					return a.errorf("invalid string %s", s)
+				}
+				b = append(b, t...)
+				continue
+			}
+			v, err := a.eval(s)
+			if err != nil {
+				return a.errorf("%v", err)
+			}
+			if v < -128 || v > 255 {
+				return a.errorf("byte value %d out of range", v)
+			}
+			b = append(b, byte(v))
+		}
+		// program memory is word addressed
+		if len(b)%2 != 0 {
+			b = append(b, 0)
+		}
+		return a.emit(b)
+
+	case ".dw", ".word":
+		var b []byte
+		for _, s := range args {
+			v, err := a.eval(s)
+			if err != nil {
+				return a.errorf("%v", err)
+			}
+			if v < -32768 || v > 65535 {
+				return a.errorf("word value %d out of range", v)
+			}
+			b = append(b, byte(v), byte(v>>8))
+		}
+		return a.emit(b)
+
+	default:
+		return a.errorf("unknown directive %s", name)
+	}
+	return nil
+}
+
+func (a *assembler) emit(b []byte) error {
+	if a.pc < 0 {
+		return a.errorf("negative location %d", a.pc)
+	}
+	if a.final {
+		if err := a.mem.Add(uint64(a.pc), b); err != nil {
+			return a.errorf("%v", err)
+		}
+	}
+	a.pc += int64(len(b))
+	return nil
+}
+
+func (a *assembler) instruction(name string, args []string) error {
+	op, ok := mnemonics[name]
+	if !ok {
+		return a.errorf("unknown instruction %q", name)
+	}
+
+	// aliases of other instructions
+	switch op {
+	case CLR, TST, LSL, ROL:
+		if len(args) == 1 {
+			args = append(args, args[0])
+		}
+		op = map[Op]Op{CLR: EOR, TST: AND, LSL: ADD, ROL: ADC}[op]
+	case SER:
+		op, args = LDI, append(args, "0xff")
+	case SBR:
+		op = ORI
+	case CBR:
+		if len(args) == 2 {
+			args[1] = "~(" + args[1] + ")&0xff"
+		}
+		op = ANDI
+	}
+
+	in := Inst{Op: op}
+	err := a.operands(&in, args)
+	if err != nil {
+		return err
+	}
+
+	if !a.final {
+		// only the size matters on the first pass
+		n := int64(2)
+		switch in.Mode {
+		case DPA, DDL, DDS:
+			n = 4
+		}
+		a.pc += n
+		return nil
+	}
+	b, err := Encode(in)
+	if err != nil {
+		return a.errorf("%v", err)
+	}
+	return a.emit(b)
+}
+
+func (a *assembler) operands(in *Inst, args []string) error {
+	want := func(n int) error {
+		if len(args) != n {
+			return a.errorf("%v expects %d operands, got %d", in.Op, n, len(args))
+		}
+		return nil
+	}
+	var err error
+	reg := func(s string) uint8 {
+		if err != nil {
+			return 0
+		}
+		var r uint8
+		r, err = a.reg(s)
+		return r
+	}
+	ptr := func(s string, modes [4]Mode) {
+		ri, mode, rel, perr := a.pointer(s, modes)
+		if err == nil {
+			err = perr
+		}
+		in.Ri, in.Mode, in.Rel = ri, mode, rel
+	}
+	val := func(s string) uint32 {
+		if err != nil {
+			return 0
+		}
+		var v int64
+		v, err = a.eval(s)
+		return uint32(v)
+	}
+
+	_, rdt := rdtOps[in.Op]
+	_, rdi := rdiOps[in.Op]
+	_, rds := rdsOps[in.Op]
+	_, imp := impOps[in.Op]
+	_, bit := bitOps[in.Op]
+	_, br := brOps[in.Op]
+	_, fmul := fmulOps[in.Op]
+	_, iob := iobOps[in.Op]
+
+	switch {
+	case (in.Op == LPM || in.Op == ELPM) && len(args) > 0:
+		if err := want(2); err != nil {
+			return err
+		}
+		in.Rd = reg(args[0])
+		ptr(args[1], [4]Mode{DIL, DIPIL})
+
+	case imp:
+		in.Mode = IMP
+		return want(0)
+
+	case in.Op == BSET || in.Op == BCLR:
+		if err := want(1); err != nil {
+			return err
+		}
+		in.Mode, in.Val = IMM, val(args[0])
+
+	case rdt || fmul || in.Op == MOVW || in.Op == MULS:
+		if err := want(2); err != nil {
+			return err
+		}
+		in.Mode, in.Rd, in.Rr = RDT, reg(args[0]), reg(args[1])
+
+	case rdi || bit || in.Op == ADIW || in.Op == SBIW:
+		if err := want(2); err != nil {
+			return err
+		}
+		in.Mode, in.Rd, in.Val = RIM, reg(args[0]), val(args[1])
+		if v := int32(in.Val); rdi && -128 <= v && v < 0 {
+			in.Val &= 0xff
+		}
+
+	case rds:
+		if err := want(1); err != nil {
+			return err
+		}
+		in.Mode, in.Rd = RDS, reg(args[0])
+
+	case in.Op == LD || in.Op == LDD:
+		if err := want(2); err != nil {
+			return err
+		}
+		in.Rd = reg(args[0])
+		ptr(args[1], [4]Mode{DIL, DIPIL, DIPDL, DIPIOL})
+		if in.Mode == DIPIOL {
+			in.Op = LDD
+		}
+
+	case in.Op == ST || in.Op == STD:
+		if err := want(2); err != nil {
+			return err
+		}
+		ptr(args[0], [4]Mode{DIS, DIPIS, DIPDS, DIPIOS})
+		in.Rd = reg(args[1])
+		if in.Mode == DIPIOS {
+			in.Op = STD
+		}
+
+	case in.Op == IN:
+		if err := want(2); err != nil {
+			return err
+		}
+		in.Mode, in.Rd, in.Addr = IOS, reg(args[0]), val(args[1])
+
+	case iob:
+		if err := want(2); err != nil {
+			return err
+		}
+		in.Mode, in.Addr, in.Val = IOB, val(args[0]), val(args[1])
+
+	case in.Op == LDS:
+		if err := want(2); err != nil {
+			return err
+		}
+		in.Mode, in.Rd, in.Addr = DDL, reg(args[0]), val(args[1])
+
+	case in.Op == STS:
+		if err := want(2); err != nil {
+			return err
+		}
+		in.Mode, in.Addr, in.Rd = DDS, val(args[0]), reg(args[1])
+
+	case in.Op == OUT:
+		if err := want(2); err != nil {
+			return err
+		}
+		in.Mode, in.Addr, in.Rr = IOD, val(args[0]), reg(args[1])
+
+	case in.Op == JMP || in.Op == CALL:
+		if err := want(1); err != nil {
+			return err
+		}
+		in.Mode, in.Addr = DPA, val(args[0])
+
+	case in.Op == RJMP || in.Op == RCALL || br:
+		if err := want(1); err != nil {
+			return err
+		}
+		in.Mode = RPA
+		if s := strings.TrimSpace(args[0]); strings.HasPrefix(s, ".") && len(s) > 1 && (s[1] == '+' || s[1] == '-') {
+			in.Rel = int32(val(s[1:]))
+		} else {
+			in.Rel = int32(val(s)) - int32(a.pc+2)
+		}
+
+	default:
+		return a.errorf("unsupported instruction %v", in.Op)
+	}
+	if err != nil {
+		return a.errorf("%v", err)
+	}
+	return nil
+}
+
+func (a *assembler) reg(s string) (uint8, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if strings.HasPrefix(s, "r") {
+		n, err := strconv.ParseUint(s[1:], 10, 8)
+		if err == nil && n < 32 {
+			return uint8(n), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid register %q", s)
+}
+
+// pointer parses an X, Y or Z operand and picks the mode for the plain,
+// post increment, pre decrement or displacement form from modes, a zero
+// mode marks a form the instruction does not have.
+func (a *assembler) pointer(s string, modes [4]Mode) (uint8, Mode, int32, error) {
+	s = strings.TrimSpace(s)
+	form := 0
+	if strings.HasPrefix(s, "-") {
+		form, s = 2, strings.TrimSpace(s[1:])
+	}
+	if s == "" || strings.IndexByte("XYZxyz", s[0]) < 0 {
+		return 0, 0, 0, fmt.Errorf("invalid pointer operand %q", s)
+	}
+	ri := uint8(strings.IndexByte("XYZxyz", s[0]) % 3)
+	s = strings.TrimSpace(s[1:])
+
+	var disp int64
+	switch {
+	case s == "":
+	case s == "+" && form == 0:
+		form = 1
+	case strings.HasPrefix(s, "+") && form == 0:
+		v, err := a.eval(s[1:])
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		form, disp = 3, v
+	default:
+		return 0, 0, 0, fmt.Errorf("invalid pointer operand %q", s)
+	}
+	if modes[form] == 0 {
+		return 0, 0, 0, fmt.Errorf("unsupported pointer operand %q", s)
+	}
+	return ri, modes[form], int32(disp), nil
+}
+
+func (a *assembler) eval(s string) (int64, error) {
+	p := &exprParser{a: a, s: s}
+	v, err := p.parse(0)
+	if err == nil {
+		p.skip()
+		if p.i < len(p.s) {
+			err = fmt.Errorf("unexpected %q in expression %q", p.s[p.i:], s)
+		}
+	}
+	return v, err
+}
+
+type exprParser struct {
+	a *assembler
+	s string
+	i int
+}
+
+var binaryOps = []struct {
+	tok  string
+	prec int
+}{
+	{"<<", 5}, {">>", 5}, {"|", 1}, {"^", 2}, {"&", 3},
+	{"+", 4}, {"-", 4}, {"*", 6}, {"/", 6}, {"%", 6},
+}
+
+func (p *exprParser) skip() {
+	for p.i < len(p.s) && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+func (p *exprParser) parse(prec int) (int64, error) {
+	x, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		p.skip()
+		var tok string
+		var tprec int
+		for _, o := range binaryOps {
+			if strings.HasPrefix(p.s[p.i:], o.tok) {
+				tok, tprec = o.tok, o.prec
+				break
+			}
+		}
+		if tok == "" || tprec <= prec {
+			return x, nil
+		}
+		p.i += len(tok)
+		y, err := p.parse(tprec)
+		if err != nil {
+			return 0, err
+		}
+		switch tok {
+		case "<<":
+			x <<= uint64(y)
+		case ">>":
+			x >>= uint64(y)
+		case "|":
+			x |= y
+		case "^":
+			x ^= y
+		case "&":
+			x &= y
+		case "+":
+			x += y
+		case "-":
+			x -= y
+		case "*":
+			x *= y
+		case "/", "%":
+			if y == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			if tok == "/" {
+				x /= y
+			} else {
+				x %= y
+			}
+		}
+	}
+}
+
+func (p *exprParser) unary() (int64, error) {
+	p.skip()
+	if p.i >= len(p.s) {
+		return 0, fmt.Errorf("missing operand")
+	}
+
+	switch c := p.s[p.i]; {
+	case c == '-' || c == '~' || c == '+':
+		p.i++
+		x, err := p.unary()
+		switch c {
+		case '-':
+			x = -x
+		case '~':
+			x = ^x
+		}
+		return x, err
+
+	case c == '(':
+		p.i++
+		x, err := p.parse(0)
+		if err != nil {
+			return 0, err
+		}
+		p.skip()
+		if p.i >= len(p.s) || p.s[p.i] != ')' {
+			return 0, fmt.Errorf("missing )")
+		}
+		p.i++
+		return x, nil
+
+	case c == '\'':
+		if p.i+2 < len(p.s) && p.s[p.i+2] == '\'' {
+			x := int64(p.s[p.i+1])
+			p.i += 3
+			return x, nil
+		}
+		return 0, fmt.Errorf("invalid character constant")
+
+	case '0' <= c && c <= '9' || c == '$':
+		j := p.i + 1
+		for j < len(p.s) && isIdentChar(p.s[j]) {
+			j++
+		}
+		t := p.s[p.i:j]
+		p.i = j
+		if t[0] == '$' {
+			t = "0x" + t[1:]
+		}
+		x, err := strconv.ParseInt(t, 0, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", t)
+		}
+		return x, nil
+
+	case isIdentChar(c) || c == '.':
+		j := p.i + 1
+		for j < len(p.s) && isIdentChar(p.s[j]) {
+			j++
+		}
+		name := p.s[p.i:j]
+		p.i = j
+		p.skip()
+		if p.i < len(p.s) && p.s[p.i] == '(' {
+			return p.call(name)
+		}
+		if name == "." {
+			return p.a.pc, nil
+		}
+		x, ok := p.a.symbols[name]
+		if !ok && p.a.final {
+			return 0, fmt.Errorf("undefined symbol %q", name)
+		}
+		return x, nil
+	}
+	return 0, fmt.Errorf("unexpected %q", p.s[p.i:])
+}
+
+func (p *exprParser) call(name string) (int64, error) {
+	x, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	switch strings.ToLower(name) {
+	case "lo8":
+		return x & 0xff, nil
+	case "hi8":
+		return x >> 8 & 0xff, nil
+	case "hh8":
+		return x >> 16 & 0xff, nil
+	case "pm":
+		return x >> 1, nil
+	}
+	return 0, fmt.Errorf("unknown function %q", name)
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '.' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isIdent(s string) bool {
+	if s == "" || '0' <= s[0] && s[0] <= '9' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isIdentChar(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func indexOutsideQuotes(s string, c byte) int {
+	quote := byte(0)
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0 && s[i] == '\\':
+			i++
+		case quote != 0 && s[i] == quote:
+			quote = 0
+		case quote == 0 && (s[i] == '"' || s[i] == '\''):
+			quote = s[i]
+		case quote == 0 && s[i] == c:
+			return i
+		}
+	}
+	return -1
+}
+
+func splitArgs(s string) []string {
+	var args []string
+	for s != "" {
+		i := indexOutsideQuotes(s, ',')
+		if i < 0 {
+			args = append(args, strings.TrimSpace(s))
+			break
+		}
+		args = append(args, strings.TrimSpace(s[:i]))
+		s = s[i+1:]
+	}
+	return args
+}
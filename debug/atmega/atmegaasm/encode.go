@@ -0,0 +1,391 @@
+package atmegaasm
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+var rdtOps = map[Op]uint16{
+	CPC:  0x0400,
+	SBC:  0x0800,
+	ADD:  0x0c00,
+	CPSE: 0x1000,
+	CP:   0x1400,
+	SUB:  0x1800,
+	ADC:  0x1c00,
+	AND:  0x2000,
+	EOR:  0x2400,
+	OR:   0x2800,
+	MOV:  0x2c00,
+	MUL:  0x9c00,
+}
+
+var fmulOps = map[Op]uint16{
+	MULSU:  0x0300,
+	FMUL:   0x0308,
+	FMULS:  0x0380,
+	FMULSU: 0x0388,
+}
+
+var iobOps = map[Op]uint16{
+	CBI:  0x9800,
+	SBIC: 0x9900,
+	SBI:  0x9a00,
+	SBIS: 0x9b00,
+}
+
+// indirect loads and stores indexed by the pointer register X, Y or Z
+var ptrOps = map[Mode][3]uint16{
+	DIL:   {0x900c, 0x8008, 0x8000},
+	DIPIL: {0x900d, 0x9009, 0x9001},
+	DIPDL: {0x900e, 0x900a, 0x9002},
+	DIS:   {0x920c, 0x8208, 0x8200},
+	DIPIS: {0x920d, 0x9209, 0x9201},
+	DIPDS: {0x920e, 0x920a, 0x9202},
+}
+
+var rdiOps = map[Op]uint16{
+	CPI:  0x3000,
+	SBCI: 0x4000,
+	SUBI: 0x5000,
+	ORI:  0x6000,
+	ANDI: 0x7000,
+	LDI:  0xe000,
+}
+
+var rdsOps = map[Op]uint16{
+	POP:  0x900f,
+	PUSH: 0x920f,
+	COM:  0x9400,
+	NEG:  0x9401,
+	SWAP: 0x9402,
+	INC:  0x9403,
+	ASR:  0x9405,
+	LSR:  0x9406,
+	ROR:  0x9407,
+	DEC:  0x940a,
+}
+
+var impOps = map[Op]uint16{
+	NOP:    0x0000,
+	SEC:    0x9408,
+	IJMP:   0x9409,
+	SEZ:    0x9418,
+	EIJMP:  0x9419,
+	SEN:    0x9428,
+	SEV:    0x9438,
+	SES:    0x9448,
+	SEH:    0x9458,
+	SET:    0x9468,
+	SEI:    0x9478,
+	CLC:    0x9488,
+	CLZ:    0x9498,
+	CLN:    0x94a8,
+	CLV:    0x94b8,
+	CLS:    0x94c8,
+	CLH:    0x94d8,
+	CLT:    0x94e8,
+	CLI:    0x94f8,
+	RET:    0x9508,
+	ICALL:  0x9509,
+	RETI:   0x9518,
+	EICALL: 0x9519,
+	SLEEP:  0x9588,
+	BREAK:  0x9598,
+	WDR:    0x95a8,
+	LPM:    0x95c8,
+	ELPM:   0x95d8,
+	SPM:    0x95e8,
+}
+
+var bitOps = map[Op]uint16{
+	BLD:  0xf800,
+	BST:  0xfa00,
+	SBRC: 0xfc00,
+	SBRS: 0xfe00,
+}
+
+// branch on status flag, the low 3 bits select the flag and 0x400 branches
+// when it is clear
+var brOps = map[Op]uint16{
+	BRCS: 0xf000, BRLO: 0xf000, BREQ: 0xf001, BRMI: 0xf002, BRVS: 0xf003,
+	BRLT: 0xf004, BRHS: 0xf005, BRTS: 0xf006, BRIE: 0xf007,
+	BRCC: 0xf400, BRSH: 0xf400, BRNE: 0xf401, BRPL: 0xf402, BRVC: 0xf403,
+	BRGE: 0xf404, BRHC: 0xf405, BRTC: 0xf406, BRID: 0xf407,
+}
+
+// Encode returns the machine code of an instruction, it accepts the
+// instructions produced by Decode and BSET/BCLR in IMM mode.
+func Encode(i Inst) ([]byte, error) {
+	w, err := encode(i)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, len(w)*2)
+	for n := range w {
+		binary.LittleEndian.PutUint16(b[n*2:], w[n])
+	}
+	return b, nil
+}
+
+func encode(i Inst) ([]uint16, error) {
+	rd, rr := uint16(i.Rd), uint16(i.Rr)
+	reg := func(r uint16, lo, hi uint16) error {
+		if r < lo || r > hi {
+			return fmt.Errorf("%v: register r%d out of range r%d-r%d", i.Op, r, lo, hi)
+		}
+		return nil
+	}
+	imm := func(v, max uint32) error {
+		if v > max {
+			return fmt.Errorf("%v: value %#x out of range %#x", i.Op, v, max)
+		}
+		return nil
+	}
+	rel := func(bits uint) (uint16, error) {
+		lim := int32(1) << bits
+		if i.Rel&1 != 0 || i.Rel < -lim || i.Rel >= lim {
+			return 0, fmt.Errorf("%v: relative branch %+d out of range", i.Op, i.Rel)
+		}
+		return uint16(i.Rel>>1) & uint16(lim-1), nil
+	}
+	idx := func() (uint16, error) {
+		switch i.Ri {
+		case 1:
+			return 0x8, nil
+		case 2:
+			return 0, nil
+		}
+		return 0, fmt.Errorf("%v: invalid index register %c", i.Op, 'X'+i.Ri)
+	}
+
+	switch i.Mode {
+	case IMP:
+		if w, ok := impOps[i.Op]; ok {
+			return []uint16{w}, nil
+		}
+
+	case IMM:
+		if err := imm(i.Val, 7); err != nil {
+			return nil, err
+		}
+		switch i.Op {
+		case BSET:
+			return []uint16{0x9408 | uint16(i.Val)<<4}, nil
+		case BCLR:
+			return []uint16{0x9488 | uint16(i.Val)<<4}, nil
+		}
+
+	case RDT:
+		switch i.Op {
+		case MOVW:
+			if rd&1 != 0 || rr&1 != 0 {
+				return nil, fmt.Errorf("%v: registers r%d, r%d must be even", i.Op, rd, rr)
+			}
+			return []uint16{0x0100 | rd>>1<<4 | rr>>1}, nil
+		case MULSU, FMUL, FMULS, FMULSU:
+			if err := reg(rd, 16, 23); err != nil {
+				return nil, err
+			}
+			if err := reg(rr, 16, 23); err != nil {
+				return nil, err
+			}
+			return []uint16{fmulOps[i.Op] | (rd-16)<<4 | (rr - 16)}, nil
+		case MULS:
+			if err := reg(rd, 16, 31); err != nil {
+				return nil, err
+			}
+			if err := reg(rr, 16, 31); err != nil {
+				return nil, err
+			}
+			return []uint16{0x0200 | (rd-16)<<4 | (rr - 16)}, nil
+		}
+		if w, ok := rdtOps[i.Op]; ok {
+			if err := reg(rd, 0, 31); err != nil {
+				return nil, err
+			}
+			if err := reg(rr, 0, 31); err != nil {
+				return nil, err
+			}
+			return []uint16{w | (rr&0x10)<<5 | rd<<4 | rr&0xf}, nil
+		}
+
+	case RIM:
+		if w, ok := rdiOps[i.Op]; ok {
+			if err := reg(rd, 16, 31); err != nil {
+				return nil, err
+			}
+			if err := imm(i.Val, 0xff); err != nil {
+				return nil, err
+			}
+			k := uint16(i.Val)
+			return []uint16{w | (k&0xf0)<<4 | (rd-16)<<4 | k&0xf}, nil
+		}
+		if w, ok := bitOps[i.Op]; ok {
+			if err := reg(rd, 0, 31); err != nil {
+				return nil, err
+			}
+			if err := imm(i.Val, 7); err != nil {
+				return nil, err
+			}
+			return []uint16{w | rd<<4 | uint16(i.Val)}, nil
+		}
+		switch i.Op {
+		case ADIW, SBIW:
+			if rd < 24 || rd&1 != 0 {
+				return nil, fmt.Errorf("%v: register r%d must be one of r24, r26, r28, r30", i.Op, rd)
+			}
+			if err := imm(i.Val, 63); err != nil {
+				return nil, err
+			}
+			w := uint16(0x9600)
+			if i.Op == SBIW {
+				w = 0x9700
+			}
+			k := uint16(i.Val)
+			return []uint16{w | (k&0x30)<<2 | (rd-24)>>1<<4 | k&0xf}, nil
+		}
+
+	case RDS:
+		if w, ok := rdsOps[i.Op]; ok {
+			if err := reg(rd, 0, 31); err != nil {
+				return nil, err
+			}
+			return []uint16{w | rd<<4}, nil
+		}
+
+	case DIL, DIPIL, DIPDL, DIS, DIPIS, DIPDS:
+		if err := reg(rd, 0, 31); err != nil {
+			return nil, err
+		}
+		if i.Ri > 2 {
+			return nil, fmt.Errorf("%v: invalid index register %c", i.Op, 'X'+i.Ri)
+		}
+		w := ptrOps[i.Mode][i.Ri] | rd<<4
+		switch i.Op {
+		case LD:
+			if i.Mode == DIL || i.Mode == DIPIL || i.Mode == DIPDL {
+				return []uint16{w}, nil
+			}
+		case ST:
+			if i.Mode == DIS || i.Mode == DIPIS || i.Mode == DIPDS {
+				return []uint16{w}, nil
+			}
+		case LPM, ELPM:
+			if i.Ri != 2 || (i.Mode != DIL && i.Mode != DIPIL) {
+				return nil, fmt.Errorf("%v: index register must be Z or Z+", i.Op)
+			}
+			w := 0x9004 | rd<<4
+			if i.Op == ELPM {
+				w |= 2
+			}
+			if i.Mode == DIPIL {
+				w |= 1
+			}
+			return []uint16{w}, nil
+		}
+
+	case DIPIOL, DIPIOS:
+		if err := reg(rd, 0, 31); err != nil {
+			return nil, err
+		}
+		if i.Rel < 0 || i.Rel > 63 {
+			return nil, fmt.Errorf("%v: displacement %d out of range 0-63", i.Op, i.Rel)
+		}
+		y, err := idx()
+		if err != nil {
+			return nil, err
+		}
+		q := uint16(i.Rel)
+		w := 0x8000 | (q&0x20)<<8 | (q&0x18)<<7 | q&7 | rd<<4 | y
+		switch {
+		case i.Op == LDD && i.Mode == DIPIOL:
+			return []uint16{w}, nil
+		case i.Op == STD && i.Mode == DIPIOS:
+			return []uint16{w | 0x200}, nil
+		}
+
+	case IOS:
+		if i.Op == IN {
+			if err := reg(rd, 0, 31); err != nil {
+				return nil, err
+			}
+			if err := imm(i.Addr, 63); err != nil {
+				return nil, err
+			}
+			a := uint16(i.Addr)
+			return []uint16{0xb000 | (a&0x30)<<5 | rd<<4 | a&0xf}, nil
+		}
+
+	case IOB:
+		if w, ok := iobOps[i.Op]; ok {
+			if err := imm(i.Addr, 31); err != nil {
+				return nil, err
+			}
+			if err := imm(i.Val, 7); err != nil {
+				return nil, err
+			}
+			return []uint16{w | uint16(i.Addr)<<3 | uint16(i.Val)}, nil
+		}
+
+	case DDL, DDS:
+		if err := reg(rd, 0, 31); err != nil {
+			return nil, err
+		}
+		if err := imm(i.Addr, 0xffff); err != nil {
+			return nil, err
+		}
+		switch {
+		case i.Op == LDS && i.Mode == DDL:
+			return []uint16{0x9000 | rd<<4, uint16(i.Addr)}, nil
+		case i.Op == STS && i.Mode == DDS:
+			return []uint16{0x9200 | rd<<4, uint16(i.Addr)}, nil
+		}
+
+	case IOD:
+		if i.Op == OUT {
+			if err := reg(rr, 0, 31); err != nil {
+				return nil, err
+			}
+			if err := imm(i.Addr, 63); err != nil {
+				return nil, err
+			}
+			a := uint16(i.Addr)
+			return []uint16{0xb800 | (a&0x30)<<5 | rr<<4 | a&0xf}, nil
+		}
+
+	case DPA:
+		if i.Addr&1 != 0 || i.Addr >= 1<<23 {
+			return nil, fmt.Errorf("%v: invalid address %#x", i.Op, i.Addr)
+		}
+		k := i.Addr >> 1
+		w := 0x940c | uint16(k>>16)&1 | uint16(k>>17)<<4
+		switch i.Op {
+		case CALL:
+			return []uint16{w | 2, uint16(k)}, nil
+		case JMP:
+			return []uint16{w, uint16(k)}, nil
+		}
+
+	case RPA:
+		switch i.Op {
+		case RJMP, RCALL:
+			k, err := rel(12)
+			if err != nil {
+				return nil, err
+			}
+			if i.Op == RCALL {
+				return []uint16{0xd000 | k}, nil
+			}
+			return []uint16{0xc000 | k}, nil
+		}
+		if w, ok := brOps[i.Op]; ok {
+			k, err := rel(7)
+			if err != nil {
+				return nil, err
+			}
+			return []uint16{w | k<<3}, nil
+		}
+	}
+	return nil, fmt.Errorf("%v: unsupported addressing mode %d", i.Op, i.Mode)
+}
@@ -27,6 +27,13 @@ const (
 	IOD
 	DPA
 	RPA
+	IOS
+	IOB
+	DDL
+	DDS
+	DIPIS
+	DIPDL
+	DIPDS
 )
 
 type Inst struct {
@@ -80,13 +87,13 @@ func rjc(src []byte) (op Op, enc uint32, len_ int, mode Mode, addr uint32, err e
 		return
 	}
 	op = JMP
-	if src[0]&0x3 != 0 {
+	if src[0]&0x2 != 0 {
 		op = CALL
 	}
 	enc = binary.LittleEndian.Uint32(src)
 	len_ = 4
 	mode = DPA
-	addr = (enc&0xffff0000)>>16 | (enc&0x1)<<16 | (enc&0x1f0)<<17
+	addr = (enc&0xffff0000)>>16 | (enc&0x1)<<16 | (enc&0x1f0)<<13
 	addr <<= 1
 	return
 }
@@ -128,8 +135,13 @@ func Decode(src []byte) (Inst, error) {
 
 	case 0x02:
 		op, mode, rr, rd = rdt(MULS, src)
+		rd += 16
 
 	case 0x03:
+		op = [...]Op{MULSU, FMUL, FMULS, FMULSU}[(src[0]>>6)&2|(src[0]>>3)&1]
+		mode = RDT
+		rd = 16 + (src[0]>>4)&0x7
+		rr = 16 + src[0]&0x7
 
 	case 0x04, 0x05, 0x06, 0x07:
 		op, mode, rr, rd = rdt(CPC, src)
@@ -165,7 +177,7 @@ func Decode(src []byte) (Inst, error) {
 		op, mode, rr, rd = rdt(MOV, src)
 
 	case 0x30, 0x31, 0x32, 0x33, 0x34, 0x35, 0x36, 0x37,
-		0x38, 0x39, 0x3a, 0x3b, 0x3c, 0x3d, 0x3f:
+		0x38, 0x39, 0x3a, 0x3b, 0x3c, 0x3d, 0x3e, 0x3f:
 		op, mode, rd, val = rdi(CPI, src)
 
 	case 0x40, 0x41, 0x42, 0x43, 0x44, 0x45, 0x46, 0x47,
@@ -218,8 +230,46 @@ func Decode(src []byte) (Inst, error) {
 		op, mode, rd, ri, rel = ind(op, mode, ri, src)
 
 	case 0x90, 0x91, 0x92, 0x93:
+		st := src[1] >= 0x92
 		switch i := src[0] & 0xf; {
-		case 0x4 <= i && i <= 0x7 && src[1] < 0x92:
+		case i == 0x0:
+			if len(src) < 4 {
+				err = ErrShortInst
+				break
+			}
+			op, mode = LDS, DDL
+			if st {
+				op, mode = STS, DDS
+			}
+			enc = binary.LittleEndian.Uint32(src)
+			len_ = 4
+			addr = uint32(binary.LittleEndian.Uint16(src[2:]))
+
+		case 0x1 <= i && i <= 0x2, 0x9 <= i && i <= 0xe && i != 0xb:
+			op, mode = LD, DIPIL
+			if st {
+				op, mode = ST, DIPIS
+			}
+			switch {
+			case i < 0x8:
+				ri = 2
+			case i < 0xc:
+				ri = 1
+			}
+			switch i & 0x3 {
+			case 0x0:
+				mode = DIL
+				if st {
+					mode = DIS
+				}
+			case 0x2:
+				mode = DIPDL
+				if st {
+					mode = DIPDS
+				}
+			}
+
+		case 0x4 <= i && i <= 0x7 && !st:
 			op = LPM
 			if i >= 0x6 {
 				op = ELPM
@@ -232,7 +282,7 @@ func Decode(src []byte) (Inst, error) {
 
 		case i == 0xf:
 			op, mode = POP, RDS
-			if src[1] >= 0x92 {
+			if st {
 				op = PUSH
 			}
 		}
@@ -410,16 +460,31 @@ func Decode(src []byte) (Inst, error) {
 		case 0xd8:
 			op, mode = ELPM, IMP
 
-		case 0xe8, 0xf8:
+		case 0xe8:
 			op, mode = SPM, IMP
 		}
 
 	case 0x96:
 		op, mode, rd, val = rdiw(ADIW, src)
 
+	case 0x98, 0x99, 0x9a, 0x9b:
+		op = [...]Op{CBI, SBIC, SBI, SBIS}[src[1]&0x3]
+		mode = IOB
+		addr = uint32(src[0] >> 3)
+		val = uint32(src[0] & 0x7)
+
+	case 0x9c, 0x9d, 0x9e, 0x9f:
+		op, mode, rr, rd = rdt(MUL, src)
+
 	case 0x97:
 		op, mode, rd, val = rdiw(SBIW, src)
 
+	case 0xb0, 0xb1, 0xb2, 0xb3, 0xb4, 0xb5, 0xb6, 0xb7:
+		op = IN
+		mode = IOS
+		addr = (enc & 0xf) | (enc&0x600)>>5
+		rd = uint8((enc >> 4) & 0x1f)
+
 	case 0xb8, 0xb9, 0xba, 0xbb, 0xbc, 0xbd, 0xbe, 0xbf:
 		op = OUT
 		mode = IOD
@@ -480,17 +545,12 @@ func Decode(src []byte) (Inst, error) {
 			op, mode, rel = br1(BRID, src)
 		}
 
-	case 0xf8, 0xf9:
-		op, mode, rd, val = bit(BLD, src)
-
-	case 0xfa, 0xfb:
-		op, mode, rd, val = bit(BST, src)
-
-	case 0xfc, 0xfd:
-		op, mode, rd, val = bit(SBRC, src)
-
-	case 0xfe, 0xff:
-		op, mode, rd, val = bit(SBRS, src)
+	case 0xf8, 0xf9, 0xfa, 0xfb, 0xfc, 0xfd, 0xfe, 0xff:
+		// bit 3 is reserved and always clear
+		if src[0]&0x8 == 0 {
+			op = [...]Op{BLD, BST, SBRC, SBRS}[(src[1]>>1)&0x3]
+			op, mode, rd, val = bit(op, src)
+		}
 	}
 
 	return Inst{
@@ -521,6 +581,20 @@ func (i Inst) String() string {
 		args = fmt.Sprintf("r%d, r%d", i.Rd, i.Rr)
 	case IOD:
 		args = fmt.Sprintf("%#x, r%d", i.Addr, i.Rr)
+	case IOS:
+		args = fmt.Sprintf("r%d, %#x", i.Rd, i.Addr)
+	case IOB:
+		args = fmt.Sprintf("%#x, %d", i.Addr, i.Val)
+	case DDL:
+		args = fmt.Sprintf("r%d, %#x", i.Rd, i.Addr)
+	case DDS:
+		args = fmt.Sprintf("%#x, r%d", i.Addr, i.Rd)
+	case DIPIS:
+		args = fmt.Sprintf("%c+, r%d", 'X'+i.Ri, i.Rd)
+	case DIPDL:
+		args = fmt.Sprintf("r%d, -%c", i.Rd, 'X'+i.Ri)
+	case DIPDS:
+		args = fmt.Sprintf("-%c, r%d", 'X'+i.Ri, i.Rd)
 	case DIL:
 		args = fmt.Sprintf("r%d, %c", i.Rd, 'X'+i.Ri)
 	case DIPIL:
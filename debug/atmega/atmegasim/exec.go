@@ -0,0 +1,489 @@
+package atmegasim
+
+import (
+	"fmt"
+
+	"github.com/qeedquan/go-media/debug/atmega/atmegaasm"
+)
+
+// branches maps the conditional branches to the SREG bit they test and
+// the value that takes the branch.
+var branches = map[atmegaasm.Op]struct {
+	flag byte
+	set  bool
+}{
+	atmegaasm.BRCS: {FLAG_C, true},
+	atmegaasm.BRLO: {FLAG_C, true},
+	atmegaasm.BRCC: {FLAG_C, false},
+	atmegaasm.BRSH: {FLAG_C, false},
+	atmegaasm.BREQ: {FLAG_Z, true},
+	atmegaasm.BRNE: {FLAG_Z, false},
+	atmegaasm.BRMI: {FLAG_N, true},
+	atmegaasm.BRPL: {FLAG_N, false},
+	atmegaasm.BRVS: {FLAG_V, true},
+	atmegaasm.BRVC: {FLAG_V, false},
+	atmegaasm.BRLT: {FLAG_S, true},
+	atmegaasm.BRGE: {FLAG_S, false},
+	atmegaasm.BRHS: {FLAG_H, true},
+	atmegaasm.BRHC: {FLAG_H, false},
+	atmegaasm.BRTS: {FLAG_T, true},
+	atmegaasm.BRTC: {FLAG_T, false},
+	atmegaasm.BRIE: {FLAG_I, true},
+	atmegaasm.BRID: {FLAG_I, false},
+}
+
+var flagOps = map[atmegaasm.Op]struct {
+	flag byte
+	set  bool
+}{
+	atmegaasm.SEC: {FLAG_C, true},
+	atmegaasm.CLC: {FLAG_C, false},
+	atmegaasm.SEZ: {FLAG_Z, true},
+	atmegaasm.CLZ: {FLAG_Z, false},
+	atmegaasm.SEN: {FLAG_N, true},
+	atmegaasm.CLN: {FLAG_N, false},
+	atmegaasm.SEV: {FLAG_V, true},
+	atmegaasm.CLV: {FLAG_V, false},
+	atmegaasm.SES: {FLAG_S, true},
+	atmegaasm.CLS: {FLAG_S, false},
+	atmegaasm.SEH: {FLAG_H, true},
+	atmegaasm.CLH: {FLAG_H, false},
+	atmegaasm.SET: {FLAG_T, true},
+	atmegaasm.CLT: {FLAG_T, false},
+	atmegaasm.SEI: {FLAG_I, true},
+	atmegaasm.CLI: {FLAG_I, false},
+}
+
+// Decode decodes the instruction at a flash address.
+func (c *CPU) Decode(pc uint32) (atmegaasm.Inst, error) {
+	if pc+2 > uint32(len(c.Flash)) {
+		return atmegaasm.Inst{}, fmt.Errorf("atmegasim: pc %#x outside of flash", pc)
+	}
+	end := pc + 4
+	if end > uint32(len(c.Flash)) {
+		end = uint32(len(c.Flash))
+	}
+	return atmegaasm.Decode(c.Flash[pc:end])
+}
+
+func (c *CPU) step() (int, error) {
+	if c.inhibit {
+		c.inhibit = false
+	} else if c.pending != 0 && c.Flag(FLAG_I) {
+		return c.service(), nil
+	}
+
+	if c.Sleeping {
+		if !c.Flag(FLAG_I) {
+			return 0, ErrSleep
+		}
+		return 1, nil
+	}
+
+	pc := c.PC
+	in, err := c.Decode(pc)
+	if err != nil {
+		return 0, err
+	}
+	if in.Op == atmegaasm.UNK {
+		return 0, &IllegalError{pc, in.Enc}
+	}
+	c.PC = pc + uint32(in.Len)
+	return c.exec(in, pc)
+}
+
+// service enters the lowest numbered pending interrupt.
+func (c *CPU) service() int {
+	n := uint(0)
+	for c.pending&(1<<n) == 0 {
+		n++
+	}
+	c.pending &^= 1 << n
+	c.Sleeping = false
+	c.pushPC(c.PC)
+	c.SetFlag(FLAG_I, false)
+	c.PC = uint32(n) * uint32(c.VectorSize) * 2
+	return 2 + c.PCBytes
+}
+
+// skip skips the next instruction, returning the cycles it took.
+func (c *CPU) skip() (int, error) {
+	in, err := c.Decode(c.PC)
+	if err != nil {
+		return 0, err
+	}
+	c.PC += uint32(in.Len)
+	return 1 + in.Len/2, nil
+}
+
+// ptr returns the address held in the X, Y or Z register pair.
+func (c *CPU) ptr(ri uint8) uint16 {
+	r := 26 + 2*int(ri)
+	return uint16(c.Data[r]) | uint16(c.Data[r+1])<<8
+}
+
+func (c *CPU) setPtr(ri uint8, val uint16) {
+	r := 26 + 2*int(ri)
+	c.Data[r] = uint8(val)
+	c.Data[r+1] = uint8(val >> 8)
+}
+
+// ea computes the effective address of an indirect access, applying the
+// pre-decrement and post-increment of the pointer.
+func (c *CPU) ea(in atmegaasm.Inst) uint16 {
+	p := c.ptr(in.Ri)
+	switch in.Mode {
+	case atmegaasm.DIPIL, atmegaasm.DIPIS:
+		c.setPtr(in.Ri, p+1)
+	case atmegaasm.DIPDL, atmegaasm.DIPDS:
+		p--
+		c.setPtr(in.Ri, p)
+	case atmegaasm.DIPIOL, atmegaasm.DIPIOS:
+		p += uint16(in.Rel)
+	}
+	return p
+}
+
+func (c *CPU) logic(res byte) byte {
+	c.nzs(res, false)
+	return res
+}
+
+// nzs sets N, Z, V and S from a result and its overflow.
+func (c *CPU) nzs(res byte, v bool) {
+	c.SetFlag(FLAG_N, res&0x80 != 0)
+	c.SetFlag(FLAG_Z, res == 0)
+	c.SetFlag(FLAG_V, v)
+	c.SetFlag(FLAG_S, (res&0x80 != 0) != v)
+}
+
+func (c *CPU) add(d, r, carry byte) byte {
+	res := d + r + carry
+	cy := d&r | r&^res | ^res&d
+	c.SetFlag(FLAG_H, cy&0x08 != 0)
+	c.SetFlag(FLAG_C, cy&0x80 != 0)
+	c.nzs(res, (d&r&^res|^d&^r&res)&0x80 != 0)
+	return res
+}
+
+// sub subtracts with borrow, keepZ leaves Z clear if it was clear before as
+// the instructions that chain multi-byte compares do.
+func (c *CPU) sub(d, r, carry byte, keepZ bool) byte {
+	res := d - r - carry
+	z := c.Flag(FLAG_Z)
+	bw := ^d&r | r&res | res&^d
+	c.SetFlag(FLAG_H, bw&0x08 != 0)
+	c.SetFlag(FLAG_C, bw&0x80 != 0)
+	c.nzs(res, (d&^r&^res|^d&r&res)&0x80 != 0)
+	if keepZ {
+		c.SetFlag(FLAG_Z, res == 0 && z)
+	}
+	return res
+}
+
+// shift sets the flags of the right shifts and rotates.
+func (c *CPU) shift(d, res byte) byte {
+	cf := d&1 != 0
+	c.SetFlag(FLAG_C, cf)
+	c.nzs(res, (res&0x80 != 0) != cf)
+	return res
+}
+
+func (c *CPU) mul(in atmegaasm.Inst) {
+	d, r := c.Data[in.Rd], c.Data[in.Rr]
+	var p int32
+	switch in.Op {
+	case atmegaasm.MUL, atmegaasm.FMUL:
+		p = int32(d) * int32(r)
+	case atmegaasm.MULS, atmegaasm.FMULS:
+		p = int32(int8(d)) * int32(int8(r))
+	case atmegaasm.MULSU, atmegaasm.FMULSU:
+		p = int32(int8(d)) * int32(r)
+	}
+	res := uint16(p)
+	c.SetFlag(FLAG_C, res&0x8000 != 0)
+	switch in.Op {
+	case atmegaasm.FMUL, atmegaasm.FMULS, atmegaasm.FMULSU:
+		res <<= 1
+	}
+	c.SetFlag(FLAG_Z, res == 0)
+	c.Data[0] = uint8(res)
+	c.Data[1] = uint8(res >> 8)
+}
+
+func (c *CPU) exec(in atmegaasm.Inst, pc uint32) (int, error) {
+	r := c.Data
+	d := r[in.Rd]
+	call := 1 + c.PCBytes
+	switch in.Op {
+	case atmegaasm.NOP, atmegaasm.WDR:
+
+	case atmegaasm.MOV:
+		r[in.Rd] = r[in.Rr]
+
+	case atmegaasm.MOVW:
+		r[in.Rd], r[in.Rd+1] = r[in.Rr], r[in.Rr+1]
+
+	case atmegaasm.LDI:
+		r[in.Rd] = uint8(in.Val)
+
+	case atmegaasm.ADD:
+		r[in.Rd] = c.add(d, r[in.Rr], 0)
+
+	case atmegaasm.ADC:
+		r[in.Rd] = c.add(d, r[in.Rr], r[SREG]&FLAG_C)
+
+	case atmegaasm.SUB:
+		r[in.Rd] = c.sub(d, r[in.Rr], 0, false)
+
+	case atmegaasm.SUBI:
+		r[in.Rd] = c.sub(d, uint8(in.Val), 0, false)
+
+	case atmegaasm.SBC:
+		r[in.Rd] = c.sub(d, r[in.Rr], r[SREG]&FLAG_C, true)
+
+	case atmegaasm.SBCI:
+		r[in.Rd] = c.sub(d, uint8(in.Val), r[SREG]&FLAG_C, true)
+
+	case atmegaasm.CP:
+		c.sub(d, r[in.Rr], 0, false)
+
+	case atmegaasm.CPC:
+		c.sub(d, r[in.Rr], r[SREG]&FLAG_C, true)
+
+	case atmegaasm.CPI:
+		c.sub(d, uint8(in.Val), 0, false)
+
+	case atmegaasm.AND:
+		r[in.Rd] = c.logic(d & r[in.Rr])
+
+	case atmegaasm.ANDI:
+		r[in.Rd] = c.logic(d & uint8(in.Val))
+
+	case atmegaasm.OR:
+		r[in.Rd] = c.logic(d | r[in.Rr])
+
+	case atmegaasm.ORI:
+		r[in.Rd] = c.logic(d | uint8(in.Val))
+
+	case atmegaasm.EOR:
+		r[in.Rd] = c.logic(d ^ r[in.Rr])
+
+	case atmegaasm.COM:
+		r[in.Rd] = c.logic(^d)
+		c.SetFlag(FLAG_C, true)
+
+	case atmegaasm.NEG:
+		res := -d
+		c.SetFlag(FLAG_H, (res|d)&0x08 != 0)
+		c.SetFlag(FLAG_C, res != 0)
+		c.nzs(res, res == 0x80)
+		r[in.Rd] = res
+
+	case atmegaasm.INC:
+		r[in.Rd] = d + 1
+		c.nzs(d+1, d == 0x7f)
+
+	case atmegaasm.DEC:
+		r[in.Rd] = d - 1
+		c.nzs(d-1, d == 0x80)
+
+	case atmegaasm.ASR:
+		r[in.Rd] = c.shift(d, d>>1|d&0x80)
+
+	case atmegaasm.LSR:
+		r[in.Rd] = c.shift(d, d>>1)
+
+	case atmegaasm.ROR:
+		r[in.Rd] = c.shift(d, d>>1|(r[SREG]&FLAG_C)<<7)
+
+	case atmegaasm.SWAP:
+		r[in.Rd] = d<<4 | d>>4
+
+	case atmegaasm.ADIW, atmegaasm.SBIW:
+		w := uint16(d) | uint16(r[in.Rd+1])<<8
+		res := w + uint16(in.Val)
+		v := ^w&res&0x8000 != 0
+		cf := ^res&w&0x8000 != 0
+		if in.Op == atmegaasm.SBIW {
+			res = w - uint16(in.Val)
+			v = w&^res&0x8000 != 0
+			cf = res&^w&0x8000 != 0
+		}
+		r[in.Rd], r[in.Rd+1] = uint8(res), uint8(res>>8)
+		c.SetFlag(FLAG_C, cf)
+		c.SetFlag(FLAG_N, res&0x8000 != 0)
+		c.SetFlag(FLAG_Z, res == 0)
+		c.SetFlag(FLAG_V, v)
+		c.SetFlag(FLAG_S, (res&0x8000 != 0) != v)
+		return 2, nil
+
+	case atmegaasm.MUL, atmegaasm.MULS, atmegaasm.MULSU,
+		atmegaasm.FMUL, atmegaasm.FMULS, atmegaasm.FMULSU:
+		c.mul(in)
+		return 2, nil
+
+	case atmegaasm.BST:
+		c.SetFlag(FLAG_T, d&(1<<in.Val) != 0)
+
+	case atmegaasm.BLD:
+		if c.Flag(FLAG_T) {
+			r[in.Rd] |= 1 << in.Val
+		} else {
+			r[in.Rd] &^= 1 << in.Val
+		}
+
+	case atmegaasm.SEC, atmegaasm.CLC, atmegaasm.SEZ, atmegaasm.CLZ,
+		atmegaasm.SEN, atmegaasm.CLN, atmegaasm.SEV, atmegaasm.CLV,
+		atmegaasm.SES, atmegaasm.CLS, atmegaasm.SEH, atmegaasm.CLH,
+		atmegaasm.SET, atmegaasm.CLT, atmegaasm.SEI, atmegaasm.CLI:
+		f := flagOps[in.Op]
+		c.SetFlag(f.flag, f.set)
+		if in.Op == atmegaasm.SEI {
+			c.inhibit = true
+		}
+
+	case atmegaasm.IN:
+		r[in.Rd] = c.Read(uint16(in.Addr) + 0x20)
+
+	case atmegaasm.OUT:
+		c.Write(uint16(in.Addr)+0x20, r[in.Rr])
+
+	case atmegaasm.SBI, atmegaasm.CBI:
+		a := uint16(in.Addr) + 0x20
+		v := c.Read(a)
+		if in.Op == atmegaasm.SBI {
+			v |= 1 << in.Val
+		} else {
+			v &^= 1 << in.Val
+		}
+		c.Write(a, v)
+		return 2, nil
+
+	case atmegaasm.SBIC, atmegaasm.SBIS:
+		set := c.Read(uint16(in.Addr)+0x20)&(1<<in.Val) != 0
+		if set == (in.Op == atmegaasm.SBIS) {
+			return c.skip()
+		}
+
+	case atmegaasm.SBRC, atmegaasm.SBRS:
+		set := d&(1<<in.Val) != 0
+		if set == (in.Op == atmegaasm.SBRS) {
+			return c.skip()
+		}
+
+	case atmegaasm.CPSE:
+		if d == r[in.Rr] {
+			return c.skip()
+		}
+
+	case atmegaasm.LD, atmegaasm.LDD:
+		r[in.Rd] = c.Read(c.ea(in))
+		return 2, nil
+
+	case atmegaasm.ST, atmegaasm.STD:
+		c.Write(c.ea(in), d)
+		return 2, nil
+
+	case atmegaasm.LDS:
+		r[in.Rd] = c.Read(uint16(in.Addr))
+		return 2, nil
+
+	case atmegaasm.STS:
+		c.Write(uint16(in.Addr), d)
+		return 2, nil
+
+	case atmegaasm.LPM, atmegaasm.ELPM:
+		a := uint32(c.ptr(2))
+		if in.Op == atmegaasm.ELPM {
+			a |= uint32(r[RAMPZ]) << 16
+		}
+		if a >= uint32(len(c.Flash)) {
+			c.faultf("program memory read from %#x outside of flash", a)
+			return 3, nil
+		}
+		switch in.Mode {
+		case atmegaasm.IMP:
+			r[0] = c.Flash[a]
+		case atmegaasm.DIPIL:
+			a++
+			c.setPtr(2, uint16(a))
+			if in.Op == atmegaasm.ELPM {
+				r[RAMPZ] = uint8(a >> 16)
+			}
+			r[in.Rd] = c.Flash[a-1]
+		default:
+			r[in.Rd] = c.Flash[a]
+		}
+		return 3, nil
+
+	case atmegaasm.PUSH:
+		c.push(d)
+		return 2, nil
+
+	case atmegaasm.POP:
+		r[in.Rd] = c.pop()
+		return 2, nil
+
+	case atmegaasm.RJMP:
+		c.PC = uint32(int32(c.PC) + in.Rel)
+		return 2, nil
+
+	case atmegaasm.JMP:
+		c.PC = in.Addr
+		return 3, nil
+
+	case atmegaasm.IJMP:
+		c.PC = uint32(c.ptr(2)) << 1
+		return 2, nil
+
+	case atmegaasm.EIJMP:
+		c.PC = (uint32(r[EIND])<<16 | uint32(c.ptr(2))) << 1
+		return 2, nil
+
+	case atmegaasm.RCALL:
+		c.pushPC(c.PC)
+		c.PC = uint32(int32(c.PC) + in.Rel)
+		return call, nil
+
+	case atmegaasm.CALL:
+		c.pushPC(c.PC)
+		c.PC = in.Addr
+		return call + 1, nil
+
+	case atmegaasm.ICALL:
+		c.pushPC(c.PC)
+		c.PC = uint32(c.ptr(2)) << 1
+		return call, nil
+
+	case atmegaasm.EICALL:
+		c.pushPC(c.PC)
+		c.PC = (uint32(r[EIND])<<16 | uint32(c.ptr(2))) << 1
+		return call, nil
+
+	case atmegaasm.RET, atmegaasm.RETI:
+		c.PC = c.popPC()
+		if in.Op == atmegaasm.RETI {
+			c.SetFlag(FLAG_I, true)
+			c.inhibit = true
+		}
+		return call + 1, nil
+
+	case atmegaasm.SLEEP:
+		c.Sleeping = true
+
+	case atmegaasm.BREAK:
+		return 1, ErrBreak
+
+	default:
+		if b, ok := branches[in.Op]; ok {
+			if c.Flag(b.flag) == b.set {
+				c.PC = uint32(int32(c.PC) + in.Rel)
+				return 2, nil
+			}
+			break
+		}
+		return 0, fmt.Errorf("atmegasim: %#x: %v is not supported", pc, in.Op)
+	}
+	return 1, nil
+}
@@ -0,0 +1,418 @@
+package atmegasim
+
+import (
+	"debug/elf"
+	"errors"
+	"fmt"
+
+	"github.com/qeedquan/go-media/debug/elfutil"
+	"github.com/qeedquan/go-media/debug/memmap"
+	"github.com/qeedquan/go-media/debug/srec"
+)
+
+// Data space addresses of the core registers.
+const (
+	RAMPZ = 0x5b
+	EIND  = 0x5c
+	SPL   = 0x5d
+	SPH   = 0x5e
+	SREG  = 0x5f
+)
+
+const (
+	FLAG_C = 1 << iota
+	FLAG_Z
+	FLAG_N
+	FLAG_V
+	FLAG_S
+	FLAG_H
+	FLAG_T
+	FLAG_I
+)
+
+// EEPROM control register bits.
+const (
+	EERE  = 1 << 0
+	EEPE  = 1 << 1
+	EEMPE = 1 << 2
+	EEPM0 = 1 << 4
+	EEPM1 = 1 << 5
+)
+
+// Load address offsets used by avr-gcc for the non-flash memories.
+const (
+	ELF_DATA   = 0x800000
+	ELF_EEPROM = 0x810000
+)
+
+var (
+	ErrBreakpoint = errors.New("atmegasim: breakpoint")
+	ErrBreak      = errors.New("atmegasim: break instruction")
+	ErrSleep      = errors.New("atmegasim: sleeping with interrupts disabled")
+	ErrLimit      = errors.New("atmegasim: cycle limit reached")
+)
+
+// Config describes the memories of a device, sizes are in bytes and the
+// EEPROM registers are data space addresses, a zero EECR leaves the EEPROM
+// without a controller.
+type Config struct {
+	FlashSize  int
+	SRAMStart  int
+	DataSize   int
+	EEPROMSize int
+	VectorSize int
+	PCBytes    int
+	EECR       uint16
+	EEDR       uint16
+	EEARL      uint16
+	EEARH      uint16
+}
+
+var (
+	ATmega328P = Config{
+		FlashSize:  0x8000,
+		SRAMStart:  0x100,
+		DataSize:   0x900,
+		EEPROMSize: 0x400,
+		VectorSize: 2,
+		PCBytes:    2,
+		EECR:       0x3f,
+		EEDR:       0x40,
+		EEARL:      0x41,
+		EEARH:      0x42,
+	}
+
+	ATmega2560 = Config{
+		FlashSize:  0x40000,
+		SRAMStart:  0x200,
+		DataSize:   0x2200,
+		EEPROMSize: 0x1000,
+		VectorSize: 2,
+		PCBytes:    3,
+		EECR:       0x3f,
+		EEDR:       0x40,
+		EEARL:      0x41,
+		EEARH:      0x42,
+	}
+)
+
+type ReadHook func(c *CPU, addr uint16) byte
+
+// WriteHook is called after a value has been stored to the data space.
+type WriteHook func(c *CPU, addr uint16, val byte)
+
+// CPU is an AVR core, Data holds the register file at 0x00-0x1f followed
+// by the IO registers and SRAM. The PC is a byte address into Flash.
+type CPU struct {
+	Config
+	PC          uint32
+	Cycles      uint64
+	Flash       []byte
+	Data        []byte
+	EEPROM      []byte
+	Sleeping    bool
+	Breakpoints map[uint32]bool
+
+	// Tick is called after every step with the cycles it took, it can be
+	// used to model peripherals and raise interrupts.
+	Tick func(c *CPU, cycles int)
+
+	pending uint64
+	inhibit bool
+	fault   error
+	reads   map[uint16]ReadHook
+	writes  map[uint16]WriteHook
+	eempe   uint64
+}
+
+type IllegalError struct {
+	PC  uint32
+	Enc uint32
+}
+
+func (e *IllegalError) Error() string {
+	return fmt.Sprintf("atmegasim: %#x: illegal instruction %#04x", e.PC, e.Enc)
+}
+
+func New(cfg Config) *CPU {
+	c := &CPU{
+		Config:      cfg,
+		Flash:       make([]byte, cfg.FlashSize),
+		Data:        make([]byte, cfg.DataSize),
+		EEPROM:      make([]byte, cfg.EEPROMSize),
+		Breakpoints: make(map[uint32]bool),
+		reads:       make(map[uint16]ReadHook),
+		writes:      make(map[uint16]WriteHook),
+	}
+	for i := range c.Flash {
+		c.Flash[i] = 0xff
+	}
+	for i := range c.EEPROM {
+		c.EEPROM[i] = 0xff
+	}
+	if cfg.EECR != 0 {
+		c.HookWrite(cfg.EECR, eepromControl)
+	}
+	c.Reset()
+	return c
+}
+
+// Reset clears the registers and pending interrupts and sets the stack
+// pointer to the end of SRAM, the memories are left untouched.
+func (c *CPU) Reset() {
+	for i := 0; i < c.SRAMStart && i < len(c.Data); i++ {
+		c.Data[i] = 0
+	}
+	c.SetSP(uint16(c.DataSize - 1))
+	c.PC = 0
+	c.Cycles = 0
+	c.Sleeping = false
+	c.pending = 0
+	c.inhibit = false
+	c.fault = nil
+}
+
+func (c *CPU) HookRead(addr uint16, fn ReadHook) {
+	if fn == nil {
+		delete(c.reads, addr)
+		return
+	}
+	c.reads[addr] = fn
+}
+
+func (c *CPU) HookWrite(addr uint16, fn WriteHook) {
+	if fn == nil {
+		delete(c.writes, addr)
+		return
+	}
+	c.writes[addr] = fn
+}
+
+// Read reads the data space through the IO hooks.
+func (c *CPU) Read(addr uint16) byte {
+	if fn := c.reads[addr]; fn != nil {
+		return fn(c, addr)
+	}
+	if int(addr) >= len(c.Data) {
+		c.faultf("read from %#x outside of data space", addr)
+		return 0
+	}
+	return c.Data[addr]
+}
+
+// Write writes the data space through the IO hooks.
+func (c *CPU) Write(addr uint16, val byte) {
+	if int(addr) >= len(c.Data) {
+		c.faultf("write to %#x outside of data space", addr)
+		return
+	}
+	c.Data[addr] = val
+	if fn := c.writes[addr]; fn != nil {
+		fn(c, addr, val)
+	}
+}
+
+func (c *CPU) faultf(format string, args ...interface{}) {
+	if c.fault == nil {
+		c.fault = fmt.Errorf("atmegasim: %#x: %s", c.PC, fmt.Sprintf(format, args...))
+	}
+}
+
+func (c *CPU) Reg(n int) byte {
+	return c.Data[n]
+}
+
+func (c *CPU) SetReg(n int, val byte) {
+	c.Data[n] = val
+}
+
+func (c *CPU) SP() uint16 {
+	return uint16(c.Data[SPL]) | uint16(c.Data[SPH])<<8
+}
+
+func (c *CPU) SetSP(sp uint16) {
+	c.Data[SPL] = uint8(sp)
+	c.Data[SPH] = uint8(sp >> 8)
+}
+
+func (c *CPU) Flag(f byte) bool {
+	return c.Data[SREG]&f != 0
+}
+
+func (c *CPU) SetFlag(f byte, b bool) {
+	if b {
+		c.Data[SREG] |= f
+	} else {
+		c.Data[SREG] &^= f
+	}
+}
+
+// Interrupt raises the interrupt of a vector number, it is serviced once
+// interrupts are enabled and no lower numbered vector is pending. Raising
+// an interrupt acknowledges it, level triggered sources have to be raised
+// again.
+func (c *CPU) Interrupt(vector int) {
+	c.pending |= 1 << uint(vector)
+}
+
+func (c *CPU) Pending(vector int) bool {
+	return c.pending&(1<<uint(vector)) != 0
+}
+
+func (c *CPU) push(val byte) {
+	sp := c.SP()
+	c.Write(sp, val)
+	c.SetSP(sp - 1)
+}
+
+func (c *CPU) pop() byte {
+	sp := c.SP() + 1
+	c.SetSP(sp)
+	return c.Read(sp)
+}
+
+// pushPC pushes a byte address as a word address, low byte first.
+func (c *CPU) pushPC(pc uint32) {
+	pc >>= 1
+	for i := 0; i < c.PCBytes; i++ {
+		c.push(uint8(pc >> uint(8*i)))
+	}
+}
+
+func (c *CPU) popPC() uint32 {
+	pc := uint32(0)
+	for i := 0; i < c.PCBytes; i++ {
+		pc = pc<<8 | uint32(c.pop())
+	}
+	return pc << 1
+}
+
+// LoadMap copies a memory image into flash.
+func (c *CPU) LoadMap(m *memmap.Map) error {
+	for _, s := range m.Segments {
+		if s.Addr+uint64(len(s.Data)) > uint64(len(c.Flash)) {
+			return fmt.Errorf("atmegasim: %v does not fit in flash", s.Range())
+		}
+		copy(c.Flash[s.Addr:], s.Data)
+	}
+	return nil
+}
+
+// LoadSREC loads an S-record image into flash and starts at its start
+// address.
+func (c *CPU) LoadSREC(f *srec.File) error {
+	m, err := f.Map()
+	if err != nil {
+		return err
+	}
+	if err := c.LoadMap(m); err != nil {
+		return err
+	}
+	c.PC = uint32(f.Start)
+	return nil
+}
+
+// LoadELF loads the segments of an ELF file by their physical address,
+// using the avr-gcc offsets to place them in flash, SRAM or EEPROM, and
+// starts at the entry point.
+func (c *CPU) LoadELF(f *elfutil.File) error {
+	for _, p := range f.Progs {
+		if p.Type != elf.PT_LOAD || len(p.Data) == 0 {
+			continue
+		}
+
+		var mem []byte
+		addr := p.Paddr
+		switch {
+		case addr >= ELF_EEPROM:
+			mem, addr = c.EEPROM, addr-ELF_EEPROM
+		case addr >= ELF_DATA:
+			mem, addr = c.Data, addr-ELF_DATA
+		default:
+			mem = c.Flash
+		}
+		if addr+uint64(len(p.Data)) > uint64(len(mem)) {
+			return fmt.Errorf("atmegasim: segment at %#x of size %#x does not fit", p.Paddr, len(p.Data))
+		}
+		copy(mem[addr:], p.Data)
+	}
+	c.PC = uint32(f.Entry)
+	return nil
+}
+
+// eepromControl implements the EECR register, reads and writes complete
+// immediately.
+func eepromControl(c *CPU, addr uint16, val byte) {
+	ea := int(c.Data[c.EEARL]) | int(c.Data[c.EEARH])<<8
+	if len(c.EEPROM) > 0 {
+		ea %= len(c.EEPROM)
+	}
+	switch {
+	case val&EERE != 0:
+		if ea < len(c.EEPROM) {
+			c.Data[c.EEDR] = c.EEPROM[ea]
+		}
+		c.Cycles += 4
+
+	case val&EEPE != 0:
+		if c.eempe == 0 || c.Cycles > c.eempe+4 || ea >= len(c.EEPROM) {
+			break
+		}
+		switch val & (EEPM0 | EEPM1) {
+		case 0:
+			c.EEPROM[ea] = c.Data[c.EEDR]
+		case EEPM0:
+			c.EEPROM[ea] = 0xff
+		case EEPM1:
+			c.EEPROM[ea] &= c.Data[c.EEDR]
+		}
+		c.eempe = 0
+
+	case val&EEMPE != 0:
+		c.eempe = c.Cycles + 1
+		return
+	}
+	c.Data[addr] &^= EERE | EEPE | EEMPE
+}
+
+// Step services a pending interrupt or executes one instruction.
+func (c *CPU) Step() error {
+	cycles, err := c.step()
+	c.Cycles += uint64(cycles)
+	if c.Tick != nil {
+		c.Tick(c, cycles)
+	}
+	if err == nil {
+		err = c.fault
+	}
+	c.fault = nil
+	return err
+}
+
+// Run steps until a breakpoint or BREAK instruction is reached, an error
+// occurs or limit cycles have elapsed, a limit of 0 runs without bound.
+// A breakpoint at the starting PC is ignored so that a stopped simulation
+// can be resumed.
+func (c *CPU) Run(limit uint64) error {
+	start := c.Cycles
+	for first := true; ; first = false {
+		if !first && c.Breakpoints[c.PC] {
+			return ErrBreakpoint
+		}
+		if limit != 0 && c.Cycles-start >= limit {
+			return ErrLimit
+		}
+		if err := c.Step(); err != nil {
+			return err
+		}
+	}
+}
+
+// RunUntil runs until the PC reaches addr.
+func (c *CPU) RunUntil(addr uint32, limit uint64) error {
+	if !c.Breakpoints[addr] {
+		c.Breakpoints[addr] = true
+		defer delete(c.Breakpoints, addr)
+	}
+	return c.Run(limit)
+}
@@ -0,0 +1,698 @@
+package elfutil
+
+import (
+	"debug/elf"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/qeedquan/go-media/math/mathutil"
+)
+
+// number of unused program headers reserved when the table has to move
+const phdrReserve = 2
+
+func (s *Section) size() uint64 {
+	if s.Type == elf.SHT_NOBITS {
+		return s.Size
+	}
+	return uint64(len(s.Data))
+}
+
+// sizes returns the size of the file header, a program header, a section
+// header and a symbol along with the word size.
+func (f *File) sizes() (ehsize, phentsize, shentsize, symsize, word uint64) {
+	if f.Class == elf.ELFCLASS32 {
+		return 0x34, 0x20, 0x28, 0x10, 4
+	}
+	return 0x40, 0x38, 0x40, 0x18, 8
+}
+
+// CreateSection appends a zero filled section of the given size, the data
+// can be modified afterwards. Sections are only loaded when a segment
+// covers them, see LoadSection.
+func (f *File) CreateSection(name string, typ elf.SectionType, flags elf.SectionFlag, addr, size uint64) (*Section, error) {
+	if _, s := f.Section(name); s != nil {
+		return nil, fmt.Errorf("elf: section %s already exist", name)
+	}
+	if len(f.Sections) == 0 {
+		f.Sections = append(f.Sections, &Section{Section: &elf.Section{}})
+	}
+
+	s := &Section{
+		Section: &elf.Section{
+			SectionHeader: elf.SectionHeader{
+				Name:      name,
+				Type:      typ,
+				Flags:     flags,
+				Addr:      addr,
+				Addralign: 1,
+			},
+		},
+		new: true,
+	}
+	if typ == elf.SHT_NOBITS {
+		s.Size = size
+	} else {
+		s.Data = make([]byte, size)
+	}
+	f.Sections = append(f.Sections, s)
+	return s, nil
+}
+
+// ResizeSection truncates or zero extends a section. An allocated section
+// of an executable or shared object can only grow into the free space up to
+// the next section or at the end of its segment, see Layout.
+func (f *File) ResizeSection(name string, size uint64) error {
+	_, s := f.Section(name)
+	if s == nil {
+		return fmt.Errorf("elf: section %s does not exist", name)
+	}
+	if err := f.checkGrow(s, size); err != nil {
+		return err
+	}
+	if s.Type == elf.SHT_NOBITS {
+		s.Size = size
+	} else if n := uint64(len(s.Data)); size > n {
+		s.Data = append(s.Data, make([]byte, size-n)...)
+	} else {
+		s.Data = s.Data[:size]
+	}
+	return nil
+}
+
+// checkGrow returns an error if an allocated section of a linked file
+// can't grow to size without moving the sections after it, code refers to
+// them by address and can't be relocated.
+func (f *File) checkGrow(s *Section, size uint64) error {
+	if f.Type == elf.ET_REL || s.new || s.Flags&elf.SHF_ALLOC == 0 || size <= s.laid {
+		return nil
+	}
+	var load *Prog
+	for _, p := range f.Progs {
+		if p.Type == elf.PT_LOAD && !p.new && p.Vaddr <= s.Addr && s.Addr <= p.Vaddr+p.Memsz {
+			load = p
+		}
+	}
+	if load == nil {
+		return nil
+	}
+
+	// thread local bss overlaps the sections after it in memory
+	tbss := func(s *Section) bool {
+		return s.Type == elf.SHT_NOBITS && s.Flags&elf.SHF_TLS != 0
+	}
+	limit := ^uint64(0)
+	for _, t := range f.Sections {
+		if t == s || t.new || t.Flags&elf.SHF_ALLOC == 0 {
+			continue
+		}
+		if tbss(s) && t.Flags&elf.SHF_TLS == 0 || !tbss(s) && tbss(t) {
+			continue
+		}
+		if t.Addr >= s.Addr+s.laid && t.Addr < load.Vaddr+load.Memsz {
+			limit = mathutil.Min64(limit, t.Addr)
+		}
+	}
+	if s.Addr+size > limit {
+		return fmt.Errorf("elf: section %s can't grow past %#x without moving the sections after it", s.Name, limit)
+	}
+	return nil
+}
+
+// DeleteSection removes a section header, section links and symbol section
+// indices are renumbered. The contents of a deleted section that is part of
+// a segment stay in the segment.
+func (f *File) DeleteSection(name string) error {
+	i, s := f.Section(name)
+	if s == nil {
+		return fmt.Errorf("elf: section %s does not exist", name)
+	}
+	if i == 0 {
+		return fmt.Errorf("elf: can't delete the null section")
+	}
+	f.Sections = append(f.Sections[:i], f.Sections[i+1:]...)
+
+	idx := func(j uint32) uint32 {
+		switch {
+		case j == uint32(i):
+			return 0
+		case j > uint32(i):
+			return j - 1
+		}
+		return j
+	}
+	for _, s := range f.Sections {
+		s.Link = idx(s.Link)
+		if s.Type == elf.SHT_REL || s.Type == elf.SHT_RELA || s.Flags&elf.SHF_INFO_LINK != 0 {
+			s.Info = idx(s.Info)
+		}
+		if s.Type == elf.SHT_SYMTAB || s.Type == elf.SHT_DYNSYM {
+			f.walkSymbols(s, func(shndx *uint16, value *uint64) {
+				if *shndx < uint16(elf.SHN_LORESERVE) {
+					*shndx = uint16(idx(uint32(*shndx)))
+				}
+			})
+		}
+	}
+	return nil
+}
+
+// CreateProg appends a segment holding data, the file offset is picked by
+// Layout and a zero address for a loadable segment is placed after every
+// other loadable segment. Unused program headers are reused.
+func (f *File) CreateProg(typ elf.ProgType, flags elf.ProgFlag, vaddr, align uint64, data []byte) *Prog {
+	p := &Prog{
+		Prog: &elf.Prog{
+			ProgHeader: elf.ProgHeader{
+				Type:   typ,
+				Flags:  flags,
+				Vaddr:  vaddr,
+				Paddr:  vaddr,
+				Filesz: uint64(len(data)),
+				Memsz:  uint64(len(data)),
+				Align:  align,
+			},
+		},
+		Data: data,
+		new:  true,
+	}
+	for i := range f.Progs {
+		if f.Progs[i].Type == elf.PT_NULL {
+			f.Progs[i] = p
+			return p
+		}
+	}
+	f.Progs = append(f.Progs, p)
+	return p
+}
+
+// LoadSection creates a loadable segment for a section that is not part of
+// any segment, a zero section address is assigned by Layout.
+func (f *File) LoadSection(name string, flags elf.ProgFlag) (*Prog, error) {
+	_, s := f.Section(name)
+	if s == nil {
+		return nil, fmt.Errorf("elf: section %s does not exist", name)
+	}
+	for _, p := range f.Progs {
+		if p.sec == s {
+			return nil, fmt.Errorf("elf: section %s is already loaded", name)
+		}
+	}
+	s.Flags |= elf.SHF_ALLOC
+	p := f.CreateProg(elf.PT_LOAD, flags, s.Addr, f.pageSize(), nil)
+	p.sec = s
+	return p, nil
+}
+
+func (f *File) DeleteProg(i int) error {
+	if i < 0 || i >= len(f.Progs) {
+		return fmt.Errorf("elf: program header %d out of range", i)
+	}
+	f.Progs = append(f.Progs[:i], f.Progs[i+1:]...)
+	return nil
+}
+
+func (f *File) pageSize() uint64 {
+	a := uint64(0)
+	for _, p := range f.Progs {
+		if p.Type == elf.PT_LOAD && !p.new {
+			a = mathutil.Max64(a, p.Align)
+		}
+	}
+	if a == 0 {
+		a = 0x1000
+	}
+	return a
+}
+
+// Write lays out the file and writes it.
+func (f *File) Write(w io.Writer) error {
+	err := f.Layout()
+	if err != nil {
+		return err
+	}
+	return Format(f, w)
+}
+
+// segment tracks how the contents of an existing loadable segment moved,
+// marks hold the old relative offset of every section inside of it and the
+// distance it moved.
+type segment struct {
+	p      *Prog
+	off    uint64
+	filesz uint64
+	vaddr  uint64
+	memsz  uint64
+	secs   []*Section
+	marks  []mark
+}
+
+type mark struct {
+	rel   uint64
+	delta uint64
+	sec   *Section
+}
+
+func (g *segment) delta(rel uint64) uint64 {
+	d := uint64(0)
+	for _, m := range g.marks {
+		if m.rel > rel {
+			break
+		}
+		d = m.delta
+	}
+	return d
+}
+
+func (g *segment) containsOff(off, size uint64) bool {
+	return g.off <= off && off+size <= g.off+g.filesz && off < g.off+mathutil.Max64(g.filesz, 1)
+}
+
+func (g *segment) containsAddr(addr uint64) bool {
+	return g.vaddr <= addr && addr < g.vaddr+g.memsz
+}
+
+// Layout assigns file offsets after sections and segments were added,
+// resized or removed. Loadable segments keep their addresses and the
+// position of their contents relative to each other. Allocated sections
+// keep their addresses too, since code refers to them by address, so one
+// can only grow into the free space after it or at the end of its
+// segment. Sections outside of segments are packed after the loaded
+// contents followed by the section headers. If the program headers no
+// longer fit they are moved to a new loadable segment.
+func (f *File) Layout() error {
+	for _, s := range f.Sections {
+		if err := f.checkGrow(s, s.size()); err != nil {
+			return err
+		}
+	}
+
+	ehsize, phentsize, shentsize, _, word := f.sizes()
+	f.Phentsize, f.Shentsize = phentsize, shentsize
+	f.layoutStrtab()
+
+	// assign the sections to the existing loadable segments holding them
+	var segs []*segment
+	for _, p := range f.Progs {
+		if p.Type == elf.PT_LOAD && !p.new {
+			segs = append(segs, &segment{
+				p:      p,
+				off:    p.Off,
+				filesz: p.Filesz,
+				vaddr:  p.Vaddr,
+				memsz:  p.Memsz,
+			})
+		}
+	}
+	sort.SliceStable(segs, func(i, j int) bool {
+		return segs[i].off < segs[j].off
+	})
+
+	owned := make(map[*Section]bool)
+	for _, p := range f.Progs {
+		if p.sec != nil {
+			owned[p.sec] = true
+		}
+	}
+	for i, s := range f.Sections {
+		if i == 0 || s.new || owned[s] || s.Type == elf.SHT_NULL {
+			continue
+		}
+		for _, g := range segs {
+			var ok bool
+			if s.Type == elf.SHT_NOBITS {
+				ok = s.Flags&elf.SHF_ALLOC != 0 && g.containsAddr(s.Addr)
+			} else {
+				ok = g.containsOff(s.Offset, s.laid)
+			}
+			if ok {
+				g.secs = append(g.secs, s)
+				owned[s] = true
+				break
+			}
+		}
+	}
+
+	// the program headers stay where they are if they still fit
+	phsize := uint64(len(f.Progs)) * phentsize
+	oldph := f.Phoff
+	var phseg *segment
+	moveph := false
+	if len(f.Progs) > 0 {
+		for _, g := range segs {
+			if g.off <= oldph && oldph < g.off+g.filesz {
+				phseg = g
+			}
+		}
+		limit := ^uint64(0)
+		if phseg != nil {
+			limit = phseg.off + phseg.filesz
+		} else {
+			oldph = ehsize
+		}
+		for _, g := range segs {
+			if g != phseg && g.off >= oldph {
+				limit = mathutil.Min64(limit, g.off)
+			}
+			for _, s := range g.secs {
+				if s.Type != elf.SHT_NOBITS && s.Offset >= oldph && s.laid > 0 {
+					limit = mathutil.Min64(limit, s.Offset)
+				}
+			}
+		}
+		if oldph+phsize > limit {
+			moveph = true
+			p := &Prog{
+				Prog: &elf.Prog{
+					ProgHeader: elf.ProgHeader{
+						Type:  elf.PT_LOAD,
+						Flags: elf.PF_R,
+						Align: f.pageSize(),
+					},
+				},
+				new:  true,
+				phdr: true,
+			}
+			f.Progs = append(f.Progs, p)
+			for i := 0; i < phdrReserve; i++ {
+				f.Progs = append(f.Progs, &Prog{Prog: &elf.Prog{}})
+			}
+			phsize = uint64(len(f.Progs)) * phentsize
+		}
+	}
+
+	// move the existing segments forward if the ones before them grew
+	// a segment at the start of the file holds the headers
+	cursor := ehsize
+	if len(segs) > 0 && segs[0].off == 0 {
+		cursor = 0
+	}
+	if len(f.Progs) > 0 && !moveph && phseg == nil {
+		f.Phoff = ehsize
+		cursor += phsize
+	}
+	for _, g := range segs {
+		p := g.p
+		off := g.off
+		if off < cursor {
+			if a := p.Align; a > 1 {
+				off += mathutil.Multiple64(cursor-off, a)
+			} else {
+				off = cursor
+			}
+		}
+
+		// lay out the sections inside relative to the segment start
+		sort.SliceStable(g.secs, func(i, j int) bool {
+			return g.secs[i].Offset < g.secs[j].Offset
+		})
+		end := uint64(0)
+		if g == phseg && !moveph {
+			end = oldph - g.off + phsize
+		}
+		for _, s := range g.secs {
+			if s.Type == elf.SHT_NOBITS {
+				continue
+			}
+			rel := s.Offset - g.off
+			nrel := mathutil.Max64(rel, end)
+			if a := s.Addralign; a > 1 {
+				nrel = mathutil.Multiple64(g.vaddr+nrel, a) - g.vaddr
+			}
+			g.marks = append(g.marks, mark{rel, nrel - rel, s})
+			end = nrel + s.size()
+		}
+
+		filesz := g.filesz + g.delta(g.filesz)
+		filesz = mathutil.Max64(filesz, end)
+		data := make([]byte, filesz)
+		copy(data, p.Data)
+		for i, m := range g.marks {
+			hi := g.filesz
+			if i+1 < len(g.marks) {
+				hi = g.marks[i+1].rel
+			}
+			hi = mathutil.Min64(hi, uint64(len(p.Data)))
+			if m.delta > 0 && m.rel < hi {
+				copy(data[m.rel+m.delta:], p.Data[m.rel:hi])
+			}
+		}
+
+		for _, s := range g.secs {
+			if s.Type == elf.SHT_NOBITS {
+				continue
+			}
+			d := g.delta(s.Offset - g.off)
+			s.Offset = off + s.Offset - g.off + d
+			if s.Flags&elf.SHF_ALLOC != 0 {
+				f.moveSection(s, s.Addr+d)
+			}
+		}
+
+		// uninitialized data follows the file contents in memory
+		mend := filesz
+		for _, s := range g.secs {
+			if s.Type != elf.SHT_NOBITS {
+				continue
+			}
+			addr := s.Addr
+			if s.Flags&elf.SHF_TLS == 0 {
+				addr = mathutil.Max64(addr, g.vaddr+mend)
+				if a := s.Addralign; a > 1 {
+					addr = mathutil.Multiple64(addr, a)
+				}
+				mend = addr - g.vaddr + s.Size
+				s.Offset = off + filesz
+			} else {
+				s.Offset = off + s.Offset - g.off + g.delta(s.Offset-g.off)
+			}
+			f.moveSection(s, addr)
+		}
+
+		if g == phseg && !moveph {
+			f.Phoff = off + oldph - g.off
+		}
+		if f.Entry >= g.vaddr && f.Entry < g.vaddr+g.filesz {
+			f.Entry += g.delta(f.Entry - g.vaddr)
+		}
+
+		p.Off = off
+		p.Filesz = filesz
+		p.Memsz = mathutil.Max64(g.memsz, mend)
+		p.Data = data
+		cursor = off + filesz
+	}
+
+	byaddr := append([]*segment{}, segs...)
+	sort.SliceStable(byaddr, func(i, j int) bool {
+		return byaddr[i].vaddr < byaddr[j].vaddr
+	})
+	for i := 1; i < len(byaddr); i++ {
+		p, q := byaddr[i-1].p, byaddr[i].p
+		if p.Vaddr+p.Memsz > q.Vaddr {
+			return fmt.Errorf("elf: segment at %#x grew into segment at %#x", p.Vaddr, q.Vaddr)
+		}
+	}
+
+	// the other segments describe parts of the loaded ones
+	for _, p := range f.Progs {
+		if p.Type == elf.PT_LOAD || p.new || p.Filesz == 0 {
+			continue
+		}
+		for _, g := range segs {
+			if !g.containsOff(p.Off, p.Filesz) {
+				continue
+			}
+			rel := p.Off - g.off
+			erel := rel + p.Filesz
+			d := g.delta(rel)
+			end := erel + g.delta(erel-1)
+			for _, m := range g.marks {
+				if m.rel >= rel && m.rel+m.sec.laid <= erel {
+					end = mathutil.Max64(end, m.rel+m.delta+m.sec.size())
+				}
+			}
+			bss := p.Memsz - p.Filesz
+			p.Off = g.p.Off + rel + d
+			p.Vaddr += d
+			p.Paddr += d
+			p.Filesz = end - (rel + d)
+			p.Memsz = p.Filesz + bss
+			break
+		}
+	}
+
+	// new segments go after everything that is loaded
+	top := uint64(0)
+	for _, p := range f.Progs {
+		if p.Type == elf.PT_LOAD && !p.new {
+			top = mathutil.Max64(top, p.Vaddr+p.Memsz)
+		}
+	}
+	place := func(p *Prog) {
+		a := mathutil.Max64(p.Align, 1)
+		if p.Vaddr == 0 && p.Type == elf.PT_LOAD {
+			p.Vaddr = mathutil.Multiple64(top, a)
+			p.Paddr = p.Vaddr
+		}
+		p.Off = cursor + (p.Vaddr%a+a-cursor%a)%a
+		cursor = p.Off + p.Filesz
+		if p.Type == elf.PT_LOAD {
+			top = mathutil.Max64(top, p.Vaddr+p.Memsz)
+		}
+	}
+	if moveph {
+		for _, p := range f.Progs {
+			if p.phdr {
+				p.Filesz, p.Memsz = phsize, phsize
+				p.Data = nil
+				place(p)
+				f.Phoff = p.Off
+			}
+		}
+	}
+	for _, p := range f.Progs {
+		if !p.new || p.phdr {
+			continue
+		}
+		if s := p.sec; s != nil {
+			p.Data = s.Data
+			p.Filesz = uint64(len(s.Data))
+			p.Memsz = mathutil.Max64(p.Memsz, s.size())
+			if s.Type == elf.SHT_NOBITS {
+				p.Data, p.Filesz = nil, 0
+			}
+			if s.Addr != 0 {
+				p.Vaddr, p.Paddr = s.Addr, s.Addr
+			}
+		}
+		place(p)
+		if s := p.sec; s != nil {
+			s.Offset = p.Off
+			s.Addr = p.Vaddr
+		}
+	}
+
+	for _, p := range f.Progs {
+		if p.Type == elf.PT_PHDR {
+			p.Off, p.Filesz, p.Memsz = f.Phoff, phsize, phsize
+			for _, q := range f.Progs {
+				if q.Type == elf.PT_LOAD && q.Off <= f.Phoff && f.Phoff+phsize <= q.Off+q.Filesz {
+					p.Vaddr = q.Vaddr + f.Phoff - q.Off
+					p.Paddr = q.Paddr + f.Phoff - q.Off
+				}
+			}
+		}
+	}
+	if len(f.Progs) == 0 {
+		f.Phoff = 0
+	}
+
+	// loadable segments have to be sorted by address
+	var loads []int
+	for i, p := range f.Progs {
+		if p.Type == elf.PT_LOAD {
+			loads = append(loads, i)
+		}
+	}
+	sorted := make([]*Prog, len(loads))
+	for i, j := range loads {
+		sorted[i] = f.Progs[j]
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Vaddr < sorted[j].Vaddr
+	})
+	for i, j := range loads {
+		f.Progs[j] = sorted[i]
+	}
+
+	// pack the rest
+	for i, s := range f.Sections {
+		switch {
+		case i == 0 || s.Type == elf.SHT_NULL:
+			s.Offset = 0
+		case owned[s]:
+		case s.Type == elf.SHT_NOBITS:
+			s.Offset = cursor
+		default:
+			s.Offset = mathutil.Multiple64(cursor, mathutil.Max64(s.Addralign, 1))
+			cursor = s.Offset + uint64(len(s.Data))
+		}
+	}
+	if len(f.Sections) > 0 {
+		f.Shoff = mathutil.Multiple64(cursor, word)
+	} else {
+		f.Shoff = 0
+	}
+
+	for _, s := range f.Sections {
+		s.Size = s.size()
+		if s.Type != elf.SHT_NOBITS {
+			s.FileSize = s.Size
+		}
+		s.laid = s.Size
+		s.new = false
+	}
+	for _, p := range f.Progs {
+		p.new = false
+	}
+	return nil
+}
+
+// moveSection changes the address of a section, in executables and shared
+// objects the symbols defined in it move along.
+func (f *File) moveSection(s *Section, addr uint64) {
+	d := addr - s.Addr
+	s.Addr = addr
+	if d == 0 || f.Type == elf.ET_REL {
+		return
+	}
+	i := 0
+	for i = range f.Sections {
+		if f.Sections[i] == s {
+			break
+		}
+	}
+	for _, t := range f.Sections {
+		if t.Type == elf.SHT_SYMTAB || t.Type == elf.SHT_DYNSYM {
+			f.walkSymbols(t, func(shndx *uint16, value *uint64) {
+				if int(*shndx) == i {
+					*value += d
+				}
+			})
+		}
+	}
+}
+
+// layoutStrtab rebuilds the section name table if a name is missing.
+func (f *File) layoutStrtab() {
+	if len(f.Sections) == 0 {
+		return
+	}
+	_, t := f.Section(".shstrtab")
+	if t == nil {
+		t, _ = f.CreateSection(".shstrtab", elf.SHT_STRTAB, 0, 0, 0)
+	}
+
+	missing := false
+	for _, s := range f.Sections {
+		if strtabIndex(t.Data, s.Name) < 0 {
+			missing = true
+		}
+	}
+	if !missing {
+		return
+	}
+
+	b := []byte{0}
+	seen := make(map[string]bool)
+	for _, s := range f.Sections {
+		if s.Name != "" && !seen[s.Name] {
+			seen[s.Name] = true
+			b = append(b, s.Name...)
+			b = append(b, 0)
+		}
+	}
+	t.Data = b
+}
@@ -26,11 +26,21 @@ type File struct {
 type Section struct {
 	*elf.Section
 	Data []byte
+
+	// size at the last layout, new marks sections not placed yet
+	laid uint64
+	new  bool
 }
 
 type Prog struct {
 	*elf.Prog
 	Data []byte
+
+	// sec is the section a new segment loads, phdr marks the segment
+	// holding a relocated program header table
+	new  bool
+	sec  *Section
+	phdr bool
 }
 
 var (
@@ -58,14 +68,27 @@ func newFile(e *elf.File, r io.ReaderAt) (*File, error) {
 		File: e,
 	}
 	for _, s := range e.Sections {
-		b, err := io.ReadAll(s.Open())
+		var (
+			b   []byte
+			err error
+		)
+		switch {
+		case s.Type == elf.SHT_NOBITS:
+		case s.Flags&elf.SHF_COMPRESSED != 0:
+			// keep compressed sections as they are stored in the file
+			b, err = io.ReadAll(io.NewSectionReader(r, int64(s.Offset), int64(s.FileSize)))
+		default:
+			b, err = io.ReadAll(s.Open())
+		}
 		if err != nil {
 			return nil, err
 		}
-		f.Sections = append(f.Sections, &Section{
+		t := &Section{
 			Section: s,
 			Data:    b,
-		})
+		}
+		t.laid = t.size()
+		f.Sections = append(f.Sections, t)
 	}
 	for _, p := range e.Progs {
 		b, err := io.ReadAll(p.Open())
@@ -116,14 +139,31 @@ func (f *File) Section(name string) (int, *Section) {
 	return -1, nil
 }
 
+// Format writes the file using the offsets it already has, call Layout
+// first after modifying it or use Write.
 func Format(f *File, w io.Writer) error {
 	b := new(debug.Patchset)
 
+	// the headers are written last since the first segment usually holds
+	// a stale copy of them
+	for _, p := range f.Progs {
+		b.Seek(int64(p.Off), io.SeekStart)
+		b.Write(p.Data)
+	}
+
+	for _, s := range f.Sections {
+		if s.Type != elf.SHT_NOBITS {
+			b.Seek(int64(s.Offset), io.SeekStart)
+			b.Write(s.Data)
+		}
+	}
+
 	shstrndx, strtab := f.Section(".shstrtab")
 	if shstrndx < 0 {
 		shstrndx = 0
 	}
 
+	b.Seek(0, io.SeekStart)
 	switch f.Class {
 	case elf.ELFCLASS32:
 		binary.Write(b, f.ByteOrder, &elf.Header32{
@@ -139,7 +179,7 @@ func Format(f *File, w io.Writer) error {
 			Phoff:     uint32(f.Phoff),
 			Shoff:     uint32(f.Shoff),
 			Ehsize:    0x34,
-			Flags:     0,
+			Flags:     f.Flags,
 			Phentsize: uint16(f.Phentsize),
 			Phnum:     uint16(len(f.Progs)),
 			Shentsize: uint16(f.Shentsize),
@@ -161,7 +201,7 @@ func Format(f *File, w io.Writer) error {
 			Phoff:     f.Phoff,
 			Shoff:     f.Shoff,
 			Ehsize:    0x40,
-			Flags:     0,
+			Flags:     f.Flags,
 			Phentsize: uint16(f.Phentsize),
 			Phnum:     uint16(len(f.Progs)),
 			Shentsize: uint16(f.Shentsize),
@@ -198,15 +238,10 @@ func Format(f *File, w io.Writer) error {
 		}
 	}
 
-	for _, p := range f.Progs {
-		b.Seek(int64(p.Off), io.SeekStart)
-		b.Write(p.Data)
-	}
-
 	for i, s := range f.Sections {
 		nameoff := 0
 		if strtab != nil {
-			off := bytes.Index(strtab.Data, []byte(s.Name))
+			off := strtabIndex(strtab.Data, s.Name)
 			if off > 0 {
 				nameoff = off
 			}
@@ -242,13 +277,19 @@ func Format(f *File, w io.Writer) error {
 		}
 	}
 
-	for _, s := range f.Sections {
-		b.Seek(int64(s.Offset), io.SeekStart)
-		b.Write(s.Data)
-	}
-
 	b.Merge()
 
 	_, err := w.Write(b.Data)
 	return err
 }
+
+// strtabIndex returns the offset of name in a string table or -1.
+func strtabIndex(b []byte, name string) int {
+	if name == "" {
+		if len(b) > 0 && b[0] == 0 {
+			return 0
+		}
+		return -1
+	}
+	return bytes.Index(b, append([]byte(name), 0))
+}
@@ -0,0 +1,129 @@
+package elfutil
+
+import (
+	"debug/elf"
+	"fmt"
+)
+
+type Reloc struct {
+	Off       uint64
+	Type      RelocType
+	Sym       uint32
+	Symbol    string
+	Addend    int64
+	HasAddend bool
+}
+
+// RelocType is a machine specific relocation type.
+type RelocType struct {
+	Machine elf.Machine
+	Type    uint32
+}
+
+func (r RelocType) String() string {
+	switch r.Machine {
+	case elf.EM_X86_64:
+		return elf.R_X86_64(r.Type).String()
+	case elf.EM_386:
+		return elf.R_386(r.Type).String()
+	case elf.EM_ARM:
+		return elf.R_ARM(r.Type).String()
+	case elf.EM_AARCH64:
+		return elf.R_AARCH64(r.Type).String()
+	case elf.EM_RISCV:
+		return elf.R_RISCV(r.Type).String()
+	}
+	return fmt.Sprintf("R_%d", r.Type)
+}
+
+func (r Reloc) String() string {
+	s := fmt.Sprintf("%#x %v %s", r.Off, r.Type, r.Symbol)
+	if r.HasAddend {
+		s += fmt.Sprintf("%+#x", r.Addend)
+	}
+	return s
+}
+
+// ReadRelocs decodes a SHT_REL or SHT_RELA section.
+func (f *File) ReadRelocs(s *Section) ([]Reloc, error) {
+	if s.Type != elf.SHT_REL && s.Type != elf.SHT_RELA {
+		return nil, fmt.Errorf("elf: section %s is not a relocation section", s.Name)
+	}
+
+	var syms []elf.Symbol
+	if s.Link != 0 && int(s.Link) < len(f.Sections) {
+		var err error
+		syms, err = f.ReadSymbols(f.Sections[s.Link])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rela := s.Type == elf.SHT_RELA
+	size := 8
+	if f.Class == elf.ELFCLASS64 {
+		size = 16
+	}
+	if rela {
+		size += size / 2
+	}
+
+	var rels []Reloc
+	o := f.ByteOrder
+	for b := s.Data; len(b) >= size; b = b[size:] {
+		r := Reloc{HasAddend: rela}
+		switch f.Class {
+		case elf.ELFCLASS32:
+			info := o.Uint32(b[4:])
+			r.Off = uint64(o.Uint32(b))
+			r.Sym = elf.R_SYM32(info)
+			r.Type = RelocType{f.Machine, elf.R_TYPE32(info)}
+			if rela {
+				r.Addend = int64(int32(o.Uint32(b[8:])))
+			}
+		case elf.ELFCLASS64:
+			info := o.Uint64(b[8:])
+			r.Off = o.Uint64(b)
+			r.Sym = elf.R_SYM64(info)
+			r.Type = RelocType{f.Machine, elf.R_TYPE64(info)}
+			if rela {
+				r.Addend = int64(o.Uint64(b[16:]))
+			}
+		default:
+			return nil, ErrInvalidClass
+		}
+		if int(r.Sym) < len(syms) {
+			y := syms[r.Sym]
+			r.Symbol = y.Name
+			if r.Symbol == "" && elf.ST_TYPE(y.Info) == elf.STT_SECTION && int(y.Section) < len(f.Sections) {
+				r.Symbol = f.Sections[y.Section].Name
+			}
+		}
+		rels = append(rels, r)
+	}
+	return rels, nil
+}
+
+// Relocs returns the relocations applying to a section, if name is a
+// relocation section itself its entries are returned.
+func (f *File) Relocs(name string) ([]Reloc, error) {
+	i, s := f.Section(name)
+	if s == nil {
+		return nil, fmt.Errorf("elf: section %s does not exist", name)
+	}
+	if s.Type == elf.SHT_REL || s.Type == elf.SHT_RELA {
+		return f.ReadRelocs(s)
+	}
+
+	var rels []Reloc
+	for _, r := range f.Sections {
+		if (r.Type == elf.SHT_REL || r.Type == elf.SHT_RELA) && int(r.Info) == i && i != 0 {
+			l, err := f.ReadRelocs(r)
+			if err != nil {
+				return nil, err
+			}
+			rels = append(rels, l...)
+		}
+	}
+	return rels, nil
+}
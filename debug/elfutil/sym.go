@@ -0,0 +1,224 @@
+package elfutil
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"fmt"
+)
+
+// walkSymbols calls fn with the section index and value of every entry of
+// a symbol table, changes are written back.
+func (f *File) walkSymbols(s *Section, fn func(shndx *uint16, value *uint64)) {
+	_, _, _, symsize, _ := f.sizes()
+	o := f.ByteOrder
+	for b := s.Data; uint64(len(b)) >= symsize; b = b[symsize:] {
+		var (
+			shndx uint16
+			value uint64
+		)
+		if f.Class == elf.ELFCLASS32 {
+			value = uint64(o.Uint32(b[4:]))
+			shndx = o.Uint16(b[14:])
+			fn(&shndx, &value)
+			o.PutUint32(b[4:], uint32(value))
+			o.PutUint16(b[14:], shndx)
+		} else {
+			shndx = o.Uint16(b[6:])
+			value = o.Uint64(b[8:])
+			fn(&shndx, &value)
+			o.PutUint16(b[6:], shndx)
+			o.PutUint64(b[8:], value)
+		}
+	}
+}
+
+// ReadSymbols decodes a symbol table, unlike elf.File.Symbols the null
+// symbol is included so the slice index is the symbol index.
+func (f *File) ReadSymbols(s *Section) ([]elf.Symbol, error) {
+	if int(s.Link) >= len(f.Sections) {
+		return nil, fmt.Errorf("elf: section %s has invalid string table link %d", s.Name, s.Link)
+	}
+	str := f.Sections[s.Link].Data
+
+	var syms []elf.Symbol
+	r := bytes.NewReader(s.Data)
+	for r.Len() > 0 {
+		var sym elf.Symbol
+		var name uint32
+		switch f.Class {
+		case elf.ELFCLASS32:
+			var y elf.Sym32
+			if err := binary.Read(r, f.ByteOrder, &y); err != nil {
+				return nil, err
+			}
+			name = y.Name
+			sym = elf.Symbol{
+				Info:    y.Info,
+				Other:   y.Other,
+				Section: elf.SectionIndex(y.Shndx),
+				Value:   uint64(y.Value),
+				Size:    uint64(y.Size),
+			}
+		case elf.ELFCLASS64:
+			var y elf.Sym64
+			if err := binary.Read(r, f.ByteOrder, &y); err != nil {
+				return nil, err
+			}
+			name = y.Name
+			sym = elf.Symbol{
+				Info:    y.Info,
+				Other:   y.Other,
+				Section: elf.SectionIndex(y.Shndx),
+				Value:   y.Value,
+				Size:    y.Size,
+			}
+		default:
+			return nil, ErrInvalidClass
+		}
+		sym.Name = cstring(str, name)
+		syms = append(syms, sym)
+	}
+	return syms, nil
+}
+
+// WriteSymbols replaces the contents of a symbol table, names missing from
+// the linked string table are appended to it.
+func (f *File) WriteSymbols(s *Section, syms []elf.Symbol) error {
+	if s.Link == 0 || int(s.Link) >= len(f.Sections) {
+		return fmt.Errorf("elf: section %s has invalid string table link %d", s.Name, s.Link)
+	}
+	str := f.Sections[s.Link]
+	if len(str.Data) == 0 {
+		str.Data = []byte{0}
+	}
+
+	_, _, _, symsize, _ := f.sizes()
+	w := new(bytes.Buffer)
+	for _, sym := range syms {
+		name := strtabIndex(str.Data, sym.Name)
+		if name < 0 {
+			name = len(str.Data)
+			str.Data = append(str.Data, sym.Name...)
+			str.Data = append(str.Data, 0)
+		}
+		switch f.Class {
+		case elf.ELFCLASS32:
+			binary.Write(w, f.ByteOrder, &elf.Sym32{
+				Name:  uint32(name),
+				Value: uint32(sym.Value),
+				Size:  uint32(sym.Size),
+				Info:  sym.Info,
+				Other: sym.Other,
+				Shndx: uint16(sym.Section),
+			})
+		case elf.ELFCLASS64:
+			binary.Write(w, f.ByteOrder, &elf.Sym64{
+				Name:  uint32(name),
+				Info:  sym.Info,
+				Other: sym.Other,
+				Shndx: uint16(sym.Section),
+				Value: sym.Value,
+				Size:  sym.Size,
+			})
+		default:
+			return ErrInvalidClass
+		}
+	}
+	s.Data = w.Bytes()
+	s.Entsize = symsize
+	return nil
+}
+
+// AddSymbol adds a symbol to .symtab creating it if needed and returns its
+// index. Local symbols have to precede the global ones so adding one
+// renumbers the relocations referring to the symbols after it.
+func (f *File) AddSymbol(sym elf.Symbol) (int, error) {
+	_, _, _, symsize, word := f.sizes()
+	i, s := f.Section(".symtab")
+	if s == nil {
+		j, str := f.Section(".strtab")
+		if str == nil {
+			var err error
+			str, err = f.CreateSection(".strtab", elf.SHT_STRTAB, 0, 0, 1)
+			if err != nil {
+				return 0, err
+			}
+			j = len(f.Sections) - 1
+		}
+		var err error
+		s, err = f.CreateSection(".symtab", elf.SHT_SYMTAB, 0, 0, symsize)
+		if err != nil {
+			return 0, err
+		}
+		i = len(f.Sections) - 1
+		s.Link = uint32(j)
+		s.Info = 1
+		s.Entsize = symsize
+		s.Addralign = word
+	}
+
+	syms, err := f.ReadSymbols(s)
+	if err != nil {
+		return 0, err
+	}
+
+	n := len(syms)
+	if elf.ST_BIND(sym.Info) == elf.STB_LOCAL {
+		n = int(s.Info)
+		if n > len(syms) {
+			n = len(syms)
+		}
+		syms = append(syms[:n], append([]elf.Symbol{sym}, syms[n:]...)...)
+		s.Info++
+		f.renumberSymbols(i, func(j uint64) uint64 {
+			if j >= uint64(n) {
+				j++
+			}
+			return j
+		})
+	} else {
+		syms = append(syms, sym)
+	}
+	return n, f.WriteSymbols(s, syms)
+}
+
+// renumberSymbols maps the symbol index of every relocation using the
+// symbol table at index symtab.
+func (f *File) renumberSymbols(symtab int, fn func(uint64) uint64) {
+	o := f.ByteOrder
+	for _, s := range f.Sections {
+		if (s.Type != elf.SHT_REL && s.Type != elf.SHT_RELA) || int(s.Link) != symtab {
+			continue
+		}
+		size := 8
+		if f.Class == elf.ELFCLASS64 {
+			size = 16
+		}
+		if s.Type == elf.SHT_RELA {
+			size += size / 2
+		}
+		for b := s.Data; len(b) >= size; b = b[size:] {
+			if f.Class == elf.ELFCLASS32 {
+				info := o.Uint32(b[4:])
+				info = elf.R_INFO32(uint32(fn(uint64(elf.R_SYM32(info)))), elf.R_TYPE32(info))
+				o.PutUint32(b[4:], info)
+			} else {
+				info := o.Uint64(b[8:])
+				info = elf.R_INFO(uint32(fn(uint64(elf.R_SYM64(info)))), elf.R_TYPE64(info))
+				o.PutUint64(b[8:], info)
+			}
+		}
+	}
+}
+
+func cstring(b []byte, off uint32) string {
+	if int(off) >= len(b) {
+		return ""
+	}
+	b = b[off:]
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return string(b)
+}
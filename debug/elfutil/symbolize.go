@@ -0,0 +1,130 @@
+package elfutil
+
+import (
+	"debug/dwarf"
+	"debug/elf"
+	"io"
+
+	"github.com/qeedquan/go-media/debug"
+)
+
+// Symbolizer resolves addresses with the symbol table and DWARF line
+// tables of a file, it implements debug.Symbolizer.
+type Symbolizer struct {
+	*debug.SymbolTable
+	*debug.LineTable
+}
+
+// NewSymbolizer reads .symtab or .dynsym if the file is stripped, missing
+// debug information is not an error.
+func NewSymbolizer(f *File) (*Symbolizer, error) {
+	_, s := f.Section(".symtab")
+	if s == nil {
+		_, s = f.Section(".dynsym")
+	}
+
+	var syms []debug.Sym
+	if s != nil {
+		l, err := f.ReadSymbols(s)
+		if err != nil {
+			return nil, err
+		}
+		for _, y := range l {
+			switch elf.ST_TYPE(y.Info) {
+			case elf.STT_SECTION, elf.STT_FILE:
+				continue
+			}
+			if y.Section == elf.SHN_UNDEF || int(y.Section) >= len(f.Sections) || y.Name == "" {
+				continue
+			}
+			t := f.Sections[y.Section]
+			addr := y.Value
+			if f.Type == elf.ET_REL {
+				addr += t.Addr
+			}
+			syms = append(syms, debug.Sym{
+				Name:    y.Name,
+				Addr:    addr,
+				Size:    y.Size,
+				Section: t.Name,
+			})
+		}
+	}
+
+	var lines []debug.Line
+	d, err := f.DWARF()
+	if err == nil {
+		lines, err = readLines(d)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Symbolizer{
+		SymbolTable: debug.NewSymbolTable(syms),
+		LineTable:   debug.NewLineTable(lines),
+	}, nil
+}
+
+func readLines(d *dwarf.Data) ([]debug.Line, error) {
+	var lines []debug.Line
+	r := d.Reader()
+	for {
+		e, err := r.Next()
+		if err != nil {
+			return nil, err
+		}
+		if e == nil {
+			break
+		}
+		if e.Tag != dwarf.TagCompileUnit {
+			r.SkipChildren()
+			continue
+		}
+
+		lr, err := d.LineReader(e)
+		if err != nil {
+			return nil, err
+		}
+		r.SkipChildren()
+		if lr == nil {
+			continue
+		}
+
+		var le dwarf.LineEntry
+		for {
+			err := lr.Next(&le)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			l := debug.Line{Addr: le.Address}
+			if !le.EndSequence && le.File != nil {
+				l.File = le.File.Name
+				l.Line = le.Line
+				l.Column = le.Column
+			}
+			lines = append(lines, l)
+		}
+	}
+
+	return lines, nil
+}
+
+// FindStrings finds the strings of the sections with contents, the address of
+// a string is only set for allocated sections.
+func (f *File) FindStrings(opt *debug.StringOptions) []debug.String {
+	var tab []debug.String
+	for _, s := range f.Sections {
+		if s.Type == elf.SHT_NULL || s.Type == elf.SHT_NOBITS || s.Flags&elf.SHF_COMPRESSED != 0 {
+			continue
+		}
+		addr := s.Addr
+		if s.Flags&elf.SHF_ALLOC == 0 {
+			addr = 0
+		}
+		tab = append(tab, debug.FindSectionStrings(s.Name, addr, 1, s.Data, opt)...)
+	}
+	return tab
+}
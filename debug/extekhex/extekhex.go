@@ -5,9 +5,18 @@ import (
 	"fmt"
 	"io"
 	"strings"
+
+	"github.com/qeedquan/go-media/debug/memmap"
 )
 
+const DefaultRecordLength = 32
+
+// a record holds at most 255 characters, leaving room for this many data
+// bytes after the header and a 16 digit address
+const maxRecordData = (255 - 6 - 16) / 2
+
 type File struct {
+	Start   uint64
 	Records []Record
 }
 
@@ -29,6 +38,9 @@ func Decode(r io.Reader) (*File, error) {
 loop:
 	for ; s.Scan(); l++ {
 		t := strings.TrimSpace(s.Text())
+		if !strings.HasPrefix(t, "%") {
+			continue
+		}
 		r := strings.NewReader(t)
 
 		var (
@@ -49,6 +61,19 @@ loop:
 		if hid != '%' {
 			continue
 		}
+		if len(t) != blen+1 {
+			return nil, errf("block length mismatch, expected %d, got %d", blen, len(t)-1)
+		}
+		if u := Checksum([]byte(t[1:4] + t[6:])); u != checksum {
+			return nil, errf("checksum mismatch, expected %#x, got %#x", u, checksum)
+		}
+		if btyp == 3 {
+			// symbol blocks carry no data
+			continue
+		}
+		if addrsz == 0 {
+			addrsz = 16
+		}
 
 		format := fmt.Sprintf("%%%dx", addrsz)
 		_, err = fmt.Fscanf(r, format, &addr)
@@ -63,14 +88,14 @@ loop:
 			}
 			data = append(data, val)
 		}
-		f.Records = append(f.Records, Record{addr, data})
-
 		switch btyp {
 		case 6:
+			f.Records = append(f.Records, Record{addr, data})
 		case 8:
+			f.Start = addr
 			break loop
 		default:
-			return nil, errf("invalid block type %q", btyp)
+			return nil, errf("invalid block type %d", btyp)
 		}
 	}
 	return f, nil
@@ -78,36 +103,67 @@ loop:
 
 func Encode(w io.Writer, f *File) error {
 	b := bufio.NewWriter(w)
-	for i, r := range f.Records {
-		blen := 2 + 1 + 2 + 1 + 8 + len(r.Data)
-		btyp := 8
-		if i+1 < len(f.Records) {
-			btyp = 6
-		}
-
-		str := fmt.Sprintf("%02X%1X8%8X", blen, btyp, r.Addr)
-		for i := range r.Data {
-			str += fmt.Sprintf("%2X", r.Data[i])
-		}
-		checksum := Checksum([]byte(str))
-
-		fmt.Fprintf(b, "%02X%1X%02X8%8X", blen, btyp, checksum, r.Addr)
-		for i := range r.Data {
-			fmt.Fprintf(b, "%02X", r.Data[i])
+	for _, r := range f.Records {
+		addr, data := r.Addr, r.Data
+		for len(data) > 0 {
+			n := len(data)
+			if n > maxRecordData {
+				n = maxRecordData
+			}
+			wrc(b, 6, addr, data[:n])
+			addr, data = addr+uint64(n), data[n:]
 		}
 	}
+	wrc(b, 8, f.Start, nil)
 
-	return b.Flush()
+	err := b.Flush()
+	if err != nil {
+		return fmt.Errorf("extekhex: %v", err)
+	}
+	return nil
 }
 
+// Checksum returns the sum of the hex digit values in b, which is the
+// record text without the leading % and the checksum itself.
 func Checksum(b []byte) uint8 {
 	var s uint8
-	for i := range b {
-		s += b[i]
+	for _, c := range b {
+		switch {
+		case '0' <= c && c <= '9':
+			s += c - '0'
+		case 'A' <= c && c <= 'Z':
+			s += c - 'A' + 10
+		case 'a' <= c && c <= 'z':
+			s += c - 'a' + 10
+		}
 	}
 	return s
 }
 
+// Map returns the records as a sparse memory map, overlapping records are
+// an error.
+func (f *File) Map() (*memmap.Map, error) {
+	m := &memmap.Map{}
+	for _, r := range f.Records {
+		if err := m.Add(r.Addr, r.Data); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func FromMap(m *memmap.Map) (*File, error) {
+	f := &File{}
+	segs, err := m.Split(DefaultRecordLength)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range segs {
+		f.Records = append(f.Records, Record{s.Addr, s.Data})
+	}
+	return f, nil
+}
+
 func (f *File) Binary() []byte {
 	if len(f.Records) == 0 {
 		return nil
@@ -124,4 +180,13 @@ func (f *File) Binary() []byte {
 		copy(b[r.Addr:], r.Data)
 	}
 	return b
-}
\ No newline at end of file
+}
+
+func wrc(b *bufio.Writer, typ int, addr uint64, data []byte) {
+	a := fmt.Sprintf("%08X", addr)
+	str := fmt.Sprintf("%1X%X%s%X", typ, len(a)%16, a, data)
+	blen := 2 + 2 + len(str)
+	str = fmt.Sprintf("%02X", blen) + str
+	checksum := Checksum([]byte(str))
+	fmt.Fprintf(b, "%%%s%02X%s\n", str[:3], checksum, str[3:])
+}
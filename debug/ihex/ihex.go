@@ -0,0 +1,290 @@
+package ihex
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/qeedquan/go-media/debug/memmap"
+)
+
+const (
+	DATA                     = 0
+	END_OF_FILE              = 1
+	EXTENDED_SEGMENT_ADDRESS = 2
+	START_SEGMENT_ADDRESS    = 3
+	EXTENDED_LINEAR_ADDRESS  = 4
+	START_LINEAR_ADDRESS     = 5
+)
+
+const (
+	I8HEX  = 8
+	I16HEX = 16
+	I32HEX = 32
+)
+
+const DefaultRecordLength = 16
+
+type File struct {
+	// Format is one of I8HEX, I16HEX or I32HEX, when encoding a zero
+	// value picks the smallest format that can address every record.
+	Format int
+
+	// Start holds CS:IP as CS<<16|IP for I16HEX and EIP for I32HEX,
+	// it is only valid if HasStart is set.
+	Start    uint32
+	HasStart bool
+
+	// RecordLength is the number of data bytes per line when encoding,
+	// zero means DefaultRecordLength.
+	RecordLength int
+
+	Records []Record
+}
+
+type Record struct {
+	Addr uint64
+	Data []byte
+}
+
+func (f *File) Binary() []byte {
+	if len(f.Records) == 0 {
+		return nil
+	}
+	m := uint64(0)
+	for _, r := range f.Records {
+		l := r.Addr + uint64(len(r.Data))
+		if m < l {
+			m = l
+		}
+	}
+	b := make([]byte, m)
+	for _, r := range f.Records {
+		copy(b[r.Addr:], r.Data)
+	}
+	return b
+}
+
+// Map returns the records as a sparse memory map, overlapping records are
+// an error.
+func (f *File) Map() (*memmap.Map, error) {
+	m := &memmap.Map{}
+	for _, r := range f.Records {
+		if err := m.Add(r.Addr, r.Data); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// FromMap returns a file holding the contents of m, the format is picked
+// when encoding.
+func FromMap(m *memmap.Map) (*File, error) {
+	if end := m.End(); end > 0x100000000 {
+		return nil, fmt.Errorf("ihex: data ends at %#x past the 32-bit address space", end)
+	}
+	f := &File{}
+	for _, s := range m.Segments {
+		f.Records = append(f.Records, Record{s.Addr, s.Data})
+	}
+	return f, nil
+}
+
+func Decode(r io.Reader) (*File, error) {
+	f := &File{Format: I8HEX}
+	s := bufio.NewScanner(r)
+	l := 1
+
+	errf := func(format string, args ...interface{}) error {
+		pfx := fmt.Sprintf("ihex: #%d: ", l)
+		return fmt.Errorf(pfx+format, args...)
+	}
+
+	var base uint64
+	for ; s.Scan(); l++ {
+		t := strings.TrimSpace(s.Text())
+		if !strings.HasPrefix(t, ":") {
+			continue
+		}
+
+		b, err := hex.DecodeString(t[1:])
+		if err != nil {
+			return nil, errf("%v", err)
+		}
+		if len(b) < 5 {
+			return nil, errf("line too short")
+		}
+		if int(b[0]) != len(b)-5 {
+			return nil, errf("byte count mismatch, expected %d, got %d", b[0], len(b)-5)
+		}
+
+		u := Checksum(b[:len(b)-1])
+		if u != b[len(b)-1] {
+			return nil, errf("checksum mismatch, expected %#x, got %#x", u, b[len(b)-1])
+		}
+
+		off := uint64(b[1])<<8 | uint64(b[2])
+		typ := b[3]
+		b = b[4 : len(b)-1]
+
+		size := func(n int) error {
+			if len(b) != n {
+				return errf("record type %d has %d data bytes, expected %d", typ, len(b), n)
+			}
+			return nil
+		}
+
+		switch typ {
+		case DATA:
+			// data running past the end of an I16HEX segment wraps around to
+			// its start, I32HEX addresses are linear and keep going
+			if n := 0x10000 - off; f.Format == I16HEX && uint64(len(b)) > n {
+				f.Records = append(f.Records, Record{base + off, b[:n]})
+				off, b = 0, b[n:]
+			}
+			f.Records = append(f.Records, Record{base + off, b})
+		case END_OF_FILE:
+			return f, nil
+		case EXTENDED_SEGMENT_ADDRESS:
+			if err := size(2); err != nil {
+				return nil, err
+			}
+			base = (uint64(b[0])<<8 | uint64(b[1])) << 4
+			f.Format = I16HEX
+		case START_SEGMENT_ADDRESS:
+			if err := size(4); err != nil {
+				return nil, err
+			}
+			f.Start = uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+			f.HasStart = true
+			f.Format = I16HEX
+		case EXTENDED_LINEAR_ADDRESS:
+			if err := size(2); err != nil {
+				return nil, err
+			}
+			base = (uint64(b[0])<<8 | uint64(b[1])) << 16
+			f.Format = I32HEX
+		case START_LINEAR_ADDRESS:
+			if err := size(4); err != nil {
+				return nil, err
+			}
+			f.Start = uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+			f.HasStart = true
+			f.Format = I32HEX
+		default:
+			return nil, errf("unsupported record type %d", typ)
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, errf("%v", err)
+	}
+	return f, nil
+}
+
+func Encode(w io.Writer, f *File) error {
+	rl := f.RecordLength
+	if rl == 0 {
+		rl = DefaultRecordLength
+	}
+	if rl < 1 || rl > 255 {
+		return fmt.Errorf("ihex: invalid record length %d", rl)
+	}
+
+	format := f.Format
+	if format == 0 {
+		format = f.minFormat()
+	}
+
+	var limit uint64
+	switch format {
+	case I8HEX:
+		limit = 0x10000
+	case I16HEX:
+		limit = 0x100000
+	case I32HEX:
+		limit = 0x100000000
+	default:
+		return fmt.Errorf("ihex: unsupported format %d", format)
+	}
+
+	bw := bufio.NewWriter(w)
+	base := uint64(0)
+	for _, r := range f.Records {
+		addr, data := r.Addr, r.Data
+		if addr+uint64(len(data)) > limit {
+			return fmt.Errorf("ihex: record at %#x with length %d exceeds I%dHEX address space", addr, len(data), format)
+		}
+		for len(data) > 0 {
+			if b := addr &^ 0xffff; b != base {
+				base = b
+				switch format {
+				case I16HEX:
+					wrc(bw, EXTENDED_SEGMENT_ADDRESS, 0, []byte{byte(base >> 12), byte(base >> 4)})
+				case I32HEX:
+					wrc(bw, EXTENDED_LINEAR_ADDRESS, 0, []byte{byte(base >> 24), byte(base >> 16)})
+				}
+			}
+
+			n := rl
+			if n > len(data) {
+				n = len(data)
+			}
+			if m := base + 0x10000 - addr; uint64(n) > m {
+				n = int(m)
+			}
+			wrc(bw, DATA, uint16(addr), data[:n])
+			addr, data = addr+uint64(n), data[n:]
+		}
+	}
+
+	if f.HasStart {
+		s := []byte{byte(f.Start >> 24), byte(f.Start >> 16), byte(f.Start >> 8), byte(f.Start)}
+		switch format {
+		case I16HEX:
+			wrc(bw, START_SEGMENT_ADDRESS, 0, s)
+		case I32HEX:
+			wrc(bw, START_LINEAR_ADDRESS, 0, s)
+		default:
+			return fmt.Errorf("ihex: start address requires I16HEX or I32HEX")
+		}
+	}
+	wrc(bw, END_OF_FILE, 0, nil)
+
+	err := bw.Flush()
+	if err != nil {
+		return fmt.Errorf("ihex: %v", err)
+	}
+	return nil
+}
+
+func (f *File) minFormat() int {
+	m := uint64(0)
+	for _, r := range f.Records {
+		if l := r.Addr + uint64(len(r.Data)); m < l {
+			m = l
+		}
+	}
+	if m <= 0x10000 && !f.HasStart {
+		return I8HEX
+	}
+	return I32HEX
+}
+
+func Checksum(b []byte) byte {
+	var s byte
+	for i := range b {
+		s += b[i]
+	}
+	return -s
+}
+
+func wrc(bw *bufio.Writer, t int, addr uint16, b []byte) {
+	b = append([]byte{byte(len(b)), byte(addr >> 8), byte(addr), byte(t)}, b...)
+	u := Checksum(b)
+	h := hex.EncodeToString(b)
+	h = strings.ToUpper(h)
+	fmt.Fprintf(bw, ":%s%02X\n", h, u)
+}
@@ -0,0 +1,337 @@
+package memmap
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+type Range struct {
+	Start uint64
+	End   uint64
+}
+
+func (r Range) Len() uint64 {
+	return r.End - r.Start
+}
+
+func (r Range) String() string {
+	return fmt.Sprintf("%#x-%#x", r.Start, r.End)
+}
+
+type Segment struct {
+	Addr uint64
+	Data []byte
+}
+
+func (s Segment) Range() Range {
+	return Range{s.Addr, s.Addr + uint64(len(s.Data))}
+}
+
+type OverlapError struct {
+	Addr uint64
+	Size uint64
+	With Range
+}
+
+func (e *OverlapError) Error() string {
+	return fmt.Sprintf("memmap: data at %#x-%#x overlaps %v", e.Addr, e.Addr+e.Size, e.With)
+}
+
+// Map is a sparse memory image, the segments are kept sorted with
+// adjacent data coalesced, reads from unpopulated addresses return Fill.
+type Map struct {
+	Fill     byte
+	Segments []Segment
+}
+
+func New(fill byte) *Map {
+	return &Map{Fill: fill}
+}
+
+func FromBinary(addr uint64, b []byte) *Map {
+	m := &Map{}
+	m.Write(addr, b)
+	return m
+}
+
+func (m *Map) Clone() *Map {
+	c := &Map{Fill: m.Fill, Segments: make([]Segment, len(m.Segments))}
+	for i, s := range m.Segments {
+		c.Segments[i] = Segment{s.Addr, append([]byte{}, s.Data...)}
+	}
+	return c
+}
+
+// Start returns the lowest populated address.
+func (m *Map) Start() uint64 {
+	if len(m.Segments) == 0 {
+		return 0
+	}
+	return m.Segments[0].Addr
+}
+
+// End returns one past the highest populated address.
+func (m *Map) End() uint64 {
+	if len(m.Segments) == 0 {
+		return 0
+	}
+	return m.Segments[len(m.Segments)-1].Range().End
+}
+
+// Size returns the number of populated bytes.
+func (m *Map) Size() uint64 {
+	n := uint64(0)
+	for _, s := range m.Segments {
+		n += uint64(len(s.Data))
+	}
+	return n
+}
+
+func (m *Map) Ranges() []Range {
+	r := make([]Range, len(m.Segments))
+	for i, s := range m.Segments {
+		r[i] = s.Range()
+	}
+	return r
+}
+
+func (m *Map) Gaps() []Range {
+	var r []Range
+	for i := 1; i < len(m.Segments); i++ {
+		r = append(r, Range{m.Segments[i-1].Range().End, m.Segments[i].Addr})
+	}
+	return r
+}
+
+// search returns the index of the first segment ending after addr.
+func (m *Map) search(addr uint64) int {
+	return sort.Search(len(m.Segments), func(i int) bool {
+		return m.Segments[i].Range().End > addr
+	})
+}
+
+func (m *Map) Contains(addr uint64) bool {
+	i := m.search(addr)
+	return i < len(m.Segments) && m.Segments[i].Addr <= addr
+}
+
+// Overlap returns the first populated range intersecting size bytes at addr.
+func (m *Map) Overlap(addr, size uint64) (Range, bool) {
+	if size == 0 {
+		return Range{}, false
+	}
+	i := m.search(addr)
+	if i < len(m.Segments) && m.Segments[i].Addr < addr+size {
+		r := m.Segments[i].Range()
+		if r.Start < addr {
+			r.Start = addr
+		}
+		if r.End > addr+size {
+			r.End = addr + size
+		}
+		return r, true
+	}
+	return Range{}, false
+}
+
+// Add inserts data at addr, it is an error for it to overlap existing data.
+func (m *Map) Add(addr uint64, b []byte) error {
+	if r, ok := m.Overlap(addr, uint64(len(b))); ok {
+		return &OverlapError{addr, uint64(len(b)), r}
+	}
+	m.Write(addr, b)
+	return nil
+}
+
+// Write inserts data at addr replacing whatever was there before.
+func (m *Map) Write(addr uint64, b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	end := addr + uint64(len(b))
+
+	// find every segment touching or adjacent to the new data
+	i := sort.Search(len(m.Segments), func(i int) bool {
+		return m.Segments[i].Range().End >= addr
+	})
+	j := i
+	for j < len(m.Segments) && m.Segments[j].Addr <= end {
+		j++
+	}
+
+	start := addr
+	if i < j && m.Segments[i].Addr < start {
+		start = m.Segments[i].Addr
+	}
+	if i < j {
+		if e := m.Segments[j-1].Range().End; e > end {
+			end = e
+		}
+	}
+
+	// appending to a single segment grows it in place so decoding record
+	// after record stays linear
+	var data []byte
+	if i+1 == j && m.Segments[i].Addr == start {
+		data = m.Segments[i].Data
+		if n := end - start; uint64(len(data)) < n {
+			data = append(data, make([]byte, n-uint64(len(data)))...)
+		}
+	} else {
+		data = make([]byte, end-start)
+		for _, s := range m.Segments[i:j] {
+			copy(data[s.Addr-start:], s.Data)
+		}
+	}
+	copy(data[addr-start:], b)
+
+	seg := Segment{start, data}
+	m.Segments = append(m.Segments[:i], append([]Segment{seg}, m.Segments[j:]...)...)
+}
+
+// Delete removes the data in the range [start, end).
+func (m *Map) Delete(start, end uint64) {
+	var segs []Segment
+	for _, s := range m.Segments {
+		r := s.Range()
+		if r.End <= start || r.Start >= end {
+			segs = append(segs, s)
+			continue
+		}
+		if r.Start < start {
+			segs = append(segs, Segment{s.Addr, s.Data[:start-r.Start]})
+		}
+		if r.End > end {
+			segs = append(segs, Segment{end, append([]byte{}, s.Data[end-r.Start:]...)})
+		}
+	}
+	m.Segments = segs
+}
+
+// Crop removes everything outside the range [start, end).
+func (m *Map) Crop(start, end uint64) {
+	if start > 0 {
+		m.Delete(0, start)
+	}
+	m.Delete(end, ^uint64(0))
+}
+
+// FillGaps populates every unpopulated address in [start, end) with Fill.
+func (m *Map) FillGaps(start, end uint64) {
+	if start >= end {
+		return
+	}
+	b := make([]byte, end-start)
+	for i := range b {
+		b[i] = m.Fill
+	}
+	m.ReadAt(b, int64(start))
+	m.Write(start, b)
+}
+
+// Offset moves all data by delta bytes.
+func (m *Map) Offset(delta int64) error {
+	if len(m.Segments) == 0 {
+		return nil
+	}
+	if delta < 0 && uint64(-delta) > m.Start() {
+		return fmt.Errorf("memmap: offset %d moves data at %#x below zero", delta, m.Start())
+	}
+	if delta > 0 && m.End()-1+uint64(delta) < m.End()-1 {
+		return fmt.Errorf("memmap: offset %d moves data past the end of the address space", delta)
+	}
+	for i := range m.Segments {
+		m.Segments[i].Addr += uint64(delta)
+	}
+	return nil
+}
+
+// Merge adds all the data in o, overlapping data is an error unless both
+// maps agree on its contents.
+func (m *Map) Merge(o *Map) error {
+	for _, s := range o.Segments {
+		if r, ok := m.Overlap(s.Addr, uint64(len(s.Data))); ok {
+			b := make([]byte, len(s.Data))
+			m.ReadAt(b, int64(s.Addr))
+			for i := range b {
+				if m.Contains(s.Addr+uint64(i)) && b[i] != s.Data[i] {
+					return &OverlapError{s.Addr, uint64(len(s.Data)), r}
+				}
+			}
+		}
+	}
+	for _, s := range o.Segments {
+		m.Write(s.Addr, s.Data)
+	}
+	return nil
+}
+
+// Split returns the data as segments of at most size bytes that do not
+// cross a multiple of size, which is what record based formats expect.
+func (m *Map) Split(size uint64) ([]Segment, error) {
+	if size == 0 {
+		return nil, fmt.Errorf("memmap: invalid split size %d", size)
+	}
+	var segs []Segment
+	for _, s := range m.Segments {
+		addr, data := s.Addr, s.Data
+		for len(data) > 0 {
+			n := size - addr%size
+			if n > uint64(len(data)) {
+				n = uint64(len(data))
+			}
+			segs = append(segs, Segment{addr, data[:n]})
+			addr, data = addr+n, data[n:]
+		}
+	}
+	return segs, nil
+}
+
+func (m *Map) ReadAt(b []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, fmt.Errorf("memmap: negative offset %d", off)
+	}
+	addr := uint64(off)
+	end := m.End()
+	if addr >= end {
+		return 0, io.EOF
+	}
+	n = len(b)
+	if uint64(n) > end-addr {
+		n = int(end - addr)
+		err = io.EOF
+	}
+
+	b = b[:n]
+	for i := range b {
+		b[i] = m.Fill
+	}
+	for i := m.search(addr); i < len(m.Segments); i++ {
+		s := m.Segments[i]
+		if s.Addr >= addr+uint64(n) {
+			break
+		}
+		if s.Addr >= addr {
+			copy(b[s.Addr-addr:], s.Data)
+		} else {
+			copy(b, s.Data[addr-s.Addr:])
+		}
+	}
+	return
+}
+
+func (m *Map) WriteAt(b []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, fmt.Errorf("memmap: negative offset %d", off)
+	}
+	m.Write(uint64(off), b)
+	return len(b), nil
+}
+
+// Binary returns the contents from Start to End with gaps set to Fill.
+func (m *Map) Binary() []byte {
+	b := make([]byte, m.End()-m.Start())
+	m.ReadAt(b, int64(m.Start()))
+	return b
+}
@@ -19,7 +19,13 @@ type Patchset struct {
 	Gap  []Patch
 	Data []byte
 	Len  int64
-	off  int64
+
+	// Size is the length of the patched data if Resize is set, otherwise
+	// the data keeps its length unless the patches extend it.
+	Size   int64
+	Resize bool
+
+	off int64
 }
 
 func FindSignatures(data, sig []byte) []int {
@@ -145,3 +151,68 @@ func (p *Patchset) Merge() {
 		}
 	}
 }
+
+// Diff returns the patches turning a into b, changes separated by at most
+// gap equal bytes are coalesced into one patch. Bytes past the end of a
+// always count as changed. A patch starting at the offset spelling the IPS
+// EOF marker starts a byte earlier so the patches can always be written as
+// IPS.
+func Diff(a, b []byte, gap int) *Patchset {
+	p := &Patchset{Size: int64(len(b)), Resize: true}
+	for i := 0; i < len(b); {
+		if i < len(a) && a[i] == b[i] {
+			i++
+			continue
+		}
+
+		start, end := i, i
+		for j := i + 1; j < len(b) && j-end <= gap+1; j++ {
+			if j >= len(a) || a[j] != b[j] {
+				end = j
+			}
+		}
+		if start == IPS_EOF {
+			start--
+		}
+		p.WriteAt(b[start:end+1], int64(start))
+		i = end + 1
+	}
+	p.Merge()
+	return p
+}
+
+// Apply returns a copy of b with the patches written over it.
+func (p *Patchset) Apply(b []byte) []byte {
+	n := mathutil.Max64i(int64(len(b)), p.Len)
+	if p.Resize {
+		n = p.Size
+	}
+	r := make([]byte, n)
+	copy(r, b)
+	for _, l := range p.List {
+		if l.Start < n {
+			copy(r[l.Start:], l.Data)
+		}
+	}
+	return r
+}
+
+// Applied reports if b already holds the contents of the patches.
+func (p *Patchset) Applied(b []byte) bool {
+	if p.Resize && int64(len(b)) != p.Size {
+		return false
+	}
+	for _, l := range p.List {
+		end := l.Start + int64(len(l.Data))
+		if p.Resize && end > p.Size {
+			end = p.Size
+		}
+		if end <= l.Start {
+			continue
+		}
+		if end > int64(len(b)) || !bytes.Equal(b[l.Start:end], l.Data[:end-l.Start]) {
+			return false
+		}
+	}
+	return true
+}
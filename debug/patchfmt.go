@@ -0,0 +1,354 @@
+package debug
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+)
+
+const (
+	IPS_EOF     = 0x454f46
+	IPS_MAXOFF  = 1 << 24
+	IPS_MAXSIZE = 0xffff
+
+	// runs shorter than this are cheaper to store as they are
+	IPS_MINRLE = 9
+)
+
+const (
+	BPS_SOURCE_READ = iota
+	BPS_TARGET_READ
+	BPS_SOURCE_COPY
+	BPS_TARGET_COPY
+)
+
+// FormatIPS writes the patches as an IPS patch, runs of a repeated byte are
+// stored as RLE records. A record can't start at the offset spelling EOF so
+// it starts a byte earlier instead, a patch starting there has to include
+// the byte before it as Diff does. The size is written with the truncation
+// extension if Resize is set.
+func FormatIPS(p *Patchset, w io.Writer) error {
+	l := append([]Patch{}, p.List...)
+	sort.SliceStable(l, func(i, j int) bool {
+		return l[i].Start < l[j].Start
+	})
+
+	b := bufio.NewWriter(w)
+	b.WriteString("PATCH")
+	record := func(off int64, data []byte) {
+		b.Write([]byte{byte(off >> 16), byte(off >> 8), byte(off)})
+		binary.Write(b, binary.BigEndian, uint16(len(data)))
+		b.Write(data)
+	}
+	rle := func(off int64, n int, c byte) {
+		b.Write([]byte{byte(off >> 16), byte(off >> 8), byte(off), 0, 0})
+		binary.Write(b, binary.BigEndian, uint16(n))
+		b.WriteByte(c)
+	}
+
+	for _, q := range l {
+		if q.Start+int64(len(q.Data)) > IPS_MAXOFF {
+			return fmt.Errorf("debug: ips patch at %#x is past the 16 MiB limit", q.Start)
+		}
+
+		d := q.Data
+		for k := 0; k < len(d); {
+			off := q.Start + int64(k)
+			if off == IPS_EOF {
+				if k == 0 {
+					return fmt.Errorf("debug: ips record can't start at %#x, the patch needs to include the byte before it", off)
+				}
+				record(off-1, d[k-1:k+1])
+				k++
+				continue
+			}
+
+			n := run(d[k:], IPS_MAXSIZE)
+			if n >= IPS_MINRLE {
+				rle(off, n, d[k])
+				k += n
+				continue
+			}
+
+			// stop a literal record where a run long enough starts or
+			// at the offset spelling EOF
+			e := k + 1
+			for e < len(d) && e-k < IPS_MAXSIZE && q.Start+int64(e) != IPS_EOF && run(d[e:], IPS_MINRLE) < IPS_MINRLE {
+				e++
+			}
+			record(off, d[k:e])
+			k = e
+		}
+	}
+
+	b.WriteString("EOF")
+	if p.Resize {
+		if p.Size < 0 || p.Size >= IPS_MAXOFF {
+			return fmt.Errorf("debug: ips size %#x is past the 16 MiB limit", p.Size)
+		}
+		b.Write([]byte{byte(p.Size >> 16), byte(p.Size >> 8), byte(p.Size)})
+	}
+
+	err := b.Flush()
+	if err != nil {
+		return fmt.Errorf("debug: %v", err)
+	}
+	return nil
+}
+
+// run returns the length of the run of the first byte up to max.
+func run(b []byte, max int) int {
+	n := 1
+	for n < len(b) && n < max && b[n] == b[0] {
+		n++
+	}
+	return n
+}
+
+// NewIPS reads an IPS patch, the size is set and Resize is true if it has
+// the truncation extension.
+func NewIPS(r io.Reader) (*Patchset, error) {
+	b := bufio.NewReader(r)
+	var magic [5]byte
+	if _, err := io.ReadFull(b, magic[:]); err != nil || string(magic[:]) != "PATCH" {
+		return nil, fmt.Errorf("debug: invalid ips header")
+	}
+
+	p := &Patchset{}
+	var buf [3]byte
+	for {
+		if _, err := io.ReadFull(b, buf[:3]); err != nil {
+			return nil, fmt.Errorf("debug: ips patch is missing eof")
+		}
+		off := int64(buf[0])<<16 | int64(buf[1])<<8 | int64(buf[2])
+		if off == IPS_EOF {
+			break
+		}
+
+		var size uint16
+		if err := binary.Read(b, binary.BigEndian, &size); err != nil {
+			return nil, fmt.Errorf("debug: ips record at %#x: %v", off, err)
+		}
+		if size == 0 {
+			var n uint16
+			if err := binary.Read(b, binary.BigEndian, &n); err != nil {
+				return nil, fmt.Errorf("debug: ips record at %#x: %v", off, err)
+			}
+			c, err := b.ReadByte()
+			if err != nil {
+				return nil, fmt.Errorf("debug: ips record at %#x: %v", off, err)
+			}
+			p.WriteAt(bytes.Repeat([]byte{c}, int(n)), off)
+			continue
+		}
+
+		data := make([]byte, size)
+		if _, err := io.ReadFull(b, data); err != nil {
+			return nil, fmt.Errorf("debug: ips record at %#x: %v", off, err)
+		}
+		p.WriteAt(data, off)
+	}
+
+	if n, _ := io.ReadFull(b, buf[:3]); n == 3 {
+		p.Size = int64(buf[0])<<16 | int64(buf[1])<<8 | int64(buf[2])
+		p.Resize = true
+	}
+	p.Merge()
+	return p, nil
+}
+
+// ApplyIPS applies an IPS patch to src.
+func ApplyIPS(patch, src []byte) ([]byte, error) {
+	p, err := NewIPS(bytes.NewReader(patch))
+	if err != nil {
+		return nil, err
+	}
+	return p.Apply(src), nil
+}
+
+// FormatBPS writes the patches as a BPS patch against src. Unpatched bytes
+// are read from the source and patched ones stored in the patch, the
+// checksums of the source, the target and the patch are included.
+func FormatBPS(p *Patchset, w io.Writer, src, metadata []byte) error {
+	dst := p.Apply(src)
+	patched := make([]bool, len(dst))
+	for _, l := range p.List {
+		for i := l.Start; i < l.Start+int64(len(l.Data)) && i < int64(len(dst)); i++ {
+			patched[i] = true
+		}
+	}
+	for i := len(src); i < len(dst); i++ {
+		patched[i] = true
+	}
+
+	b := new(bytes.Buffer)
+	b.WriteString("BPS1")
+	putVarint(b, uint64(len(src)))
+	putVarint(b, uint64(len(dst)))
+	putVarint(b, uint64(len(metadata)))
+	b.Write(metadata)
+	for i := 0; i < len(dst); {
+		j := i + 1
+		for j < len(dst) && patched[j] == patched[i] {
+			j++
+		}
+		if patched[i] {
+			putVarint(b, uint64(j-i-1)<<2|BPS_TARGET_READ)
+			b.Write(dst[i:j])
+		} else {
+			putVarint(b, uint64(j-i-1)<<2|BPS_SOURCE_READ)
+		}
+		i = j
+	}
+
+	binary.Write(b, binary.LittleEndian, crc32.ChecksumIEEE(src))
+	binary.Write(b, binary.LittleEndian, crc32.ChecksumIEEE(dst))
+	binary.Write(b, binary.LittleEndian, crc32.ChecksumIEEE(b.Bytes()))
+
+	_, err := w.Write(b.Bytes())
+	if err != nil {
+		return fmt.Errorf("debug: %v", err)
+	}
+	return nil
+}
+
+// ApplyBPS applies a BPS patch to src and returns the target with the
+// metadata of the patch. The checksums of the patch and the source are
+// checked before and the one of the target after applying it.
+func ApplyBPS(patch, src []byte) (dst, metadata []byte, err error) {
+	if len(patch) < 4+3+12 || string(patch[:4]) != "BPS1" {
+		return nil, nil, fmt.Errorf("debug: invalid bps header")
+	}
+	foot := patch[len(patch)-12:]
+	srcCRC := binary.LittleEndian.Uint32(foot[0:])
+	dstCRC := binary.LittleEndian.Uint32(foot[4:])
+	patchCRC := binary.LittleEndian.Uint32(foot[8:])
+	if crc := crc32.ChecksumIEEE(patch[:len(patch)-4]); crc != patchCRC {
+		return nil, nil, fmt.Errorf("debug: bps patch checksum mismatch, expected %#x but got %#x", patchCRC, crc)
+	}
+
+	r := bytes.NewReader(patch[4 : len(patch)-12])
+	srcSize, err1 := getVarint(r)
+	dstSize, err2 := getVarint(r)
+	metaSize, err3 := getVarint(r)
+	if err1 != nil || err2 != nil || err3 != nil || metaSize > uint64(r.Len()) {
+		return nil, nil, fmt.Errorf("debug: invalid bps header")
+	}
+	if srcSize != uint64(len(src)) {
+		return nil, nil, fmt.Errorf("debug: bps source size mismatch, expected %#x but got %#x", srcSize, len(src))
+	}
+	if crc := crc32.ChecksumIEEE(src); crc != srcCRC {
+		return nil, nil, fmt.Errorf("debug: bps source checksum mismatch, expected %#x but got %#x", srcCRC, crc)
+	}
+	metadata = make([]byte, metaSize)
+	r.Read(metadata)
+
+	var srcRel, dstRel int64
+	for r.Len() > 0 {
+		v, err := getVarint(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		n := int64(v>>2) + 1
+		if uint64(len(dst))+uint64(n) > dstSize {
+			return nil, nil, fmt.Errorf("debug: bps action at %#x writes past the target", len(dst))
+		}
+
+		switch v & 3 {
+		case BPS_SOURCE_READ:
+			o := int64(len(dst))
+			if o+n > int64(len(src)) {
+				return nil, nil, fmt.Errorf("debug: bps source read at %#x is out of range", o)
+			}
+			dst = append(dst, src[o:o+n]...)
+
+		case BPS_TARGET_READ:
+			if int64(r.Len()) < n {
+				return nil, nil, fmt.Errorf("debug: bps target read at %#x is truncated", len(dst))
+			}
+			p := make([]byte, n)
+			r.Read(p)
+			dst = append(dst, p...)
+
+		case BPS_SOURCE_COPY, BPS_TARGET_COPY:
+			d, err := getVarint(r)
+			if err != nil {
+				return nil, nil, err
+			}
+			o := int64(d >> 1)
+			if d&1 != 0 {
+				o = -o
+			}
+			if v&3 == BPS_SOURCE_COPY {
+				srcRel += o
+				if srcRel < 0 || srcRel+n > int64(len(src)) {
+					return nil, nil, fmt.Errorf("debug: bps source copy at %#x is out of range", len(dst))
+				}
+				dst = append(dst, src[srcRel:srcRel+n]...)
+				srcRel += n
+			} else {
+				dstRel += o
+				if dstRel < 0 || dstRel >= int64(len(dst)) {
+					return nil, nil, fmt.Errorf("debug: bps target copy at %#x is out of range", len(dst))
+				}
+				// the copy may overlap the bytes it produces
+				for i := int64(0); i < n; i++ {
+					dst = append(dst, dst[dstRel])
+					dstRel++
+				}
+			}
+		}
+	}
+
+	if uint64(len(dst)) != dstSize {
+		return nil, nil, fmt.Errorf("debug: bps target size mismatch, expected %#x but got %#x", dstSize, len(dst))
+	}
+	if crc := crc32.ChecksumIEEE(dst); crc != dstCRC {
+		return nil, nil, fmt.Errorf("debug: bps target checksum mismatch, expected %#x but got %#x", dstCRC, crc)
+	}
+	return dst, metadata, nil
+}
+
+// NewBPS applies a BPS patch to src and returns the changes it made.
+func NewBPS(patch, src []byte) (*Patchset, error) {
+	dst, _, err := ApplyBPS(patch, src)
+	if err != nil {
+		return nil, err
+	}
+	return Diff(src, dst, 0), nil
+}
+
+// putVarint writes the BPS number encoding, every continuation byte also
+// adds one to the value so that encodings are unique.
+func putVarint(b *bytes.Buffer, v uint64) {
+	for {
+		x := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			b.WriteByte(0x80 | x)
+			return
+		}
+		b.WriteByte(x)
+		v--
+	}
+}
+
+func getVarint(r io.ByteReader) (uint64, error) {
+	v, shift := uint64(0), uint64(1)
+	for i := 0; ; i++ {
+		x, err := r.ReadByte()
+		if err != nil || i > 9 {
+			return 0, fmt.Errorf("debug: invalid bps number")
+		}
+		v += uint64(x&0x7f) * shift
+		if x&0x80 != 0 {
+			return v, nil
+		}
+		shift <<= 7
+		v += shift
+	}
+}
@@ -1,5 +1,13 @@
 package pdb
 
+import (
+	"bytes"
+	"debug/pe"
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
 type DbiStreamHeader struct {
 	VersionSignature        int32
 	VersionHeader           uint32
@@ -38,7 +46,7 @@ type SectionContribEntry struct {
 type ModInfo struct {
 	_                    uint32
 	SectionContr         SectionContribEntry
-	Flags                uint32
+	Flags                uint16
 	ModuleSymStream      uint16
 	SymByteSize          uint32
 	C11ByteSize          uint32
@@ -50,6 +58,7 @@ type ModInfo struct {
 	PdbFilePathNameIndex uint32
 	ModuleName           string
 	ObjFileName          string
+	SourceFiles          []string
 }
 
 const (
@@ -59,3 +68,244 @@ const (
 	DBI_V70  = 19990903
 	DBI_V110 = 20091201
 )
+
+const (
+	SECTION_CONTRIB_V1 = 0xeffe0000 + 19970605
+	SECTION_CONTRIB_V2 = 0xeffe0000 + 20140516
+)
+
+// indices of the optional debug header streams
+const (
+	DBG_FPO                = 0
+	DBG_EXCEPTION          = 1
+	DBG_FIXUP              = 2
+	DBG_OMAP_TO_SRC        = 3
+	DBG_OMAP_FROM_SRC      = 4
+	DBG_SECTION_HDR        = 5
+	DBG_TOKEN_RID_MAP      = 6
+	DBG_XDATA              = 7
+	DBG_PDATA              = 8
+	DBG_NEW_FPO            = 9
+	DBG_SECTION_HDR_ORIG   = 10
+	DBG_NUM_STREAMS        = 11
+	DBG_STREAM_NOT_PRESENT = 0xffff
+)
+
+type DBI struct {
+	DbiStreamHeader
+	Modules       []*ModInfo
+	Contributions []SectionContribEntry
+	DebugStreams  []uint16
+
+	// image section headers, segment numbers used by symbols and lines
+	// are 1 based indices into them
+	Sections []pe.SectionHeader32
+}
+
+func (f *File) readDBI() (*DBI, error) {
+	b, err := f.Stream(STREAM_DBI)
+	if err != nil {
+		return nil, err
+	}
+	d := &DBI{}
+	r := bytes.NewReader(b)
+	err = binary.Read(r, binary.LittleEndian, &d.DbiStreamHeader)
+	if err != nil {
+		return nil, fmt.Errorf("pdb: failed to read dbi stream header: %v", err)
+	}
+	if d.VersionSignature != -1 {
+		return nil, fmt.Errorf("pdb: unsupported dbi stream signature %d", d.VersionSignature)
+	}
+
+	b = b[64:]
+	sizes := []int32{
+		d.ModInfoSize,
+		d.SectionContributionSize,
+		d.SectionMapSize,
+		d.SourceInfoSize,
+		d.TypeServerSize,
+		d.ECSubstreamSize,
+		d.OptionalDbgHeaderSize,
+	}
+	var sub [7][]byte
+	for i, n := range sizes {
+		if n < 0 || int(n) > len(b) {
+			return nil, fmt.Errorf("pdb: invalid dbi substream %d size %d", i, n)
+		}
+		sub[i], b = b[:n], b[n:]
+	}
+
+	d.Modules, err = readModInfo(sub[0])
+	if err != nil {
+		return nil, err
+	}
+	d.Contributions, err = readSectionContribs(sub[1])
+	if err != nil {
+		return nil, err
+	}
+	err = d.readSourceInfo(sub[3])
+	if err != nil {
+		return nil, err
+	}
+	for p := sub[6]; len(p) >= 2; p = p[2:] {
+		d.DebugStreams = append(d.DebugStreams, binary.LittleEndian.Uint16(p))
+	}
+
+	if n := d.DebugStream(DBG_SECTION_HDR); n >= 0 {
+		p, err := f.Stream(n)
+		if err != nil {
+			return nil, err
+		}
+		d.Sections = make([]pe.SectionHeader32, len(p)/40)
+		binary.Read(bytes.NewReader(p), binary.LittleEndian, d.Sections)
+	}
+	return d, nil
+}
+
+func readModInfo(b []byte) ([]*ModInfo, error) {
+	var l []*ModInfo
+	for len(b) > 0 {
+		if len(b) < 64 {
+			return nil, fmt.Errorf("pdb: truncated module info %d", len(l))
+		}
+		m := &ModInfo{}
+		binary.Read(bytes.NewReader(b[4:32]), binary.LittleEndian, &m.SectionContr)
+		m.Flags = binary.LittleEndian.Uint16(b[32:])
+		m.ModuleSymStream = binary.LittleEndian.Uint16(b[34:])
+		m.SymByteSize = binary.LittleEndian.Uint32(b[36:])
+		m.C11ByteSize = binary.LittleEndian.Uint32(b[40:])
+		m.C13ByteSize = binary.LittleEndian.Uint32(b[44:])
+		m.SourceFileCount = binary.LittleEndian.Uint16(b[48:])
+		m.SourceFileNameIndex = binary.LittleEndian.Uint32(b[56:])
+		m.PdbFilePathNameIndex = binary.LittleEndian.Uint32(b[60:])
+
+		n := 64
+		m.ModuleName = cstring(b[n:])
+		n += len(m.ModuleName) + 1
+		if n > len(b) {
+			return nil, fmt.Errorf("pdb: truncated module info %d", len(l))
+		}
+		m.ObjFileName = cstring(b[n:])
+		n += len(m.ObjFileName) + 1
+		n = (n + 3) &^ 3
+		if n > len(b) {
+			n = len(b)
+		}
+		b = b[n:]
+		l = append(l, m)
+	}
+	return l, nil
+}
+
+func readSectionContribs(b []byte) ([]SectionContribEntry, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	if len(b) < 4 {
+		return nil, fmt.Errorf("pdb: truncated section contributions")
+	}
+
+	size := 28
+	switch v := binary.LittleEndian.Uint32(b); v {
+	case SECTION_CONTRIB_V1:
+	case SECTION_CONTRIB_V2:
+		// followed by the coff section index
+		size = 32
+	default:
+		return nil, fmt.Errorf("pdb: unsupported section contribution version %#x", v)
+	}
+
+	var l []SectionContribEntry
+	for b = b[4:]; len(b) >= size; b = b[size:] {
+		var c SectionContribEntry
+		binary.Read(bytes.NewReader(b), binary.LittleEndian, &c)
+		l = append(l, c)
+	}
+	sort.SliceStable(l, func(i, j int) bool {
+		if l[i].Section != l[j].Section {
+			return l[i].Section < l[j].Section
+		}
+		return l[i].Offset < l[j].Offset
+	})
+	return l, nil
+}
+
+func (d *DBI) readSourceInfo(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	if len(b) < 4 {
+		return fmt.Errorf("pdb: truncated source info")
+	}
+
+	// the file counts are stored again in the source info as the module
+	// info one overflows with more than 64K files
+	nmod := int(binary.LittleEndian.Uint16(b))
+	b = b[4:]
+	if nmod*4 > len(b) {
+		return fmt.Errorf("pdb: truncated source info")
+	}
+	counts := b[nmod*2 : nmod*4]
+	b = b[nmod*4:]
+
+	total := 0
+	for i := 0; i < nmod; i++ {
+		total += int(binary.LittleEndian.Uint16(counts[i*2:]))
+	}
+	if total*4 > len(b) {
+		return fmt.Errorf("pdb: truncated source file offsets")
+	}
+	offs, names := b[:total*4], b[total*4:]
+
+	k := 0
+	for i := 0; i < nmod && i < len(d.Modules); i++ {
+		m := d.Modules[i]
+		m.SourceFiles = nil
+		for j := 0; j < int(binary.LittleEndian.Uint16(counts[i*2:])); j++ {
+			o := binary.LittleEndian.Uint32(offs[k*4:])
+			k++
+			if o < uint32(len(names)) {
+				m.SourceFiles = append(m.SourceFiles, cstring(names[o:]))
+			}
+		}
+	}
+	return nil
+}
+
+// DebugStream returns the stream index of an optional debug header
+// stream or -1 if it is not present.
+func (d *DBI) DebugStream(i int) int {
+	if i >= len(d.DebugStreams) || d.DebugStreams[i] == DBG_STREAM_NOT_PRESENT {
+		return -1
+	}
+	return int(d.DebugStreams[i])
+}
+
+// RVA converts a segment and offset to a relative virtual address.
+func (d *DBI) RVA(seg uint16, off uint32) (uint64, bool) {
+	if seg == 0 || int(seg) > len(d.Sections) {
+		return 0, false
+	}
+	return uint64(d.Sections[seg-1].VirtualAddress) + uint64(off), true
+}
+
+// SectionName returns the name of a 1 based segment.
+func (d *DBI) SectionName(seg uint16) string {
+	if seg == 0 || int(seg) > len(d.Sections) {
+		return ""
+	}
+	return cstring(d.Sections[seg-1].Name[:])
+}
+
+// Contribution returns the section contribution covering a segment and
+// offset, its module index identifies the object file it came from.
+func (d *DBI) Contribution(seg uint16, off uint32) (SectionContribEntry, bool) {
+	l := d.Contributions
+	i := sort.Search(len(l), func(i int) bool {
+		return l[i].Section > seg || l[i].Section == seg && uint32(l[i].Offset) > off
+	}) - 1
+	if i >= 0 && l[i].Section == seg && off-uint32(l[i].Offset) < uint32(l[i].Size) {
+		return l[i], true
+	}
+	return SectionContribEntry{}, false
+}
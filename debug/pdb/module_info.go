@@ -1,5 +1,10 @@
 package pdb
 
+import (
+	"encoding/binary"
+	"fmt"
+)
+
 type ModiStream struct {
 	Signature      uint32
 	Symbols        []uint8
@@ -8,3 +13,166 @@ type ModiStream struct {
 	GlobalRefsSize uint32
 	GlobalRefs     []uint8
 }
+
+// C13 debug subsection kinds
+const (
+	DEBUG_S_SYMBOLS              = 0xf1
+	DEBUG_S_LINES                = 0xf2
+	DEBUG_S_STRINGTABLE          = 0xf3
+	DEBUG_S_FILECHKSMS           = 0xf4
+	DEBUG_S_FRAMEDATA            = 0xf5
+	DEBUG_S_INLINEELINES         = 0xf6
+	DEBUG_S_CROSSSCOPEIMPORTS    = 0xf7
+	DEBUG_S_CROSSSCOPEEXPORTS    = 0xf8
+	DEBUG_S_IL_LINES             = 0xf9
+	DEBUG_S_FUNC_MDTOKEN_MAP     = 0xfa
+	DEBUG_S_TYPE_MDTOKEN_MAP     = 0xfb
+	DEBUG_S_MERGED_ASSEMBLYINPUT = 0xfc
+	DEBUG_S_COFF_SYMBOL_RVA      = 0xfd
+	DEBUG_S_IGNORE               = 0x80000000
+)
+
+const CV_LINES_HAVE_COLUMNS = 0x1
+
+// Line maps an offset in a segment to a source line, a line of zero with
+// an empty file ends the range of the preceding entry.
+type Line struct {
+	Segment     uint16
+	Offset      uint32
+	File        string
+	Line        uint32
+	Column      uint16
+	IsStatement bool
+}
+
+// ModuleStream reads the symbols and line information of a module, it
+// returns nil if the module has no stream.
+func (f *File) ModuleStream(m *ModInfo) (*ModiStream, error) {
+	if m.ModuleSymStream == DBG_STREAM_NOT_PRESENT {
+		return nil, nil
+	}
+	b, err := f.Stream(int(m.ModuleSymStream))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &ModiStream{}
+	n := uint64(m.SymByteSize) + uint64(m.C11ByteSize) + uint64(m.C13ByteSize)
+	if m.SymByteSize < 4 || n > uint64(len(b)) {
+		return nil, fmt.Errorf("pdb: module %s stream sizes do not fit in %d bytes", m.ModuleName, len(b))
+	}
+	s.Signature = binary.LittleEndian.Uint32(b)
+	s.Symbols = b[4:m.SymByteSize]
+	b = b[m.SymByteSize:]
+	s.C11LineInfo, b = b[:m.C11ByteSize], b[m.C11ByteSize:]
+	s.C13LineInfo, b = b[:m.C13ByteSize], b[m.C13ByteSize:]
+	if len(b) >= 4 {
+		s.GlobalRefsSize = binary.LittleEndian.Uint32(b)
+		s.GlobalRefs = b[4:]
+		if uint64(s.GlobalRefsSize) <= uint64(len(s.GlobalRefs)) {
+			s.GlobalRefs = s.GlobalRefs[:s.GlobalRefsSize]
+		}
+	}
+	return s, nil
+}
+
+// ReadSymbols decodes the symbol records of a module, the record offsets
+// account for the signature at the start of the stream.
+func (s *ModiStream) ReadSymbols() ([]Symbol, error) {
+	return parseSymbols(s.Symbols, 4)
+}
+
+func forEachSubsection(b []byte, fn func(kind uint32, p []byte) error) error {
+	for len(b) >= 8 {
+		kind := binary.LittleEndian.Uint32(b)
+		n := binary.LittleEndian.Uint32(b[4:])
+		if uint64(n) > uint64(len(b)-8) {
+			return fmt.Errorf("pdb: debug subsection %#x of %d bytes is truncated", kind, n)
+		}
+		if kind&DEBUG_S_IGNORE == 0 {
+			err := fn(kind, b[8:8+n])
+			if err != nil {
+				return err
+			}
+		}
+		n = (n + 3) &^ 3
+		if uint64(n) > uint64(len(b)-8) {
+			break
+		}
+		b = b[8+n:]
+	}
+	return nil
+}
+
+// ReadLines decodes the C13 line tables of a module, file names are looked
+// up in the /names string table through the file checksum subsection.
+func (f *File) ReadLines(s *ModiStream) ([]Line, error) {
+	// the checksums can come after the lines that reference them
+	var chk []byte
+	err := forEachSubsection(s.C13LineInfo, func(kind uint32, p []byte) error {
+		if kind == DEBUG_S_FILECHKSMS {
+			chk = p
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	file := func(off uint32) string {
+		if uint64(off)+4 > uint64(len(chk)) {
+			return ""
+		}
+		return f.String(binary.LittleEndian.Uint32(chk[off:]))
+	}
+
+	var lines []Line
+	err = forEachSubsection(s.C13LineInfo, func(kind uint32, p []byte) error {
+		if kind != DEBUG_S_LINES {
+			return nil
+		}
+		if len(p) < 12 {
+			return fmt.Errorf("pdb: truncated line subsection")
+		}
+		off := binary.LittleEndian.Uint32(p)
+		seg := binary.LittleEndian.Uint16(p[4:])
+		flags := binary.LittleEndian.Uint16(p[6:])
+		size := binary.LittleEndian.Uint32(p[8:])
+		cols := flags&CV_LINES_HAVE_COLUMNS != 0
+
+		for p = p[12:]; len(p) >= 12; {
+			name := file(binary.LittleEndian.Uint32(p))
+			n := int(binary.LittleEndian.Uint32(p[4:]))
+			blk := binary.LittleEndian.Uint32(p[8:])
+			if blk < 12 || uint64(blk) > uint64(len(p)) {
+				return fmt.Errorf("pdb: invalid line block size %d", blk)
+			}
+			q := p[12:blk]
+			p = p[blk:]
+
+			if n*8 > len(q) {
+				return fmt.Errorf("pdb: truncated line block")
+			}
+			c := q[n*8:]
+			if !cols || len(c) < n*4 {
+				c = nil
+			}
+			for i := 0; i < n; i++ {
+				v := binary.LittleEndian.Uint32(q[i*8+4:])
+				l := Line{
+					Segment:     seg,
+					Offset:      off + binary.LittleEndian.Uint32(q[i*8:]),
+					File:        name,
+					Line:        v & 0xffffff,
+					IsStatement: v&0x80000000 != 0,
+				}
+				if c != nil {
+					l.Column = binary.LittleEndian.Uint16(c[i*4:])
+				}
+				lines = append(lines, l)
+			}
+		}
+		lines = append(lines, Line{Segment: seg, Offset: off + size})
+		return nil
+	})
+	return lines, err
+}
@@ -1,5 +1,13 @@
 package pdb
 
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
 type Header struct {
 	Sig      [4]byte
 	GUID     [16]byte
@@ -26,3 +34,301 @@ const (
 	VC110   = 20091201
 	VC140   = 20140508
 )
+
+// fixed stream indices
+const (
+	STREAM_OLD_DIRECTORY = 0
+	STREAM_PDB           = 1
+	STREAM_TPI           = 2
+	STREAM_DBI           = 3
+	STREAM_IPI           = 4
+)
+
+const nilStream = 0xffffffff
+
+var msfMagic = []byte("Microsoft C/C++ MSF 7.00\r\n\x1aDS\x00\x00\x00")
+
+type SuperBlock struct {
+	Magic             [32]byte
+	BlockSize         uint32
+	FreeBlockMapBlock uint32
+	NumBlocks         uint32
+	NumDirectoryBytes uint32
+	_                 uint32
+	BlockMapAddr      uint32
+}
+
+type File struct {
+	SuperBlock
+	Info         StreamHeader
+	NamedStreams map[string]uint32
+	DBI          *DBI
+	TPI          *TypeStream
+	names        []byte
+	sizes        []uint32
+	blocks       [][]uint32
+	r            io.ReaderAt
+	closer       io.Closer
+}
+
+func Open(name string) (*File, error) {
+	r, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := NewFile(r)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	f.closer = r
+	return f, nil
+}
+
+func (f *File) Close() error {
+	var err error
+	if f.closer != nil {
+		err = f.closer.Close()
+		f.closer = nil
+	}
+	return err
+}
+
+// NewFile reads the MSF stream directory along with the PDB info, DBI and
+// TPI streams, the module and symbol streams are read on demand.
+func NewFile(r io.ReaderAt) (*File, error) {
+	f := &File{r: r}
+	err := binary.Read(io.NewSectionReader(r, 0, 56), binary.LittleEndian, &f.SuperBlock)
+	if err != nil {
+		return nil, fmt.Errorf("pdb: failed to read superblock: %v", err)
+	}
+	if !bytes.Equal(f.Magic[:], msfMagic) {
+		return nil, fmt.Errorf("pdb: invalid msf magic")
+	}
+	switch f.BlockSize {
+	case 512, 1024, 2048, 4096:
+	default:
+		return nil, fmt.Errorf("pdb: invalid block size %d", f.BlockSize)
+	}
+
+	err = f.readDirectory()
+	if err != nil {
+		return nil, err
+	}
+	err = f.readInfo()
+	if err != nil {
+		return nil, err
+	}
+	if n, ok := f.NamedStreams["/names"]; ok {
+		f.names, err = f.readStringTable(n)
+		if err != nil {
+			return nil, err
+		}
+	}
+	f.DBI, err = f.readDBI()
+	if err != nil {
+		return nil, err
+	}
+	f.TPI, err = f.readTypeStream(STREAM_TPI)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *File) readBlocks(blocks []uint32, size uint32) ([]byte, error) {
+	b := make([]byte, len(blocks)*int(f.BlockSize))
+	for i, n := range blocks {
+		if n >= f.NumBlocks {
+			return nil, fmt.Errorf("block %d is past the end of the file", n)
+		}
+		p := b[i*int(f.BlockSize) : (i+1)*int(f.BlockSize)]
+		_, err := f.r.ReadAt(p, int64(n)*int64(f.BlockSize))
+		if err != nil && !(err == io.EOF && i == len(blocks)-1) {
+			return nil, err
+		}
+	}
+	return b[:size], nil
+}
+
+func (f *File) numBlocks(size uint32) int {
+	return int((uint64(size) + uint64(f.BlockSize) - 1) / uint64(f.BlockSize))
+}
+
+func (f *File) readDirectory() error {
+	// the block map lists the blocks of the directory
+	n := f.numBlocks(f.NumDirectoryBytes)
+	m, err := f.readBlocks([]uint32{f.BlockMapAddr}, f.BlockSize)
+	if err != nil {
+		return fmt.Errorf("pdb: failed to read block map: %v", err)
+	}
+	if n*4 > len(m) {
+		return fmt.Errorf("pdb: stream directory of %d bytes is too big", f.NumDirectoryBytes)
+	}
+	blocks := make([]uint32, n)
+	for i := range blocks {
+		blocks[i] = binary.LittleEndian.Uint32(m[i*4:])
+	}
+	d, err := f.readBlocks(blocks, f.NumDirectoryBytes)
+	if err != nil {
+		return fmt.Errorf("pdb: failed to read stream directory: %v", err)
+	}
+
+	if len(d) < 4 {
+		return fmt.Errorf("pdb: truncated stream directory")
+	}
+	ns := int(binary.LittleEndian.Uint32(d))
+	d = d[4:]
+	if ns*4 > len(d) {
+		return fmt.Errorf("pdb: invalid stream count %d", ns)
+	}
+	f.sizes = make([]uint32, ns)
+	for i := range f.sizes {
+		f.sizes[i] = binary.LittleEndian.Uint32(d[i*4:])
+	}
+	d = d[ns*4:]
+
+	f.blocks = make([][]uint32, ns)
+	for i, size := range f.sizes {
+		if size == nilStream {
+			continue
+		}
+		n := f.numBlocks(size)
+		if n*4 > len(d) {
+			return fmt.Errorf("pdb: truncated block list of stream %d", i)
+		}
+		f.blocks[i] = make([]uint32, n)
+		for j := range f.blocks[i] {
+			f.blocks[i][j] = binary.LittleEndian.Uint32(d[j*4:])
+		}
+		d = d[n*4:]
+	}
+	return nil
+}
+
+func (f *File) NumStreams() int {
+	return len(f.sizes)
+}
+
+// Stream reads the contents of a stream, a nil stream is empty.
+func (f *File) Stream(i int) ([]byte, error) {
+	if i < 0 || i >= len(f.sizes) {
+		return nil, fmt.Errorf("pdb: stream %d does not exist", i)
+	}
+	if f.sizes[i] == nilStream {
+		return nil, nil
+	}
+	b, err := f.readBlocks(f.blocks[i], f.sizes[i])
+	if err != nil {
+		return nil, fmt.Errorf("pdb: failed to read stream %d: %v", i, err)
+	}
+	return b, nil
+}
+
+// NamedStream reads a stream listed in the PDB info stream such as
+// "/names" or "/LinkInfo".
+func (f *File) NamedStream(name string) ([]byte, error) {
+	n, ok := f.NamedStreams[name]
+	if !ok {
+		return nil, fmt.Errorf("pdb: named stream %q does not exist", name)
+	}
+	return f.Stream(int(n))
+}
+
+func (f *File) readInfo() error {
+	b, err := f.Stream(STREAM_PDB)
+	if err != nil {
+		return err
+	}
+	r := bytes.NewReader(b)
+	err = binary.Read(r, binary.LittleEndian, &f.Info)
+	if err != nil {
+		return fmt.Errorf("pdb: failed to read info stream header: %v", err)
+	}
+
+	// the named stream map is a string buffer followed by a hash table
+	// of string offsets to stream indices
+	var size uint32
+	binary.Read(r, binary.LittleEndian, &size)
+	if int64(size) > int64(r.Len()) {
+		return fmt.Errorf("pdb: invalid named stream buffer size %d", size)
+	}
+	strs := make([]byte, size)
+	r.Read(strs)
+
+	var hdr struct {
+		Size, Capacity uint32
+	}
+	err = binary.Read(r, binary.LittleEndian, &hdr)
+	if err != nil {
+		return fmt.Errorf("pdb: failed to read named stream map: %v", err)
+	}
+	present, err := readBitVector(r)
+	if err != nil {
+		return err
+	}
+	_, err = readBitVector(r)
+	if err != nil {
+		return err
+	}
+
+	f.NamedStreams = make(map[string]uint32)
+	for i := uint32(0); i < hdr.Capacity; i++ {
+		if i/32 >= uint32(len(present)) || present[i/32]&(1<<(i%32)) == 0 {
+			continue
+		}
+		var kv [2]uint32
+		err = binary.Read(r, binary.LittleEndian, &kv)
+		if err != nil {
+			return fmt.Errorf("pdb: failed to read named stream map: %v", err)
+		}
+		if kv[0] < uint32(len(strs)) {
+			f.NamedStreams[cstring(strs[kv[0]:])] = kv[1]
+		}
+	}
+	return nil
+}
+
+func readBitVector(r *bytes.Reader) ([]uint32, error) {
+	var n uint32
+	err := binary.Read(r, binary.LittleEndian, &n)
+	if err != nil || int64(n)*4 > int64(r.Len()) {
+		return nil, fmt.Errorf("pdb: invalid bit vector")
+	}
+	v := make([]uint32, n)
+	binary.Read(r, binary.LittleEndian, v)
+	return v, nil
+}
+
+// readStringTable returns the string buffer of the /names stream, it is
+// indexed by offset from the line and file checksum tables.
+func (f *File) readStringTable(n uint32) ([]byte, error) {
+	b, err := f.Stream(int(n))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < 12 || binary.LittleEndian.Uint32(b) != 0xeffeeffe {
+		return nil, fmt.Errorf("pdb: invalid string table")
+	}
+	size := binary.LittleEndian.Uint32(b[8:])
+	if uint64(size) > uint64(len(b)-12) {
+		return nil, fmt.Errorf("pdb: invalid string table size %d", size)
+	}
+	return b[12 : 12+size], nil
+}
+
+// String returns the string at an offset of the /names string table.
+func (f *File) String(off uint32) string {
+	if off >= uint32(len(f.names)) {
+		return ""
+	}
+	return cstring(f.names[off:])
+}
+
+func cstring(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		return string(b[:i])
+	}
+	return string(b)
+}
@@ -0,0 +1,292 @@
+package pdb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// CodeView symbol record kinds
+const (
+	S_END         = 0x0006
+	S_OBJNAME     = 0x1101
+	S_THUNK32     = 0x1102
+	S_BLOCK32     = 0x1103
+	S_LABEL32     = 0x1105
+	S_CONSTANT    = 0x1107
+	S_UDT         = 0x1108
+	S_LDATA32     = 0x110c
+	S_GDATA32     = 0x110d
+	S_PUB32       = 0x110e
+	S_LPROC32     = 0x110f
+	S_GPROC32     = 0x1110
+	S_LTHREAD32   = 0x1112
+	S_GTHREAD32   = 0x1113
+	S_PROCREF     = 0x1125
+	S_DATAREF     = 0x1126
+	S_LPROCREF    = 0x1127
+	S_COMPILE3    = 0x113c
+	S_LPROC32_ID  = 0x1146
+	S_GPROC32_ID  = 0x1147
+	S_PROC_ID_END = 0x114f
+)
+
+// public symbol flags
+const (
+	CVPSF_CODE     = 0x1
+	CVPSF_FUNCTION = 0x2
+	CVPSF_MANAGED  = 0x4
+	CVPSF_MSIL     = 0x8
+)
+
+// Symbol is a decoded symbol record, the fields not used by a kind are
+// zero. Module and RefOffset locate the target of a reference record,
+// Module is 1 based.
+type Symbol struct {
+	Kind         uint16
+	Name         string
+	Segment      uint16
+	Offset       uint32
+	Size         uint32
+	Type         uint32
+	Flags        uint32
+	Module       uint16
+	RefOffset    uint32
+	RecordOffset uint32
+	Data         []byte
+}
+
+func (s *Symbol) IsProc() bool {
+	switch s.Kind {
+	case S_GPROC32, S_LPROC32, S_GPROC32_ID, S_LPROC32_ID:
+		return true
+	}
+	return false
+}
+
+func (s *Symbol) IsData() bool {
+	switch s.Kind {
+	case S_GDATA32, S_LDATA32, S_GTHREAD32, S_LTHREAD32:
+		return true
+	}
+	return false
+}
+
+// parseSymbols decodes a sequence of records, base is the stream offset of
+// the first one.
+func parseSymbols(b []byte, base uint32) ([]Symbol, error) {
+	var l []Symbol
+	for off := uint32(0); len(b) >= 4; {
+		n := uint32(binary.LittleEndian.Uint16(b)) + 2
+		if n < 4 || n > uint32(len(b)) {
+			return nil, fmt.Errorf("pdb: invalid symbol record length %d at offset %#x", n, base+off)
+		}
+		s, err := parseSymbol(b[2:n])
+		if err != nil {
+			return nil, fmt.Errorf("pdb: %v at offset %#x", err, base+off)
+		}
+		s.RecordOffset = base + off
+		l = append(l, s)
+		b = b[n:]
+		off += n
+	}
+	return l, nil
+}
+
+func parseSymbol(b []byte) (Symbol, error) {
+	s := Symbol{
+		Kind: binary.LittleEndian.Uint16(b),
+		Data: b[2:],
+	}
+	p := s.Data
+	u16 := func(i int) uint16 { return binary.LittleEndian.Uint16(p[i:]) }
+	u32 := func(i int) uint32 { return binary.LittleEndian.Uint32(p[i:]) }
+	need := func(n int) error {
+		if len(p) < n {
+			return fmt.Errorf("truncated symbol record %#x", s.Kind)
+		}
+		return nil
+	}
+
+	switch s.Kind {
+	case S_PUB32:
+		if err := need(10); err != nil {
+			return s, err
+		}
+		s.Flags = u32(0)
+		s.Offset = u32(4)
+		s.Segment = u16(8)
+		s.Name = cstring(p[10:])
+	case S_GDATA32, S_LDATA32, S_GTHREAD32, S_LTHREAD32:
+		if err := need(10); err != nil {
+			return s, err
+		}
+		s.Type = u32(0)
+		s.Offset = u32(4)
+		s.Segment = u16(8)
+		s.Name = cstring(p[10:])
+	case S_GPROC32, S_LPROC32, S_GPROC32_ID, S_LPROC32_ID:
+		if err := need(35); err != nil {
+			return s, err
+		}
+		s.Size = u32(12)
+		s.Type = u32(24)
+		s.Offset = u32(28)
+		s.Segment = u16(32)
+		s.Flags = uint32(p[34])
+		s.Name = cstring(p[35:])
+	case S_PROCREF, S_LPROCREF, S_DATAREF:
+		if err := need(10); err != nil {
+			return s, err
+		}
+		s.RefOffset = u32(4)
+		s.Module = u16(8)
+		s.Name = cstring(p[10:])
+	case S_THUNK32:
+		if err := need(21); err != nil {
+			return s, err
+		}
+		s.Offset = u32(12)
+		s.Segment = u16(16)
+		s.Size = uint32(u16(18))
+		s.Name = cstring(p[21:])
+	case S_BLOCK32:
+		if err := need(18); err != nil {
+			return s, err
+		}
+		s.Size = u32(8)
+		s.Offset = u32(12)
+		s.Segment = u16(16)
+		s.Name = cstring(p[18:])
+	case S_LABEL32:
+		if err := need(7); err != nil {
+			return s, err
+		}
+		s.Offset = u32(0)
+		s.Segment = u16(4)
+		s.Flags = uint32(p[6])
+		s.Name = cstring(p[7:])
+	case S_UDT:
+		if err := need(4); err != nil {
+			return s, err
+		}
+		s.Type = u32(0)
+		s.Name = cstring(p[4:])
+	case S_CONSTANT:
+		if err := need(4); err != nil {
+			return s, err
+		}
+		s.Type = u32(0)
+		_, n, err := numericLeaf(p[4:])
+		if err != nil {
+			return s, err
+		}
+		s.Name = cstring(p[4+n:])
+	case S_OBJNAME:
+		if err := need(4); err != nil {
+			return s, err
+		}
+		s.Name = cstring(p[4:])
+	}
+	return s, nil
+}
+
+// gsiHashRecords returns the symbol record offsets listed in the hash of a
+// global symbol index stream.
+func gsiHashRecords(b []byte) ([]uint32, error) {
+	if len(b) < 16 {
+		return nil, fmt.Errorf("pdb: truncated symbol hash header")
+	}
+	sig := binary.LittleEndian.Uint32(b)
+	ver := binary.LittleEndian.Uint32(b[4:])
+	size := binary.LittleEndian.Uint32(b[8:])
+	if sig != 0xffffffff || ver != 0xeffe0000+19990810 {
+		return nil, fmt.Errorf("pdb: unsupported symbol hash version %#x", ver)
+	}
+	if uint64(size) > uint64(len(b)-16) {
+		return nil, fmt.Errorf("pdb: symbol hash records of %d bytes are truncated", size)
+	}
+
+	var l []uint32
+	for p := b[16 : 16+size]; len(p) >= 8; p = p[8:] {
+		// offsets are stored plus one
+		l = append(l, binary.LittleEndian.Uint32(p)-1)
+	}
+	return l, nil
+}
+
+func (f *File) readSymbolRecords(offs []uint32) ([]Symbol, error) {
+	if f.DBI == nil {
+		return nil, fmt.Errorf("pdb: no dbi stream")
+	}
+	b, err := f.Stream(int(f.DBI.SymRecordStream))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(offs, func(i, j int) bool {
+		return offs[i] < offs[j]
+	})
+	var l []Symbol
+	for _, o := range offs {
+		if uint64(o)+4 > uint64(len(b)) {
+			return nil, fmt.Errorf("pdb: symbol record offset %#x is past the end of the stream", o)
+		}
+		n := uint32(binary.LittleEndian.Uint16(b[o:])) + 2
+		if uint64(o)+uint64(n) > uint64(len(b)) {
+			return nil, fmt.Errorf("pdb: symbol record at %#x is truncated", o)
+		}
+		s, err := parseSymbol(b[o+2 : o+n])
+		if err != nil {
+			return nil, fmt.Errorf("pdb: %v at offset %#x", err, o)
+		}
+		s.RecordOffset = o
+		l = append(l, s)
+	}
+	return l, nil
+}
+
+// Publics returns the S_PUB32 records of the public symbol stream, their
+// names are decorated.
+func (f *File) Publics() ([]Symbol, error) {
+	if f.DBI == nil || f.DBI.PublicStreamIndex == DBG_STREAM_NOT_PRESENT {
+		return nil, nil
+	}
+	b, err := f.Stream(int(f.DBI.PublicStreamIndex))
+	if err != nil {
+		return nil, err
+	}
+
+	// the hash follows the publics header and is followed by the
+	// address map and thunk tables
+	if len(b) < 28 {
+		return nil, fmt.Errorf("pdb: truncated publics stream header")
+	}
+	size := binary.LittleEndian.Uint32(b)
+	if uint64(size) > uint64(len(b)-28) {
+		return nil, fmt.Errorf("pdb: publics symbol hash of %d bytes is truncated", size)
+	}
+	offs, err := gsiHashRecords(b[28 : 28+size])
+	if err != nil {
+		return nil, err
+	}
+	return f.readSymbolRecords(offs)
+}
+
+// Globals returns the records of the global symbol stream, these are
+// global data, constants, user defined types and references to the
+// procedures in the module streams.
+func (f *File) Globals() ([]Symbol, error) {
+	if f.DBI == nil || f.DBI.GlobalStreamIndex == DBG_STREAM_NOT_PRESENT {
+		return nil, nil
+	}
+	b, err := f.Stream(int(f.DBI.GlobalStreamIndex))
+	if err != nil {
+		return nil, err
+	}
+	offs, err := gsiHashRecords(b)
+	if err != nil {
+		return nil, err
+	}
+	return f.readSymbolRecords(offs)
+}
@@ -0,0 +1,92 @@
+package pdb
+
+import "github.com/qeedquan/go-media/debug"
+
+// Symbolizer resolves RVAs with the procedures of the module streams, the
+// public symbols and the C13 line tables, it implements debug.Symbolizer.
+type Symbolizer struct {
+	*debug.SymbolTable
+	*debug.LineTable
+}
+
+// NewSymbolizer reads every module stream along with the public and global
+// symbols, the image section headers are needed to map them to RVAs.
+func NewSymbolizer(f *File) (*Symbolizer, error) {
+	d := f.DBI
+	var syms []debug.Sym
+	add := func(s Symbol, size uint32) {
+		addr, ok := d.RVA(s.Segment, s.Offset)
+		if !ok || s.Name == "" {
+			return
+		}
+		syms = append(syms, debug.Sym{
+			Name:    s.Name,
+			Addr:    addr,
+			Size:    uint64(size),
+			Section: d.SectionName(s.Segment),
+		})
+	}
+
+	var lines []debug.Line
+	for _, m := range d.Modules {
+		s, err := f.ModuleStream(m)
+		if err != nil {
+			return nil, err
+		}
+		if s == nil {
+			continue
+		}
+
+		l, err := s.ReadSymbols()
+		if err != nil {
+			return nil, err
+		}
+		for _, y := range l {
+			switch {
+			case y.IsProc(), y.Kind == S_THUNK32:
+				add(y, y.Size)
+			case y.IsData(), y.Kind == S_LABEL32:
+				add(y, 0)
+			}
+		}
+
+		ll, err := f.ReadLines(s)
+		if err != nil {
+			return nil, err
+		}
+		for _, x := range ll {
+			addr, ok := d.RVA(x.Segment, x.Offset)
+			if !ok {
+				continue
+			}
+			lines = append(lines, debug.Line{
+				Addr:   addr,
+				File:   x.File,
+				Line:   int(x.Line),
+				Column: int(x.Column),
+			})
+		}
+	}
+
+	pubs, err := f.Publics()
+	if err != nil {
+		return nil, err
+	}
+	for _, y := range pubs {
+		add(y, 0)
+	}
+	globs, err := f.Globals()
+	if err != nil {
+		return nil, err
+	}
+	for _, y := range globs {
+		if y.IsData() {
+			add(y, 0)
+		}
+	}
+
+	return &Symbolizer{
+		SymbolTable: debug.NewSymbolTable(syms),
+		LineTable:   debug.NewLineTable(lines),
+	}, nil
+}
@@ -0,0 +1,396 @@
+package pdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+type TpiStreamHeader struct {
+	Version                 uint32
+	HeaderSize              uint32
+	TypeIndexBegin          uint32
+	TypeIndexEnd            uint32
+	TypeRecordBytes         uint32
+	HashStreamIndex         uint16
+	HashAuxStreamIndex      uint16
+	HashKeySize             uint32
+	NumHashBuckets          uint32
+	HashValueBufferOffset   int32
+	HashValueBufferLength   uint32
+	IndexOffsetBufferOffset int32
+	IndexOffsetBufferLength uint32
+	HashAdjBufferOffset     int32
+	HashAdjBufferLength     uint32
+}
+
+// type record kinds
+const (
+	LF_MODIFIER  = 0x1001
+	LF_POINTER   = 0x1002
+	LF_PROCEDURE = 0x1008
+	LF_MFUNCTION = 0x1009
+	LF_ARGLIST   = 0x1201
+	LF_FIELDLIST = 0x1203
+	LF_BITFIELD  = 0x1205
+	LF_ARRAY     = 0x1503
+	LF_CLASS     = 0x1504
+	LF_STRUCTURE = 0x1505
+	LF_UNION     = 0x1506
+	LF_ENUM      = 0x1507
+	LF_INTERFACE = 0x1519
+)
+
+// numeric leaf kinds
+const (
+	LF_NUMERIC   = 0x8000
+	LF_CHAR      = 0x8000
+	LF_SHORT     = 0x8001
+	LF_USHORT    = 0x8002
+	LF_LONG      = 0x8003
+	LF_ULONG     = 0x8004
+	LF_QUADWORD  = 0x8009
+	LF_UQUADWORD = 0x800a
+)
+
+// TypeStream holds the records of the TPI or IPI stream, type indices
+// below TypeIndexBegin refer to simple types.
+type TypeStream struct {
+	TpiStreamHeader
+	Records []TypeRecord
+}
+
+type TypeRecord struct {
+	Kind uint16
+	Data []byte
+}
+
+func (f *File) readTypeStream(n int) (*TypeStream, error) {
+	b, err := f.Stream(n)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	t := &TypeStream{}
+	err = binary.Read(bytes.NewReader(b), binary.LittleEndian, &t.TpiStreamHeader)
+	if err != nil {
+		return nil, fmt.Errorf("pdb: failed to read type stream header: %v", err)
+	}
+	if uint64(t.HeaderSize)+uint64(t.TypeRecordBytes) > uint64(len(b)) {
+		return nil, fmt.Errorf("pdb: type records of %d bytes are truncated", t.TypeRecordBytes)
+	}
+	b = b[t.HeaderSize : t.HeaderSize+t.TypeRecordBytes]
+	for len(b) >= 4 {
+		n := int(binary.LittleEndian.Uint16(b)) + 2
+		if n < 4 || n > len(b) {
+			return nil, fmt.Errorf("pdb: invalid type record length %d", n)
+		}
+		t.Records = append(t.Records, TypeRecord{
+			Kind: binary.LittleEndian.Uint16(b[2:]),
+			Data: b[4:n],
+		})
+		b = b[n:]
+	}
+	return t, nil
+}
+
+func (t *TypeStream) Record(ti uint32) (TypeRecord, bool) {
+	if t == nil || ti < t.TypeIndexBegin || ti-t.TypeIndexBegin >= uint32(len(t.Records)) {
+		return TypeRecord{}, false
+	}
+	return t.Records[ti-t.TypeIndexBegin], true
+}
+
+// numericLeaf decodes a numeric leaf and returns the number of bytes used.
+func numericLeaf(b []byte) (uint64, int, error) {
+	if len(b) < 2 {
+		return 0, 0, fmt.Errorf("truncated numeric leaf")
+	}
+	v := binary.LittleEndian.Uint16(b)
+	if v < LF_NUMERIC {
+		return uint64(v), 2, nil
+	}
+	var n int
+	switch v {
+	case LF_CHAR:
+		n = 1
+	case LF_SHORT, LF_USHORT:
+		n = 2
+	case LF_LONG, LF_ULONG:
+		n = 4
+	case LF_QUADWORD, LF_UQUADWORD:
+		n = 8
+	default:
+		return 0, 0, fmt.Errorf("unsupported numeric leaf %#x", v)
+	}
+	if len(b) < 2+n {
+		return 0, 0, fmt.Errorf("truncated numeric leaf")
+	}
+	var x uint64
+	switch v {
+	case LF_CHAR:
+		x = uint64(int8(b[2]))
+	case LF_SHORT:
+		x = uint64(int16(binary.LittleEndian.Uint16(b[2:])))
+	case LF_USHORT:
+		x = uint64(binary.LittleEndian.Uint16(b[2:]))
+	case LF_LONG:
+		x = uint64(int32(binary.LittleEndian.Uint32(b[2:])))
+	case LF_ULONG:
+		x = uint64(binary.LittleEndian.Uint32(b[2:]))
+	default:
+		x = binary.LittleEndian.Uint64(b[2:])
+	}
+	return x, 2 + n, nil
+}
+
+var simpleTypes = map[uint32]struct {
+	name string
+	size uint64
+}{
+	0x0000: {"<no type>", 0},
+	0x0003: {"void", 0},
+	0x0008: {"HRESULT", 4},
+	0x0010: {"signed char", 1},
+	0x0020: {"unsigned char", 1},
+	0x0070: {"char", 1},
+	0x0071: {"wchar_t", 2},
+	0x007a: {"char16_t", 2},
+	0x007b: {"char32_t", 4},
+	0x007c: {"char8_t", 1},
+	0x0068: {"__int8", 1},
+	0x0069: {"unsigned __int8", 1},
+	0x0011: {"short", 2},
+	0x0021: {"unsigned short", 2},
+	0x0072: {"__int16", 2},
+	0x0073: {"unsigned __int16", 2},
+	0x0012: {"long", 4},
+	0x0022: {"unsigned long", 4},
+	0x0074: {"int", 4},
+	0x0075: {"unsigned", 4},
+	0x0013: {"__int64", 8},
+	0x0023: {"unsigned __int64", 8},
+	0x0076: {"__int64", 8},
+	0x0077: {"unsigned __int64", 8},
+	0x0014: {"__int128", 16},
+	0x0024: {"unsigned __int128", 16},
+	0x0078: {"__int128", 16},
+	0x0079: {"unsigned __int128", 16},
+	0x0030: {"bool", 1},
+	0x0031: {"__bool16", 2},
+	0x0032: {"__bool32", 4},
+	0x0033: {"__bool64", 8},
+	0x0046: {"__half", 2},
+	0x0040: {"float", 4},
+	0x0041: {"double", 8},
+	0x0042: {"long double", 10},
+	0x0043: {"__float128", 16},
+}
+
+// TypeName returns a C like name of a type index, records that cannot be
+// named such as field lists are shown by kind.
+func (t *TypeStream) TypeName(ti uint32) string {
+	return t.typeName(ti, 0)
+}
+
+// names of nested types are cut off at this depth
+const maxTypeDepth = 32
+
+func (t *TypeStream) typeName(ti uint32, depth int) string {
+	if depth > maxTypeDepth {
+		return "..."
+	}
+	if ti < 0x1000 {
+		s, ok := simpleTypes[ti&0xff]
+		name := s.name
+		if !ok {
+			name = fmt.Sprintf("<simple type %#x>", ti&0xff)
+		}
+		if ti&0xf00 != 0 {
+			if ti&0xff == 0x0003 {
+				return "void*"
+			}
+			return name + "*"
+		}
+		return name
+	}
+
+	r, ok := t.Record(ti)
+	if !ok {
+		return fmt.Sprintf("<type %#x>", ti)
+	}
+	p := r.Data
+	u16 := func(i int) uint16 { return binary.LittleEndian.Uint16(p[i:]) }
+	u32 := func(i int) uint32 { return binary.LittleEndian.Uint32(p[i:]) }
+	switch r.Kind {
+	case LF_MODIFIER:
+		if len(p) < 6 {
+			break
+		}
+		var mods []string
+		m := u16(4)
+		if m&1 != 0 {
+			mods = append(mods, "const")
+		}
+		if m&2 != 0 {
+			mods = append(mods, "volatile")
+		}
+		if m&4 != 0 {
+			mods = append(mods, "__unaligned")
+		}
+		return strings.Join(append(mods, t.typeName(u32(0), depth+1)), " ")
+
+	case LF_POINTER:
+		if len(p) < 8 {
+			break
+		}
+		name := t.typeName(u32(0), depth+1)
+		switch (u32(4) >> 5) & 7 {
+		case 1:
+			return name + "&"
+		case 4:
+			return name + "&&"
+		case 2, 3:
+			return name + "::*"
+		}
+		return name + "*"
+
+	case LF_PROCEDURE:
+		if len(p) < 12 {
+			break
+		}
+		return fmt.Sprintf("%s (%s)", t.typeName(u32(0), depth+1), t.argList(u32(8), depth+1))
+
+	case LF_MFUNCTION:
+		if len(p) < 20 {
+			break
+		}
+		return fmt.Sprintf("%s %s::(%s)", t.typeName(u32(0), depth+1), t.typeName(u32(4), depth+1), t.argList(u32(16), depth+1))
+
+	case LF_ARRAY:
+		if len(p) < 8 {
+			break
+		}
+		elem := u32(0)
+		size, _, err := numericLeaf(p[8:])
+		if err != nil {
+			break
+		}
+		if es := t.TypeSize(elem); es > 0 {
+			return fmt.Sprintf("%s[%d]", t.typeName(elem, depth+1), size/es)
+		}
+		return t.typeName(elem, depth+1) + "[]"
+
+	case LF_CLASS, LF_STRUCTURE, LF_INTERFACE:
+		if len(p) < 16 {
+			break
+		}
+		_, n, err := numericLeaf(p[16:])
+		if err != nil {
+			break
+		}
+		return cstring(p[16+n:])
+
+	case LF_UNION:
+		if len(p) < 8 {
+			break
+		}
+		_, n, err := numericLeaf(p[8:])
+		if err != nil {
+			break
+		}
+		return cstring(p[8+n:])
+
+	case LF_ENUM:
+		if len(p) < 12 {
+			break
+		}
+		return cstring(p[12:])
+
+	case LF_BITFIELD:
+		if len(p) < 6 {
+			break
+		}
+		return fmt.Sprintf("%s : %d", t.typeName(u32(0), depth+1), p[4])
+	}
+	return fmt.Sprintf("<%#x type %#x>", r.Kind, ti)
+}
+
+func (t *TypeStream) argList(ti uint32, depth int) string {
+	r, ok := t.Record(ti)
+	if !ok || r.Kind != LF_ARGLIST || len(r.Data) < 4 {
+		return ""
+	}
+	n := binary.LittleEndian.Uint32(r.Data)
+	var args []string
+	for i := uint32(0); i < n && int(i)*4+8 <= len(r.Data); i++ {
+		args = append(args, t.typeName(binary.LittleEndian.Uint32(r.Data[4+i*4:]), depth))
+	}
+	return strings.Join(args, ", ")
+}
+
+// TypeSize returns the size in bytes of a type or 0 if it is unknown.
+func (t *TypeStream) TypeSize(ti uint32) uint64 {
+	for depth := 0; depth < maxTypeDepth; depth++ {
+		if ti < 0x1000 {
+			switch (ti >> 8) & 0xf {
+			case 0:
+				return simpleTypes[ti&0xff].size
+			case 1, 4:
+				return 4
+			case 6:
+				return 8
+			}
+			return 0
+		}
+
+		r, ok := t.Record(ti)
+		if !ok {
+			return 0
+		}
+		p := r.Data
+		switch r.Kind {
+		case LF_MODIFIER:
+			if len(p) < 4 {
+				return 0
+			}
+			ti = binary.LittleEndian.Uint32(p)
+			continue
+		case LF_ENUM:
+			if len(p) < 8 {
+				return 0
+			}
+			ti = binary.LittleEndian.Uint32(p[4:])
+			continue
+		case LF_POINTER:
+			if len(p) < 8 {
+				return 0
+			}
+			return uint64((binary.LittleEndian.Uint32(p[4:]) >> 13) & 0x3f)
+		case LF_ARRAY:
+			if len(p) < 8 {
+				return 0
+			}
+			v, _, _ := numericLeaf(p[8:])
+			return v
+		case LF_CLASS, LF_STRUCTURE, LF_INTERFACE:
+			if len(p) < 16 {
+				return 0
+			}
+			v, _, _ := numericLeaf(p[16:])
+			return v
+		case LF_UNION:
+			if len(p) < 8 {
+				return 0
+			}
+			v, _, _ := numericLeaf(p[8:])
+			return v
+		}
+		return 0
+	}
+	return 0
+}
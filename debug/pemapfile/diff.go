@@ -0,0 +1,40 @@
+package pemapfile
+
+import (
+	"github.com/qeedquan/go-media/debug"
+)
+
+// Diff holds the symbols, sections and object files whose size changed
+// between two links.
+type Diff struct {
+	Symbols  []debug.SizeDiff
+	Sections []debug.SizeDiff
+	Files    []debug.SizeDiff
+}
+
+// Compare diffs the symbol sizes of two map files, symbols are matched by
+// name and object file. The map file doesn't list section or object file
+// sizes so those are summed from the public symbols, padding and static
+// functions missing from the publics aren't counted.
+func Compare(a, b *File) *Diff {
+	syms := debug.NewSizeDiffer()
+	secs := debug.NewSizeDiffer()
+	files := debug.NewSizeDiffer()
+	add := func(m *File, old bool) {
+		for i := range m.Symbols {
+			p := &m.Symbols[i]
+			sec := m.SectionName(p)
+			syms.Add(p.Name, p.File, sec, p.Size, old)
+			secs.Add(sec, "", "", p.Size, old)
+			files.Add(p.File, "", "", p.Size, old)
+		}
+	}
+	add(a, true)
+	add(b, false)
+
+	return &Diff{
+		Symbols:  syms.Changed(),
+		Sections: secs.Changed(),
+		Files:    files.Changed(),
+	}
+}
@@ -0,0 +1,88 @@
+package pemapfile
+
+import (
+	"sort"
+
+	"github.com/qeedquan/go-media/debug"
+)
+
+// SectionName returns the name of the section holding a symbol.
+func (m *File) SectionName(p *Symbol) string {
+	if p.Section < 0 || p.Section >= len(m.Sections) {
+		return ""
+	}
+	return m.Sections[p.Section].Name
+}
+
+// LookupSymbol returns the first symbol with a name, both the decorated
+// names and the ones listed in the static symbols are searched.
+func (m *File) LookupSymbol(name string) *Symbol {
+	for i := range m.Symbols {
+		if m.Symbols[i].Name == name {
+			return &m.Symbols[i]
+		}
+	}
+	return nil
+}
+
+// LookupVA returns the symbol containing a virtual address and the
+// offset of the address into it.
+func (m *File) LookupVA(va uint64) (*Symbol, uint64, bool) {
+	syms := m.addrSymbols()
+	i := sort.Search(len(syms), func(i int) bool {
+		return syms[i].Addr > va
+	}) - 1
+	if i < 0 {
+		return nil, 0, false
+	}
+	p := syms[i]
+	if va-p.Addr >= p.Size && p.Size != 0 {
+		return nil, 0, false
+	}
+	return p, va - p.Addr, true
+}
+
+// LookupRVA is LookupVA relative to the preferred load address.
+func (m *File) LookupRVA(rva uint64) (*Symbol, uint64, bool) {
+	return m.LookupVA(m.BaseAddr + rva)
+}
+
+// addrSymbols returns the symbols with an address sorted by it, the
+// symbols are sorted by segment and offset so this is usually the same
+// order.
+func (m *File) addrSymbols() []*Symbol {
+	var l []*Symbol
+	for i := range m.Symbols {
+		if p := &m.Symbols[i]; p.Addr != 0 && p.Segment != 0 {
+			l = append(l, p)
+		}
+	}
+	sort.SliceStable(l, func(i, j int) bool {
+		return l[i].Addr < l[j].Addr
+	})
+	return l
+}
+
+// Symbolizer implements debug.Symbolizer over the symbols of a map file
+// with addresses relative to the preferred load address, map files have
+// no line information.
+type Symbolizer struct {
+	*debug.SymbolTable
+}
+
+func NewSymbolizer(m *File) *Symbolizer {
+	var syms []debug.Sym
+	for _, p := range m.addrSymbols() {
+		syms = append(syms, debug.Sym{
+			Name:    p.Name,
+			Addr:    p.Addr - m.BaseAddr,
+			Size:    p.Size,
+			Section: m.SectionName(p),
+		})
+	}
+	return &Symbolizer{debug.NewSymbolTable(syms)}
+}
+
+func (z *Symbolizer) AddrLine(addr uint64) (debug.Line, bool) {
+	return debug.Line{}, false
+}
@@ -3,7 +3,7 @@ package pemapfile
 import (
 	"bufio"
 	"fmt"
-	"math"
+	"io"
 	"os"
 	"sort"
 	"strings"
@@ -20,7 +20,8 @@ type File struct {
 }
 
 type Segment struct {
-	Size uint64
+	Number uint64
+	Size   uint64
 }
 
 type Section struct {
@@ -52,6 +53,15 @@ func streq(s, t []string) bool {
 }
 
 func Open(name string) (*File, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+func Parse(r io.Reader) (*File, error) {
 	const (
 		NAME = iota
 		TIMESTAMP
@@ -61,15 +71,9 @@ func Open(name string) (*File, error) {
 		SYMBOL
 	)
 
-	f, err := os.Open(name)
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-
 	var t [8]string
 	m := &File{}
-	sc := bufio.NewScanner(f)
+	sc := bufio.NewScanner(r)
 	st := NAME
 	for sc.Scan() {
 		ln := strings.TrimSpace(sc.Text())
@@ -124,7 +128,10 @@ func Open(name string) (*File, error) {
 
 		case SYMBOL:
 			var p Symbol
-			fmt.Sscanf(ln, "%x:%x %s %x", &p.Segment, &p.Offset, &p.Name, &p.Addr)
+			n, _ := fmt.Sscanf(ln, "%x:%x %s %x", &p.Segment, &p.Offset, &p.Name, &p.Addr)
+			if n != 4 {
+				continue
+			}
 
 			t := strings.Split(ln, " ")
 			if len(t) == 0 {
@@ -163,52 +170,38 @@ func Open(name string) (*File, error) {
 		return x.Segment < y.Segment
 	})
 
-	if l := len(m.Sections); l > 0 {
-		m.Segments = make([]Segment, m.Sections[l-1].Segment+1)
-
-		seg := uint64(math.MaxUint64)
-		for i := l - 1; i >= 0; i-- {
-			p := &m.Sections[i]
-			if seg != p.Segment {
-				seg = p.Segment
-				m.Segments[seg].Size = p.Offset + p.Size
-			}
+	for _, p := range m.Sections {
+		n := len(m.Segments)
+		if n == 0 || m.Segments[n-1].Number != p.Segment {
+			m.Segments = append(m.Segments, Segment{Number: p.Segment})
+			n++
 		}
-
-		var s []Segment
-		for _, p := range m.Segments {
-			if p.Size != 0 {
-				s = append(s, p)
-			}
+		if end := p.Offset + p.Size; end > m.Segments[n-1].Size {
+			m.Segments[n-1].Size = end
 		}
-		m.Segments = s
 	}
 
 	l := len(m.Symbols)
-	for i := 0; i < l-1; i++ {
+	for i := 0; i < l; i++ {
 		x := &m.Symbols[i]
-		y := &m.Symbols[i+1]
-		if x.Segment >= uint64(len(m.Segments)) || y.Segment >= uint64(len(m.Segments)) {
+		g := m.Segment(x.Segment)
+		if g == nil {
 			continue
 		}
 
-		if x.Segment != y.Segment {
-			x.Size = m.Segments[x.Segment].Size - x.Offset
-		} else {
-			x.Size = y.Offset - x.Offset
-		}
-	}
-	if l > 0 {
-		x := &m.Symbols[l-1]
-		if x.Segment < uint64(len(m.Segments)) {
-			x.Size = m.Segments[x.Segment].Size - x.Offset
+		if i+1 < l && m.Symbols[i+1].Segment == x.Segment {
+			x.Size = m.Symbols[i+1].Offset - x.Offset
+		} else if g.Size > x.Offset {
+			x.Size = g.Size - x.Offset
 		}
 	}
 
+	// symbols outside of any section such as absolute ones get -1
 	for i := 0; i < len(m.Symbols); i++ {
 		x := &m.Symbols[i]
+		x.Section = -1
 		for i, y := range m.Sections {
-			if x.Segment == y.Segment && y.Offset <= x.Offset && x.Offset <= y.Offset+y.Size {
+			if x.Segment == y.Segment && y.Offset <= x.Offset && x.Offset < y.Offset+y.Size {
 				x.Section = i
 				break
 			}
@@ -217,3 +210,14 @@ func Open(name string) (*File, error) {
 
 	return m, nil
 }
+
+// Segment returns the segment with a number, segments are numbered like
+// the sections of the image starting at 1.
+func (m *File) Segment(n uint64) *Segment {
+	for i := range m.Segments {
+		if m.Segments[i].Number == n {
+			return &m.Segments[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package pemapfile
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/qeedquan/go-media/debug/peutil"
+)
+
+// Validate checks that a map file describes an image, the timestamp, load
+// address, segment bounds and symbol addresses have to agree with it. All
+// mismatches found are returned.
+func (m *File) Validate(f *peutil.File) []error {
+	var errs []error
+	if ts, err := strconv.ParseUint(m.Checksum, 16, 32); err == nil && uint32(ts) != f.TimeDateStamp {
+		errs = append(errs, fmt.Errorf("timestamp %#x does not match image timestamp %#x", ts, f.TimeDateStamp))
+	}
+	if m.BaseAddr != f.ImageBase {
+		errs = append(errs, fmt.Errorf("load address %#x does not match image base %#x", m.BaseAddr, f.ImageBase))
+	}
+
+	for _, g := range m.Segments {
+		s := section(f, g.Number)
+		if s == nil {
+			errs = append(errs, fmt.Errorf("segment %d does not exist in the image", g.Number))
+			continue
+		}
+		size := uint64(s.VirtualSize)
+		if uint64(s.Size) > size {
+			size = uint64(s.Size)
+		}
+		if g.Size > size {
+			errs = append(errs, fmt.Errorf("segment %d of size %#x does not fit in section %s of size %#x", g.Number, g.Size, s.Name, size))
+		}
+
+		// sections are grouped by the name before the $ and the linker
+		// merges some groups, one of them has to match
+		found := false
+		for _, p := range m.Sections {
+			if p.Segment == g.Number && groupName(p.Name) == s.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("segment %d has no sections matching section %s", g.Number, s.Name))
+		}
+	}
+
+	for i := range m.Symbols {
+		p := &m.Symbols[i]
+		if p.Segment == 0 || p.Addr == 0 {
+			continue
+		}
+		s := section(f, p.Segment)
+		if s == nil {
+			continue
+		}
+		va := f.ImageBase + uint64(s.VirtualAddress) + p.Offset
+		if va != p.Addr {
+			errs = append(errs, fmt.Errorf("symbol %s at %d:%#x has address %#x but is at %#x in the image", p.Name, p.Segment, p.Offset, p.Addr, va))
+		}
+	}
+	return errs
+}
+
+func section(f *peutil.File, n uint64) *peutil.Section {
+	if n == 0 || n > uint64(len(f.Sections)) {
+		return nil
+	}
+	return f.Sections[n-1]
+}
+
+func groupName(name string) string {
+	if i := strings.IndexByte(name, '$'); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
@@ -0,0 +1,310 @@
+package peutil
+
+import (
+	"bytes"
+	"crypto"
+	"debug/pe"
+	"encoding/asn1"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"sort"
+)
+
+const (
+	WIN_CERT_REVISION_1_0 = 0x0100
+	WIN_CERT_REVISION_2_0 = 0x0200
+)
+
+const (
+	WIN_CERT_TYPE_X509             = 0x0001
+	WIN_CERT_TYPE_PKCS_SIGNED_DATA = 0x0002
+	WIN_CERT_TYPE_RESERVED_1       = 0x0003
+	WIN_CERT_TYPE_TS_STACK_SIGNED  = 0x0004
+)
+
+// Certificate is a WIN_CERTIFICATE entry of the certificate table, for
+// Authenticode signatures the data is a PKCS#7 SignedData blob.
+type Certificate struct {
+	Revision uint16
+	Type     uint16
+	Data     []byte
+}
+
+// imageLayout holds the file offsets Authenticode and the checksum skip.
+type imageLayout struct {
+	checksum int
+	certdir  int
+	headers  int
+	certoff  int
+	certsize int
+	sections [][2]int
+}
+
+func parseImageLayout(b []byte) (*imageLayout, error) {
+	if len(b) < 0x40 || b[0] != 'M' || b[1] != 'Z' {
+		return nil, fmt.Errorf("missing DOS header")
+	}
+	lfanew := int(binary.LittleEndian.Uint32(b[0x3c:]))
+	if lfanew+24 > len(b) || !bytes.Equal(b[lfanew:lfanew+4], []byte("PE\x00\x00")) {
+		return nil, fmt.Errorf("missing PE signature")
+	}
+
+	fh := lfanew + 4
+	nsect := int(binary.LittleEndian.Uint16(b[fh+2:]))
+	optsize := int(binary.LittleEndian.Uint16(b[fh+16:]))
+	opt := fh + 20
+	if opt+optsize > len(b) || optsize < 64+4 {
+		return nil, fmt.Errorf("truncated optional header")
+	}
+
+	l := &imageLayout{
+		checksum: opt + 64,
+		headers:  int(binary.LittleEndian.Uint32(b[opt+60:])),
+	}
+	var dirs int
+	switch magic := binary.LittleEndian.Uint16(b[opt:]); magic {
+	case 0x10b:
+		dirs = opt + 96
+	case 0x20b:
+		dirs = opt + 112
+	default:
+		return nil, fmt.Errorf("unknown optional header magic %#x", magic)
+	}
+	ndirs := int(binary.LittleEndian.Uint32(b[dirs-4:]))
+	l.certdir = dirs + pe.IMAGE_DIRECTORY_ENTRY_SECURITY*8
+	if ndirs > pe.IMAGE_DIRECTORY_ENTRY_SECURITY && l.certdir+8 <= opt+optsize {
+		l.certoff = int(binary.LittleEndian.Uint32(b[l.certdir:]))
+		l.certsize = int(binary.LittleEndian.Uint32(b[l.certdir+4:]))
+	} else {
+		l.certdir = -1
+	}
+	if l.certoff+l.certsize > len(b) {
+		return nil, fmt.Errorf("certificate table at %#x size %#x is past the end of the file", l.certoff, l.certsize)
+	}
+	if l.headers > len(b) || l.headers < l.checksum+4 {
+		return nil, fmt.Errorf("invalid size of headers %#x", l.headers)
+	}
+
+	sh := opt + optsize
+	for i := 0; i < nsect; i++ {
+		p := sh + i*40
+		if p+40 > len(b) {
+			return nil, fmt.Errorf("truncated section table")
+		}
+		size := int(binary.LittleEndian.Uint32(b[p+16:]))
+		off := int(binary.LittleEndian.Uint32(b[p+20:]))
+		if size == 0 {
+			continue
+		}
+		if off+size > len(b) {
+			return nil, fmt.Errorf("section %d data at %#x size %#x is past the end of the file", i, off, size)
+		}
+		l.sections = append(l.sections, [2]int{off, size})
+	}
+	sort.Slice(l.sections, func(i, j int) bool {
+		return l.sections[i][0] < l.sections[j][0]
+	})
+	return l, nil
+}
+
+// Checksum computes the optional header CheckSum of a PE image, the
+// checksum field itself is skipped.
+func Checksum(b []byte) (uint32, error) {
+	l, err := parseImageLayout(b)
+	if err != nil {
+		return 0, err
+	}
+
+	var sum uint64
+	for i := 0; i < len(b); i += 2 {
+		if i >= l.checksum && i < l.checksum+4 {
+			continue
+		}
+		v := uint64(b[i])
+		if i+1 < len(b) {
+			v |= uint64(b[i+1]) << 8
+		}
+		sum += v
+		sum = (sum & 0xffff) + (sum >> 16)
+	}
+	sum = (sum & 0xffff) + (sum >> 16)
+	return uint32(sum) + uint32(len(b)), nil
+}
+
+// AuthenticodeHash computes the Authenticode digest of a PE image, the
+// checksum, the certificate table directory entry and the certificate
+// table are excluded.
+func AuthenticodeHash(b []byte, h hash.Hash) ([]byte, error) {
+	l, err := parseImageLayout(b)
+	if err != nil {
+		return nil, err
+	}
+
+	h.Reset()
+	if l.certdir < 0 {
+		h.Write(b[:l.checksum])
+		h.Write(b[l.checksum+4 : l.headers])
+	} else {
+		h.Write(b[:l.checksum])
+		h.Write(b[l.checksum+4 : l.certdir])
+		h.Write(b[l.certdir+8 : l.headers])
+	}
+
+	hashed := l.headers
+	for _, s := range l.sections {
+		h.Write(b[s[0] : s[0]+s[1]])
+		hashed += s[1]
+	}
+
+	// data after the sections is included up to the certificate table
+	end := len(b)
+	if l.certsize > 0 {
+		end = l.certoff
+	}
+	if hashed < end {
+		h.Write(b[hashed:end])
+	}
+	return h.Sum(nil), nil
+}
+
+func (f *File) image() ([]byte, error) {
+	w := new(bytes.Buffer)
+	err := Format(f, w)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// UpdateChecksum recomputes the optional header CheckSum of the image as
+// Format would write it.
+func (f *File) UpdateChecksum() error {
+	b, err := f.image()
+	if err != nil {
+		return err
+	}
+	sum, err := Checksum(b)
+	if err != nil {
+		return err
+	}
+	switch h := f.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		h.CheckSum = sum
+	case *pe.OptionalHeader64:
+		h.CheckSum = sum
+	}
+	return nil
+}
+
+// AuthenticodeHash computes the Authenticode digest of the image as
+// Format would write it.
+func (f *File) AuthenticodeHash(h hash.Hash) ([]byte, error) {
+	b, err := f.image()
+	if err != nil {
+		return nil, err
+	}
+	return AuthenticodeHash(b, h)
+}
+
+// ParseCertificates decodes the WIN_CERTIFICATE entries of a certificate
+// table, each of them starts on an 8 byte boundary.
+func ParseCertificates(b []byte) ([]Certificate, error) {
+	var certs []Certificate
+	for off := 0; off+8 <= len(b); {
+		n := int(binary.LittleEndian.Uint32(b[off:]))
+		if n < 8 || off+n > len(b) {
+			return nil, fmt.Errorf("invalid certificate length %d at offset %#x", n, off)
+		}
+		certs = append(certs, Certificate{
+			Revision: binary.LittleEndian.Uint16(b[off+4:]),
+			Type:     binary.LittleEndian.Uint16(b[off+6:]),
+			Data:     b[off+8 : off+n],
+		})
+		off += (n + 7) &^ 7
+	}
+	return certs, nil
+}
+
+func (f *File) Certificates() ([]Certificate, error) {
+	return ParseCertificates(f.CertificateTable)
+}
+
+// StripCertificates removes the certificate table.
+func (f *File) StripCertificates() {
+	f.CertificateTable = nil
+	if dd := f.DataDirectory(pe.IMAGE_DIRECTORY_ENTRY_SECURITY); dd != nil {
+		dd.VirtualAddress = 0
+		dd.Size = 0
+	}
+}
+
+var (
+	oidSHA1   = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidSHA256 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSHA384 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidSHA512 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+	oidMD5    = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 5}
+)
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	ContentInfo      contentInfo
+}
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+type spcIndirectDataContent struct {
+	Data          asn1.RawValue
+	MessageDigest struct {
+		DigestAlgorithm algorithmIdentifier
+		Digest          []byte
+	}
+}
+
+// Digest returns the image digest signed by an Authenticode signature,
+// it can be compared against AuthenticodeHash to check if the image was
+// modified. The signature itself is not verified.
+func (c *Certificate) Digest() (crypto.Hash, []byte, error) {
+	if c.Type != WIN_CERT_TYPE_PKCS_SIGNED_DATA {
+		return 0, nil, fmt.Errorf("certificate type %d is not PKCS#7 signed data", c.Type)
+	}
+
+	var ci contentInfo
+	if _, err := asn1.Unmarshal(c.Data, &ci); err != nil {
+		return 0, nil, fmt.Errorf("invalid content info: %v", err)
+	}
+	var sd signedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return 0, nil, fmt.Errorf("invalid signed data: %v", err)
+	}
+	var ind spcIndirectDataContent
+	if _, err := asn1.Unmarshal(sd.ContentInfo.Content.Bytes, &ind); err != nil {
+		return 0, nil, fmt.Errorf("invalid indirect data content: %v", err)
+	}
+
+	md := ind.MessageDigest
+	alg := md.DigestAlgorithm.Algorithm
+	switch {
+	case alg.Equal(oidSHA1):
+		return crypto.SHA1, md.Digest, nil
+	case alg.Equal(oidSHA256):
+		return crypto.SHA256, md.Digest, nil
+	case alg.Equal(oidSHA384):
+		return crypto.SHA384, md.Digest, nil
+	case alg.Equal(oidSHA512):
+		return crypto.SHA512, md.Digest, nil
+	case alg.Equal(oidMD5):
+		return crypto.MD5, md.Digest, nil
+	}
+	return 0, nil, fmt.Errorf("unknown digest algorithm %v", alg)
+}
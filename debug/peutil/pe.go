@@ -235,6 +235,7 @@ type File struct {
 	DOSStub          []byte
 	Sections         []*Section
 	Strings          []string
+	CertificateTable []byte
 	r                io.ReaderAt
 }
 
@@ -367,6 +368,15 @@ func newFile(p *pe.File, r io.ReaderAt) (*File, error) {
 		f.FileAlignment = f.SectionAlignment
 	}
 
+	// the certificate table is addressed by file offset and not loaded
+	if dd := f.DataDirectory(pe.IMAGE_DIRECTORY_ENTRY_SECURITY); dd != nil && dd.Size > 0 {
+		f.CertificateTable = make([]byte, dd.Size)
+		_, err = r.ReadAt(f.CertificateTable, int64(dd.VirtualAddress))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read certificate table: %v", err)
+		}
+	}
+
 	f.updateImageHeaderSize()
 	return f, nil
 }
@@ -417,11 +427,12 @@ func (f *File) calcImageSizes() (rawSizeOfHeaders, sizeOfHeaders, sizeOfImage ui
 	}
 	sizeOfHeaders = mathutil.Multiple64(sizeOfHeaders, f.FileAlignment)
 
-	sizeOfImage = sizeOfHeaders
+	// the image ends with the highest section in memory
+	sizeOfImage = mathutil.Multiple64(sizeOfHeaders, f.SectionAlignment)
 	for _, s := range f.Sections {
-		sizeOfImage += uint64(mathutil.Multiple64(uint64(s.VirtualSize), f.SectionAlignment))
+		end := mathutil.Multiple64(uint64(s.VirtualAddress)+uint64(s.VirtualSize), f.SectionAlignment)
+		sizeOfImage = mathutil.Max64(sizeOfImage, end)
 	}
-	sizeOfImage = mathutil.Multiple64(sizeOfImage, f.FileAlignment)
 	return
 }
 
@@ -929,14 +940,17 @@ func (f *File) SwapSectionName(a, b string) error {
 	return nil
 }
 
-func (f *File) FindStrings() []debug.String {
+// FindStrings finds the strings of the sections, the address of a string
+// is its virtual address in the image. Nil options search for the ASCII
+// strings of 4 to 256 characters.
+func (f *File) FindStrings(opt *debug.StringOptions) []debug.String {
+	if opt == nil {
+		opt = &debug.StringOptions{MinWidth: 4, MaxWidth: 256, ASCII: true}
+	}
 	var tab []debug.String
 	for _, s := range f.Sections {
-		st := debug.FindStringsBuffer(s.Data, 4, 256, true)
-		for i := range st {
-			st[i].Name = s.Name
-		}
-		tab = append(tab, st...)
+		addr := f.ImageBase + uint64(s.VirtualAddress)
+		tab = append(tab, debug.FindSectionStrings(s.Name, addr, 1, s.Data, opt)...)
 	}
 	return tab
 }
@@ -1024,6 +1038,32 @@ func (dt *ImportDescriptionList) LookupSymbol(name string) *Symbol {
 func Format(f *File, w io.Writer) error {
 	b := bufio.NewWriter(w)
 
+	// section data is written at the raw offsets in the section headers
+	secs := make([]*Section, 0, len(f.Sections))
+	for _, s := range f.Sections {
+		if len(s.Data) > 0 {
+			secs = append(secs, s)
+		}
+	}
+	sort.SliceStable(secs, func(i, j int) bool {
+		return secs[i].Offset < secs[j].Offset
+	})
+	end := f.SizeOfHeaders
+	for _, s := range secs {
+		if uint64(s.Offset) < end {
+			return fmt.Errorf("section %s at offset %#x overlaps the data before it", s.Name, s.Offset)
+		}
+		end = uint64(s.Offset) + uint64(len(s.Data))
+	}
+
+	// the certificate table goes at the end of the file on an 8 byte boundary
+	certoff := mathutil.Multiple64(end, 8)
+	if len(f.CertificateTable) > 0 {
+		dd := f.CreateDataDirectory(pe.IMAGE_DIRECTORY_ENTRY_SECURITY)
+		dd.VirtualAddress = uint32(certoff)
+		dd.Size = uint32(len(f.CertificateTable))
+	}
+
 	binary.Write(b, binary.LittleEndian, &f.DOSHeader)
 	b.Write(f.DOSStub)
 
@@ -1045,8 +1085,16 @@ func Format(f *File, w io.Writer) error {
 	pad := make([]byte, f.SizeOfHeaders-f.RawSizeOfHeaders)
 	b.Write(pad)
 
-	for _, s := range f.Sections {
+	off := f.SizeOfHeaders
+	for _, s := range secs {
+		b.Write(make([]byte, uint64(s.Offset)-off))
 		b.Write(s.Data)
+		off = uint64(s.Offset) + uint64(len(s.Data))
+	}
+
+	if len(f.CertificateTable) > 0 {
+		b.Write(make([]byte, certoff-end))
+		b.Write(f.CertificateTable)
 	}
 
 	return b.Flush()
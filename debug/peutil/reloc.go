@@ -0,0 +1,208 @@
+package peutil
+
+import (
+	"debug/pe"
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+const (
+	IMAGE_REL_BASED_ABSOLUTE       = 0
+	IMAGE_REL_BASED_HIGH           = 1
+	IMAGE_REL_BASED_LOW            = 2
+	IMAGE_REL_BASED_HIGHLOW        = 3
+	IMAGE_REL_BASED_HIGHADJ        = 4
+	IMAGE_REL_BASED_MIPS_JMPADDR   = 5
+	IMAGE_REL_BASED_ARM_MOV32      = 5
+	IMAGE_REL_BASED_RISCV_HIGH20   = 5
+	IMAGE_REL_BASED_THUMB_MOV32    = 7
+	IMAGE_REL_BASED_RISCV_LOW12I   = 7
+	IMAGE_REL_BASED_RISCV_LOW12S   = 8
+	IMAGE_REL_BASED_MIPS_JMPADDR16 = 9
+	IMAGE_REL_BASED_DIR64          = 10
+)
+
+// BaseReloc is an entry of the base relocation table, Arg holds the low
+// half of the value for IMAGE_REL_BASED_HIGHADJ.
+type BaseReloc struct {
+	Addr uint32
+	Type uint8
+	Arg  uint16
+}
+
+func (r BaseReloc) String() string {
+	var typ string
+	switch r.Type {
+	case IMAGE_REL_BASED_ABSOLUTE:
+		typ = "ABSOLUTE"
+	case IMAGE_REL_BASED_HIGH:
+		typ = "HIGH"
+	case IMAGE_REL_BASED_LOW:
+		typ = "LOW"
+	case IMAGE_REL_BASED_HIGHLOW:
+		typ = "HIGHLOW"
+	case IMAGE_REL_BASED_HIGHADJ:
+		typ = "HIGHADJ"
+	case IMAGE_REL_BASED_DIR64:
+		typ = "DIR64"
+	default:
+		typ = fmt.Sprint(r.Type)
+	}
+	return fmt.Sprintf("%#x %s", r.Addr, typ)
+}
+
+// readRVA returns n contiguous bytes of section data at an address.
+func (f *File) readRVA(rva uint64, n int) ([]byte, error) {
+	_, b, _ := f.LookupVirtualAddress(rva)
+	if len(b) < n {
+		return nil, fmt.Errorf("invalid read of %d bytes at unmapped address %#x", n, rva)
+	}
+	return b[:n], nil
+}
+
+// BaseRelocs decodes the base relocation table, the padding entries of
+// each block are dropped.
+func (f *File) BaseRelocs() ([]BaseReloc, error) {
+	dd := f.DataDirectory(pe.IMAGE_DIRECTORY_ENTRY_BASERELOC)
+	if dd == nil || dd.Size == 0 {
+		return nil, nil
+	}
+	b, err := f.readRVA(uint64(dd.VirtualAddress), int(dd.Size))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read base relocation table: %v", err)
+	}
+
+	var rels []BaseReloc
+	for len(b) >= 8 {
+		page := binary.LittleEndian.Uint32(b)
+		size := binary.LittleEndian.Uint32(b[4:])
+		if size < 8 || size > uint32(len(b)) {
+			return nil, fmt.Errorf("invalid base relocation block size %#x for page %#x", size, page)
+		}
+		for p := b[8:size]; len(p) >= 2; p = p[2:] {
+			v := binary.LittleEndian.Uint16(p)
+			r := BaseReloc{
+				Addr: page + uint32(v&0xfff),
+				Type: uint8(v >> 12),
+			}
+			if r.Type == IMAGE_REL_BASED_ABSOLUTE {
+				continue
+			}
+			if r.Type == IMAGE_REL_BASED_HIGHADJ {
+				if len(p) < 4 {
+					return nil, fmt.Errorf("truncated HIGHADJ relocation at %#x", r.Addr)
+				}
+				p = p[2:]
+				r.Arg = binary.LittleEndian.Uint16(p)
+			}
+			rels = append(rels, r)
+		}
+		b = b[size:]
+	}
+	return rels, nil
+}
+
+// EncodeBaseRelocs builds a base relocation table grouping the entries by
+// 4K pages, each block is padded to a 4 byte boundary.
+func EncodeBaseRelocs(rels []BaseReloc) []byte {
+	l := append([]BaseReloc(nil), rels...)
+	sort.SliceStable(l, func(i, j int) bool {
+		return l[i].Addr < l[j].Addr
+	})
+
+	var b []byte
+	for i := 0; i < len(l); {
+		page := l[i].Addr &^ 0xfff
+		blk := make([]byte, 8)
+		binary.LittleEndian.PutUint32(blk, page)
+		for ; i < len(l) && l[i].Addr&^0xfff == page; i++ {
+			r := l[i]
+			v := uint16(r.Type)<<12 | uint16(r.Addr&0xfff)
+			blk = append(blk, uint8(v), uint8(v>>8))
+			if r.Type == IMAGE_REL_BASED_HIGHADJ {
+				blk = append(blk, uint8(r.Arg), uint8(r.Arg>>8))
+			}
+		}
+		if len(blk)%4 != 0 {
+			blk = append(blk, 0, 0)
+		}
+		binary.LittleEndian.PutUint32(blk[4:], uint32(len(blk)))
+		b = append(b, blk...)
+	}
+	return b
+}
+
+// Rebase applies the base relocations for a new image base and updates the
+// optional header.
+func (f *File) Rebase(base uint64) error {
+	delta := base - f.ImageBase
+	if delta == 0 {
+		return nil
+	}
+	if f.Characteristics&IMAGE_FILE_RELOCS_STRIPPED != 0 {
+		return fmt.Errorf("relocations are stripped")
+	}
+	if f.WordSize == 4 && base > 0xffffffff {
+		return fmt.Errorf("image base %#x is out of range", base)
+	}
+
+	rels, err := f.BaseRelocs()
+	if err != nil {
+		return err
+	}
+	for _, r := range rels {
+		err := f.applyBaseReloc(r, delta)
+		if err != nil {
+			return err
+		}
+	}
+
+	f.ImageBase = base
+	switch h := f.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		h.ImageBase = uint32(base)
+	case *pe.OptionalHeader64:
+		h.ImageBase = base
+	}
+	return nil
+}
+
+func (f *File) applyBaseReloc(r BaseReloc, delta uint64) error {
+	va := uint64(r.Addr)
+	switch r.Type {
+	case IMAGE_REL_BASED_HIGH:
+		var v uint16
+		if err := f.ReadVirtualAddress(va, &v); err != nil {
+			return err
+		}
+		return f.WriteVirtualAddress(va, v+uint16(delta>>16))
+	case IMAGE_REL_BASED_LOW:
+		var v uint16
+		if err := f.ReadVirtualAddress(va, &v); err != nil {
+			return err
+		}
+		return f.WriteVirtualAddress(va, v+uint16(delta))
+	case IMAGE_REL_BASED_HIGHLOW:
+		var v uint32
+		if err := f.ReadVirtualAddress(va, &v); err != nil {
+			return err
+		}
+		return f.WriteVirtualAddress(va, v+uint32(delta))
+	case IMAGE_REL_BASED_HIGHADJ:
+		var v uint16
+		if err := f.ReadVirtualAddress(va, &v); err != nil {
+			return err
+		}
+		x := uint32(v)<<16 + uint32(int32(int16(r.Arg)))
+		x += uint32(delta) + 0x8000
+		return f.WriteVirtualAddress(va, uint16(x>>16))
+	case IMAGE_REL_BASED_DIR64:
+		var v uint64
+		if err := f.ReadVirtualAddress(va, &v); err != nil {
+			return err
+		}
+		return f.WriteVirtualAddress(va, v+delta)
+	}
+	return fmt.Errorf("unsupported base relocation type %d at %#x", r.Type, r.Addr)
+}
@@ -0,0 +1,469 @@
+package peutil
+
+import (
+	"debug/pe"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/qeedquan/go-media/math/mathutil"
+)
+
+const (
+	RT_CURSOR       = 1
+	RT_BITMAP       = 2
+	RT_ICON         = 3
+	RT_MENU         = 4
+	RT_DIALOG       = 5
+	RT_STRING       = 6
+	RT_FONTDIR      = 7
+	RT_FONT         = 8
+	RT_ACCELERATOR  = 9
+	RT_RCDATA       = 10
+	RT_MESSAGETABLE = 11
+	RT_GROUP_CURSOR = 12
+	RT_GROUP_ICON   = 14
+	RT_VERSION      = 16
+	RT_DLGINCLUDE   = 17
+	RT_PLUGPLAY     = 19
+	RT_VXD          = 20
+	RT_ANICURSOR    = 21
+	RT_ANIICON      = 22
+	RT_HTML         = 23
+	RT_MANIFEST     = 24
+)
+
+// the directory nesting is type, name and language
+const maxResourceDepth = 8
+
+// ResourceID identifies a resource type, name or language, named entries
+// have a non-empty Name and ignore ID.
+type ResourceID struct {
+	ID   uint32
+	Name string
+}
+
+func (r ResourceID) String() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return fmt.Sprint(r.ID)
+}
+
+func (r ResourceID) less(o ResourceID) bool {
+	if (r.Name != "") != (o.Name != "") {
+		return r.Name != ""
+	}
+	if r.Name != "" {
+		return strings.ToUpper(r.Name) < strings.ToUpper(o.Name)
+	}
+	return r.ID < o.ID
+}
+
+type ResourceDir struct {
+	Characteristics uint32
+	TimeDateStamp   uint32
+	MajorVersion    uint16
+	MinorVersion    uint16
+	Entries         []*ResourceEntry
+}
+
+// ResourceEntry is either a subdirectory or a leaf holding data.
+type ResourceEntry struct {
+	ID       ResourceID
+	Dir      *ResourceDir
+	Data     []byte
+	CodePage uint32
+}
+
+// Resource is a leaf of a type, name and language tree.
+type Resource struct {
+	Type     ResourceID
+	Name     ResourceID
+	Lang     uint32
+	CodePage uint32
+	Data     []byte
+}
+
+// ReadResources reads the resource tree, an image without a resource
+// directory gives an empty tree.
+func (f *File) ReadResources() (*ResourceDir, error) {
+	dd := f.DataDirectory(pe.IMAGE_DIRECTORY_ENTRY_RESOURCE)
+	if dd == nil || dd.VirtualAddress == 0 {
+		return &ResourceDir{}, nil
+	}
+	_, b, _ := f.LookupVirtualAddress(uint64(dd.VirtualAddress))
+	if b == nil {
+		return nil, fmt.Errorf("resource directory at %#x is not in any section", dd.VirtualAddress)
+	}
+	return f.readResourceDir(b, 0, 0)
+}
+
+func (f *File) readResourceDir(b []byte, off uint32, depth int) (*ResourceDir, error) {
+	if depth >= maxResourceDepth {
+		return nil, fmt.Errorf("resource directory nested too deeply")
+	}
+	if uint64(off)+16 > uint64(len(b)) {
+		return nil, fmt.Errorf("resource directory at %#x out of bounds", off)
+	}
+
+	p := b[off:]
+	d := &ResourceDir{
+		Characteristics: binary.LittleEndian.Uint32(p[0:]),
+		TimeDateStamp:   binary.LittleEndian.Uint32(p[4:]),
+		MajorVersion:    binary.LittleEndian.Uint16(p[8:]),
+		MinorVersion:    binary.LittleEndian.Uint16(p[10:]),
+	}
+	n := int(binary.LittleEndian.Uint16(p[12:])) + int(binary.LittleEndian.Uint16(p[14:]))
+	if uint64(off)+16+uint64(n)*8 > uint64(len(b)) {
+		return nil, fmt.Errorf("resource directory at %#x has too many entries", off)
+	}
+
+	for i := 0; i < n; i++ {
+		q := p[16+i*8:]
+		name := binary.LittleEndian.Uint32(q)
+		data := binary.LittleEndian.Uint32(q[4:])
+
+		e := &ResourceEntry{}
+		if name&0x80000000 != 0 {
+			s, err := readResourceString(b, name&0x7fffffff)
+			if err != nil {
+				return nil, err
+			}
+			e.ID.Name = s
+		} else {
+			e.ID.ID = name
+		}
+
+		if data&0x80000000 != 0 {
+			var err error
+			e.Dir, err = f.readResourceDir(b, data&0x7fffffff, depth+1)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			if uint64(data)+16 > uint64(len(b)) {
+				return nil, fmt.Errorf("resource data entry at %#x out of bounds", data)
+			}
+			rva := binary.LittleEndian.Uint32(b[data:])
+			size := binary.LittleEndian.Uint32(b[data+4:])
+			e.CodePage = binary.LittleEndian.Uint32(b[data+8:])
+			_, r, _ := f.LookupVirtualAddress(uint64(rva))
+			if uint64(len(r)) < uint64(size) {
+				return nil, fmt.Errorf("resource data at %#x size %d out of bounds", rva, size)
+			}
+			e.Data = append([]byte{}, r[:size]...)
+		}
+		d.Entries = append(d.Entries, e)
+	}
+	return d, nil
+}
+
+func readResourceString(b []byte, off uint32) (string, error) {
+	if uint64(off)+2 > uint64(len(b)) {
+		return "", fmt.Errorf("resource name at %#x out of bounds", off)
+	}
+	n := uint64(binary.LittleEndian.Uint16(b[off:]))
+	if uint64(off)+2+n*2 > uint64(len(b)) {
+		return "", fmt.Errorf("resource name at %#x out of bounds", off)
+	}
+	return decodeUTF16(b[off+2 : uint64(off)+2+n*2]), nil
+}
+
+func decodeUTF16(b []byte) string {
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[i*2:])
+	}
+	return string(utf16.Decode(u))
+}
+
+func encodeUTF16(s string) []byte {
+	u := utf16.Encode([]rune(s))
+	b := make([]byte, len(u)*2)
+	for i := range u {
+		binary.LittleEndian.PutUint16(b[i*2:], u[i])
+	}
+	return b
+}
+
+func (d *ResourceDir) Entry(id ResourceID) *ResourceEntry {
+	for _, e := range d.Entries {
+		if e.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+// Resources flattens the type, name and language levels of the tree.
+func (d *ResourceDir) Resources() []Resource {
+	var l []Resource
+	for _, t := range d.Entries {
+		if t.Dir == nil {
+			continue
+		}
+		for _, n := range t.Dir.Entries {
+			if n.Dir == nil {
+				continue
+			}
+			for _, g := range n.Dir.Entries {
+				if g.Dir == nil {
+					l = append(l, Resource{t.ID, n.ID, g.ID.ID, g.CodePage, g.Data})
+				}
+			}
+		}
+	}
+	return l
+}
+
+// Resource returns the resource of a type and name in a language.
+func (d *ResourceDir) Resource(typ, name ResourceID, lang uint32) (*Resource, bool) {
+	for _, r := range d.Resources() {
+		if r.Type == typ && r.Name == name && r.Lang == lang {
+			return &r, true
+		}
+	}
+	return nil, false
+}
+
+// Lookup returns the first resource of a type and name in any language.
+func (d *ResourceDir) Lookup(typ, name ResourceID) (*Resource, bool) {
+	for _, r := range d.Resources() {
+		if r.Type == typ && r.Name == name {
+			return &r, true
+		}
+	}
+	return nil, false
+}
+
+func (d *ResourceDir) ResourcesOfType(typ ResourceID) []Resource {
+	var l []Resource
+	for _, r := range d.Resources() {
+		if r.Type == typ {
+			l = append(l, r)
+		}
+	}
+	return l
+}
+
+// SetResource adds a resource or replaces the one with the same type, name
+// and language.
+func (d *ResourceDir) SetResource(r Resource) {
+	t := d.subdir(r.Type)
+	n := t.subdir(r.Name)
+	g := n.Entry(ResourceID{ID: r.Lang})
+	if g == nil {
+		g = &ResourceEntry{ID: ResourceID{ID: r.Lang}}
+		n.Entries = append(n.Entries, g)
+	}
+	g.Dir = nil
+	g.Data = r.Data
+	g.CodePage = r.CodePage
+}
+
+func (d *ResourceDir) subdir(id ResourceID) *ResourceDir {
+	e := d.Entry(id)
+	if e == nil {
+		e = &ResourceEntry{ID: id}
+		d.Entries = append(d.Entries, e)
+	}
+	if e.Dir == nil {
+		e.Dir = &ResourceDir{}
+		e.Data = nil
+	}
+	return e.Dir
+}
+
+// DeleteResource removes a resource, directories left empty are removed.
+func (d *ResourceDir) DeleteResource(typ, name ResourceID, lang uint32) bool {
+	t := d.Entry(typ)
+	if t == nil || t.Dir == nil {
+		return false
+	}
+	n := t.Dir.Entry(name)
+	if n == nil || n.Dir == nil {
+		return false
+	}
+	if !n.Dir.remove(ResourceID{ID: lang}) {
+		return false
+	}
+	if len(n.Dir.Entries) == 0 {
+		t.Dir.remove(name)
+	}
+	if len(t.Dir.Entries) == 0 {
+		d.remove(typ)
+	}
+	return true
+}
+
+func (d *ResourceDir) remove(id ResourceID) bool {
+	for i, e := range d.Entries {
+		if e.ID == id {
+			d.Entries = append(d.Entries[:i], d.Entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Encode serializes the tree for a resource section loaded at rva.
+// Directories come first followed by the data entries, the names and
+// finally the data itself.
+func (d *ResourceDir) Encode(rva uint32) []byte {
+	var (
+		dirs    []*ResourceDir
+		leaves  []*ResourceEntry
+		names   []string
+		nameoff = make(map[string]uint32)
+	)
+	for q := []*ResourceDir{d}; len(q) > 0; q = q[1:] {
+		p := q[0]
+		sort.SliceStable(p.Entries, func(i, j int) bool {
+			return p.Entries[i].ID.less(p.Entries[j].ID)
+		})
+		dirs = append(dirs, p)
+		for _, e := range p.Entries {
+			if e.ID.Name != "" {
+				if _, ok := nameoff[e.ID.Name]; !ok {
+					nameoff[e.ID.Name] = 0
+					names = append(names, e.ID.Name)
+				}
+			}
+			if e.Dir != nil {
+				q = append(q, e.Dir)
+			} else {
+				leaves = append(leaves, e)
+			}
+		}
+	}
+
+	off := uint32(0)
+	diroff := make(map[*ResourceDir]uint32)
+	for _, p := range dirs {
+		diroff[p] = off
+		off += 16 + 8*uint32(len(p.Entries))
+	}
+	leafoff := make(map[*ResourceEntry]uint32)
+	for _, e := range leaves {
+		leafoff[e] = off
+		off += 16
+	}
+	for _, s := range names {
+		nameoff[s] = off
+		off += 2 + uint32(len(encodeUTF16(s)))
+		off = mathutil.Multiple32(off, 2)
+	}
+	dataoff := make(map[*ResourceEntry]uint32)
+	for _, e := range leaves {
+		off = mathutil.Multiple32(off, 8)
+		dataoff[e] = off
+		off += uint32(len(e.Data))
+	}
+
+	b := make([]byte, mathutil.Multiple32(off, 8))
+	le := binary.LittleEndian
+	for _, p := range dirs {
+		o := diroff[p]
+		le.PutUint32(b[o:], p.Characteristics)
+		le.PutUint32(b[o+4:], p.TimeDateStamp)
+		le.PutUint16(b[o+8:], p.MajorVersion)
+		le.PutUint16(b[o+10:], p.MinorVersion)
+		var named, ids uint16
+		for _, e := range p.Entries {
+			if e.ID.Name != "" {
+				named++
+			} else {
+				ids++
+			}
+		}
+		le.PutUint16(b[o+12:], named)
+		le.PutUint16(b[o+14:], ids)
+		for i, e := range p.Entries {
+			q := b[o+16+uint32(i)*8:]
+			if e.ID.Name != "" {
+				le.PutUint32(q, nameoff[e.ID.Name]|0x80000000)
+			} else {
+				le.PutUint32(q, e.ID.ID&0x7fffffff)
+			}
+			if e.Dir != nil {
+				le.PutUint32(q[4:], diroff[e.Dir]|0x80000000)
+			} else {
+				le.PutUint32(q[4:], leafoff[e])
+			}
+		}
+	}
+	for _, e := range leaves {
+		o := leafoff[e]
+		le.PutUint32(b[o:], rva+dataoff[e])
+		le.PutUint32(b[o+4:], uint32(len(e.Data)))
+		le.PutUint32(b[o+8:], e.CodePage)
+		copy(b[dataoff[e]:], e.Data)
+	}
+	for _, s := range names {
+		o := nameoff[s]
+		u := encodeUTF16(s)
+		le.PutUint16(b[o:], uint16(len(u)/2))
+		copy(b[o+2:], u)
+	}
+	return b
+}
+
+// WriteResources rebuilds the .rsrc section from the tree. The section is
+// rewritten in place if the data fits, otherwise it grows when it is the
+// last section or only followed by base relocations which are moved up.
+// Without a resource section a new one is created.
+func (f *File) WriteResources(d *ResourceDir) error {
+	size := uint64(len(d.Encode(0)))
+	s := f.Section(".rsrc")
+	if s == nil {
+		va := uint64(0)
+		for _, p := range f.Sections {
+			va = mathutil.Max64(va, uint64(p.VirtualAddress)+mathutil.Multiple64(uint64(p.VirtualSize), f.SectionAlignment))
+		}
+		var err error
+		s, err = f.CreateSection(".rsrc", va, mathutil.Multiple64(size, f.FileAlignment), IMAGE_SCN_CNT_INITIALIZED_DATA|IMAGE_SCN_MEM_READ)
+		if err != nil {
+			return err
+		}
+	}
+
+	room := mathutil.Multiple64(uint64(s.VirtualSize), f.SectionAlignment)
+	if size > room {
+		var after []*Section
+		for _, p := range f.Sections {
+			if p.VirtualAddress > s.VirtualAddress {
+				if p.Name != ".reloc" {
+					return fmt.Errorf("resource section of size %d does not fit in %d bytes and is followed by section %s", size, room, p.Name)
+				}
+				after = append(after, p)
+			}
+		}
+		delta := uint32(mathutil.Multiple64(size, f.SectionAlignment) - room)
+		for _, p := range after {
+			p.VirtualAddress += delta
+		}
+		if dd := f.DataDirectory(pe.IMAGE_DIRECTORY_ENTRY_BASERELOC); dd != nil && dd.VirtualAddress > s.VirtualAddress {
+			dd.VirtualAddress += delta
+		}
+	}
+
+	data := d.Encode(s.VirtualAddress)
+	raw := uint32(mathutil.Multiple64(uint64(len(data)), f.FileAlignment))
+	for _, p := range f.Sections {
+		if p.Offset > s.Offset {
+			p.Offset = p.Offset - s.Size + raw
+		}
+	}
+	s.Data = append(data, make([]byte, raw-uint32(len(data)))...)
+	s.Size = raw
+	s.VirtualSize = uint32(len(data))
+
+	dd := f.CreateDataDirectory(pe.IMAGE_DIRECTORY_ENTRY_RESOURCE)
+	dd.VirtualAddress = s.VirtualAddress
+	dd.Size = uint32(len(data))
+	f.updateImageHeaderSize()
+	return nil
+}
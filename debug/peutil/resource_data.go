@@ -0,0 +1,501 @@
+package peutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/qeedquan/go-media/math/mathutil"
+)
+
+const VS_FFI_SIGNATURE = 0xfeef04bd
+
+type FixedFileInfo struct {
+	Signature        uint32
+	StrucVersion     uint32
+	FileVersionMS    uint32
+	FileVersionLS    uint32
+	ProductVersionMS uint32
+	ProductVersionLS uint32
+	FileFlagsMask    uint32
+	FileFlags        uint32
+	FileOS           uint32
+	FileType         uint32
+	FileSubtype      uint32
+	FileDateMS       uint32
+	FileDateLS       uint32
+}
+
+func (v *FixedFileInfo) FileVersion() string {
+	return fmt.Sprintf("%d.%d.%d.%d", v.FileVersionMS>>16, v.FileVersionMS&0xffff, v.FileVersionLS>>16, v.FileVersionLS&0xffff)
+}
+
+func (v *FixedFileInfo) ProductVersion() string {
+	return fmt.Sprintf("%d.%d.%d.%d", v.ProductVersionMS>>16, v.ProductVersionMS&0xffff, v.ProductVersionLS>>16, v.ProductVersionLS&0xffff)
+}
+
+// VersionInfo is a decoded VS_VERSIONINFO resource.
+type VersionInfo struct {
+	Fixed        *FixedFileInfo
+	StringTables []VersionStringTable
+
+	// Translations holds the language in the low and the code page in
+	// the high word.
+	Translations []uint32
+}
+
+type VersionStringTable struct {
+	// Key is the language and code page as 8 hex digits such as 040904b0
+	Key     string
+	Strings []VersionString
+}
+
+type VersionString struct {
+	Key   string
+	Value string
+}
+
+// verNode is a generic version resource block, text values are strings
+// and binary values are raw bytes.
+type verNode struct {
+	key      string
+	text     bool
+	value    []byte
+	children []*verNode
+}
+
+func parseVerNode(b []byte) (*verNode, int, error) {
+	if len(b) < 6 {
+		return nil, 0, fmt.Errorf("version block too short")
+	}
+	n := int(binary.LittleEndian.Uint16(b))
+	vlen := int(binary.LittleEndian.Uint16(b[2:]))
+	typ := binary.LittleEndian.Uint16(b[4:])
+	if n < 6 || n > len(b) {
+		return nil, 0, fmt.Errorf("invalid version block length %d", n)
+	}
+	b = b[:n]
+
+	v := &verNode{text: typ == 1}
+	p := 6
+	for ; p+1 < n; p += 2 {
+		if b[p] == 0 && b[p+1] == 0 {
+			break
+		}
+	}
+	v.key = decodeUTF16(b[6:p])
+	p = mathutil.Multiple(p+2, 4)
+
+	if v.text {
+		vlen *= 2
+	}
+	if p+vlen > n {
+		// some linkers count text values in bytes
+		vlen = mathutil.Max(0, n-p)
+	}
+	if p < n {
+		v.value = b[p : p+vlen]
+	}
+	p = mathutil.Multiple(p+vlen, 4)
+
+	for p < n {
+		c, m, err := parseVerNode(b[p:])
+		if err != nil {
+			return nil, 0, err
+		}
+		v.children = append(v.children, c)
+		p = mathutil.Multiple(p+m, 4)
+	}
+	return v, n, nil
+}
+
+func (v *verNode) bytes() []byte {
+	w := new(bytes.Buffer)
+	w.Write(make([]byte, 6))
+	w.Write(encodeUTF16(v.key))
+	w.Write([]byte{0, 0})
+	for w.Len()%4 != 0 {
+		w.WriteByte(0)
+	}
+	w.Write(v.value)
+	vlen := len(v.value)
+	if v.text {
+		vlen /= 2
+	}
+	for _, c := range v.children {
+		for w.Len()%4 != 0 {
+			w.WriteByte(0)
+		}
+		w.Write(c.bytes())
+	}
+
+	b := w.Bytes()
+	binary.LittleEndian.PutUint16(b, uint16(len(b)))
+	binary.LittleEndian.PutUint16(b[2:], uint16(vlen))
+	if v.text {
+		binary.LittleEndian.PutUint16(b[4:], 1)
+	}
+	return b
+}
+
+func textValue(b []byte) string {
+	s := decodeUTF16(b)
+	if i := strings.IndexByte(s, 0); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
+func ParseVersionInfo(b []byte) (*VersionInfo, error) {
+	root, _, err := parseVerNode(b)
+	if err != nil {
+		return nil, err
+	}
+	if root.key != "VS_VERSION_INFO" {
+		return nil, fmt.Errorf("unexpected version info key %q", root.key)
+	}
+
+	v := &VersionInfo{}
+	if len(root.value) >= 52 {
+		v.Fixed = &FixedFileInfo{}
+		binary.Read(bytes.NewReader(root.value), binary.LittleEndian, v.Fixed)
+		if v.Fixed.Signature != VS_FFI_SIGNATURE {
+			return nil, fmt.Errorf("invalid fixed file info signature %#x", v.Fixed.Signature)
+		}
+	}
+
+	for _, c := range root.children {
+		switch c.key {
+		case "StringFileInfo":
+			for _, t := range c.children {
+				st := VersionStringTable{Key: t.key}
+				for _, s := range t.children {
+					st.Strings = append(st.Strings, VersionString{s.key, textValue(s.value)})
+				}
+				v.StringTables = append(v.StringTables, st)
+			}
+		case "VarFileInfo":
+			for _, t := range c.children {
+				if t.key != "Translation" {
+					continue
+				}
+				for p := t.value; len(p) >= 4; p = p[4:] {
+					v.Translations = append(v.Translations, binary.LittleEndian.Uint32(p))
+				}
+			}
+		}
+	}
+	return v, nil
+}
+
+func (v *VersionInfo) Bytes() []byte {
+	root := &verNode{key: "VS_VERSION_INFO"}
+	if v.Fixed != nil {
+		w := new(bytes.Buffer)
+		binary.Write(w, binary.LittleEndian, v.Fixed)
+		root.value = w.Bytes()
+	}
+
+	if len(v.StringTables) > 0 {
+		sfi := &verNode{key: "StringFileInfo", text: true}
+		for _, t := range v.StringTables {
+			tn := &verNode{key: t.Key, text: true}
+			for _, s := range t.Strings {
+				val := append(encodeUTF16(s.Value), 0, 0)
+				tn.children = append(tn.children, &verNode{key: s.Key, text: true, value: val})
+			}
+			sfi.children = append(sfi.children, tn)
+		}
+		root.children = append(root.children, sfi)
+	}
+
+	if len(v.Translations) > 0 {
+		val := make([]byte, 4*len(v.Translations))
+		for i, t := range v.Translations {
+			binary.LittleEndian.PutUint32(val[i*4:], t)
+		}
+		vfi := &verNode{key: "VarFileInfo", text: true}
+		vfi.children = append(vfi.children, &verNode{key: "Translation", value: val})
+		root.children = append(root.children, vfi)
+	}
+	return root.bytes()
+}
+
+// Get returns a string from the first string table defining it.
+func (v *VersionInfo) Get(key string) (string, bool) {
+	for _, t := range v.StringTables {
+		for _, s := range t.Strings {
+			if s.Key == key {
+				return s.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Set changes a string in every string table, if there are none a table
+// for US English Unicode is created.
+func (v *VersionInfo) Set(key, value string) {
+	if len(v.StringTables) == 0 {
+		v.StringTables = append(v.StringTables, VersionStringTable{Key: "040904b0"})
+		if len(v.Translations) == 0 {
+			v.Translations = append(v.Translations, 0x04b00409)
+		}
+	}
+	for i := range v.StringTables {
+		t := &v.StringTables[i]
+		found := false
+		for j := range t.Strings {
+			if t.Strings[j].Key == key {
+				t.Strings[j].Value = value
+				found = true
+			}
+		}
+		if !found {
+			t.Strings = append(t.Strings, VersionString{key, value})
+		}
+	}
+}
+
+// VersionInfo decodes the first RT_VERSION resource, executables normally
+// have only one.
+func (d *ResourceDir) VersionInfo() (*VersionInfo, error) {
+	l := d.ResourcesOfType(ResourceID{ID: RT_VERSION})
+	if len(l) == 0 {
+		return nil, fmt.Errorf("no version info resource")
+	}
+	return ParseVersionInfo(l[0].Data)
+}
+
+// SetVersionInfo replaces every RT_VERSION resource or adds one with id 1
+// for language neutral if there is none.
+func (d *ResourceDir) SetVersionInfo(v *VersionInfo) {
+	typ := ResourceID{ID: RT_VERSION}
+	b := v.Bytes()
+	l := d.ResourcesOfType(typ)
+	for _, r := range l {
+		r.Data = b
+		d.SetResource(r)
+	}
+	if len(l) == 0 {
+		d.SetResource(Resource{Type: typ, Name: ResourceID{ID: 1}, Data: b})
+	}
+}
+
+// the group directory entries mirror the .ico ones with the file offset
+// replaced by the RT_ICON id
+const (
+	icoHeaderLen  = 6
+	icoEntryLen   = 16
+	groupEntryLen = 14
+	icoTypeIcon   = 1
+)
+
+// IconGroup returns an RT_GROUP_ICON resource and the icons it refers to
+// as the contents of an .ico file that image/ico can decode.
+func (d *ResourceDir) IconGroup(name ResourceID, lang uint32) ([]byte, error) {
+	g, ok := d.Resource(ResourceID{ID: RT_GROUP_ICON}, name, lang)
+	if !ok {
+		return nil, fmt.Errorf("icon group %v language %#x does not exist", name, lang)
+	}
+	b := g.Data
+	if len(b) < icoHeaderLen {
+		return nil, fmt.Errorf("icon group %v too short", name)
+	}
+	n := int(binary.LittleEndian.Uint16(b[4:]))
+	if len(b) < icoHeaderLen+n*groupEntryLen {
+		return nil, fmt.Errorf("icon group %v has truncated entries", name)
+	}
+
+	var imgs [][]byte
+	for i := 0; i < n; i++ {
+		e := b[icoHeaderLen+i*groupEntryLen:]
+		id := uint32(binary.LittleEndian.Uint16(e[12:]))
+		r, ok := d.Resource(ResourceID{ID: RT_ICON}, ResourceID{ID: id}, lang)
+		if !ok {
+			r, ok = d.Lookup(ResourceID{ID: RT_ICON}, ResourceID{ID: id})
+		}
+		if !ok {
+			return nil, fmt.Errorf("icon group %v refers to missing icon %d", name, id)
+		}
+		imgs = append(imgs, r.Data)
+	}
+
+	w := new(bytes.Buffer)
+	w.Write(b[:icoHeaderLen])
+	off := icoHeaderLen + n*icoEntryLen
+	for i, m := range imgs {
+		e := b[icoHeaderLen+i*groupEntryLen:]
+		w.Write(e[:8])
+		binary.Write(w, binary.LittleEndian, uint32(len(m)))
+		binary.Write(w, binary.LittleEndian, uint32(off))
+		off += len(m)
+	}
+	for _, m := range imgs {
+		w.Write(m)
+	}
+	return w.Bytes(), nil
+}
+
+// SetIconGroup replaces an icon group with the images of an .ico file,
+// icons only used by the old group are deleted.
+func (d *ResourceDir) SetIconGroup(name ResourceID, lang uint32, ico []byte) error {
+	if len(ico) < icoHeaderLen || binary.LittleEndian.Uint16(ico[2:]) != icoTypeIcon {
+		return fmt.Errorf("invalid icon file")
+	}
+	n := int(binary.LittleEndian.Uint16(ico[4:]))
+	if len(ico) < icoHeaderLen+n*icoEntryLen {
+		return fmt.Errorf("icon file has truncated entries")
+	}
+
+	icon := ResourceID{ID: RT_ICON}
+	group := ResourceID{ID: RT_GROUP_ICON}
+	if old, ok := d.Resource(group, name, lang); ok {
+		d.DeleteResource(group, name, lang)
+		used := make(map[uint32]bool)
+		for _, r := range d.ResourcesOfType(group) {
+			for _, id := range groupIconIDs(r.Data) {
+				used[id] = true
+			}
+		}
+		for _, id := range groupIconIDs(old.Data) {
+			if !used[id] {
+				d.DeleteResource(icon, ResourceID{ID: id}, lang)
+			}
+		}
+	}
+
+	next := uint32(1)
+	for _, r := range d.ResourcesOfType(icon) {
+		if r.Name.Name == "" && r.Name.ID >= next {
+			next = r.Name.ID + 1
+		}
+	}
+
+	w := new(bytes.Buffer)
+	w.Write(ico[:icoHeaderLen])
+	for i := 0; i < n; i++ {
+		e := ico[icoHeaderLen+i*icoEntryLen:]
+		size := binary.LittleEndian.Uint32(e[8:])
+		off := binary.LittleEndian.Uint32(e[12:])
+		if uint64(off)+uint64(size) > uint64(len(ico)) {
+			return fmt.Errorf("icon image %d out of bounds", i)
+		}
+		d.SetResource(Resource{
+			Type: icon,
+			Name: ResourceID{ID: next},
+			Lang: lang,
+			Data: append([]byte{}, ico[off:off+size]...),
+		})
+		w.Write(e[:12])
+		binary.Write(w, binary.LittleEndian, uint16(next))
+		next++
+	}
+	d.SetResource(Resource{Type: group, Name: name, Lang: lang, Data: w.Bytes()})
+	return nil
+}
+
+func groupIconIDs(b []byte) []uint32 {
+	if len(b) < icoHeaderLen {
+		return nil
+	}
+	var ids []uint32
+	n := int(binary.LittleEndian.Uint16(b[4:]))
+	for i := 0; i < n && icoHeaderLen+(i+1)*groupEntryLen <= len(b); i++ {
+		ids = append(ids, uint32(binary.LittleEndian.Uint16(b[icoHeaderLen+i*groupEntryLen+12:])))
+	}
+	return ids
+}
+
+// Manifest returns the first application manifest.
+func (d *ResourceDir) Manifest() (string, bool) {
+	l := d.ResourcesOfType(ResourceID{ID: RT_MANIFEST})
+	if len(l) == 0 {
+		return "", false
+	}
+	return string(l[0].Data), true
+}
+
+// SetManifest replaces the first application manifest or adds one with
+// the id used for executables.
+func (d *ResourceDir) SetManifest(s string) {
+	l := d.ResourcesOfType(ResourceID{ID: RT_MANIFEST})
+	r := Resource{Type: ResourceID{ID: RT_MANIFEST}, Name: ResourceID{ID: 1}, Lang: 0x409}
+	if len(l) > 0 {
+		r = l[0]
+	}
+	r.Data = []byte(s)
+	d.SetResource(r)
+}
+
+// StringTable decodes the RT_STRING blocks of a language, each block
+// holds 16 strings with ids starting at (block-1)*16.
+func (d *ResourceDir) StringTable(lang uint32) (map[uint32]string, error) {
+	m := make(map[uint32]string)
+	for _, r := range d.ResourcesOfType(ResourceID{ID: RT_STRING}) {
+		if r.Lang != lang || r.Name.Name != "" || r.Name.ID == 0 {
+			continue
+		}
+		l, err := parseStringBlock(r.Data)
+		if err != nil {
+			return nil, fmt.Errorf("string block %d: %v", r.Name.ID, err)
+		}
+		for i, s := range l {
+			if s != "" {
+				m[(r.Name.ID-1)*16+uint32(i)] = s
+			}
+		}
+	}
+	return m, nil
+}
+
+func parseStringBlock(b []byte) ([16]string, error) {
+	var l [16]string
+	for i := range l {
+		if len(b) < 2 {
+			return l, fmt.Errorf("truncated string %d", i)
+		}
+		n := int(binary.LittleEndian.Uint16(b)) * 2
+		b = b[2:]
+		if len(b) < n {
+			return l, fmt.Errorf("truncated string %d", i)
+		}
+		l[i] = decodeUTF16(b[:n])
+		b = b[n:]
+	}
+	return l, nil
+}
+
+// SetString changes a string table entry, an empty string removes it.
+func (d *ResourceDir) SetString(id uint32, lang uint32, s string) error {
+	name := ResourceID{ID: id/16 + 1}
+	typ := ResourceID{ID: RT_STRING}
+
+	var l [16]string
+	if r, ok := d.Resource(typ, name, lang); ok {
+		var err error
+		l, err = parseStringBlock(r.Data)
+		if err != nil {
+			return fmt.Errorf("string block %d: %v", name.ID, err)
+		}
+	}
+	l[id%16] = s
+
+	w := new(bytes.Buffer)
+	empty := true
+	for _, s := range l {
+		u := encodeUTF16(s)
+		binary.Write(w, binary.LittleEndian, uint16(len(u)/2))
+		w.Write(u)
+		if s != "" {
+			empty = false
+		}
+	}
+	if empty {
+		d.DeleteResource(typ, name, lang)
+		return nil
+	}
+	d.SetResource(Resource{Type: typ, Name: name, Lang: lang, Data: w.Bytes()})
+	return nil
+}
@@ -0,0 +1,76 @@
+package peutil
+
+import (
+	"debug/pe"
+	"fmt"
+)
+
+// TLSDirectory is IMAGE_TLS_DIRECTORY, the addresses are virtual addresses
+// and not RVAs. Callbacks lists the zero terminated callback array and
+// Template the initialized part of the TLS data.
+type TLSDirectory struct {
+	StartAddressOfRawData uint64
+	EndAddressOfRawData   uint64
+	AddressOfIndex        uint64
+	AddressOfCallBacks    uint64
+	SizeOfZeroFill        uint32
+	Characteristics       uint32
+	Callbacks             []uint64
+	Template              []byte
+}
+
+// ReadTLSDirectory decodes the TLS directory, it returns nil if the image
+// has none.
+func (f *File) ReadTLSDirectory() (*TLSDirectory, error) {
+	dd := f.DataDirectory(pe.IMAGE_DIRECTORY_ENTRY_TLS)
+	if dd == nil || dd.VirtualAddress == 0 {
+		return nil, nil
+	}
+
+	var w [4]Word
+	va := uint64(dd.VirtualAddress)
+	for i := range w {
+		err := f.ReadVirtualAddress(va, &w[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls directory: %v", err)
+		}
+		va += f.WordSize
+	}
+	t := &TLSDirectory{
+		StartAddressOfRawData: uint64(w[0]),
+		EndAddressOfRawData:   uint64(w[1]),
+		AddressOfIndex:        uint64(w[2]),
+		AddressOfCallBacks:    uint64(w[3]),
+	}
+	err := f.ReadVirtualAddress(va, &t.SizeOfZeroFill)
+	if err == nil {
+		err = f.ReadVirtualAddress(va+4, &t.Characteristics)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tls directory: %v", err)
+	}
+
+	if t.AddressOfCallBacks != 0 {
+		for va := t.AddressOfCallBacks - f.ImageBase; ; va += f.WordSize {
+			var cb Word
+			err := f.ReadVirtualAddress(va, &cb)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read tls callbacks: %v", err)
+			}
+			if cb == 0 {
+				break
+			}
+			t.Callbacks = append(t.Callbacks, uint64(cb))
+		}
+	}
+
+	if t.EndAddressOfRawData > t.StartAddressOfRawData {
+		n := t.EndAddressOfRawData - t.StartAddressOfRawData
+		b, err := f.readRVA(t.StartAddressOfRawData-f.ImageBase, int(n))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls template: %v", err)
+		}
+		t.Template = b
+	}
+	return t, nil
+}
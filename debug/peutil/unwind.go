@@ -0,0 +1,248 @@
+package peutil
+
+import (
+	"debug/pe"
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+const (
+	UNW_FLAG_NHANDLER  = 0x0
+	UNW_FLAG_EHANDLER  = 0x1
+	UNW_FLAG_UHANDLER  = 0x2
+	UNW_FLAG_CHAININFO = 0x4
+)
+
+const (
+	UWOP_PUSH_NONVOL     = 0
+	UWOP_ALLOC_LARGE     = 1
+	UWOP_ALLOC_SMALL     = 2
+	UWOP_SET_FPREG       = 3
+	UWOP_SAVE_NONVOL     = 4
+	UWOP_SAVE_NONVOL_FAR = 5
+	UWOP_EPILOG          = 6
+	UWOP_SPARE_CODE      = 7
+	UWOP_SAVE_XMM128     = 8
+	UWOP_SAVE_XMM128_FAR = 9
+	UWOP_PUSH_MACHFRAME  = 10
+)
+
+// RuntimeFunction is an x64 .pdata entry, the addresses are RVAs.
+type RuntimeFunction struct {
+	BeginAddress      uint32
+	EndAddress        uint32
+	UnwindInfoAddress uint32
+}
+
+// UnwindInfo is the decoded UNWIND_INFO of a runtime function, codes are
+// in the order they are stored which is the reverse of the prolog.
+type UnwindInfo struct {
+	Version       uint8
+	Flags         uint8
+	SizeOfProlog  uint8
+	CountOfCodes  uint8
+	FrameRegister uint8
+	FrameOffset   uint8
+	Codes         []UnwindCode
+
+	// language specific handler for UNW_FLAG_EHANDLER and UNW_FLAG_UHANDLER,
+	// the handler data following it is opaque
+	Handler uint32
+
+	// parent entry for UNW_FLAG_CHAININFO
+	Chained *RuntimeFunction
+}
+
+// UnwindCode is an operation of the prolog, Value holds the allocation size
+// or stack offset decoded from the extra slots of the operation.
+type UnwindCode struct {
+	Offset uint8
+	Op     uint8
+	Info   uint8
+	Value  uint32
+}
+
+var x64Regs = [...]string{
+	"rax", "rcx", "rdx", "rbx", "rsp", "rbp", "rsi", "rdi",
+	"r8", "r9", "r10", "r11", "r12", "r13", "r14", "r15",
+}
+
+func (c UnwindCode) String() string {
+	reg := x64Regs[c.Info&15]
+	switch c.Op {
+	case UWOP_PUSH_NONVOL:
+		return fmt.Sprintf("%#x: PUSH_NONVOL %s", c.Offset, reg)
+	case UWOP_ALLOC_LARGE:
+		return fmt.Sprintf("%#x: ALLOC_LARGE %#x", c.Offset, c.Value)
+	case UWOP_ALLOC_SMALL:
+		return fmt.Sprintf("%#x: ALLOC_SMALL %#x", c.Offset, c.Value)
+	case UWOP_SET_FPREG:
+		return fmt.Sprintf("%#x: SET_FPREG", c.Offset)
+	case UWOP_SAVE_NONVOL:
+		return fmt.Sprintf("%#x: SAVE_NONVOL %s, %#x", c.Offset, reg, c.Value)
+	case UWOP_SAVE_NONVOL_FAR:
+		return fmt.Sprintf("%#x: SAVE_NONVOL_FAR %s, %#x", c.Offset, reg, c.Value)
+	case UWOP_EPILOG:
+		return fmt.Sprintf("%#x: EPILOG %#x", c.Offset, c.Info)
+	case UWOP_SAVE_XMM128:
+		return fmt.Sprintf("%#x: SAVE_XMM128 xmm%d, %#x", c.Offset, c.Info, c.Value)
+	case UWOP_SAVE_XMM128_FAR:
+		return fmt.Sprintf("%#x: SAVE_XMM128_FAR xmm%d, %#x", c.Offset, c.Info, c.Value)
+	case UWOP_PUSH_MACHFRAME:
+		return fmt.Sprintf("%#x: PUSH_MACHFRAME %d", c.Offset, c.Info)
+	}
+	return fmt.Sprintf("%#x: UWOP_%d %d", c.Offset, c.Op, c.Info)
+}
+
+// RuntimeFunctions decodes the exception directory of an x64 image, the
+// entries are sorted by address.
+func (f *File) RuntimeFunctions() ([]RuntimeFunction, error) {
+	if f.Machine != pe.IMAGE_FILE_MACHINE_AMD64 {
+		return nil, fmt.Errorf("unsupported machine type %v for runtime functions", MachineType(f.Machine))
+	}
+	dd := f.DataDirectory(pe.IMAGE_DIRECTORY_ENTRY_EXCEPTION)
+	if dd == nil || dd.Size == 0 {
+		return nil, nil
+	}
+	b, err := f.readRVA(uint64(dd.VirtualAddress), int(dd.Size))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exception directory: %v", err)
+	}
+
+	var l []RuntimeFunction
+	for ; len(b) >= 12; b = b[12:] {
+		l = append(l, RuntimeFunction{
+			BeginAddress:      binary.LittleEndian.Uint32(b),
+			EndAddress:        binary.LittleEndian.Uint32(b[4:]),
+			UnwindInfoAddress: binary.LittleEndian.Uint32(b[8:]),
+		})
+	}
+	sort.Slice(l, func(i, j int) bool {
+		return l[i].BeginAddress < l[j].BeginAddress
+	})
+	return l, nil
+}
+
+// LookupRuntimeFunction finds the entry covering an RVA in a list returned
+// by RuntimeFunctions.
+func LookupRuntimeFunction(l []RuntimeFunction, rva uint32) (RuntimeFunction, bool) {
+	i := sort.Search(len(l), func(i int) bool {
+		return l[i].EndAddress > rva
+	})
+	if i < len(l) && l[i].BeginAddress <= rva {
+		return l[i], true
+	}
+	return RuntimeFunction{}, false
+}
+
+// UnwindInfo decodes the unwind information of a runtime function, an
+// unwind address with the low bit set refers to another runtime function
+// and is followed.
+func (f *File) UnwindInfo(r RuntimeFunction) (*UnwindInfo, error) {
+	addr := uint64(r.UnwindInfoAddress)
+	if addr&1 != 0 {
+		b, err := f.readRVA(addr&^1, 12)
+		if err != nil {
+			return nil, err
+		}
+		addr = uint64(binary.LittleEndian.Uint32(b[8:]))
+	}
+
+	b, err := f.readRVA(addr, 4)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read unwind info of function %#x: %v", r.BeginAddress, err)
+	}
+	u := &UnwindInfo{
+		Version:       b[0] & 7,
+		Flags:         b[0] >> 3,
+		SizeOfProlog:  b[1],
+		CountOfCodes:  b[2],
+		FrameRegister: b[3] & 15,
+		FrameOffset:   b[3] >> 4,
+	}
+	if u.Version != 1 && u.Version != 2 {
+		return nil, fmt.Errorf("unsupported unwind info version %d for function %#x", u.Version, r.BeginAddress)
+	}
+
+	// the slot array is padded to an even count
+	n := (int(u.CountOfCodes) + 1) &^ 1
+	b, err = f.readRVA(addr+4, n*2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read unwind codes of function %#x: %v", r.BeginAddress, err)
+	}
+	u.Codes, err = decodeUnwindCodes(b[:int(u.CountOfCodes)*2])
+	if err != nil {
+		return nil, fmt.Errorf("function %#x: %v", r.BeginAddress, err)
+	}
+
+	tail := addr + 4 + uint64(n)*2
+	switch {
+	case u.Flags&UNW_FLAG_CHAININFO != 0:
+		b, err := f.readRVA(tail, 12)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chained function of %#x: %v", r.BeginAddress, err)
+		}
+		u.Chained = &RuntimeFunction{
+			BeginAddress:      binary.LittleEndian.Uint32(b),
+			EndAddress:        binary.LittleEndian.Uint32(b[4:]),
+			UnwindInfoAddress: binary.LittleEndian.Uint32(b[8:]),
+		}
+	case u.Flags&(UNW_FLAG_EHANDLER|UNW_FLAG_UHANDLER) != 0:
+		err := f.ReadVirtualAddress(tail, &u.Handler)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read exception handler of %#x: %v", r.BeginAddress, err)
+		}
+	}
+	return u, nil
+}
+
+func decodeUnwindCodes(b []byte) ([]UnwindCode, error) {
+	var l []UnwindCode
+	slot := func(i int) uint32 {
+		return uint32(binary.LittleEndian.Uint16(b[i*2:]))
+	}
+	for len(b) >= 2 {
+		c := UnwindCode{
+			Offset: b[0],
+			Op:     b[1] & 15,
+			Info:   b[1] >> 4,
+		}
+
+		n := 1
+		switch c.Op {
+		case UWOP_ALLOC_LARGE:
+			n = 2
+			if c.Info != 0 {
+				n = 3
+			}
+		case UWOP_SAVE_NONVOL, UWOP_SAVE_XMM128:
+			n = 2
+		case UWOP_SAVE_NONVOL_FAR, UWOP_SAVE_XMM128_FAR:
+			n = 3
+		}
+		if len(b) < n*2 {
+			return nil, fmt.Errorf("truncated unwind code %d", c.Op)
+		}
+
+		switch c.Op {
+		case UWOP_ALLOC_LARGE:
+			if c.Info == 0 {
+				c.Value = slot(1) * 8
+			} else {
+				c.Value = slot(1) | slot(2)<<16
+			}
+		case UWOP_ALLOC_SMALL:
+			c.Value = uint32(c.Info)*8 + 8
+		case UWOP_SAVE_NONVOL:
+			c.Value = slot(1) * 8
+		case UWOP_SAVE_XMM128:
+			c.Value = slot(1) * 16
+		case UWOP_SAVE_NONVOL_FAR, UWOP_SAVE_XMM128_FAR:
+			c.Value = slot(1) | slot(2)<<16
+		}
+		l = append(l, c)
+		b = b[n*2:]
+	}
+	return l, nil
+}
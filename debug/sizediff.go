@@ -0,0 +1,81 @@
+package debug
+
+import (
+	"sort"
+)
+
+// SizeDiff is the change in size of an entry between two builds, a zero
+// old size means it was added and a zero new size that it was removed.
+type SizeDiff struct {
+	Name    string
+	File    string
+	Section string
+	OldSize uint64
+	NewSize uint64
+}
+
+func (d SizeDiff) Delta() int64 {
+	return int64(d.NewSize) - int64(d.OldSize)
+}
+
+type sizeKey struct {
+	name, file string
+}
+
+// SizeDiffer sums the sizes of entries matched by name and file across an
+// old and a new build.
+type SizeDiffer struct {
+	m map[sizeKey]*SizeDiff
+}
+
+func NewSizeDiffer() *SizeDiffer {
+	return &SizeDiffer{m: make(map[sizeKey]*SizeDiff)}
+}
+
+// Add adds size to the old or new size of the entry, the section is taken
+// from the new build when the entry moved.
+func (s *SizeDiffer) Add(name, file, section string, size uint64, old bool) {
+	k := sizeKey{name, file}
+	d := s.m[k]
+	if d == nil {
+		d = &SizeDiff{Name: name, File: file, Section: section}
+		s.m[k] = d
+	}
+	if old {
+		d.OldSize += size
+	} else {
+		d.Section = section
+		d.NewSize += size
+	}
+}
+
+// Changed returns the entries that changed size, sorted by the largest
+// growth first.
+func (s *SizeDiffer) Changed() []SizeDiff {
+	var l []SizeDiff
+	for _, p := range s.m {
+		if p.OldSize != p.NewSize {
+			l = append(l, *p)
+		}
+	}
+	sort.Slice(l, func(i, j int) bool {
+		x, y := l[i].Delta(), l[j].Delta()
+		if x != y {
+			return x > y
+		}
+		if l[i].Name != l[j].Name {
+			return l[i].Name < l[j].Name
+		}
+		return l[i].File < l[j].File
+	})
+	return l
+}
+
+// TotalDelta returns the sum of the size changes.
+func TotalDelta(l []SizeDiff) int64 {
+	var n int64
+	for _, p := range l {
+		n += p.Delta()
+	}
+	return n
+}
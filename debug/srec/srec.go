@@ -6,8 +6,12 @@ import (
 	"fmt"
 	"io"
 	"strings"
+
+	"github.com/qeedquan/go-media/debug/memmap"
 )
 
+const DefaultRecordLength = 32
+
 type File struct {
 	Version int
 	Start   uint64
@@ -37,6 +41,42 @@ func (f *File) Binary() []byte {
 	return b
 }
 
+// Map returns the records as a sparse memory map, overlapping records are
+// an error.
+func (f *File) Map() (*memmap.Map, error) {
+	m := &memmap.Map{}
+	for _, r := range f.Records {
+		if err := m.Add(r.Addr, r.Data); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// FromMap returns a file holding the contents of m, using the smallest
+// address size that fits.
+func FromMap(m *memmap.Map) (*File, error) {
+	f := &File{}
+	switch end := m.End(); {
+	case end <= 0x10000:
+		f.Version = 1
+	case end <= 0x1000000:
+		f.Version = 2
+	case end <= 0x100000000:
+		f.Version = 3
+	default:
+		return nil, fmt.Errorf("srec: data ends at %#x past the 32-bit address space", end)
+	}
+	segs, err := m.Split(DefaultRecordLength)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range segs {
+		f.Records = append(f.Records, Record{s.Addr, s.Data})
+	}
+	return f, nil
+}
+
 func Decode(r io.Reader) (*File, error) {
 	f := &File{Version: 3}
 	s := bufio.NewScanner(r)
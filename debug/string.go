@@ -5,13 +5,19 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
+	"unicode/utf8"
 )
 
 type StringType int
 
 const (
 	UTF8 StringType = iota
+	UTF16LE
+	UTF16BE
+	UTF32LE
+	UTF32BE
 )
 
 type String struct {
@@ -19,17 +25,51 @@ type String struct {
 	Name   string
 	Value  string
 	Offset uint64
+	Size   uint64
+	Addr   uint64
+}
+
+// StringOptions selects what FindStrings extracts, widths are counted in
+// characters and default to 4 and 256. Longer strings are split at the
+// maximum width. If ASCII is set only characters below 0xff are accepted.
+// The wide encodings are only searched at offsets aligned to their unit
+// size unless Unaligned is set, little and big endian text look alike
+// when shifted by a byte.
+type StringOptions struct {
+	MinWidth  int
+	MaxWidth  int
+	Types     []StringType
+	ASCII     bool
+	Unaligned bool
 }
 
 func (s StringType) String() string {
 	switch s {
 	case UTF8:
 		return "utf8"
+	case UTF16LE:
+		return "utf16le"
+	case UTF16BE:
+		return "utf16be"
+	case UTF32LE:
+		return "utf32le"
+	case UTF32BE:
+		return "utf32be"
 	default:
 		return "unknown"
 	}
 }
 
+func (s StringType) unit() int {
+	switch s {
+	case UTF16LE, UTF16BE:
+		return 2
+	case UTF32LE, UTF32BE:
+		return 4
+	}
+	return 1
+}
+
 func (s String) String() string {
 	return fmt.Sprintf("(%s %s+%#0x:%q)", StringType(s.Type), s.Name, s.Offset, s.Value)
 }
@@ -40,44 +80,396 @@ func FindStringsBuffer(buf []byte, minWidth, maxWidth int, ascii bool) []String
 
 func FindStringsReader(r io.Reader, minWidth, maxWidth int, ascii bool) []String {
 	var tab []String
+	opt := &StringOptions{
+		MinWidth: minWidth,
+		MaxWidth: maxWidth,
+		ASCII:    ascii,
+	}
+	FindStrings(r, opt, func(s String) error {
+		tab = append(tab, s)
+		return nil
+	})
+	return tab
+}
 
-	in := bufio.NewReader(r)
-	str := make([]rune, 0, maxWidth)
-	pos := int64(0)
-	off := uint64(0)
-	print := func() {
-		if len(str) >= minWidth {
-			tab = append(tab, String{
-				Type:   UTF8,
-				Value:  string(str),
-				Offset: off,
-			})
+// strdec decodes one encoding at one alignment of the stream.
+type strdec struct {
+	typ   StringType
+	phase int
+	buf   [4]byte
+	n     int
+	hi    rune
+	str   []rune
+	offs  []uint64
+	start uint64
+	end   uint64
+}
+
+// strcand is a string waiting for the strings overlapping it to complete,
+// offs holds the offset of every character so it can be cut down.
+type strcand struct {
+	String
+	runes []rune
+	offs  []uint64
+	score int
+}
+
+type strspan struct {
+	start, end uint64
+}
+
+// stringFinder runs a decoder for every encoding and alignment over the
+// stream. The candidates are held until no string found later can overlap
+// them, then the overlapping ones are resolved by keeping the best and
+// cutting the others down to the parts outside of it.
+type stringFinder struct {
+	opt    StringOptions
+	decs   []*strdec
+	pend   []*strcand
+	kept   []strspan
+	pos    uint64
+	window uint64
+	fn     func(String) error
+	err    error
+}
+
+// FindStrings calls fn for the strings in the stream once no string found
+// later can overlap them. Of the strings overlapping each other the one
+// with the most ascii characters over the others is kept, then the one of
+// the earliest listed type and then the longest one. The strings losing to
+// it are cut down to the characters outside of it and kept if they are
+// still long enough. The stream is read incrementally so the memory used is
+// bounded by the maximum width. If fn returns an error the search stops
+// with it.
+func FindStrings(r io.Reader, opt *StringOptions, fn func(String) error) error {
+	f := &stringFinder{fn: fn}
+	if opt != nil {
+		f.opt = *opt
+	}
+	if f.opt.MinWidth <= 0 {
+		f.opt.MinWidth = 4
+	}
+	if f.opt.MaxWidth <= 0 {
+		f.opt.MaxWidth = 256
+	}
+	if len(f.opt.Types) == 0 {
+		f.opt.Types = []StringType{UTF8}
+	}
+	for _, t := range f.opt.Types {
+		n := t.unit()
+		if !f.opt.Unaligned {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			f.decs = append(f.decs, &strdec{typ: t, phase: i})
 		}
-		str = str[:0]
 	}
+	f.window = uint64(f.opt.MaxWidth)*4 + 4
 
+	in := bufio.NewReader(r)
 	for {
-		var (
-			r   rune
-			wid int
-			err error
-		)
-
-		for ; ; pos += int64(wid) {
-			r, wid, err = in.ReadRune()
-			if err != nil {
-				return tab
+		c, err := in.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		for _, d := range f.decs {
+			f.feed(d, f.pos, c)
+		}
+		f.pos++
+		if f.pos%f.window == 0 {
+			f.flush(false)
+		}
+		if f.err != nil {
+			return f.err
+		}
+	}
+	for _, d := range f.decs {
+		f.emit(d)
+	}
+	f.flush(true)
+	return f.err
+}
+
+// feed adds the byte at pos to a decoder.
+func (f *stringFinder) feed(d *strdec, pos uint64, c byte) {
+	u := d.typ.unit()
+	if d.n == 0 && u > 1 && int(pos%uint64(u)) != d.phase {
+		return
+	}
+	d.buf[d.n] = c
+	d.n++
+
+	var (
+		r    rune
+		size int
+	)
+	switch d.typ {
+	case UTF8:
+		if !utf8.FullRune(d.buf[:d.n]) {
+			return
+		}
+		r, size = utf8.DecodeRune(d.buf[:d.n])
+		if r == utf8.RuneError && size == 1 {
+			// restart after the first byte of an invalid sequence
+			f.emit(d)
+			rest := append([]byte{}, d.buf[1:d.n]...)
+			d.n = 0
+			for i, b := range rest {
+				f.feed(d, pos-uint64(len(rest)-1-i), b)
+			}
+			return
+		}
+
+	case UTF16LE, UTF16BE:
+		if d.n < 2 {
+			return
+		}
+		v := rune(d.buf[0]) | rune(d.buf[1])<<8
+		if d.typ == UTF16BE {
+			v = rune(d.buf[0])<<8 | rune(d.buf[1])
+		}
+		size = 2
+		switch {
+		case 0xd800 <= v && v < 0xdc00:
+			if d.hi != 0 {
+				f.emit(d)
+			}
+			d.hi = v
+			d.n = 0
+			return
+		case 0xdc00 <= v && v < 0xe000:
+			if d.hi == 0 {
+				r = utf8.RuneError
+			} else {
+				r = 0x10000 + (d.hi-0xd800)<<10 + (v - 0xdc00)
+				size = 4
+			}
+		default:
+			if d.hi != 0 {
+				f.emit(d)
+			}
+			r = v
+		}
+		d.hi = 0
+
+	case UTF32LE, UTF32BE:
+		if d.n < 4 {
+			return
+		}
+		v := uint32(d.buf[0]) | uint32(d.buf[1])<<8 | uint32(d.buf[2])<<16 | uint32(d.buf[3])<<24
+		if d.typ == UTF32BE {
+			v = uint32(d.buf[0])<<24 | uint32(d.buf[1])<<16 | uint32(d.buf[2])<<8 | uint32(d.buf[3])
+		}
+		r, size = utf8.RuneError, 4
+		if v <= utf8.MaxRune {
+			r = rune(v)
+		}
+	}
+	d.n = 0
+
+	start := pos + 1 - uint64(size)
+	if r == utf8.RuneError || !utf8.ValidRune(r) || !strconv.IsPrint(r) || f.opt.ASCII && r >= 0xff {
+		f.emit(d)
+		return
+	}
+	if len(d.str) >= f.opt.MaxWidth {
+		f.emit(d)
+	}
+	if len(d.str) == 0 {
+		d.start = start
+	}
+	d.str = append(d.str, r)
+	d.offs = append(d.offs, start)
+	d.end = pos + 1
+}
+
+// emit ends the current string of a decoder.
+func (f *stringFinder) emit(d *strdec) {
+	if len(d.str) >= f.opt.MinWidth {
+		c := &strcand{
+			String: String{
+				Type:   d.typ,
+				Value:  string(d.str),
+				Offset: d.start,
+				Size:   d.end - d.start,
+			},
+			runes: append([]rune{}, d.str...),
+			offs:  append([]uint64{}, d.offs...),
+		}
+
+		// text decoded with the wrong encoding is mostly made of
+		// characters outside of ascii
+		for _, r := range d.str {
+			if r < 0x80 {
+				c.score++
+			} else {
+				c.score--
+			}
+		}
+		f.pend = append(f.pend, c)
+	}
+	d.str = d.str[:0]
+	d.offs = d.offs[:0]
+}
+
+// rank orders the types by preference.
+func (f *stringFinder) rank(t StringType) int {
+	for i, u := range f.opt.Types {
+		if u == t {
+			return i
+		}
+	}
+	return len(f.opt.Types)
+}
+
+func (f *stringFinder) better(a, b *strcand) bool {
+	if a.score != b.score {
+		return a.score > b.score
+	}
+	if x, y := f.rank(a.Type), f.rank(b.Type); x != y {
+		return x < y
+	}
+	if a.Size != b.Size {
+		return a.Size > b.Size
+	}
+	return a.Offset < b.Offset
+}
+
+// flush resolves the groups of overlapping candidates that no string found
+// later can join. A group that keeps growing across a long run of text is
+// resolved in parts once its candidates are a window behind, the strings
+// kept from it then cut down the candidates that come after.
+func (f *stringFinder) flush(final bool) {
+	// strings still being decoded start at or after the cutoff, and so do
+	// the characters held in a decoder buffer
+	cutoff := ^uint64(0)
+	if !final {
+		cutoff = 0
+		if f.pos > 3 {
+			cutoff = f.pos - 3
+		}
+		for _, d := range f.decs {
+			if len(d.str) > 0 && d.start < cutoff {
+				cutoff = d.start
+			}
+		}
+	}
+
+	sort.SliceStable(f.pend, func(i, j int) bool {
+		return f.pend[i].Offset < f.pend[j].Offset
+	})
+	var (
+		done []*strcand
+		rest []*strcand
+	)
+	for i := 0; i < len(f.pend); {
+		j, end := i+1, f.pend[i].Offset+f.pend[i].Size
+		for ; j < len(f.pend) && f.pend[j].Offset < end; j++ {
+			if e := f.pend[j].Offset + f.pend[j].Size; e > end {
+				end = e
 			}
-			if !strconv.IsPrint(r) || ascii && r >= 0xFF {
-				print()
+		}
+		for _, c := range f.pend[i:j] {
+			if end <= cutoff || (!final && c.Offset+c.Size+f.window <= cutoff) {
+				done = append(done, c)
+			} else {
+				rest = append(rest, c)
+			}
+		}
+		i = j
+	}
+	f.pend = rest
+	f.resolve(done)
+
+	lo := cutoff
+	for _, c := range f.pend {
+		if c.Offset < lo {
+			lo = c.Offset
+		}
+	}
+	n := 0
+	for _, p := range f.kept {
+		if p.end > lo && !final {
+			f.kept[n] = p
+			n++
+		}
+	}
+	f.kept = f.kept[:n]
+}
+
+// resolve goes over the candidates from the best to the worst and cuts each
+// one down to the characters outside of the strings already kept.
+func (f *stringFinder) resolve(l []*strcand) {
+	sort.SliceStable(l, func(i, j int) bool {
+		return f.better(l[i], l[j])
+	})
+
+	var out []String
+	for _, c := range l {
+		i := 0
+		for i < len(c.runes) {
+			if f.overlaps(c, i) {
+				i++
 				continue
 			}
-			if len(str) >= maxWidth {
-				print()
+			j := i + 1
+			for j < len(c.runes) && !f.overlaps(c, j) {
+				j++
+			}
+			if j-i >= f.opt.MinWidth {
+				s := c.String
+				s.Value = string(c.runes[i:j])
+				s.Offset = c.offs[i]
+				s.Size = c.runeEnd(j-1) - s.Offset
+				out = append(out, s)
+				f.kept = append(f.kept, strspan{s.Offset, s.Offset + s.Size})
 			}
-			str = append(str, r)
-			off += uint64(wid)
+			i = j
 		}
 	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Offset < out[j].Offset
+	})
+	for _, s := range out {
+		if f.err != nil {
+			break
+		}
+		f.err = f.fn(s)
+	}
+}
+
+// overlaps reports if a character of a candidate overlaps a kept string.
+func (f *stringFinder) overlaps(c *strcand, i int) bool {
+	start, end := c.offs[i], c.runeEnd(i)
+	for _, p := range f.kept {
+		if p.start < end && start < p.end {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *strcand) runeEnd(i int) uint64 {
+	if i+1 < len(c.offs) {
+		return c.offs[i+1]
+	}
+	return c.Offset + c.Size
+}
+
+// FindSectionStrings finds the strings of a section, the address of a
+// string is the section address plus its offset divided by the number of
+// bytes in an addressable unit.
+func FindSectionStrings(name string, addr uint64, unit int, data []byte, opt *StringOptions) []String {
+	var tab []String
+	FindStrings(bytes.NewReader(data), opt, func(s String) error {
+		s.Name = name
+		s.Addr = addr + s.Offset/uint64(unit)
+		tab = append(tab, s)
+		return nil
+	})
 	return tab
 }
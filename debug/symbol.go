@@ -0,0 +1,158 @@
+package debug
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Symbolizer maps between addresses, symbols and source lines of an
+// executable, the object file packages provide implementations.
+type Symbolizer interface {
+	// AddrSymbol returns the symbol containing addr.
+	AddrSymbol(addr uint64) (Sym, bool)
+
+	// SymbolAddr returns the symbol with the given name.
+	SymbolAddr(name string) (Sym, bool)
+
+	// AddrLine returns the source line that generated addr.
+	AddrLine(addr uint64) (Line, bool)
+}
+
+type Sym struct {
+	Name    string
+	Addr    uint64
+	Size    uint64
+	Section string
+}
+
+func (s Sym) String() string {
+	return fmt.Sprintf("%s@%#x", s.Name, s.Addr)
+}
+
+type Line struct {
+	Addr   uint64
+	File   string
+	Line   int
+	Column int
+}
+
+func (l Line) String() string {
+	if l.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d", l.File, l.Line, l.Column)
+	}
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
+// SymbolTable implements the symbol lookups of a Symbolizer over a list
+// of symbols.
+type SymbolTable struct {
+	syms  []Sym
+	ends  []uint64
+	names map[string]int
+}
+
+func NewSymbolTable(syms []Sym) *SymbolTable {
+	t := &SymbolTable{
+		syms:  append([]Sym{}, syms...),
+		names: make(map[string]int),
+	}
+	sort.SliceStable(t.syms, func(i, j int) bool {
+		return t.syms[i].Addr < t.syms[j].Addr
+	})
+
+	// ends holds the furthest end of any symbol up to an index so a
+	// lookup knows when to stop searching backwards
+	t.ends = make([]uint64, len(t.syms))
+	end := uint64(0)
+	for i, s := range t.syms {
+		if e := s.Addr + s.Size; e > end {
+			end = e
+		}
+		t.ends[i] = end
+		if _, ok := t.names[s.Name]; !ok && s.Name != "" {
+			t.names[s.Name] = i
+		}
+	}
+	return t
+}
+
+func (t *SymbolTable) Symbols() []Sym {
+	return t.syms
+}
+
+// AddrSymbol returns the sized symbol containing addr, the innermost one
+// if they nest. If none does and the closest symbol before addr has no
+// size that one is returned instead.
+func (t *SymbolTable) AddrSymbol(addr uint64) (Sym, bool) {
+	i := sort.Search(len(t.syms), func(i int) bool {
+		return t.syms[i].Addr > addr
+	}) - 1
+	if i < 0 {
+		return Sym{}, false
+	}
+
+	unsized := -1
+	if t.syms[i].Size == 0 {
+		unsized = i
+	}
+	for j := i; j >= 0 && t.ends[j] > addr; j-- {
+		if s := t.syms[j]; s.Size > 0 && addr < s.Addr+s.Size {
+			return s, true
+		}
+	}
+	if unsized >= 0 {
+		// prefer the first of several labels at the same address
+		for unsized > 0 && t.syms[unsized-1].Addr == t.syms[unsized].Addr && t.syms[unsized-1].Size == 0 {
+			unsized--
+		}
+		return t.syms[unsized], true
+	}
+	return Sym{}, false
+}
+
+func (t *SymbolTable) SymbolAddr(name string) (Sym, bool) {
+	i, ok := t.names[name]
+	if !ok {
+		return Sym{}, false
+	}
+	return t.syms[i], true
+}
+
+// SortLines sorts line entries by address, an entry without a file ends
+// the range of the one before it and an entry starting where a range ends
+// takes precedence over the end.
+func SortLines(lines []Line) {
+	sort.SliceStable(lines, func(i, j int) bool {
+		a, b := lines[i], lines[j]
+		if a.Addr != b.Addr {
+			return a.Addr < b.Addr
+		}
+		return a.File == "" && b.File != ""
+	})
+}
+
+// LineTable implements the line lookup of a Symbolizer over the rows of
+// line tables, each one covers the addresses up to the next.
+type LineTable struct {
+	lines []Line
+}
+
+func NewLineTable(lines []Line) *LineTable {
+	t := &LineTable{lines: append([]Line{}, lines...)}
+	SortLines(t.lines)
+	return t
+}
+
+func (t *LineTable) Lines() []Line {
+	return t.lines
+}
+
+func (t *LineTable) AddrLine(addr uint64) (Line, bool) {
+	i := sort.Search(len(t.lines), func(i int) bool {
+		return t.lines[i].Addr > addr
+	}) - 1
+	if i < 0 || t.lines[i].File == "" {
+		return Line{}, false
+	}
+	return t.lines[i], true
+}
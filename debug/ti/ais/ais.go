@@ -44,6 +44,21 @@ const (
 	VLYNQ
 	RAW
 	LEGACY
+	SPISLAVE
+	I2CSLAVE
+)
+
+const (
+	CRC_NONE = iota
+	CRC_SECTION
+	CRC_SINGLE
+)
+
+// section fill and set access widths
+const (
+	WIDTH_8  = 0
+	WIDTH_16 = 1
+	WIDTH_32 = 2
 )
 
 // based on what the aisgen tool generates
@@ -0,0 +1,263 @@
+package ais
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type BuildConfig struct {
+	// CRC selects a REQUEST_CRC after every section or a single one after
+	// the last section.
+	CRC int
+
+	// Fill is the minimum size in bytes of a run of a repeated word in a
+	// section load that is turned into a SECTION_FILL, 0 disables it.
+	Fill int
+
+	// Entry is the JUMP_CLOSE address if the image has none.
+	Entry uint32
+
+	// Regions are the memories the sections are allowed to load into.
+	Regions []Region
+}
+
+var crcTable [256]uint32
+
+func init() {
+	for i := range crcTable {
+		c := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if c&0x80000000 != 0 {
+				c = c<<1 ^ 0x04c11db7
+			} else {
+				c <<= 1
+			}
+		}
+		crcTable[i] = c
+	}
+}
+
+// CRC updates a CRC-32 with the polynomial 0x04c11db7 shifted MSB first,
+// the boot loader starts from 0 without a final xor.
+func CRC(crc uint32, b []byte) uint32 {
+	for _, c := range b {
+		crc = crc<<8 ^ crcTable[byte(crc>>24)^c]
+	}
+	return crc
+}
+
+// sectionCRC adds the arguments and the padded data of a section command to
+// the CRC.
+func sectionCRC(crc uint32, c *Cmd) uint32 {
+	var buf [16]byte
+	binary.LittleEndian.PutUint32(buf[0:], c.Addr)
+	switch c.Op {
+	case SECTION_FILL:
+		binary.LittleEndian.PutUint32(buf[4:], c.Size)
+		binary.LittleEndian.PutUint32(buf[8:], c.Type)
+		binary.LittleEndian.PutUint32(buf[12:], c.Pattern)
+		return CRC(crc, buf[:16])
+	default:
+		size := uint32(len(c.Data))
+		binary.LittleEndian.PutUint32(buf[4:], size)
+		crc = CRC(crc, buf[:8])
+		crc = CRC(crc, c.Data)
+		return CRC(crc, make([]byte, pad(size)-size))
+	}
+}
+
+func pad(n uint32) uint32 {
+	return (n + 3) &^ 3
+}
+
+// cmdSize returns the size in bytes of a command in the stream.
+func cmdSize(c *Cmd) int {
+	switch c.Op {
+	case FUNCTION_EXEC:
+		return 8 + 4*len(c.Args)
+	case SECTION_LOAD, CMP_SECTION_LOAD:
+		return 12 + int(pad(uint32(len(c.Data))))
+	case SECTION_FILL:
+		return 20
+	case SET:
+		return 20
+	case REQUEST_CRC:
+		return 12
+	case JUMP, JUMP_CLOSE:
+		return 8
+	}
+	return 4
+}
+
+// StartWord returns the first word of the stream for a boot mode, the
+// master modes read the magic while the slave modes synchronize on the
+// transmit start word.
+func StartWord(mode int) (uint32, error) {
+	switch mode {
+	case NONE, SPIMASTER, I2CMASTER, EMIFA, NAND, EMAC, UART, MMC_SD:
+		return MAGIC, nil
+	case SPISLAVE, I2CSLAVE, HPI, PCI, USB, VLYNQ:
+		return XMT_START_WORD, nil
+	}
+	return 0, fmt.Errorf("ais: boot mode %d does not use AIS", mode)
+}
+
+// Build returns a complete boot image of the commands. Section loads are
+// compacted into fills and CRC requests are generated as configured, the
+// CRC commands of the image are dropped. Other commands are kept in order
+// and never covered by the seek of a CRC request so that a retry does not
+// run them again. The image ends with a JUMP_CLOSE and is checked with
+// Simulate before it is returned.
+func (m *Image) Build(cfg *BuildConfig) (*Image, error) {
+	var (
+		out     Image
+		crc     uint32
+		seek    int
+		enabled bool
+		entry   = cfg.Entry
+		closed  = false
+	)
+	request := func() {
+		out.Cmds = append(out.Cmds, Cmd{Op: REQUEST_CRC, CRC: crc, Seek: -int32(seek + 12)})
+		crc, seek = 0, 0
+	}
+	section := func(c Cmd) {
+		if c.Op == SECTION_LOAD {
+			c.Size = uint32(len(c.Data))
+		}
+		if cfg.CRC != CRC_NONE && !enabled {
+			out.Cmds = append(out.Cmds, Cmd{Op: ENABLE_CRC})
+			enabled = true
+		}
+		out.Cmds = append(out.Cmds, c)
+		if enabled {
+			crc = sectionCRC(crc, &c)
+			seek += cmdSize(&c)
+			if cfg.CRC == CRC_SECTION {
+				request()
+			}
+		}
+	}
+
+	for i, c := range m.Cmds {
+		if closed {
+			return nil, fmt.Errorf("ais: command %d: command after jump_close", i)
+		}
+
+		switch c.Op {
+		case SECTION_LOAD:
+			for _, s := range compact(c, cfg.Fill) {
+				section(s)
+			}
+
+		case SECTION_FILL:
+			section(c)
+
+		case ENABLE_CRC, DISABLE_CRC, REQUEST_CRC:
+
+		case JUMP_CLOSE:
+			entry = c.Addr
+			closed = true
+
+		default:
+			if seek > 0 {
+				request()
+			}
+			out.Cmds = append(out.Cmds, c)
+		}
+	}
+	if seek > 0 {
+		request()
+	}
+	out.Cmds = append(out.Cmds, Cmd{Op: JUMP_CLOSE, Addr: entry})
+
+	if _, _, err := out.Simulate(cfg.Regions); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// compact splits a section load at the word aligned runs of at least min
+// bytes and replaces them with fills.
+func compact(c Cmd, min int) []Cmd {
+	if min <= 0 || c.Addr&3 != 0 {
+		return []Cmd{c}
+	}
+
+	var cmds []Cmd
+	load := func(start, end int) {
+		if start < end {
+			cmds = append(cmds, Cmd{Op: SECTION_LOAD, Addr: c.Addr + uint32(start), Data: c.Data[start:end]})
+		}
+	}
+	n := len(c.Data) &^ 3
+	start := 0
+	for i := 0; i < n; {
+		w := binary.LittleEndian.Uint32(c.Data[i:])
+		j := i + 4
+		for j < n && binary.LittleEndian.Uint32(c.Data[j:]) == w {
+			j += 4
+		}
+		if j-i >= min {
+			load(start, i)
+			cmds = append(cmds, Cmd{
+				Op:      SECTION_FILL,
+				Addr:    c.Addr + uint32(i),
+				Size:    uint32(j - i),
+				Type:    WIDTH_32,
+				Pattern: w,
+			})
+			start = j
+		}
+		i = j
+	}
+	load(start, len(c.Data))
+	return cmds
+}
+
+// FormatASCII writes the stream as one hex word per line, the form taken by
+// the host side boot utilities.
+func FormatASCII(m *Image, w io.Writer, mode int) error {
+	var b bytes.Buffer
+	if err := FormatMode(m, &b, mode); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	p := b.Bytes()
+	for i := 0; i+4 <= len(p); i += 4 {
+		fmt.Fprintf(bw, "0x%08X\n", binary.LittleEndian.Uint32(p[i:]))
+	}
+	err := bw.Flush()
+	if err != nil {
+		return fmt.Errorf("ais: %v", err)
+	}
+	return nil
+}
+
+// NewASCII parses a stream of hex words separated by spaces or commas.
+func NewASCII(r io.Reader) (*Image, error) {
+	var b bytes.Buffer
+	s := bufio.NewScanner(r)
+	s.Split(bufio.ScanWords)
+	for s.Scan() {
+		t := strings.Trim(s.Text(), ",")
+		if t == "" {
+			continue
+		}
+		v, err := strconv.ParseUint(t, 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("ais: invalid word %q", t)
+		}
+		binary.Write(&b, binary.LittleEndian, uint32(v))
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("ais: %v", err)
+	}
+	return NewFile(&b)
+}
@@ -28,6 +28,11 @@ type Cmd struct {
 
 	Func uint32
 	Args []uint32
+
+	CRC   uint32
+	Seek  int32
+	Value uint32
+	Sleep uint32
 }
 
 func Open(name string) (*Image, error) {
@@ -44,7 +49,7 @@ func NewFile(r io.Reader) (*Image, error) {
 
 	var sig uint32
 	binary.Read(b, binary.LittleEndian, &sig)
-	if sig != MAGIC {
+	if sig != MAGIC && sig != XMT_START_WORD {
 		return nil, fmt.Errorf("ais: invalid header signature %#x", sig)
 	}
 
@@ -75,10 +80,11 @@ func NewFile(r io.Reader) (*Image, error) {
 				Args: args,
 			})
 
-		case SECTION_LOAD:
+		case SECTION_LOAD, CMP_SECTION_LOAD:
 			binary.Read(b, binary.LittleEndian, buf[:2])
-			data := make([]byte, buf[1])
+			data := make([]byte, pad(buf[1]))
 			binary.Read(b, binary.LittleEndian, data)
+			data = data[:buf[1]]
 
 			cmds = append(cmds, Cmd{
 				Op:   op,
@@ -101,6 +107,20 @@ func NewFile(r io.Reader) (*Image, error) {
 			binary.Read(b, binary.LittleEndian, &buf[0])
 			cmds = append(cmds, Cmd{Op: op, Addr: buf[0]})
 
+		case REQUEST_CRC:
+			binary.Read(b, binary.LittleEndian, buf[:2])
+			cmds = append(cmds, Cmd{Op: op, CRC: buf[0], Seek: int32(buf[1])})
+
+		case SET:
+			binary.Read(b, binary.LittleEndian, buf[:4])
+			cmds = append(cmds, Cmd{
+				Op:    op,
+				Type:  buf[0],
+				Addr:  buf[1],
+				Value: buf[2],
+				Sleep: buf[3],
+			})
+
 		default:
 			cmds = append(cmds, Cmd{Op: op})
 		}
@@ -112,9 +132,17 @@ func NewFile(r io.Reader) (*Image, error) {
 }
 
 func Format(m *Image, w io.Writer) error {
-	b := bufio.NewWriter(w)
+	return FormatMode(m, w, NONE)
+}
+
+// FormatMode writes the image as the boot mode expects it, see StartWord.
+func FormatMode(m *Image, w io.Writer, mode int) error {
+	sig, err := StartWord(mode)
+	if err != nil {
+		return err
+	}
 
-	sig := uint32(MAGIC)
+	b := bufio.NewWriter(w)
 	binary.Write(b, binary.LittleEndian, &sig)
 	for _, c := range m.Cmds {
 		binary.Write(b, binary.LittleEndian, c.Op)
@@ -126,11 +154,12 @@ func Format(m *Image, w io.Writer) error {
 				binary.Write(b, binary.LittleEndian, &a)
 			}
 
-		case SECTION_LOAD:
+		case SECTION_LOAD, CMP_SECTION_LOAD:
 			size := uint32(len(c.Data))
 			binary.Write(b, binary.LittleEndian, &c.Addr)
 			binary.Write(b, binary.LittleEndian, &size)
 			binary.Write(b, binary.LittleEndian, c.Data)
+			b.Write(make([]byte, pad(size)-size))
 
 		case SECTION_FILL:
 			binary.Write(b, binary.LittleEndian, &c.Addr)
@@ -141,12 +170,24 @@ func Format(m *Image, w io.Writer) error {
 		case JUMP, JUMP_CLOSE:
 			binary.Write(b, binary.LittleEndian, &c.Addr)
 
+		case REQUEST_CRC:
+			binary.Write(b, binary.LittleEndian, &c.CRC)
+			binary.Write(b, binary.LittleEndian, &c.Seek)
+
+		case SET:
+			binary.Write(b, binary.LittleEndian, &c.Type)
+			binary.Write(b, binary.LittleEndian, &c.Addr)
+			binary.Write(b, binary.LittleEndian, &c.Value)
+			binary.Write(b, binary.LittleEndian, &c.Sleep)
+
+		case ENABLE_CRC, DISABLE_CRC, START_OVER, SEQ_READ_ENABLE:
+
 		default:
 			return fmt.Errorf("ais: unknown op(%#x)", c.Op)
 		}
 	}
 
-	err := b.Flush()
+	err = b.Flush()
 	if err != nil {
 		return fmt.Errorf("ais: %v", err)
 	}
@@ -180,6 +221,21 @@ func Disasm(c *Cmd) string {
 	case JUMP_CLOSE:
 		s = fmt.Sprintf("jump_close(addr = %#x)", c.Addr)
 
+	case CMP_SECTION_LOAD:
+		s = fmt.Sprintf("cmp_section_load(addr = %#x, size = %#x)", c.Addr, len(c.Data))
+
+	case REQUEST_CRC:
+		s = fmt.Sprintf("request_crc(crc = %#x, seek = %d)", c.CRC, c.Seek)
+
+	case SET:
+		s = fmt.Sprintf("set(type = %#x, addr = %#x, data = %#x, sleep = %#x)", c.Type, c.Addr, c.Value, c.Sleep)
+
+	case START_OVER:
+		s = fmt.Sprintf("start_over()")
+
+	case SEQ_READ_ENABLE:
+		s = fmt.Sprintf("seq_read_enable()")
+
 	case ENABLE_CRC:
 		s = fmt.Sprintf("enable_crc()")
 
@@ -0,0 +1,120 @@
+package ais
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/qeedquan/go-media/debug/memmap"
+)
+
+// Region is a memory the boot loader is allowed to write.
+type Region struct {
+	Name string
+	memmap.Range
+}
+
+// Simulate runs the commands the way the boot loader does and returns the
+// memory written by the section and set commands with the entry point of
+// the JUMP_CLOSE. CRC requests are checked against the sections since the
+// previous request and if regions are given every write has to fall inside
+// one of them.
+func (m *Image) Simulate(regions []Region) (*memmap.Map, uint32, error) {
+	var (
+		mem     = memmap.New(0)
+		crc     uint32
+		enabled bool
+	)
+	write := func(i int, addr uint32, b []byte) error {
+		if len(regions) > 0 {
+			start, end := uint64(addr), uint64(addr)+uint64(len(b))
+			found := false
+			for _, r := range regions {
+				if r.Start <= start && end <= r.End {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("ais: command %d: write to %v is outside of the memory map", i, memmap.Range{Start: start, End: end})
+			}
+		}
+		mem.Write(uint64(addr), b)
+		return nil
+	}
+
+	for i := range m.Cmds {
+		c := &m.Cmds[i]
+		switch c.Op {
+		case SECTION_LOAD:
+			if err := write(i, c.Addr, c.Data); err != nil {
+				return nil, 0, err
+			}
+
+		case SECTION_FILL:
+			b, err := fill(c.Type, c.Pattern, c.Size)
+			if err != nil {
+				return nil, 0, fmt.Errorf("ais: command %d: %v", i, err)
+			}
+			if err := write(i, c.Addr, b); err != nil {
+				return nil, 0, err
+			}
+
+		case SET:
+			if c.Type&3 == 3 {
+				return nil, 0, fmt.Errorf("ais: command %d: set type %#x is not supported", i, c.Type)
+			}
+			b, _ := fill(c.Type&3, c.Value, 1<<(c.Type&3))
+			if err := write(i, c.Addr, b); err != nil {
+				return nil, 0, err
+			}
+
+		case ENABLE_CRC:
+			enabled = true
+			crc = 0
+
+		case DISABLE_CRC:
+			enabled = false
+
+		case REQUEST_CRC:
+			if enabled && crc != c.CRC {
+				return nil, 0, fmt.Errorf("ais: command %d: crc mismatch, expected %#x but got %#x", i, c.CRC, crc)
+			}
+			crc = 0
+
+		case JUMP_CLOSE:
+			if i+1 != len(m.Cmds) {
+				return nil, 0, fmt.Errorf("ais: command %d: command after jump_close", i+1)
+			}
+			return mem, c.Addr, nil
+
+		case FUNCTION_EXEC, JUMP, SEQ_READ_ENABLE:
+
+		default:
+			return nil, 0, fmt.Errorf("ais: command %d: %s is not supported", i, Disasm(c))
+		}
+
+		if enabled && (c.Op == SECTION_LOAD || c.Op == SECTION_FILL) {
+			crc = sectionCRC(crc, c)
+		}
+	}
+	return nil, 0, fmt.Errorf("ais: missing jump_close")
+}
+
+// fill repeats a pattern of an access width over size bytes.
+func fill(typ, pattern, size uint32) ([]byte, error) {
+	if typ > WIDTH_32 {
+		return nil, fmt.Errorf("invalid width %d", typ)
+	}
+	n := uint32(1) << typ
+	if size%n != 0 {
+		return nil, fmt.Errorf("size %#x is not a multiple of the width", size)
+	}
+
+	var p [4]byte
+	binary.LittleEndian.PutUint32(p[:], pattern)
+	b := make([]byte, size)
+	for i := range b {
+		b[i] = p[uint32(i)%n]
+	}
+	return b, nil
+}
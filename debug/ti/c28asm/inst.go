@@ -0,0 +1,985 @@
+package c28asm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	ErrShortInst   = errors.New("short instruction")
+	ErrUnknownInst = errors.New("unknown instruction")
+)
+
+// Inst is a decoded instruction, Len is in bytes and the two words of a
+// 32-bit instruction are stored in Enc with the first word in the high half.
+type Inst struct {
+	Op   Op
+	Enc  uint32
+	Len  int
+	Args []Arg
+}
+
+type Arg interface {
+	String() string
+}
+
+type Reg uint8
+
+const (
+	AL Reg = iota + 1
+	AH
+	ACC
+	P
+	PH
+	PL
+	XT
+	T
+	TH
+	SP
+	DP
+	AR0
+	AR1
+	AR2
+	AR3
+	AR4
+	AR5
+	AR6
+	AR7
+	XAR0
+	XAR1
+	XAR2
+	XAR3
+	XAR4
+	XAR5
+	XAR6
+	XAR7
+	R0H
+	R1H
+	R2H
+	R3H
+	R4H
+	R5H
+	R6H
+	R7H
+	VCRC
+	IER
+	IFR
+	DBGIER
+	ST0
+	ST1
+	RPC
+	TL
+)
+
+var regNames = [...]string{
+	AL: "AL", AH: "AH", ACC: "ACC", P: "P", PH: "PH", PL: "PL",
+	XT: "XT", T: "T", TH: "TH", SP: "SP", DP: "DP",
+	AR0: "AR0", AR1: "AR1", AR2: "AR2", AR3: "AR3",
+	AR4: "AR4", AR5: "AR5", AR6: "AR6", AR7: "AR7",
+	XAR0: "XAR0", XAR1: "XAR1", XAR2: "XAR2", XAR3: "XAR3",
+	XAR4: "XAR4", XAR5: "XAR5", XAR6: "XAR6", XAR7: "XAR7",
+	R0H: "R0H", R1H: "R1H", R2H: "R2H", R3H: "R3H",
+	R4H: "R4H", R5H: "R5H", R6H: "R6H", R7H: "R7H",
+	VCRC: "VCRC", IER: "IER", IFR: "IFR", DBGIER: "DBGIER",
+	ST0: "ST0", ST1: "ST1", RPC: "RPC", TL: "TL",
+}
+
+func (r Reg) String() string {
+	if int(r) < len(regNames) && regNames[r] != "" {
+		return regNames[r]
+	}
+	return fmt.Sprintf("Reg(%d)", r)
+}
+
+// Loc is a loc16 or loc32 operand, Mode is the 8-bit addressing mode field
+// of the opcode interpreted with AMODE = 0.
+type Loc struct {
+	Mode uint8
+	Long bool
+}
+
+func (l Loc) String() string {
+	m := l.Mode
+	n := m & 7
+	switch {
+	case m < 0x40:
+		return fmt.Sprintf("@0x%x", m)
+	case m < 0x80:
+		return fmt.Sprintf("*-SP[%d]", m&0x3f)
+	case m < 0x88:
+		return fmt.Sprintf("*XAR%d++", n)
+	case m < 0x90:
+		return fmt.Sprintf("*--XAR%d", n)
+	case m < 0x98:
+		return fmt.Sprintf("*+XAR%d[AR0]", n)
+	case m < 0xa0:
+		return fmt.Sprintf("*+XAR%d[AR1]", n)
+	case m < 0xa8:
+		if l.Long {
+			return fmt.Sprintf("@XAR%d", n)
+		}
+		return fmt.Sprintf("@AR%d", n)
+	case m >= 0xb0 && m < 0xb8:
+		return fmt.Sprintf("*,ARP%d", n)
+	case m >= 0xc0:
+		return fmt.Sprintf("*+XAR%d[%d]", n, (m>>3)&7)
+	}
+
+	switch m {
+	case 0xa8:
+		return "@AH"
+	case 0xa9:
+		if l.Long {
+			return "@ACC"
+		}
+		return "@AL"
+	case 0xaa:
+		return "@PH"
+	case 0xab:
+		if l.Long {
+			return "@P"
+		}
+		return "@PL"
+	case 0xac:
+		if l.Long {
+			return "@XT"
+		}
+		return "@TH"
+	case 0xad:
+		return "@SP"
+	case 0xae:
+		return "*BR0++"
+	case 0xaf:
+		return "*BR0--"
+	case 0xb8:
+		return "*"
+	case 0xb9:
+		return "*++"
+	case 0xba:
+		return "*--"
+	case 0xbb:
+		return "*0++"
+	case 0xbc:
+		return "*0--"
+	case 0xbd:
+		return "*SP++"
+	case 0xbe:
+		return "*--SP"
+	}
+	return "*AR6%++"
+}
+
+type Imm int32
+
+func (i Imm) String() string {
+	switch {
+	case -10 < i && i < 10:
+		return fmt.Sprintf("#%d", int32(i))
+	case i < 0:
+		return fmt.Sprintf("#-0x%x", -int64(i))
+	}
+	return fmt.Sprintf("#0x%x", int32(i))
+}
+
+// Float is the float immediate of the FPU instructions.
+type Float float32
+
+func (f Float) String() string {
+	return fmt.Sprintf("#%g", float32(f))
+}
+
+// Addr is an absolute 22-bit program address.
+type Addr uint32
+
+func (a Addr) String() string {
+	return fmt.Sprintf("0x%06x", uint32(a))
+}
+
+// Rel is a branch offset in words relative to the instruction address.
+type Rel int32
+
+func (r Rel) String() string {
+	return fmt.Sprintf(".%+d", int32(r))
+}
+
+// Ptr is a 16-bit data or I/O space address.
+type Ptr uint16
+
+func (p Ptr) String() string {
+	return fmt.Sprintf("*(0x%04x)", uint16(p))
+}
+
+// Shift is a shift applied to the previous operand.
+type Shift uint8
+
+func (s Shift) String() string {
+	return fmt.Sprintf("<<#%d", s)
+}
+
+// PostDec is an auxiliary register decremented after use.
+type PostDec Reg
+
+func (p PostDec) String() string {
+	return Reg(p).String() + "--"
+}
+
+// Sym is a keyword operand such as a condition or a status bit.
+type Sym string
+
+func (s Sym) String() string {
+	return string(s)
+}
+
+var conds = [...]Sym{
+	"NEQ", "EQ", "GT", "GEQ", "LT", "LEQ", "HI", "HIS",
+	"LO", "LOS", "NOV", "OV", "NTC", "TC", "NBIO", "UNC",
+}
+
+var statusBits = [...]Sym{
+	"SXM", "OVM", "TC", "C", "INTM", "DBGM", "PAGE0", "VMAP",
+}
+
+var intrs = [...]Sym{
+	"INT1", "INT2", "INT3", "INT4", "INT5", "INT6", "INT7", "INT8",
+	"INT9", "INT10", "INT11", "INT12", "INT13", "INT14", "DLOGINT", "RTOSINT",
+}
+
+func loc16(m uint8) Loc { return Loc{Mode: m} }
+func loc32(m uint8) Loc { return Loc{Mode: m, Long: true} }
+
+// ax selects AL or AH from the low bit of the opcode high byte.
+func ax(hi uint8) Reg {
+	if hi&1 != 0 {
+		return AH
+	}
+	return AL
+}
+
+func status(m uint8) []Arg {
+	var args []Arg
+	for i := range statusBits {
+		if m&(1<<i) != 0 {
+			args = append(args, statusBits[i])
+		}
+	}
+	return args
+}
+
+// the auxiliary registers loaded and stored by MOVL indexed by opcode
+var (
+	movlXAR = map[uint8]Reg{
+		0x8e: XAR0, 0x8b: XAR1, 0x86: XAR2, 0x82: XAR3,
+		0x8a: XAR4, 0x83: XAR5, 0xc4: XAR6, 0xc5: XAR7,
+	}
+	movlLocXAR = map[uint8]Reg{
+		0x3a: XAR0, 0xb2: XAR1, 0xaa: XAR2, 0xa2: XAR3,
+		0xa8: XAR4, 0xa0: XAR5, 0xc2: XAR6, 0xc3: XAR7,
+	}
+)
+
+// the register only stack operations of the 0x00 and 0x76 groups
+var (
+	stack00 = map[uint8]struct {
+		op  Op
+		arg Arg
+	}{
+		0x02: {POP, IFR}, 0x03: {POP, Sym("AR1H:AR0H")}, 0x04: {PUSH, RPC},
+		0x05: {PUSH, Sym("AR1H:AR0H")}, 0x07: {POP, RPC},
+	}
+	stack76 = map[uint8]struct {
+		op  Op
+		arg Arg
+	}{
+		0x00: {POP, ST1}, 0x01: {POP, Sym("DP:ST1")}, 0x03: {POP, DP},
+		0x05: {POP, Sym("AR5:AR4")}, 0x06: {POP, Sym("AR1:AR0")}, 0x07: {POP, Sym("AR3:AR2")},
+		0x08: {PUSH, ST1}, 0x09: {PUSH, Sym("DP:ST1")}, 0x0a: {PUSH, IFR}, 0x0b: {PUSH, DP},
+		0x0c: {PUSH, Sym("AR5:AR4")}, 0x0d: {PUSH, Sym("AR1:AR0")}, 0x0e: {PUSH, DBGIER},
+		0x0f: {PUSH, Sym("AR3:AR2")}, 0x11: {POP, P}, 0x12: {POP, DBGIER}, 0x13: {POP, ST0},
+		0x15: {POP, Sym("T:ST0")}, 0x18: {PUSH, ST0}, 0x19: {PUSH, Sym("T:ST0")}, 0x1d: {PUSH, P},
+	}
+)
+
+func fpr(n uint32) Reg {
+	return R0H + Reg(n&7)
+}
+
+// Decode decodes the instruction at the start of src. A word that isn't
+// recognized is returned as UNKNOWN along with ErrUnknownInst, its Len is 4
+// for the groups that are all 32-bit and 2 otherwise, which can be wrong in
+// the 0x56 group that mixes both.
+func Decode(src []byte) (Inst, error) {
+	if len(src) < 2 {
+		return Inst{}, ErrShortInst
+	}
+
+	w := binary.LittleEndian.Uint16(src)
+	hi, lo := uint8(w>>8), uint8(w)
+	in := Inst{
+		Op:  UNKNOWN,
+		Enc: uint32(w),
+		Len: 2,
+	}
+
+	// long reads the second word of a 32-bit instruction
+	var w2 uint32
+	long := func() bool {
+		if len(src) < 4 {
+			return false
+		}
+		w2 = uint32(binary.LittleEndian.Uint16(src[2:]))
+		in.Enc = uint32(w)<<16 | w2
+		in.Len = 4
+		return true
+	}
+	set := func(op Op, args ...Arg) {
+		in.Op, in.Args = op, args
+	}
+
+	switch hi {
+	case 0x00:
+		switch {
+		case lo == 0x00:
+			set(ITRAP0)
+		case lo == 0x01:
+			set(ABORTI)
+		case lo == 0x06:
+			set(LRETR)
+		case lo < 0x08:
+			e := stack00[lo]
+			set(e.op, e.arg)
+		case lo >= 0x08 && lo < 0x10:
+			if !long() {
+				return Inst{}, ErrShortInst
+			}
+			set(BANZ, Rel(int16(w2)), PostDec(AR0+Reg(lo&7)))
+		case lo >= 0x10 && lo < 0x20:
+			set(INTR, intrs[lo&0xf])
+		case lo >= 0x20 && lo < 0x40:
+			set(TRAP, Imm(lo&0x1f))
+		default:
+			if !long() {
+				return Inst{}, ErrShortInst
+			}
+			addr := Addr(uint32(lo&0x3f)<<16 | w2)
+			switch lo >> 6 {
+			case 1:
+				set(LB, addr)
+			case 2:
+				set(LC, addr)
+			case 3:
+				set(FFC, XAR7, addr)
+			}
+		}
+
+	case 0x02:
+		set(MOVB, ACC, Imm(lo))
+	case 0x03:
+		set(SUBL, ACC, loc32(lo))
+	case 0x05:
+		set(ADD, ACC, loc16(lo), Shift(16))
+	case 0x06:
+		set(MOVL, ACC, loc32(lo))
+	case 0x07:
+		set(ADDL, ACC, loc32(lo))
+	case 0x09:
+		set(ADDB, ACC, Imm(lo))
+	case 0x0a:
+		set(INC, loc16(lo))
+	case 0x0b:
+		set(DEC, loc16(lo))
+	case 0x0c:
+		set(ADDCU, ACC, loc16(lo))
+	case 0x0d:
+		set(ADDU, ACC, loc16(lo))
+	case 0x0e:
+		set(MOVU, ACC, loc16(lo))
+	case 0x0f:
+		set(CMPL, ACC, loc32(lo))
+	case 0x19:
+		set(SUBB, ACC, Imm(lo))
+	case 0x1e:
+		set(MOVL, loc32(lo), ACC)
+	case 0x1f:
+		set(SUBU, ACC, loc16(lo))
+	case 0x25:
+		set(MOV, ACC, loc16(lo), Shift(16))
+	case 0x2b:
+		set(MOV, loc16(lo), Imm(0))
+	case 0x2d:
+		set(MOV, loc16(lo), T)
+
+	case 0x10:
+		set(MOVA, T, loc16(lo))
+	case 0x11:
+		set(MOVS, T, loc16(lo))
+	case 0x12:
+		set(MPY, ACC, T, loc16(lo))
+	case 0x13:
+		set(MPYS, P, T, loc16(lo))
+	case 0x16:
+		set(MOVP, T, loc16(lo))
+	case 0x17:
+		set(MPYA, P, T, loc16(lo))
+	case 0x22:
+		set(PUSH, loc16(lo))
+	case 0x2a:
+		set(POP, loc16(lo))
+	case 0x30:
+		set(MPYXU, ACC, T, loc16(lo))
+	case 0x31:
+		set(MPYB, P, T, Imm(lo))
+	case 0x32:
+		set(MPYXU, P, T, loc16(lo))
+	case 0x33:
+		set(MPY, P, T, loc16(lo))
+	case 0x35:
+		set(MPYB, ACC, T, Imm(lo))
+	case 0x36:
+		set(MPYU, ACC, T, loc16(lo))
+	case 0x37:
+		set(MPYU, P, T, loc16(lo))
+	case 0x57:
+		set(MOVH, loc16(lo), P)
+	case 0x81:
+		set(ADD, ACC, loc16(lo))
+	case 0x85:
+		set(MOV, ACC, loc16(lo))
+	case 0x89:
+		set(AND, ACC, loc16(lo))
+	case 0xaf:
+		set(OR, ACC, loc16(lo))
+	case 0xa5:
+		set(DMOV, loc16(lo))
+	case 0xa7:
+		set(MOVAD, T, loc16(lo))
+
+	case 0x82, 0x83, 0x86, 0x8a, 0x8b, 0x8e, 0xc4, 0xc5:
+		set(MOVL, movlXAR[hi], loc32(lo))
+	case 0x3a, 0xa0, 0xa2, 0xa8, 0xaa, 0xb2, 0xc2, 0xc3:
+		set(MOVL, loc32(lo), movlLocXAR[hi])
+
+	case 0x08, 0x14, 0x15, 0x18, 0x1a, 0x1b, 0x1c, 0x28, 0x2c, 0x2e,
+		0x34, 0xb4, 0xbc, 0xcc, 0xcd, 0xf4, 0xf5:
+		if !long() {
+			return Inst{}, ErrShortInst
+		}
+		switch hi {
+		case 0x14:
+			set(MAC, P, loc16(lo), Addr(w2))
+		case 0x15:
+			set(MPYA, P, loc16(lo), Imm(int16(w2)))
+		case 0x2c:
+			set(LOOPZ, loc16(lo), Imm(w2))
+		case 0x2e:
+			set(LOOPNZ, loc16(lo), Imm(w2))
+		case 0x34:
+			set(MPY, ACC, loc16(lo), Imm(int16(w2)))
+		case 0xb4:
+			set(IN, loc16(lo), Ptr(w2))
+		case 0xbc:
+			set(OUT, Ptr(w2), loc16(lo))
+		case 0xcc, 0xcd:
+			set(AND, ax(hi), loc16(lo), Imm(w2))
+		case 0xf4:
+			set(MOV, loc16(lo), Ptr(w2))
+		case 0xf5:
+			set(MOV, Ptr(w2), loc16(lo))
+		case 0x08:
+			set(ADD, loc16(lo), Imm(int16(w2)))
+		case 0x18:
+			set(AND, loc16(lo), Imm(w2))
+		case 0x1a:
+			set(OR, loc16(lo), Imm(w2))
+		case 0x1b:
+			set(CMP, loc16(lo), Imm(int16(w2)))
+		case 0x1c:
+			set(XOR, loc16(lo), Imm(w2))
+		case 0x28:
+			set(MOV, loc16(lo), Imm(w2))
+		}
+
+	case 0x29:
+		if lo == 0x10 {
+			set(EINT)
+		} else {
+			set(CLRC, status(lo)...)
+		}
+	case 0x3b:
+		if lo == 0x10 {
+			set(DINT)
+		} else {
+			set(SETC, status(lo)...)
+		}
+
+	case 0x40, 0x41, 0x42, 0x43, 0x44, 0x45, 0x46, 0x47,
+		0x48, 0x49, 0x4a, 0x4b, 0x4c, 0x4d, 0x4e, 0x4f:
+		set(TBIT, loc16(lo), Imm(hi&0xf))
+
+	case 0x50, 0x51:
+		set(ORB, ax(hi), Imm(lo))
+	case 0x52, 0x53:
+		set(CMPB, ax(hi), Imm(lo))
+	case 0x54, 0x55:
+		set(CMP, ax(hi), loc16(lo))
+	case 0x56:
+		if err := decode56(&in, lo, src); err != nil {
+			return Inst{}, err
+		}
+	case 0x58, 0x59, 0x5a, 0x5b, 0x5c, 0x5d, 0x5e, 0x5f:
+		set(MOVZ, AR0+Reg(hi&7), loc16(lo))
+
+	case 0x60, 0x61, 0x62, 0x63, 0x64, 0x65, 0x66, 0x67,
+		0x68, 0x69, 0x6a, 0x6b, 0x6c, 0x6d, 0x6e, 0x6f:
+		set(SB, Rel(int8(lo)), conds[hi&0xf])
+
+	case 0x70, 0x71:
+		set(XOR, ax(hi), loc16(lo))
+	case 0x72, 0x73:
+		set(ADD, loc16(lo), ax(hi))
+
+	case 0x76:
+		switch lo {
+		case 0x02:
+			set(IRET)
+		case 0x04:
+			set(LC, Sym("*XAR7"))
+		case 0x10:
+			set(LRETE)
+		case 0x14:
+			set(LRET)
+		case 0x17:
+			set(NASP)
+		case 0x1a:
+			set(EDIS)
+		case 0x1b:
+			set(ASP)
+		case 0x20:
+			set(LB, Sym("*XAR7"))
+		case 0x21:
+			set(IDLE)
+		case 0x22:
+			set(EALLOW)
+		case 0x24:
+			set(ESTOP1)
+		case 0x25:
+			set(ESTOP0)
+		case 0x1f, 0x23, 0x26, 0x27, 0x2f, 0x3f:
+			if !long() {
+				return Inst{}, ErrShortInst
+			}
+			switch lo {
+			case 0x1f:
+				set(MOVW, DP, Imm(w2))
+			case 0x23:
+				set(OR, IER, Imm(w2))
+			case 0x26:
+				set(AND, IER, Imm(w2))
+			case 0x27:
+				set(OR, IFR, Imm(w2))
+			case 0x2f:
+				set(AND, IFR, Imm(w2))
+			default:
+				set(IACK, Imm(w2))
+			}
+		default:
+			if e, ok := stack76[lo]; ok {
+				set(e.op, e.arg)
+				break
+			}
+			if lo < 0x40 {
+				break
+			}
+			if !long() {
+				return Inst{}, ErrShortInst
+			}
+			addr := Addr(uint32(lo&0x3f)<<16 | w2)
+			switch lo >> 6 {
+			case 1:
+				set(LCR, addr)
+			case 2:
+				set(MOVL, XAR6, Imm(addr))
+			case 3:
+				set(MOVL, XAR7, Imm(addr))
+			}
+		}
+
+	case 0x77:
+		if lo == 0 {
+			set(NOP)
+		} else {
+			set(NOP, loc16(lo))
+		}
+
+	case 0x87:
+		set(MOVL, XT, loc32(lo))
+
+	case 0x8f:
+		if lo < 0x40 || lo >= 0xc0 {
+			break
+		}
+		if !long() {
+			return Inst{}, ErrShortInst
+		}
+		addr := Addr(uint32(lo&0x3f)<<16 | w2)
+		if lo < 0x80 {
+			set(MOVL, XAR4, Imm(addr))
+		} else {
+			set(MOVL, XAR5, Imm(addr))
+		}
+
+	case 0x90, 0x91:
+		set(ANDB, ax(hi), Imm(lo))
+	case 0x92, 0x93:
+		set(MOV, ax(hi), loc16(lo))
+	case 0x94, 0x95:
+		set(ADD, ax(hi), loc16(lo))
+	case 0x96, 0x97:
+		set(MOV, loc16(lo), ax(hi))
+	case 0x98, 0x99:
+		set(OR, loc16(lo), ax(hi))
+	case 0x9a, 0x9b:
+		set(ADDB, ax(hi), Imm(lo))
+	case 0x9c, 0x9d:
+		set(SUBB, ax(hi), Imm(lo))
+	case 0x9e, 0x9f:
+		set(SUB, ax(hi), loc16(lo))
+
+	case 0xa3:
+		set(MOVL, P, loc32(lo))
+	case 0xa9:
+		set(MOVL, loc32(lo), P)
+	case 0xab:
+		set(MOVL, loc32(lo), XT)
+
+	case 0xb8, 0xb9, 0xba, 0xbb:
+		set(MOVZ, DP, Imm(w&0x3ff))
+
+	case 0xc0, 0xc1:
+		set(AND, loc16(lo), ax(hi))
+	case 0xca, 0xcb:
+		set(OR, ax(hi), loc16(lo))
+	case 0xce, 0xcf:
+		set(AND, ax(hi), loc16(lo))
+
+	case 0xd0, 0xd1, 0xd2, 0xd3, 0xd4, 0xd5, 0xd6, 0xd7:
+		set(MOVB, XAR0+Reg(hi&7), Imm(lo))
+	case 0xd8, 0xd9, 0xda, 0xdb, 0xdc, 0xdd, 0xde, 0xdf:
+		if lo&0x80 != 0 {
+			set(SUBB, XAR0+Reg(hi&7), Imm(lo&0x7f))
+		} else {
+			set(ADDB, XAR0+Reg(hi&7), Imm(lo&0x7f))
+		}
+
+	case 0xe0, 0xe1, 0xe2, 0xe3, 0xe4, 0xe5, 0xe6, 0xe7,
+		0xe8, 0xe9, 0xea, 0xeb:
+		// the FPU and VCU instructions are all 32 bits
+		if !long() {
+			return Inst{}, ErrShortInst
+		}
+		decodeExt(&in, hi, lo, w2)
+
+	case 0xec, 0xed, 0xee, 0xef:
+		set(SBF, Rel(int8(lo)), [...]Sym{"EQ", "NEQ", "TC", "NTC"}[hi&3])
+
+	case 0xf6:
+		set(RPT, Imm(lo))
+	case 0xf7:
+		set(RPT, loc16(lo))
+
+	case 0xfc:
+		set(ADRK, Imm(lo))
+	case 0xfd:
+		set(SBRK, Imm(lo))
+
+	case 0xfe:
+		if lo&0x80 != 0 {
+			set(SUBB, SP, Imm(lo&0x7f))
+		} else {
+			set(ADDB, SP, Imm(lo&0x7f))
+		}
+
+	case 0xff:
+		switch {
+		case lo < 0x30:
+			if !long() {
+				return Inst{}, ErrShortInst
+			}
+			op := [...]Op{SUB, ADD, MOV}[lo>>4]
+			set(op, ACC, Imm(w2))
+			if lo&0xf != 0 {
+				in.Args = append(in.Args, Shift(lo&0xf))
+			}
+		case lo < 0x40:
+			set(LSL, ACC, Imm(lo&0xf+1))
+		case lo < 0x50:
+			set(SFR, ACC, Imm(lo&0xf+1))
+		case lo == 0x50:
+			set(LSL, ACC, T)
+		case lo == 0x51:
+			set(SFR, ACC, T)
+		case lo == 0x52:
+			set(ROR, ACC)
+		case lo == 0x53:
+			set(ROL, ACC)
+		case lo == 0x54:
+			set(NEG, ACC)
+		case lo == 0x55:
+			set(NOT, ACC)
+		case lo == 0x56:
+			set(ABS, ACC)
+		case lo == 0x57:
+			set(SAT, ACC)
+		case lo == 0x58:
+			set(TEST, ACC)
+		case lo == 0x59:
+			set(CMPL, ACC, P, Sym("<<PM"))
+		case lo == 0x5a:
+			set(MOVL, P, ACC)
+		case lo == 0x5c, lo == 0x5d:
+			set(NEG, ax(lo))
+		case lo == 0x5e, lo == 0x5f:
+			set(NOT, ax(lo))
+		case lo >= 0x62 && lo < 0x68:
+			set([...]Op{LSR, ASR, LSL}[(lo-0x62)>>1], ax(lo), T)
+		case lo >= 0x68 && lo < 0x70:
+			set(SPM, Imm(lo&7))
+		case lo >= 0x80 && lo < 0xe0:
+			set([...]Op{LSL, ASR, LSR}[(lo-0x80)>>5], ax(lo>>4), Imm(lo&0xf+1))
+		case lo >= 0xe0 && lo < 0xf0:
+			if !long() {
+				return Inst{}, ErrShortInst
+			}
+			set(B, Rel(int16(w2)), conds[lo&0xf])
+		}
+	}
+
+	if in.Op == UNKNOWN {
+		return in, ErrUnknownInst
+	}
+	return in, nil
+}
+
+// decode56 decodes the 0x56 group, the extended instructions of the C28x
+// that weren't part of the C27x.
+func decode56(in *Inst, lo uint8, src []byte) error {
+	set := func(op Op, args ...Arg) {
+		in.Op, in.Args = op, args
+	}
+
+	switch {
+	case lo >= 0x80 && lo < 0xb0:
+		set([...]Op{ASR64, LSR64, LSL64}[(lo-0x80)>>4], Sym("ACC:P"), Imm(lo&0xf+1))
+		return nil
+	case lo == 0x70, lo == 0x71:
+		set(FLIP, ax(lo))
+		return nil
+	}
+
+	switch lo {
+	case 0x10:
+		set(ASRL, ACC, T)
+	case 0x16:
+		set(C28ADDR)
+	case 0x1a:
+		set(C28MAP)
+	case 0x1e:
+		set(LPADDR)
+	case 0x1f:
+		set(C28OBJ)
+	case 0x22:
+		set(LSRL, ACC, T)
+	case 0x2c:
+		set(ASR64, Sym("ACC:P"), T)
+	case 0x32:
+		set(ZAPA)
+	case 0x35:
+		set(CSB, ACC)
+	case 0x36:
+		set(C27OBJ)
+	case 0x3b:
+		set(LSLL, ACC, T)
+	case 0x3f:
+		set(C27MAP)
+	case 0x52:
+		set(LSL64, Sym("ACC:P"), T)
+	case 0x58:
+		set(NEG64, Sym("ACC:P"))
+	case 0x5b:
+		set(LSR64, Sym("ACC:P"), T)
+	case 0x5e:
+		set(CMP64, Sym("ACC:P"))
+	case 0x5f:
+		set(ABSTC, ACC)
+	}
+	if in.Op != UNKNOWN {
+		return nil
+	}
+
+	if !op56Long(lo) {
+		return nil
+	}
+	if len(src) < 4 {
+		return ErrShortInst
+	}
+	w2 := binary.LittleEndian.Uint16(src[2:])
+	in.Enc = in.Enc<<16 | uint32(w2)
+	in.Len = 4
+
+	m := uint8(w2)
+	n := Shift(w2 >> 8 & 0xf)
+	switch lo {
+	case 0x01:
+		set(ADDL, loc32(m), ACC)
+	case 0x03:
+		set(MOV, ACC, loc16(m), n)
+	case 0x04:
+		set(ADD, ACC, loc16(m), n)
+	case 0x05:
+		set(IMPYL, P, XT, loc32(m))
+	case 0x06:
+		set(MOV, ACC, loc16(m), Sym("<<T"))
+	case 0x08:
+		set(AND, ACC, Imm(w2), Shift(16))
+	case 0x21:
+		set(MOVX, TL, loc16(m))
+	case 0x23:
+		set(ADD, ACC, loc16(m), Sym("<<T"))
+	case 0x27:
+		set(SUB, ACC, loc16(m), Sym("<<T"))
+	case 0x2d:
+		set(MOV, loc16(m), ACC, n&7+1)
+	case 0x2f:
+		set(MOVH, loc16(m), ACC, n&7+1)
+	case 0x40:
+		set(ADDCL, ACC, loc32(m))
+	case 0x41:
+		set(MAXL, ACC, loc32(m))
+	case 0x43:
+		set(IMPYSL, P, XT, loc32(m))
+	case 0x44:
+		set(IMPYL, ACC, XT, loc32(m))
+	case 0x4c:
+		set(IMPYAL, P, XT, loc32(m))
+	case 0x50:
+		set(MINL, ACC, loc32(m))
+	case 0x51:
+		set(MAXCUL, P, loc32(m))
+	case 0x53:
+		set(ADDUL, ACC, loc32(m))
+	case 0x57:
+		set(ADDUL, P, loc32(m))
+	case 0x59:
+		set(MINCUL, P, loc32(m))
+	case 0x65:
+		set(IMPYXUL, P, XT, loc32(m))
+	case 0x72, 0x73:
+		set(MAX, ax(lo), loc16(m))
+	case 0x74, 0x75:
+		set(MIN, ax(lo), loc16(m))
+	default:
+		set(BF, Rel(int16(w2)), conds[lo&0xf])
+	}
+	return nil
+}
+
+// op56Long reports if the 0x56 group opcode is a 32-bit instruction.
+func op56Long(lo uint8) bool {
+	switch lo {
+	case 0x01, 0x03, 0x04, 0x05, 0x06, 0x08, 0x21, 0x23, 0x27, 0x2d, 0x2f,
+		0x40, 0x41, 0x43, 0x44, 0x4c, 0x50, 0x51, 0x53, 0x57, 0x59, 0x65,
+		0x72, 0x73, 0x74, 0x75:
+		return true
+	}
+	return lo >= 0xc0 && lo < 0xd0
+}
+
+// decodeExt decodes the 32-bit FPU and VCU instructions.
+func decodeExt(in *Inst, hi, lo uint8, w2 uint32) {
+	a, b, c := fpr(w2), fpr(w2>>3), fpr(w2>>6)
+	set := func(op Op, args ...Arg) {
+		in.Op, in.Args = op, args
+	}
+
+	switch hi {
+	case 0xe2:
+		switch lo {
+		case 0x03:
+			set(MOV32, loc32(uint8(w2)), fpr(w2>>8))
+		case 0xaf:
+			set(MOV32, fpr(w2>>8), loc32(uint8(w2)))
+		case 0xc8:
+			set(VCRC8L_1, loc16(uint8(w2)))
+		case 0xc9:
+			set(VCRC8H_1, loc16(uint8(w2)))
+		case 0xca:
+			set(VCRC16P1L_1, loc16(uint8(w2)))
+		case 0xcb:
+			set(VCRC16P1H_1, loc16(uint8(w2)))
+		case 0xcc:
+			set(VCRC32L_1, loc16(uint8(w2)))
+		case 0xcd:
+			set(VCRC32H_1, loc16(uint8(w2)))
+		}
+
+	case 0xe6:
+		if w2 > 0x3f {
+			break
+		}
+		switch lo {
+		case 0x88:
+			set(I32TOF32, a, b)
+		case 0x8a:
+			set(UI32TOF32, a, b)
+		case 0x8c:
+			set(F32TOI32, a, b)
+		case 0x8e:
+			set(F32TOUI32, a, b)
+		case 0x90:
+			set(CMPF32, a, b)
+		case 0x94:
+			set(NEGF32, a, b)
+		case 0x95:
+			set(ABSF32, a, b)
+		case 0x96:
+			set(MAXF32, a, b)
+		case 0x97:
+			set(MINF32, a, b)
+		case 0xcf:
+			set(MOV32, a, b)
+		}
+
+	case 0xe7:
+		if w2 > 0x1ff {
+			break
+		}
+		switch lo {
+		case 0x00:
+			set(MPYF32, a, b, c)
+		case 0x10:
+			set(ADDF32, a, b, c)
+		case 0x20:
+			set(SUBF32, a, b, c)
+		}
+
+	case 0xe8:
+		if lo&0xf8 != 0 {
+			break
+		}
+		imm := uint32(lo&7)<<13 | w2>>3
+		set(MOVIZF32, a, Float(math.Float32frombits(imm<<16)))
+	}
+}
+
+func (i Inst) String() string {
+	var args strings.Builder
+	for n, a := range i.Args {
+		if _, ok := a.(Shift); !ok && n > 0 {
+			args.WriteString(",")
+		}
+		args.WriteString(a.String())
+	}
+	return fmt.Sprintf("%-12s %s", i.Op, args.String())
+}
@@ -23,7 +23,6 @@ const (
 	ASRL
 	B
 	BANZ
-	BAR
 	BF
 	C27MAP
 	C27OBJ
@@ -35,11 +34,9 @@ const (
 	CMP64
 	CMPB
 	CMPL
-	CMPR
 	CSB
 	DEC
 	DINT
-	DMAC
 	DMOV
 	EALLOW
 	EDIS
@@ -50,7 +47,6 @@ const (
 	FLIP
 	IACK
 	IDLE
-	IMACL
 	IMPYAL
 	IMPYL
 	IMPYSL
@@ -59,6 +55,7 @@ const (
 	INC
 	INTR
 	IRET
+	ITRAP0
 	LB
 	LC
 	LCR
@@ -85,7 +82,6 @@ const (
 	MOVA
 	MOVAD
 	MOVB
-	MOVDL
 	MOVH
 	MOVL
 	MOVP
@@ -103,62 +99,54 @@ const (
 	NASP
 	NEG
 	NEG64
-	NEGTC
 	NOP
-	NORM
 	NOT
 	OR
 	ORB
 	OUT
 	POP
-	PREAD
 	PUSH
-	PWRITE
-	QMACL
-	QMPYAL
-	QMPYL
-	QMPYSL
-	QMPYUL
-	QMPYXUL
 	ROL
 	ROR
 	RPT
 	SAT
-	SAT64
 	SB
-	SBBU
 	SBF
 	SBRK
 	SETC
 	SFR
 	SPM
-	SQRA
 	SUB
 	SUBB
-	SUBBL
-	SUBCU
-	SUBCUL
-	SUBLSUBR
-	SUBRL
+	SUBL
 	SUBU
-	SUBUL
 	TBIT
-	TCLR
 	TEST
 	TRAP
-	TSET
-	UOUT
-	XB
-	XBANZ
-	XCALL
-	XMAC
-	XMACD
 	XOR
-	XPREAD
-	XPWRITE
-	XRET
-	XRETC
-	ZALR
-	ZAP
 	ZAPA
+
+	// FPU
+	ABSF32
+	ADDF32
+	CMPF32
+	F32TOI32
+	F32TOUI32
+	I32TOF32
+	MAXF32
+	MINF32
+	MOV32
+	MOVIZF32
+	MPYF32
+	NEGF32
+	SUBF32
+	UI32TOF32
+
+	// VCU
+	VCRC8H_1
+	VCRC8L_1
+	VCRC16P1H_1
+	VCRC16P1L_1
+	VCRC32H_1
+	VCRC32L_1
 )
@@ -0,0 +1,342 @@
+package c66asm
+
+// Compact instructions are 16 bits wide and only occur in fetch packets
+// with a header. Bit 0 selects the side of the unit and bits 2:1 the format
+// group: 00 .L, 10 .D loads and stores, 01 .S and 11 the formats shared by
+// .L, .S and .D with bits 4:3 naming the unit (11 is .M). The 3-bit
+// register fields address A0-A7 or, when the header RS bit is set, A16-A23.
+// The header also supplies the load and store sizes (DSZ), whether the .S
+// encodings are branches (BR) and the saturating forms (SAT).
+
+var (
+	cl3Ops  = [2][2]Op{{ADD, SUB}, {SADD, SSUB}}
+	cl2cOps = [8]Op{AND, OR, XOR, CMPEQ, CMPLT, CMPGT, CMPLTU, CMPGTU}
+	cm3Ops  = [2][4]Op{{MPY, MPYH, MPYLH, MPYHL}, {SMPY, SMPYH, SMPYLH, SMPYHL}}
+	cs3Ops  = [2][2]Op{{ADD, SUB}, {SADD, SUB}}
+	cshOps  = [4]Op{SHL, SHR, SHRU, SSHL}
+	csc5Ops = [3]Op{EXTU, SET, CLR}
+	cunits  = [3]Unit{L1, S1, D1}
+)
+
+// compact load and store sizes, a DSZ below 4 selects the size used when
+// sz is set and word accesses otherwise, from 4 up sz clear is a
+// doubleword access to a register pair
+const (
+	cW = iota
+	cB
+	cBU
+	cH
+	cHU
+	cNW
+	cDW
+	cNDW
+)
+
+var (
+	cldSizes = [8]int{cBU, cB, cHU, cH, cW, cB, cNW, cH}
+	cldOps   = [8]Op{LDW, LDB, LDBU, LDH, LDHU, LDNW, LDDW, LDNDW}
+	cstOps   = [8]Op{STW, STB, STB, STH, STH, STNW, STDW, STNDW}
+)
+
+// creg returns the register of a 3-bit field.
+func creg(side bool, n uint32, hdr *Header) Reg {
+	if hdr.RS {
+		n += 16
+	}
+	return Reg{side, uint8(n)}
+}
+
+// decodeCompact decodes a 16-bit instruction, the formats that are not
+// recognized are returned as UNKNOWN with their encoding.
+func decodeCompact(h uint16, hdr *Header) Inst {
+	w := uint32(h)
+	in := Inst{
+		Op:      UNKNOWN,
+		Enc:     w,
+		Len:     2,
+		Compact: true,
+	}
+	if w&0x1fff == 0x0c6e {
+		in.Op = NOP
+		if n := w>>13 + 1; n > 1 {
+			in.Args = []Arg{Imm(n)}
+		}
+		return in
+	}
+
+	switch w & 6 {
+	case 0:
+		decodeCompactL(&in, w, hdr)
+	case 4:
+		decodeCompactD(&in, w, hdr)
+	case 2:
+		if hdr.BR && w&8 != 0 {
+			decodeCompactB(&in, w)
+		} else {
+			decodeCompactS(&in, w, hdr)
+		}
+	case 6:
+		if w&0x18 == 0x18 {
+			decodeCompactM(&in, w, hdr)
+		} else {
+			decodeCompactX(&in, w, hdr)
+		}
+	}
+	return in
+}
+
+// three operand fields shared by L3, S3, M3, L2c and the x2op formats
+func cfields(w uint32) (side, x bool, src1, src2 uint32) {
+	return w&1 != 0, w&(1<<12) != 0, field(w, 7, 3), field(w, 13, 3)
+}
+
+func sat(hdr *Header) int {
+	if hdr.SAT {
+		return 1
+	}
+	return 0
+}
+
+func decodeCompactL(in *Inst, w uint32, hdr *Header) {
+	side, x, src1, src2 := cfields(w)
+	other := side != x
+	in.Unit, in.Cross = unit(L1, side), x
+	switch w & 0x40e {
+	case 0x000:
+		// L3: ADD/SUB src1, src2, dst
+		in.Op = cl3Ops[sat(hdr)][field(w, 11, 1)]
+		in.Args = []Arg{creg(side, src1, hdr), creg(other, src2, hdr), creg(side, field(w, 4, 3), hdr)}
+
+	case 0x400:
+		// L3i: ADD scst4, src2, dst with a zero constant meaning 8
+		n := int32(src1)
+		if n == 0 {
+			n = 8
+		}
+		if w&(1<<11) != 0 {
+			n = -n
+		}
+		in.Op = ADD
+		in.Args = []Arg{Imm(n), creg(other, src2, hdr), creg(side, field(w, 4, 3), hdr)}
+
+	case 0x408:
+		// L2c: logical and compare operations writing A0/A1 or B0/B1
+		in.Op = cl2cOps[field(w, 11, 1)<<2|field(w, 5, 2)]
+		in.Args = []Arg{creg(side, src1, hdr), creg(other, src2, hdr), Reg{side, uint8(field(w, 4, 1))}}
+
+	default:
+		in.Unit, in.Cross = 0, false
+	}
+}
+
+func decodeCompactM(in *Inst, w uint32, hdr *Header) {
+	// M3: 16-bit multiplies, the destination is one of the first four
+	// registers of the window
+	side, x, src1, src2 := cfields(w)
+	in.Op = cm3Ops[sat(hdr)][field(w, 5, 2)]
+	in.Unit, in.Cross = unit(M1, side), x
+	in.Args = []Arg{creg(side, src1, hdr), creg(side != x, src2, hdr), creg(side, field(w, 10, 2), hdr)}
+}
+
+func decodeCompactD(in *Inst, w uint32, hdr *Header) {
+	side := w&1 != 0
+	t := w&(1<<12) != 0
+	load := w&8 != 0
+	ptr := creg(side, field(w, 7, 2)+4, hdr)
+	sz := field(w, 9, 1)
+
+	size := cW
+	switch {
+	case sz != 0:
+		size = cldSizes[hdr.DSZ&7]
+	case hdr.DSZ >= 4 && w&0x10 != 0:
+		size = cNDW
+	case hdr.DSZ >= 4:
+		size = cDW
+	}
+
+	var m Mem
+	switch {
+	case w&0x400 == 0:
+		// Doff4: *+ptr[ucst4]
+		m = Mem{1, ptr, Imm(field(w, 13, 3) | field(w, 11, 1)<<3)}
+	case w&0x800 == 0:
+		// Dind: *+ptr[src1]
+		m = Mem{5, ptr, creg(side, field(w, 13, 3), hdr)}
+	case w&0x8000 == 0:
+		// Dinc and Ddec: *ptr++[ucst0 + 1] and *--ptr[ucst0 + 1]
+		m = Mem{0xb, ptr, Imm(field(w, 13, 1) + 1)}
+		if w&0x4000 != 0 {
+			m.Mode = 8
+		}
+	default:
+		// Dstk: words on the stack at *+B15[ucst5]
+		size = cW
+		ptr = Reg{true, 15}
+		side = true
+		m = Mem{1, ptr, Imm(field(w, 13, 2) | field(w, 7, 3)<<2)}
+	}
+
+	op := cstOps[size]
+	if load {
+		op = cldOps[size]
+	}
+	var data Reg
+	if size == cDW || size == cNDW {
+		data = creg(t, field(w, 5, 2)<<1, hdr)
+	} else {
+		data = creg(t, field(w, 4, 3), hdr)
+	}
+
+	in.Op, in.Unit, in.Path = op, unit(D1, side), 1
+	if t {
+		in.Path = 2
+	}
+	in.Args = ldst(op, m, data)
+}
+
+// decodeCompactB decodes the .S encodings used as branches when the header
+// BR bit is set, displacements count half words. An 8-bit displacement
+// replaces the NOP count and uses the B side when bits 15:14 are set.
+func decodeCompactB(in *Inst, w uint32) {
+	side := w&1 != 0
+	n := Imm(field(w, 13, 3))
+	disp := Disp(sext(field(w, 6, 7), 7) << 1)
+	if w&0xc000 == 0xc000 {
+		side, n = true, 5
+		disp = Disp(field(w, 6, 8) << 1)
+	}
+
+	switch {
+	case w&0x30 == 0x00:
+		// Sbs7 and Sbu8
+		in.Op, in.Unit = BNOP, unit(S1, side)
+		in.Args = []Arg{disp, n}
+
+	case w&0x30 == 0x10:
+		// Scs10: CALLP scst10, A3 or B3
+		side = w&1 != 0
+		in.Op, in.Unit = CALLP, unit(S1, side)
+		in.Args = []Arg{Disp(sext(field(w, 6, 10), 10) << 1), Reg{side, 3}}
+
+	default:
+		// Sbs7c and Sbu8c: BNOP predicated on A0 or B0
+		in.Op, in.Unit = BNOP, unit(S1, side)
+		in.Cond = Cond{Reg: 6, Zero: w&0x10 != 0}
+		if side {
+			in.Cond.Reg = 1
+		}
+		in.Args = []Arg{disp, n}
+	}
+}
+
+func decodeCompactS(in *Inst, w uint32, hdr *Header) {
+	side, x, src1, src2 := cfields(w)
+	other := side != x
+	ucst5 := Imm(field(w, 7, 3) | field(w, 11, 2)<<3)
+	in.Unit = unit(S1, side)
+	switch {
+	case w&0x40e == 0x00a:
+		// S3: ADD/SUB src1, src2, dst
+		in.Op, in.Cross = cs3Ops[sat(hdr)][field(w, 11, 1)], x
+		in.Args = []Arg{creg(side, src1, hdr), creg(other, src2, hdr), creg(side, field(w, 4, 3), hdr)}
+
+	case w&0x40e == 0x40a:
+		// S3i: SHL/SHR src2, ucst3, dst with a zero shift meaning 8
+		n := src1
+		if n == 0 {
+			n = 8
+		}
+		in.Op, in.Cross = [2]Op{SHL, SHR}[field(w, 11, 1)], x
+		in.Args = []Arg{creg(other, src2, hdr), Imm(n), creg(side, field(w, 4, 3), hdr)}
+
+	case w&0x1e == 0x12:
+		// Smvk8: MVK ucst8, dst
+		n := field(w, 13, 3) | field(w, 11, 2)<<3 | field(w, 5, 2)<<5 | field(w, 10, 1)<<7
+		in.Op = MVK
+		in.Args = []Arg{Imm(n), creg(side, src1, hdr)}
+
+	case w&0x47e == 0x462:
+		// S2sh: shifts of src2/dst by a register
+		r := creg(side, src2, hdr)
+		in.Op = cshOps[field(w, 11, 2)]
+		in.Args = []Arg{r, creg(side, src1, hdr), r}
+
+	case w&0x41e == 0x402:
+		// Ssh5: shifts of src2/dst by ucst5
+		r := creg(side, src2, hdr)
+		in.Op = cshOps[field(w, 5, 2)]
+		if in.Op == SHL && hdr.SAT {
+			in.Op = SSHL
+		}
+		in.Args = []Arg{r, ucst5, r}
+
+	case w&0x47e == 0x062:
+		// S2ext: sign or zero extend the low byte or half word
+		n := Imm(16)
+		if w&0x800 != 0 {
+			n = 24
+		}
+		in.Op = [2]Op{EXT, EXTU}[field(w, 12, 1)]
+		in.Args = []Arg{creg(side, src2, hdr), n, n, creg(side, src1, hdr)}
+
+	case w&0x41e == 0x002:
+		// Sc5: EXTU src2, ucst5, 31, A0/B0 or SET/CLR a bit of src2/dst
+		r := creg(side, src2, hdr)
+		in.Op = csc5Ops[field(w, 5, 2)]
+		in.Args = []Arg{r, ucst5, ucst5, r}
+		if in.Op == EXTU {
+			in.Args = []Arg{r, ucst5, Imm(31), Reg{side, 0}}
+		}
+
+	default:
+		in.Unit = 0
+	}
+}
+
+// decodeCompactX decodes the formats shared by .L, .S and .D, bits 4:3 are
+// the unit and bits 6:5 the kind of operation.
+func decodeCompactX(in *Inst, w uint32, hdr *Header) {
+	side, x, src1, src2 := cfields(w)
+	other := side != x
+	u := field(w, 3, 2)
+	in.Unit = unit(cunits[u], side)
+	switch field(w, 5, 2) {
+	case 0:
+		// LSDmvto: MV from any register to the register window
+		in.Op, in.Cross = MV, x
+		in.Args = []Arg{Reg{other, uint8(src2 | field(w, 10, 2)<<3)}, creg(side, src1, hdr)}
+
+	case 2:
+		// LSDmvfr: MV from the register window to any register
+		in.Op, in.Cross = MV, x
+		in.Args = []Arg{creg(other, src1, hdr), Reg{side, uint8(src2 | field(w, 10, 2)<<3)}}
+
+	case 1:
+		ucst5 := Imm(field(w, 13, 3) | field(w, 11, 2)<<3)
+		dst := creg(side, src1, hdr)
+		switch {
+		case w&0x400 == 0 && u != 0:
+			// Sx2op and Dx2op: ADD/SUB src2, src1/dst
+			in.Op, in.Cross = [2]Op{ADD, SUB}[field(w, 11, 1)], x
+			in.Args = []Arg{creg(other, src2, hdr), dst, dst}
+		case w&0x400 == 0:
+			in.Unit = 0
+		case u == 0:
+			// Lx5: MVK scst5, dst
+			in.Op = MVK
+			in.Args = []Arg{Imm(sext(uint32(ucst5), 5)), dst}
+		case u == 1:
+			// Sx5: ADDK ucst5, dst
+			in.Op = ADDK
+			in.Args = []Arg{ucst5, dst}
+		default:
+			// Dx5: ADDAW B15, ucst5, dst
+			in.Op, in.Unit = ADDAW, D2
+			in.Args = []Arg{Reg{true, 15}, ucst5, dst}
+		}
+
+	default:
+		in.Unit = 0
+	}
+}
@@ -0,0 +1,490 @@
+package c66asm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrShortInst = errors.New("short instruction")
+)
+
+type Unit uint8
+
+const (
+	L1 Unit = iota + 1
+	L2
+	S1
+	S2
+	M1
+	M2
+	D1
+	D2
+)
+
+func (u Unit) String() string {
+	switch u {
+	case L1, L2:
+		return fmt.Sprintf("L%d", u-L1+1)
+	case S1, S2:
+		return fmt.Sprintf("S%d", u-S1+1)
+	case M1, M2:
+		return fmt.Sprintf("M%d", u-M1+1)
+	case D1, D2:
+		return fmt.Sprintf("D%d", u-D1+1)
+	}
+	return ""
+}
+
+// Inst is a decoded instruction. Parallel is the p-bit, when set the next
+// instruction of the execute packet runs in parallel with this one. Path is
+// the data path (1 or 2) of a load or store.
+type Inst struct {
+	Op       Op
+	Enc      uint32
+	Len      int
+	Unit     Unit
+	Cross    bool
+	Path     uint8
+	Parallel bool
+	Compact  bool
+	Cond     Cond
+	Args     []Arg
+}
+
+type Arg interface {
+	String() string
+}
+
+// Reg is a general purpose register of the A (B false) or B side.
+type Reg struct {
+	B bool
+	N uint8
+}
+
+func (r Reg) String() string {
+	if r.B {
+		return fmt.Sprintf("B%d", r.N)
+	}
+	return fmt.Sprintf("A%d", r.N)
+}
+
+// RegPair is a 64-bit register pair, N is the even register.
+type RegPair Reg
+
+func (r RegPair) String() string {
+	hi := Reg{r.B, r.N | 1}
+	return hi.String() + ":" + Reg(r).String()
+}
+
+type CtrlReg uint8
+
+var ctrlRegs = map[CtrlReg]string{
+	0x00: "AMR", 0x01: "CSR", 0x02: "ISR", 0x03: "ICR", 0x04: "IER",
+	0x05: "ISTP", 0x06: "IRP", 0x07: "NRP", 0x0a: "TSCL", 0x0b: "TSCH",
+	0x0d: "ILC", 0x0e: "RILC", 0x0f: "REP", 0x10: "PCE1", 0x11: "DNUM",
+	0x15: "SSR", 0x16: "GPLYA", 0x17: "GPLYB", 0x18: "GFPGFR", 0x19: "DIER",
+	0x1a: "TSR", 0x1b: "ITSR", 0x1c: "NTSR", 0x1d: "EFR", 0x1f: "IERR",
+}
+
+func (c CtrlReg) String() string {
+	if s, ok := ctrlRegs[c]; ok {
+		return s
+	}
+	return fmt.Sprintf("CR%d", uint8(c))
+}
+
+type Imm int32
+
+func (i Imm) String() string {
+	switch {
+	case -10 < i && i < 10:
+		return fmt.Sprintf("%d", int32(i))
+	case i < 0:
+		return fmt.Sprintf("-0x%x", -int64(i))
+	}
+	return fmt.Sprintf("0x%x", int32(i))
+}
+
+// Disp is a branch displacement in bytes relative to the address of the
+// fetch packet containing the branch.
+type Disp int32
+
+func (d Disp) String() string {
+	return fmt.Sprintf("PCE1%+#x", int32(d))
+}
+
+// Mem is a load or store address, Mode is the 4-bit addressing mode field
+// and Off an Imm index scaled by the access size or an offset register.
+type Mem struct {
+	Mode uint8
+	Base Reg
+	Off  Arg
+}
+
+func (m Mem) String() string {
+	var pre, post string
+	switch m.Mode &^ 4 {
+	case 0x0:
+		pre = "-"
+	case 0x1:
+		pre = "+"
+	case 0x8:
+		pre = "--"
+	case 0x9:
+		pre = "++"
+	case 0xa:
+		post = "--"
+	case 0xb:
+		post = "++"
+	}
+	return fmt.Sprintf("*%s%v%s[%v]", pre, m.Base, post, m.Off)
+}
+
+// Cond is the predicate of an instruction, the zero value is unconditional.
+type Cond struct {
+	Reg  uint8
+	Zero bool
+}
+
+var condRegs = [...]string{"", "B0", "B1", "B2", "A1", "A2", "A0", ""}
+
+func (c Cond) String() string {
+	if c.Reg == 0 || c.Reg == 7 {
+		return ""
+	}
+	if c.Zero {
+		return "[!" + condRegs[c.Reg] + "]"
+	}
+	return "[" + condRegs[c.Reg] + "]"
+}
+
+// operand kinds of the tables: r register, x register on the cross path
+// side, l register pair, c scst5, u ucst5, _ unused. A trailing s prints
+// src2 before src1.
+type form struct {
+	op   Op
+	args string
+}
+
+var lOps = map[uint32]form{
+	0x03: {ADD, "rxr"}, 0x23: {ADD, "rxl"}, 0x21: {ADD, "xll"}, 0x02: {ADD, "cxr"}, 0x20: {ADD, "cll"},
+	0x2b: {ADDU, "rxl"}, 0x29: {ADDU, "xll"},
+	0x07: {SUB, "rxr"}, 0x06: {SUB, "cxr"}, 0x27: {SUB, "rxl"}, 0x24: {SUB, "cll"},
+	0x2f: {SUBU, "rxl"},
+	0x7b: {AND, "rxr"}, 0x7a: {AND, "cxr"},
+	0x7f: {OR, "rxr"}, 0x7e: {OR, "cxr"},
+	0x6f: {XOR, "rxr"}, 0x6e: {XOR, "cxr"},
+	0x53: {CMPEQ, "rxr"}, 0x52: {CMPEQ, "cxr"}, 0x51: {CMPEQ, "xlr"}, 0x50: {CMPEQ, "clr"},
+	0x47: {CMPGT, "rxr"}, 0x46: {CMPGT, "cxr"},
+	0x4f: {CMPGTU, "rxr"}, 0x4e: {CMPGTU, "uxr"},
+	0x57: {CMPLT, "rxr"}, 0x56: {CMPLT, "cxr"},
+	0x5f: {CMPLTU, "rxr"}, 0x5e: {CMPLTU, "uxr"},
+	0x1a: {ABS, "_xr"}, 0x38: {ABS, "_ll"},
+	0x63: {NORM, "_xr"}, 0x60: {NORM, "_lr"},
+	0x6b: {LMBD, "rxr"}, 0x6a: {LMBD, "cxr"},
+	0x13: {SADD, "rxr"}, 0x31: {SADD, "xll"}, 0x12: {SADD, "cxr"},
+	0x0f: {SSUB, "rxr"}, 0x0e: {SSUB, "cxr"},
+	0x40: {SAT, "_lr"},
+	0x05: {ADD2, "rxr"}, 0x65: {ADD4, "rxr"}, 0x04: {SUB2, "rxr"}, 0x66: {SUB4, "rxr"},
+	0x42: {MAX2, "rxr"}, 0x41: {MIN2, "rxr"}, 0x43: {MAXU4, "rxr"}, 0x48: {MINU4, "rxr"},
+	0x00: {PACK2, "rxr"}, 0x1e: {PACKH2, "rxr"}, 0x1b: {PACKLH2, "rxr"}, 0x1c: {PACKHL2, "rxr"},
+}
+
+var sOps = map[uint32]form{
+	0x07: {ADD, "rxr"}, 0x06: {ADD, "cxr"},
+	0x17: {SUB, "rxr"}, 0x16: {SUB, "cxr"},
+	0x1f: {AND, "rxr"}, 0x1e: {AND, "cxr"},
+	0x1b: {OR, "rxr"}, 0x1a: {OR, "cxr"},
+	0x0b: {XOR, "rxr"}, 0x0a: {XOR, "cxr"},
+	0x33: {SHL, "rxrs"}, 0x32: {SHL, "uxrs"}, 0x31: {SHL, "rlls"}, 0x30: {SHL, "ulls"}, 0x13: {SHL, "rxls"}, 0x12: {SHL, "uxls"},
+	0x37: {SHR, "rxrs"}, 0x36: {SHR, "uxrs"}, 0x35: {SHR, "rlls"}, 0x34: {SHR, "ulls"},
+	0x27: {SHRU, "rxrs"}, 0x26: {SHRU, "uxrs"}, 0x25: {SHRU, "rlls"}, 0x24: {SHRU, "ulls"},
+	0x23: {SSHL, "rxrs"}, 0x22: {SSHL, "uxrs"},
+	0x2f: {EXT, "rxrs"}, 0x2b: {EXTU, "rxrs"}, 0x3b: {SET, "rxrs"}, 0x3f: {CLR, "rxrs"},
+	0x01: {ADD2, "rxr"}, 0x11: {SUB2, "rxr"},
+}
+
+var mOps = map[uint32]form{
+	0x19: {MPY, "rxr"}, 0x18: {MPY, "cxr"},
+	0x1f: {MPYU, "rxr"}, 0x1d: {MPYUS, "rxr"}, 0x1b: {MPYSU, "rxr"}, 0x1e: {MPYSU, "cxr"},
+	0x01: {MPYH, "rxr"}, 0x07: {MPYHU, "rxr"}, 0x05: {MPYHUS, "rxr"}, 0x03: {MPYHSU, "rxr"},
+	0x09: {MPYHL, "rxr"}, 0x0f: {MPYHLU, "rxr"}, 0x0d: {MPYHULS, "rxr"}, 0x0b: {MPYHSLU, "rxr"},
+	0x11: {MPYLH, "rxr"}, 0x17: {MPYLHU, "rxr"}, 0x15: {MPYLUHS, "rxr"}, 0x13: {MPYLSHU, "rxr"},
+	0x1a: {SMPY, "rxr"}, 0x0a: {SMPYHL, "rxr"}, 0x12: {SMPYLH, "rxr"}, 0x02: {SMPYH, "rxr"},
+}
+
+// C64x+ .M operations with the extended opcode field
+var mxOps = map[uint32]form{
+	0x10: {MPY32, "rxr"}, 0x00: {MPY2, "rxl"}, 0x0c: {DOTP2, "rxr"},
+}
+
+var dOps = map[uint32]form{
+	0x10: {ADD, "rrrs"}, 0x12: {ADD, "urrs"}, 0x11: {SUB, "rrrs"}, 0x13: {SUB, "urrs"},
+	0x30: {ADDAB, "rrrs"}, 0x32: {ADDAB, "urrs"},
+	0x34: {ADDAH, "rrrs"}, 0x36: {ADDAH, "urrs"},
+	0x38: {ADDAW, "rrrs"}, 0x3a: {ADDAW, "urrs"},
+	0x31: {SUBAB, "rrrs"}, 0x33: {SUBAB, "urrs"},
+	0x35: {SUBAH, "rrrs"}, 0x37: {SUBAH, "urrs"},
+	0x39: {SUBAW, "rrrs"}, 0x3b: {SUBAW, "urrs"},
+}
+
+// load and store operations indexed by r<<3 | op
+var ldstOps = [16]Op{
+	LDHU, LDBU, LDB, STB, LDH, STH, LDW, STW,
+	0, 0, LDNDW, LDNW, STDW, STNW, LDDW, STNDW,
+}
+
+func sext(v uint32, bits uint) int32 {
+	return int32(v<<(32-bits)) >> (32 - bits)
+}
+
+func field(w uint32, lo, n uint) uint32 {
+	return (w >> lo) & (1<<n - 1)
+}
+
+// operands builds the arguments of a three operand table entry.
+func (f form) operands(side bool, x bool, src1, src2, dst uint32) []Arg {
+	other := side != x
+	arg := func(k byte, n uint32) Arg {
+		switch k {
+		case 'r':
+			return Reg{side, uint8(n)}
+		case 'x':
+			return Reg{other, uint8(n)}
+		case 'l':
+			return RegPair{side, uint8(n)}
+		case 'c':
+			return Imm(sext(n, 5))
+		case 'u':
+			return Imm(n)
+		}
+		return nil
+	}
+
+	a1, a2 := arg(f.args[0], src1), arg(f.args[1], src2)
+	if len(f.args) > 3 && f.args[3] == 's' {
+		a1, a2 = a2, a1
+	}
+	var args []Arg
+	for _, a := range []Arg{a1, a2} {
+		if a != nil {
+			args = append(args, a)
+		}
+	}
+	return append(args, arg(f.args[2], dst))
+}
+
+func unit(u Unit, side bool) Unit {
+	if side {
+		return u + 1
+	}
+	return u
+}
+
+// Decode decodes a 32-bit instruction, compact instructions are only
+// recognized inside a fetch packet by DecodePacket.
+func Decode(src []byte) (Inst, error) {
+	if len(src) < 4 {
+		return Inst{}, ErrShortInst
+	}
+
+	w := binary.LittleEndian.Uint32(src)
+	in := Inst{
+		Op:       UNKNOWN,
+		Enc:      w,
+		Len:      4,
+		Parallel: w&1 != 0,
+		Cond:     Cond{Reg: uint8(w >> 29), Zero: w&(1<<28) != 0},
+	}
+	side := w&2 != 0
+	dst, src2, src1 := field(w, 23, 5), field(w, 18, 5), field(w, 13, 5)
+	x := w&(1<<12) != 0
+
+	table := func(u Unit, m map[uint32]form, op uint32, cross bool) {
+		f, ok := m[op]
+		if !ok {
+			return
+		}
+		in.Op, in.Unit, in.Cross = f.op, unit(u, side), cross && x
+		in.Args = f.operands(side, cross && x, src1, src2, dst)
+	}
+
+	switch {
+	case w&^(0xf<<13|1) == 0:
+		in.Op = NOP
+		if n := field(w, 13, 4); n == 0xf {
+			in.Op = IDLE
+		} else if n != 0 {
+			in.Args = []Arg{Imm(n + 1)}
+		}
+
+	case w&0xc == 0x4:
+		// load/store with a register base
+		y := w&0x80 != 0
+		op := ldstOps[field(w, 8, 1)<<3|field(w, 4, 3)]
+		if op == 0 {
+			break
+		}
+		mode := uint8(field(w, 9, 4))
+		var off Arg = Imm(src1)
+		if mode&4 != 0 {
+			off = Reg{y, uint8(src1)}
+		}
+		in.Op, in.Unit, in.Path = op, unit(D1, y), 1
+		if side {
+			in.Path = 2
+		}
+		in.Args = ldst(op, Mem{mode, Reg{y, uint8(src2)}, off}, Reg{side, uint8(dst)})
+
+	case w&0xc == 0xc:
+		// load/store with a 15-bit offset from B14 or B15
+		op := ldstOps[field(w, 4, 3)]
+		base := Reg{true, 14}
+		if w&0x80 != 0 {
+			base.N = 15
+		}
+		in.Op, in.Unit, in.Path = op, D2, 1
+		if side {
+			in.Path = 2
+		}
+		in.Args = ldst(op, Mem{1, base, Imm(field(w, 8, 15))}, Reg{side, uint8(dst)})
+
+	case w&0x1c == 0x18:
+		table(L1, lOps, field(w, 5, 7), true)
+		if in.Op == ADD && field(w, 5, 7) == 0x02 && src1 == 0 {
+			in.Op, in.Args = MV, in.Args[1:]
+		}
+
+	case w&0x7c == 0x00:
+		table(M1, mOps, field(w, 7, 5), true)
+
+	case w&0x3c == 0x30 && w&0x800 == 0:
+		table(M1, mxOps, field(w, 6, 5), true)
+
+	case w&0x7c == 0x40:
+		table(D1, dOps, field(w, 7, 6), false)
+		if in.Op == ADD && field(w, 7, 6) == 0x12 && src1 == 0 {
+			in.Op, in.Args = MV, []Arg{in.Args[0], in.Args[2]}
+		}
+
+	case w&0x1ffc == 0x120:
+		in.Op, in.Unit = BNOP, unit(S1, side)
+		in.Args = []Arg{Disp(sext(field(w, 16, 12), 12) << 2), Imm(src1)}
+
+	case w&0x1ffc == 0x160:
+		in.Op, in.Unit = ADDKPC, unit(S1, side)
+		in.Args = []Arg{Disp(sext(field(w, 16, 7), 7) << 2), Reg{side, uint8(dst)}, Imm(src1)}
+
+	case w&0x3c == 0x20:
+		decodeS(&in, w, side, x, src1, src2, dst)
+		if in.Op == 0 {
+			table(S1, sOps, field(w, 6, 6), true)
+		}
+		if in.Op == ADD && field(w, 6, 6) == 0x06 && src1 == 0 {
+			in.Op, in.Args = MV, in.Args[1:]
+		}
+
+	case w&0x3c == 0x28:
+		in.Op, in.Unit = MVK, unit(S1, side)
+		cst := field(w, 7, 16)
+		in.Args = []Arg{Imm(sext(cst, 16)), Reg{side, uint8(dst)}}
+		if w&0x40 != 0 {
+			in.Op = MVKH
+			in.Args[0] = Imm(cst << 16)
+		}
+
+	case w&0x7c == 0x50:
+		in.Op, in.Unit = ADDK, unit(S1, side)
+		in.Args = []Arg{Imm(sext(field(w, 7, 16), 16)), Reg{side, uint8(dst)}}
+
+	case w&0x7c == 0x10:
+		in.Op, in.Unit = B, unit(S1, side)
+		in.Args = []Arg{Disp(sext(field(w, 7, 21), 21) << 2)}
+		if in.Cond.Reg == 0 && in.Cond.Zero {
+			in.Op, in.Cond = CALLP, Cond{}
+			in.Args = append(in.Args, Reg{side, 3})
+		}
+
+	case w&0x3c == 0x08:
+		// bit field operations with constant positions
+		op := [...]Op{EXTU, EXT, SET, CLR}[field(w, 6, 2)]
+		in.Op, in.Unit = op, unit(S1, side)
+		in.Args = []Arg{Reg{side, uint8(src2)}, Imm(src1), Imm(field(w, 8, 5)), Reg{side, uint8(dst)}}
+	}
+
+	return in, nil
+}
+
+// decodeS handles the .S operations that do not fit the three operand table.
+func decodeS(in *Inst, w uint32, side, x bool, src1, src2, dst uint32) {
+	other := side != x
+	switch field(w, 6, 6) {
+	case 0x0d:
+		in.Op, in.Unit, in.Cross = B, S2, x
+		in.Args = []Arg{Reg{other, uint8(src2)}}
+	case 0x03:
+		switch src2 {
+		case 6:
+			in.Op, in.Unit = B, S2
+			in.Args = []Arg{CtrlReg(6)}
+		case 7:
+			in.Op, in.Unit = B, S2
+			in.Args = []Arg{CtrlReg(7)}
+		}
+	case 0x0e:
+		in.Op, in.Unit, in.Cross = MVC, S2, x
+		in.Args = []Arg{Reg{other, uint8(src2)}, CtrlReg(dst)}
+	case 0x0f:
+		in.Op, in.Unit = MVC, S2
+		in.Args = []Arg{CtrlReg(src2), Reg{true, uint8(dst)}}
+	}
+}
+
+// ldst orders the operands of a load or store, doubleword accesses use a
+// register pair.
+func ldst(op Op, m Mem, r Reg) []Arg {
+	var data Arg = r
+	switch op {
+	case LDDW, LDNDW, STDW, STNDW:
+		data = RegPair{r.B, r.N &^ 1}
+	}
+	switch op {
+	case STB, STH, STW, STDW, STNW, STNDW:
+		return []Arg{data, m}
+	}
+	return []Arg{m, data}
+}
+
+func (i Inst) String() string {
+	return i.format(0, false)
+}
+
+func (i Inst) format(pce uint32, abs bool) string {
+	var args []string
+	for _, a := range i.Args {
+		if d, ok := a.(Disp); ok && abs {
+			args = append(args, fmt.Sprintf("0x%08x", pce+uint32(d)))
+			continue
+		}
+		args = append(args, a.String())
+	}
+
+	op := i.Op.String()
+	if i.Unit != 0 {
+		op += " ." + i.Unit.String()
+		if i.Path != 0 {
+			op += fmt.Sprintf("T%d", i.Path)
+		}
+		if i.Cross {
+			op += "X"
+		}
+	}
+	s := strings.TrimSpace(fmt.Sprintf("%-10s %s", op, strings.Join(args, ",")))
+	if c := i.Cond.String(); c != "" {
+		s = c + " " + s
+	}
+	return s
+}
@@ -162,8 +162,10 @@ const (
 	INTSPU
 	LAND
 	LANDN
+	LDB
 	LDBU
 	LDDW
+	LDH
 	LDHU
 	LDNDW
 	LDNW
@@ -189,7 +191,7 @@ const (
 	MPYHL
 	MPYHLU
 	MPYHSLU
-	HPYHSU
+	MPYHSU
 	MPYHU
 	MPYHULS
 	MPYHUS
@@ -0,0 +1,130 @@
+package c66asm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+const PACKET_SIZE = 32
+
+// Header is the fetch packet header stored in the last word of a packet
+// containing compact instructions. Bit i of Layout is set when word i holds
+// two compact instructions and bit i of PBits is the p-bit of half word i.
+type Header struct {
+	Layout uint8
+	PBits  uint16
+	PROT   bool
+	RS     bool
+	DSZ    uint8
+	BR     bool
+	SAT    bool
+}
+
+func (h *Header) String() string {
+	flag := func(b bool, t, f string) string {
+		if b {
+			return t
+		}
+		return f
+	}
+	return fmt.Sprintf(".fphead %s, %s, %03b, %s, %s, %07b",
+		flag(h.PROT, "p", "n"), flag(h.RS, "h", "l"), h.DSZ,
+		flag(h.BR, "br", "nobr"), flag(h.SAT, "sat", "nosat"), h.Layout)
+}
+
+// Packet is a decoded fetch packet, the header word is not part of Insts.
+type Packet struct {
+	Header *Header
+	Insts  []Inst
+}
+
+func decodeHeader(w uint32) *Header {
+	if w>>28 != 0xe {
+		return nil
+	}
+	return &Header{
+		Layout: uint8(field(w, 21, 7)),
+		PBits:  uint16(field(w, 0, 14)),
+		PROT:   w&(1<<20) != 0,
+		RS:     w&(1<<19) != 0,
+		DSZ:    uint8(field(w, 16, 3)),
+		BR:     w&(1<<15) != 0,
+		SAT:    w&(1<<14) != 0,
+	}
+}
+
+// DecodePacket decodes a 32-byte fetch packet, splitting the words marked
+// by the header layout into compact instructions.
+func DecodePacket(src []byte) (*Packet, error) {
+	if len(src) < PACKET_SIZE {
+		return nil, ErrShortInst
+	}
+
+	p := &Packet{
+		Header: decodeHeader(binary.LittleEndian.Uint32(src[28:])),
+	}
+	n := 8
+	if p.Header != nil {
+		n = 7
+	}
+	for i := 0; i < n; i++ {
+		b := src[i*4:]
+		if p.Header == nil || p.Header.Layout&(1<<i) == 0 {
+			in, err := Decode(b)
+			if err != nil {
+				return nil, err
+			}
+			p.Insts = append(p.Insts, in)
+			continue
+		}
+
+		for j := 0; j < 2; j++ {
+			h := binary.LittleEndian.Uint16(b[j*2:])
+			in := decodeCompact(h, p.Header)
+			in.Parallel = p.Header.PBits&(1<<(i*2+j)) != 0
+			p.Insts = append(p.Insts, in)
+		}
+	}
+	return p, nil
+}
+
+// Listing disassembles the packet at an address, instructions executing in
+// parallel with the previous one are prefixed by ||.
+func (p *Packet) Listing(addr uint32) string {
+	var b strings.Builder
+	off := uint32(0)
+	par := false
+	for _, in := range p.Insts {
+		enc := fmt.Sprintf("%08x", in.Enc)
+		if in.Compact {
+			enc = fmt.Sprintf("%04x    ", in.Enc)
+		}
+		prefix := "  "
+		if par {
+			prefix = "||"
+		}
+		fmt.Fprintf(&b, "%08x   %s %s %s\n", addr+off, enc, prefix, in.format(addr, true))
+		off += uint32(in.Len)
+		par = in.Parallel
+	}
+	if p.Header != nil {
+		fmt.Fprintf(&b, "%08x   %08x    %v\n", addr+28, p.encodeHeader(), p.Header)
+	}
+	return b.String()
+}
+
+func (p *Packet) encodeHeader() uint32 {
+	h := p.Header
+	w := uint32(0xe)<<28 | uint32(h.Layout)<<21 | uint32(h.DSZ)<<16 | uint32(h.PBits)
+	bits := []struct {
+		set bool
+		bit uint
+	}{{h.PROT, 20}, {h.RS, 19}, {h.BR, 15}, {h.SAT, 14}}
+	for _, b := range bits {
+		if b.set {
+			w |= 1 << b.bit
+		}
+	}
+	return w
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"math"
 	"os"
+
+	"github.com/qeedquan/go-media/debug"
 )
 
 const (
@@ -149,6 +151,9 @@ const (
 )
 
 const (
+	R_MTABS     = 0x0000
+	R_MTRELBYTE = 0x000f
+	R_MTRELWORD = 0x0010
 	R_MTRELLONG = 0x0011
 	R_MTPCR23H  = 0x0061
 	R_MTPCR24W  = 0x0017
@@ -160,6 +165,15 @@ const (
 	N_DEBUG = 2
 )
 
+const (
+	DT_NON = 0
+	DT_PTR = 1
+	DT_FCN = 2
+	DT_ARY = 3
+)
+
+const SYMBOL_SIZE = 18
+
 const (
 	C_EFCN    = -1
 	C_NULL    = 0
@@ -228,6 +242,7 @@ type Section struct {
 	SectionHeader
 	Data   []byte
 	Relocs []Reloc
+	Lines  []Line
 }
 
 type SectionHeader struct {
@@ -294,13 +309,9 @@ type Reloc1 struct {
 	Type        uint16
 }
 
-type Reloc2 struct {
-	VirtAddr    uint32
-	SymbolIndex uint16
-	_           [2]byte
-	Type        uint16
-}
-
+// Symbol is an entry of the symbol table, the auxiliary entries following a
+// symbol are kept as entries of their own with only Raw set so that the
+// indices match the ones used by relocations and line numbers.
 type Symbol struct {
 	Name    string
 	NameOff int64
@@ -309,6 +320,7 @@ type Symbol struct {
 	Type    uint16
 	Class   uint8
 	Aux     uint8
+	Raw     [SYMBOL_SIZE]byte
 }
 
 type Symbol1 struct {
@@ -390,7 +402,7 @@ func NewFile(r io.ReaderAt) (*File, error) {
 
 	for i, s := range f.Sections {
 		if s.DataOff != 0 {
-			s.Data = make([]byte, s.Size)
+			s.Data = make([]byte, s.Size*f.Unit())
 			sr = io.NewSectionReader(r, int64(s.DataOff), math.MaxUint32)
 			err = binary.Read(sr, binary.LittleEndian, s.Data)
 			if err != nil {
@@ -401,40 +413,47 @@ func NewFile(r io.ReaderAt) (*File, error) {
 		if s.RelocOff != 0 {
 			sr = io.NewSectionReader(r, int64(s.RelocOff), math.MaxUint32)
 			for j := uint32(0); j < s.NumRelocs; j++ {
-				switch f.Version {
-				case 0xc1:
-					var rl Reloc1
-					err = binary.Read(sr, binary.LittleEndian, &rl)
-					s.Relocs = append(s.Relocs, Reloc{
-						VirtAddr:    uint64(rl.VirtAddr),
-						SymbolIndex: uint64(rl.SymbolIndex),
-						Extended:    rl.Extended,
-						Type:        rl.Type,
-					})
-
-				default:
-					var rl Reloc2
-					err = binary.Read(sr, binary.LittleEndian, &rl)
-					s.Relocs = append(s.Relocs, Reloc{
-						VirtAddr:    uint64(rl.VirtAddr),
-						SymbolIndex: uint64(rl.SymbolIndex),
-						Type:        rl.Type,
-					})
-				}
+				var rl Reloc1
+				err = binary.Read(sr, binary.LittleEndian, &rl)
 				if err != nil {
 					return nil, fmt.Errorf("coff: failed to read section %d relocation entry %d: %v", i+1, j+1, err)
 				}
+				s.Relocs = append(s.Relocs, Reloc{
+					VirtAddr:    uint64(rl.VirtAddr),
+					SymbolIndex: uint64(rl.SymbolIndex),
+					Extended:    rl.Extended,
+					Type:        rl.Type,
+				})
+			}
+		}
+
+		if s.LineOff != 0 {
+			sr = io.NewSectionReader(r, int64(s.LineOff), math.MaxUint32)
+			s.Lines = make([]Line, s.NumLines)
+			err = binary.Read(sr, binary.LittleEndian, s.Lines)
+			if err != nil {
+				return nil, fmt.Errorf("coff: failed to read section %d line numbers: %v", i+1, err)
 			}
 		}
 	}
 
 	sr = io.NewSectionReader(r, int64(f.SymbolOff), math.MaxUint32)
+	aux := 0
 	for i := uint32(0); i < f.NumSymbols; i++ {
-		var y Symbol1
-		err = binary.Read(sr, binary.LittleEndian, &y)
+		var raw [SYMBOL_SIZE]byte
+		_, err = io.ReadFull(sr, raw[:])
 		if err != nil {
 			return nil, fmt.Errorf("coff: failed to read symbol %d: %v", i+1, err)
 		}
+		if aux > 0 {
+			f.Symbols = append(f.Symbols, &Symbol{Raw: raw})
+			aux--
+			continue
+		}
+
+		var y Symbol1
+		binary.Read(bytes.NewReader(raw[:]), binary.LittleEndian, &y)
+		aux = int(y.Aux)
 
 		name := ""
 		nameoff := f.nameoff(y.Name[:])
@@ -450,6 +469,7 @@ func NewFile(r io.ReaderAt) (*File, error) {
 			Type:    y.Type,
 			Class:   y.Class,
 			Aux:     y.Aux,
+			Raw:     raw,
 		})
 	}
 
@@ -478,6 +498,16 @@ func NewFile(r io.ReaderAt) (*File, error) {
 	return f, nil
 }
 
+// Unit returns the size in bytes of an addressable unit, the word
+// addressed targets count section sizes and addresses in 16-bit words.
+func (f *File) Unit() uint32 {
+	switch f.TargetID {
+	case TMS320C2800, TMS320C5400:
+		return 2
+	}
+	return 1
+}
+
 func (f *File) nameoff(p []byte) int64 {
 	z := binary.LittleEndian.Uint32(p[0:])
 	o := binary.LittleEndian.Uint32(p[4:])
@@ -558,6 +588,18 @@ func (f *File) LookupSymbol(name string) *Symbol {
 	return nil
 }
 
+// FindStrings finds the strings of the sections with contents, the address of
+// a string is counted in addressable units.
+func (f *File) FindStrings(opt *debug.StringOptions) []debug.String {
+	var tab []debug.String
+	for _, s := range f.Sections {
+		if s.Data != nil {
+			tab = append(tab, debug.FindSectionStrings(s.Name, uint64(s.VirtAddr), int(f.Unit()), s.Data, opt)...)
+		}
+	}
+	return tab
+}
+
 type TargetID uint16
 
 func (t TargetID) String() string {
@@ -627,7 +669,15 @@ func Format(f *File, w io.Writer) (n int, err error) {
 		binary.Write(b, binary.LittleEndian, s.Data)
 	}
 
+	aux := 0
 	for _, y := range f.Symbols {
+		if aux > 0 {
+			b.Write(y.Raw[:])
+			aux--
+			continue
+		}
+		aux = int(y.Aux)
+
 		yl := Symbol1{
 			Value:   y.Value,
 			Section: y.Section,
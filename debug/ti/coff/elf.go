@@ -0,0 +1,223 @@
+package coff
+
+import (
+	"debug/elf"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/qeedquan/go-media/debug/elfutil"
+)
+
+// ELF converts the file into an ELF file with the same sections and
+// symbols. Executables get a loadable segment for every initialized or
+// uninitialized section on page 0, the other pages are separate address
+// spaces that ELF has no notion of so their sections are not loaded.
+// Addresses of the word addressed targets are scaled to bytes. The
+// relocations and line numbers are not converted.
+func (f *File) ELF() (*elfutil.File, error) {
+	e := &elfutil.File{File: &elf.File{}}
+	e.Class = elf.ELFCLASS32
+	e.Version = elf.EV_CURRENT
+	e.Data = elf.ELFDATA2LSB
+	e.ByteOrder = binary.LittleEndian
+	if f.Flags&F_BIG != 0 {
+		e.Data = elf.ELFDATA2MSB
+		e.ByteOrder = binary.BigEndian
+	}
+	e.Type = elf.ET_REL
+	if f.Flags&F_EXEC != 0 {
+		e.Type = elf.ET_EXEC
+	}
+	switch f.TargetID {
+	case TMS470:
+		e.Machine = elf.EM_ARM
+	case TMS320C6000:
+		e.Machine = elf.EM_TI_C6000
+	case TMS320C2800:
+		e.Machine = elf.EM_TI_C2000
+	case TMS320C5500, TMS320C5500P:
+		e.Machine = elf.EM_TI_C5500
+	case MPS430:
+		e.Machine = elf.EM_MSP430
+	default:
+		return nil, fmt.Errorf("coff: no elf machine for target %v", TargetID(f.TargetID))
+	}
+
+	unit := uint64(f.Unit())
+	if f.OptionalHeader != nil {
+		e.Entry = uint64(f.OptionalHeader.Entry) * unit
+	}
+
+	// the loaded sections are given the offsets they end up at after the
+	// headers, the others are placed by the layout
+	loaded := func(s *Section) bool {
+		return e.Type == elf.ET_EXEC && s.Page == 0 && s.Size != 0 &&
+			s.Flags&(STYP_TEXT|STYP_DATA|STYP_BSS) != 0 && s.Flags&(STYP_DSECT|STYP_NOLOAD|STYP_COPY) == 0
+	}
+	off := uint64(0x34)
+	for _, s := range f.Sections {
+		if loaded(s) {
+			off += 0x20
+		}
+	}
+
+	e.Sections = append(e.Sections, &elfutil.Section{Section: &elf.Section{}})
+	for _, s := range f.Sections {
+		typ := elf.SHT_PROGBITS
+		if s.Data == nil {
+			typ = elf.SHT_NOBITS
+		}
+		var flags elf.SectionFlag
+		switch {
+		case s.Flags&STYP_TEXT != 0:
+			flags = elf.SHF_ALLOC | elf.SHF_EXECINSTR
+		case s.Flags&(STYP_DATA|STYP_BSS) != 0:
+			flags = elf.SHF_ALLOC | elf.SHF_WRITE
+		}
+		if s.Flags&(STYP_DSECT|STYP_NOLOAD|STYP_COPY) != 0 {
+			flags = 0
+		}
+		addr := uint64(s.VirtAddr) * unit
+		size := uint64(s.Size) * unit
+		if e.Type == elf.ET_REL {
+			addr = 0
+		}
+
+		if !loaded(s) {
+			t, err := e.CreateSection(s.Name, typ, flags, addr, size)
+			if err != nil {
+				return nil, err
+			}
+			if s.Data != nil {
+				t.Data = s.Data
+			}
+			continue
+		}
+
+		e.Sections = append(e.Sections, &elfutil.Section{
+			Section: &elf.Section{
+				SectionHeader: elf.SectionHeader{
+					Name:      s.Name,
+					Type:      typ,
+					Flags:     flags,
+					Addr:      addr,
+					Offset:    off,
+					Size:      size,
+					FileSize:  uint64(len(s.Data)),
+					Addralign: 1,
+				},
+			},
+			Data: s.Data,
+		})
+
+		pflags := elf.PF_R
+		if flags&elf.SHF_EXECINSTR != 0 {
+			pflags |= elf.PF_X
+		}
+		if flags&elf.SHF_WRITE != 0 {
+			pflags |= elf.PF_W
+		}
+		e.Progs = append(e.Progs, &elfutil.Prog{
+			Prog: &elf.Prog{
+				ProgHeader: elf.ProgHeader{
+					Type:   elf.PT_LOAD,
+					Flags:  pflags,
+					Off:    off,
+					Vaddr:  addr,
+					Paddr:  uint64(s.PhysAddr) * unit,
+					Filesz: uint64(len(s.Data)),
+					Memsz:  size,
+					Align:  1,
+				},
+			},
+			Data: s.Data,
+		})
+		off += uint64(len(s.Data))
+	}
+
+	_, err := e.CreateSection(".strtab", elf.SHT_STRTAB, 0, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	symtab, err := e.CreateSection(".symtab", elf.SHT_SYMTAB, 0, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	symtab.Link = uint32(len(e.Sections) - 2)
+	symtab.Addralign = 4
+
+	locals, globals := f.elfSymbols(unit)
+	symtab.Info = uint32(len(locals))
+	err = e.WriteSymbols(symtab, append(locals, globals...))
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.Layout()
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// elfSymbols converts the symbol table, the local symbols come first and
+// start with the null symbol.
+func (f *File) elfSymbols(unit uint64) (locals, globals []elf.Symbol) {
+	locals = append(locals, elf.Symbol{})
+	aux := f.auxMap()
+	for i, y := range f.Symbols {
+		if aux[i] || y.Name == "" {
+			continue
+		}
+
+		sym := elf.Symbol{
+			Name:  y.Name,
+			Value: uint64(y.Value),
+			Size:  uint64(f.FuncSize(i)) * unit,
+		}
+		switch n := int16(y.Section); {
+		case y.Class == C_FILE:
+			sym.Name = f.FileName(i)
+			sym.Section = elf.SHN_ABS
+			sym.Value = 0
+		case n == N_UNDEF:
+			sym.Section = elf.SHN_UNDEF
+		case n > 0 && int(n) <= len(f.Sections):
+			sym.Section = elf.SectionIndex(n)
+			sym.Value *= unit
+			if f.Flags&F_EXEC == 0 {
+				sym.Value -= uint64(f.Sections[n-1].VirtAddr) * unit
+			}
+		case n == -N_ABS:
+			sym.Section = elf.SHN_ABS
+		default:
+			continue
+		}
+
+		typ := elf.STT_NOTYPE
+		switch {
+		case y.Class == C_FILE:
+			typ = elf.STT_FILE
+		case y.IsFunc():
+			typ = elf.STT_FUNC
+		case sym.Section > 0 && sym.Section < elf.SHN_LORESERVE && y.Class == C_STAT && y.Name == f.Sections[sym.Section-1].Name:
+			typ = elf.STT_SECTION
+			sym.Name = ""
+		case sym.Section > 0 && sym.Section < elf.SHN_LORESERVE && f.Sections[sym.Section-1].Flags&STYP_TEXT == 0:
+			typ = elf.STT_OBJECT
+		}
+
+		switch y.Class {
+		case C_EXT, C_EXTDEF:
+			sym.Info = elf.ST_INFO(elf.STB_GLOBAL, typ)
+			globals = append(globals, sym)
+		case C_WEAKEXT:
+			sym.Info = elf.ST_INFO(elf.STB_WEAK, typ)
+			globals = append(globals, sym)
+		case C_STAT, C_LABEL, C_HIDDEN, C_FILE:
+			sym.Info = elf.ST_INFO(elf.STB_LOCAL, typ)
+			locals = append(locals, sym)
+		}
+	}
+	return locals, globals
+}
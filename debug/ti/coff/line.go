@@ -0,0 +1,271 @@
+package coff
+
+import (
+	"debug/dwarf"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/qeedquan/go-media/debug"
+)
+
+// IsFunc reports if the derived type of a symbol is a function.
+func (y *Symbol) IsFunc() bool {
+	return (y.Type>>4)&3 == DT_FCN
+}
+
+// auxMap marks the auxiliary entries of the symbol table.
+func (f *File) auxMap() []bool {
+	m := make([]bool, len(f.Symbols))
+	for i := 0; i < len(f.Symbols); i++ {
+		n := int(f.Symbols[i].Aux)
+		for j := 1; j <= n && i+j < len(m); j++ {
+			m[i+j] = true
+		}
+		i += n
+	}
+	return m
+}
+
+// aux returns the first auxiliary entry of a symbol.
+func (f *File) aux(i int) []byte {
+	if i+1 >= len(f.Symbols) || f.Symbols[i].Aux == 0 {
+		return nil
+	}
+	return f.Symbols[i+1].Raw[:]
+}
+
+// FuncSize returns the size of a function from its auxiliary entry.
+func (f *File) FuncSize(i int) uint32 {
+	p := f.aux(i)
+	if p == nil || !f.Symbols[i].IsFunc() {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(p[4:])
+}
+
+// FileName returns the source file of the symbol at an index, taken from
+// the closest .file symbol before it.
+func (f *File) FileName(i int) string {
+	for ; i >= 0; i-- {
+		y := f.Symbols[i]
+		if y.Class != C_FILE || y.Name == "" {
+			continue
+		}
+		p := f.aux(i)
+		if p == nil {
+			return y.Name
+		}
+		if binary.LittleEndian.Uint32(p[0:]) == 0 {
+			return f.mkname(nil, int64(binary.LittleEndian.Uint32(p[4:])))
+		}
+		return strz(p[:14])
+	}
+	return ""
+}
+
+// Lines resolves the line number entries of the sections. The entries of a
+// function are relative to the line of its .bf symbol and start with one
+// referencing the function symbol. The rows are sorted by address, each one
+// covers up to the next and an empty file marks the end of a function.
+func (f *File) Lines() ([]debug.Line, error) {
+	var lines []debug.Line
+	for _, s := range f.Sections {
+		var (
+			file string
+			base int
+			end  uint64
+			open bool
+		)
+		closeFunc := func() {
+			if open {
+				lines = append(lines, debug.Line{Addr: end})
+				open = false
+			}
+		}
+		for _, l := range s.Lines {
+			if l.Num != 0 {
+				if !open {
+					return nil, fmt.Errorf("coff: section %s: line number entry before function entry", s.Name)
+				}
+				lines = append(lines, debug.Line{
+					Addr: uint64(l.Loc),
+					File: file,
+					Line: base + int(l.Num) - 1,
+				})
+				continue
+			}
+
+			closeFunc()
+			i := int(l.Loc)
+			if i >= len(f.Symbols) {
+				return nil, fmt.Errorf("coff: section %s: line number entry refers to invalid symbol %d", s.Name, i)
+			}
+			y := f.Symbols[i]
+			file = f.FileName(i)
+			base = 0
+			end = uint64(y.Value) + uint64(f.FuncSize(i))
+			for j := i + 1 + int(y.Aux); j < len(f.Symbols); j += 1 + int(f.Symbols[j].Aux) {
+				p := f.Symbols[j]
+				if p.Class != C_FCN {
+					continue
+				}
+				if p.Name == ".bf" && base == 0 {
+					if a := f.aux(j); a != nil {
+						base = int(binary.LittleEndian.Uint16(a[4:]))
+					}
+				} else if p.Name == ".ef" {
+					if f.FuncSize(i) == 0 {
+						end = uint64(p.Value)
+					}
+					break
+				}
+			}
+			if base == 0 {
+				base = 1
+			}
+			open = true
+		}
+		closeFunc()
+	}
+
+	debug.SortLines(lines)
+	return lines, nil
+}
+
+// DWARF returns the debug information of the .debug sections.
+func (f *File) DWARF() (*dwarf.Data, error) {
+	var sects [8][]byte
+	names := []string{"abbrev", "aranges", "frame", "info", "line", "pubnames", "ranges", "str"}
+	for i, n := range names {
+		if s := f.Section(".debug_" + n); s != nil {
+			sects[i] = s.Data
+		}
+	}
+	if sects[3] == nil {
+		return nil, fmt.Errorf("coff: missing .debug_info section")
+	}
+	d, err := dwarf.New(sects[0], sects[1], sects[2], sects[3], sects[4], sects[5], sects[6], sects[7])
+	if err != nil {
+		return nil, fmt.Errorf("coff: %v", err)
+	}
+	return d, nil
+}
+
+// Symbolizer resolves addresses with the symbol table and the line numbers
+// of a file, the DWARF line tables are used if the file has no COFF line
+// numbers. It implements debug.Symbolizer.
+type Symbolizer struct {
+	*debug.SymbolTable
+	*debug.LineTable
+}
+
+// NewSymbolizer takes the symbols defined in a section, the section and
+// debugging symbols are left out.
+func NewSymbolizer(f *File) (*Symbolizer, error) {
+	var syms []debug.Sym
+	aux := f.auxMap()
+	for i, y := range f.Symbols {
+		if aux[i] || y.Name == "" || y.Section == N_UNDEF || int16(y.Section) < 0 || int(y.Section) > len(f.Sections) {
+			continue
+		}
+		switch y.Class {
+		case C_EXT, C_EXTDEF, C_STAT, C_LABEL, C_WEAKEXT, C_HIDDEN:
+		default:
+			continue
+		}
+		s := f.Sections[y.Section-1]
+		if y.Class == C_STAT && y.Name == s.Name && !y.IsFunc() {
+			continue
+		}
+		syms = append(syms, debug.Sym{
+			Name:    y.Name,
+			Addr:    uint64(y.Value),
+			Size:    uint64(f.FuncSize(i)),
+			Section: s.Name,
+		})
+	}
+
+	lines, err := f.Lines()
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		lines, err = dwarfLines(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Symbolizer{
+		SymbolTable: debug.NewSymbolTable(syms),
+		LineTable:   debug.NewLineTable(lines),
+	}, nil
+}
+
+func dwarfLines(f *File) ([]debug.Line, error) {
+	if f.Section(".debug_info") == nil {
+		return nil, nil
+	}
+	d, err := f.DWARF()
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []debug.Line
+	r := d.Reader()
+	for {
+		e, err := r.Next()
+		if err != nil {
+			return nil, fmt.Errorf("coff: %v", err)
+		}
+		if e == nil {
+			break
+		}
+		if e.Tag != dwarf.TagCompileUnit {
+			r.SkipChildren()
+			continue
+		}
+
+		lr, err := d.LineReader(e)
+		if err != nil {
+			return nil, fmt.Errorf("coff: %v", err)
+		}
+		r.SkipChildren()
+		if lr == nil {
+			continue
+		}
+
+		var le dwarf.LineEntry
+		for {
+			err := lr.Next(&le)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("coff: %v", err)
+			}
+			l := debug.Line{Addr: le.Address}
+			if !le.EndSequence && le.File != nil {
+				l.File = le.File.Name
+				l.Line = le.Line
+				l.Column = le.Column
+			}
+			lines = append(lines, l)
+		}
+	}
+
+	return lines, nil
+}
+
+// LineAddrs returns the addresses generated by a source line, the file
+// matches if it is a suffix of the path in the line table.
+func (z *Symbolizer) LineAddrs(file string, line int) []uint64 {
+	var addrs []uint64
+	for _, l := range z.Lines() {
+		if l.Line == line && l.File != "" && strings.HasSuffix(l.File, file) {
+			addrs = append(addrs, l.Addr)
+		}
+	}
+	return addrs
+}
@@ -0,0 +1,166 @@
+package coff
+
+import (
+	"fmt"
+)
+
+// RelocType is a target specific relocation type.
+type RelocType struct {
+	Target uint16
+	Type   uint16
+}
+
+var exprRelocNames = map[uint16]string{
+	RE_ADD:    "RE_ADD",
+	RE_SUB:    "RE_SUB",
+	RE_NEG:    "RE_NEG",
+	RE_MPY:    "RE_MPY",
+	RE_DIV:    "RE_DIV",
+	RE_MOD:    "RE_MOD",
+	RE_SR:     "RE_SR",
+	RE_ASR:    "RE_ASR",
+	RE_SL:     "RE_SL",
+	RE_AND:    "RE_AND",
+	RE_OR:     "RE_OR",
+	RE_XOR:    "RE_XOR",
+	RE_NOTB:   "RE_NOTB",
+	RE_ULDFLD: "RE_ULDFLD",
+	RE_SLDFLD: "RE_SLDFLD",
+	RE_USTFLD: "RE_USTFLD",
+	RE_SSTFLD: "RE_SSTFLD",
+	RE_PUSH:   "RE_PUSH",
+	RE_PUSHSK: "RE_PUSHSK",
+	RE_PUSHUK: "RE_PUSHUK",
+	RE_PUSHPC: "RE_PUSHPC",
+	RE_DUP:    "RE_DUP",
+	RE_XSTFLD: "RE_XSTFLD",
+	RE_PUSHV:  "RE_PUSHV",
+}
+
+var relocNames = map[uint16]map[uint16]string{
+	TMS320C6000: {
+		R_60ABS:     "R_60ABS",
+		R_60RELBYTE: "R_60RELBYTE",
+		R_60RELWORD: "R_60RELWORD",
+		R_60RELLONG: "R_60RELLONG",
+		R_C60BASE:   "R_C60BASE",
+		R_C60DIR15:  "R_C60DIR15",
+		R_C60PCR21:  "R_C60PCR21",
+		R_C60PCR10:  "R_C60PCR10",
+		R_C60LO16:   "R_C60LO16",
+		R_C60HI16:   "R_C60HI16",
+		R_C60SECT:   "R_C60SECT",
+		R_C60S16:    "R_C60S16",
+		R_C60PCR7:   "R_C60PCR7",
+		R_C60PCR12:  "R_C60PCR12",
+	},
+	TMS320C2800: {
+		R_C28ABS:       "R_C28ABS",
+		R_C28RELBYTE:   "R_C28RELBYTE",
+		R_C28RELWORD:   "R_C28RELWORD",
+		R_C28RELLONG:   "R_C28RELLONG",
+		R_C28PARTLS7:   "R_C28PARTLS7",
+		R_C28PARTLS6:   "R_C28PARTLS6",
+		R_C28PARTMID10: "R_C28PARTMID10",
+		R_C28REL22:     "R_C28REL22",
+		R_C28PARTMS6:   "R_C28PARTMS6",
+		R_C28PARTS16:   "R_C28PARTS16",
+		R_C28PCR16:     "R_C28PCR16",
+		R_C28PCR8:      "R_C28PCR8",
+		R_C28PTR:       "R_C28PTR",
+		R_C28HI16:      "R_C28HI16",
+		R_C28LOPTR:     "R_C28LOPTR",
+		R_C28NWORD:     "R_C28NWORD",
+		R_C28NBYTE:     "R_C28NBYTE",
+		R_C28HIBYTE:    "R_C28HIBYTE",
+		R_C28RELS13:    "R_C28RELS13",
+	},
+	TMS470: {
+		R_MTABS:     "R_MTABS",
+		R_MTRELBYTE: "R_MTRELBYTE",
+		R_MTRELWORD: "R_MTRELWORD",
+		R_MTRELLONG: "R_MTRELLONG",
+		R_MTPCR23H:  "R_MTPCR23H",
+		R_MTPCR24W:  "R_MTPCR24W",
+	},
+	TMS320C5400: {
+		R_C54ABS:     "R_C54ABS",
+		R_C54REL24:   "R_C54REL24",
+		R_C54RELBYTE: "R_C54RELBYTE",
+		R_C54RELWORD: "R_C54RELWORD",
+		R_C54RELLONG: "R_C54RELLONG",
+		R_C54PARTSL7: "R_C54PARTSL7",
+		R_C54PARTSM9: "R_C54PARTSM9",
+		R_C54REL13:   "R_C54REL13",
+	},
+	TMS320C5500: {
+		R_C55ABS:        "R_C55ABS",
+		R_C55REL24:      "R_C55REL24",
+		R_C55RELBYTE:    "R_C55RELBYTE",
+		R_C55RELWORD:    "R_C55RELWORD",
+		R_C55RELLONG:    "R_C55RELLONG",
+		R_C55_LD3_DMA:   "R_C55_LD3_DMA",
+		R_C55_LD3_MDP:   "R_C55_LD3_MDP",
+		R_C55_LD3_PDP:   "R_C55_LD3_PDP",
+		R_C55_LD3_REL23: "R_C55_LD3_REL23",
+		R_C55_LD3_k8:    "R_C55_LD3_k8",
+		R_C55_LD3_k16:   "R_C55_LD3_k16",
+		R_C55_LD3_K8:    "R_C55_LD3_K8",
+		R_C55_LD3_K16:   "R_C55_LD3_K16",
+		R_C55_LD3_l8:    "R_C55_LD3_l8",
+		R_C55_LD3_l16:   "R_C55_LD3_l16",
+		R_C55_LD3_L8:    "R_C55_LD3_L8",
+		R_C55_LD3_L16:   "R_C55_LD3_L16",
+		R_C55_LD3_k4:    "R_C55_LD3_k4",
+		R_C55_LD3_k5:    "R_C55_LD3_k5",
+		R_C55_LD3_K5:    "R_C55_LD3_K5",
+		R_C55_LD3_k6:    "R_C55_LD3_k6",
+		R_C55_LD3_k12:   "R_C55_LD3_k12",
+	},
+}
+
+func init() {
+	relocNames[TMS320C5500P] = relocNames[TMS320C5500]
+}
+
+func (r RelocType) String() string {
+	if s, ok := exprRelocNames[r.Type]; ok {
+		return s
+	}
+	if s, ok := relocNames[r.Target][r.Type]; ok {
+		return s
+	}
+	return fmt.Sprintf("R_%#x", r.Type)
+}
+
+// Relocation is a decoded relocation entry, Off is relative to the start of
+// the section. Expression operators do not reference a symbol.
+type Relocation struct {
+	Off      uint64
+	Type     RelocType
+	Sym      uint32
+	Symbol   string
+	Extended uint16
+}
+
+func (r Relocation) String() string {
+	return fmt.Sprintf("%#x %v %s", r.Off, r.Type, r.Symbol)
+}
+
+// Relocations decodes the relocation entries of a section.
+func (f *File) Relocations(s *Section) []Relocation {
+	var l []Relocation
+	for _, p := range s.Relocs {
+		r := Relocation{
+			Off:      p.VirtAddr - uint64(s.VirtAddr),
+			Type:     RelocType{f.TargetID, p.Type},
+			Sym:      uint32(p.SymbolIndex),
+			Extended: p.Extended,
+		}
+		if _, ok := exprRelocNames[p.Type]; !ok && p.SymbolIndex < uint64(len(f.Symbols)) {
+			r.Symbol = f.Symbols[p.SymbolIndex].Name
+		}
+		l = append(l, r)
+	}
+	return l
+}
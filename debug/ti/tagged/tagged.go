@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"io"
 	"strings"
+
+	"github.com/qeedquan/go-media/debug/memmap"
 )
 
+const DefaultRecordLength = 32
+
 type File struct {
 	Ident   string
 	Header  *Header
@@ -43,7 +47,7 @@ func Decode(r io.Reader) (*File, error) {
 	}
 
 	flushdata := func() {
-		if addr != 0 {
+		if len(data) > 0 {
 			f.Records = append(f.Records, Record{addr, data})
 			addr += uint64(len(data))
 			data = nil
@@ -142,6 +146,7 @@ scan:
 			}
 		}
 	}
+	flushdata()
 
 	return f, nil
 }
@@ -181,6 +186,33 @@ func Checksum(b []byte) uint16 {
 	return ^s + 1
 }
 
+// Map returns the records as a sparse memory map, overlapping records are
+// an error.
+func (f *File) Map() (*memmap.Map, error) {
+	m := &memmap.Map{}
+	for _, r := range f.Records {
+		if err := m.Add(r.Addr, r.Data); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func FromMap(m *memmap.Map) (*File, error) {
+	if end := m.End(); end > 0x10000 {
+		return nil, fmt.Errorf("tagged: data ends at %#x past the 16-bit address space", end)
+	}
+	f := &File{}
+	segs, err := m.Split(DefaultRecordLength)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range segs {
+		f.Records = append(f.Records, Record{s.Addr, s.Data})
+	}
+	return f, nil
+}
+
 func (f *File) Binary() []byte {
 	if len(f.Records) == 0 {
 		return nil
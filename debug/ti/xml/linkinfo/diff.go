@@ -0,0 +1,50 @@
+package linkinfo
+
+import (
+	"github.com/qeedquan/go-media/debug"
+)
+
+// Diff holds the memory areas, object files, libraries and object
+// components whose size changed between two builds. Components keep
+// their object file in File, the sizes are in the target's addressable
+// units.
+type Diff struct {
+	Areas      []debug.SizeDiff
+	Objects    []debug.SizeDiff
+	Libraries  []debug.SizeDiff
+	Components []debug.SizeDiff
+}
+
+// Compare diffs two builds, components are matched by section name and
+// object file. Area usage is taken from the placement map so it includes
+// the linker generated sections and holes that no object file owns.
+func Compare(a, b *File) *Diff {
+	comps := debug.NewSizeDiffer()
+	areas := debug.NewSizeDiffer()
+	objs := debug.NewSizeDiffer()
+	libs := debug.NewSizeDiffer()
+	add := func(f *File, old bool) {
+		for i := range f.ObjectComponents {
+			c := &f.ObjectComponents[i]
+			comps.Add(c.Name, c.ObjectName(), "", Number(c.Size), old)
+		}
+		for _, u := range f.Usage() {
+			areas.Add(u.Name, "", "", u.Used, old)
+		}
+		for _, p := range f.ObjectSizes() {
+			objs.Add(p.Name, "", "", p.Size, old)
+		}
+		for _, p := range f.LibrarySizes() {
+			libs.Add(p.Name, "", "", p.Size, old)
+		}
+	}
+	add(a, true)
+	add(b, false)
+
+	return &Diff{
+		Areas:      areas.Changed(),
+		Objects:    objs.Changed(),
+		Libraries:  libs.Changed(),
+		Components: comps.Changed(),
+	}
+}
@@ -1,6 +1,15 @@
 package linkinfo
 
-import "encoding/xml"
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
 
 type File struct {
 	XMLName          xml.Name `xml:"link_info"`
@@ -17,6 +26,11 @@ type File struct {
 	CRCTables        []CRCTable `xml:"crc_table_list>crc_table"`
 	Symbols          []Symbol   `xml:"symbol_table>symbol"`
 	Title            string     `xml:"title"`
+
+	components map[string]*ObjectComponent
+	inputs     map[string]*InputFile
+	groups     map[string]*LogicalGroup
+	placed     map[*ObjectComponent][]*MemoryArea
 }
 
 type EntryPoint struct {
@@ -57,6 +71,8 @@ type ObjectComponent struct {
 	RunAddress   string   `xml:"run_address"`
 	Size         string   `xml:"size"`
 	InputFileRef InputFileRef
+
+	Input *InputFile `xml:"-"`
 }
 
 type LogicalGroup struct {
@@ -69,11 +85,15 @@ type LogicalGroup struct {
 	RunAddress  string   `xml:"run_address"`
 	Size        string   `xml:"size"`
 	Contents    LogicalGroupContents
+
+	Components []*ObjectComponent `xml:"-"`
+	Groups     []*LogicalGroup    `xml:"-"`
 }
 
 type LogicalGroupContents struct {
 	XMLName             xml.Name             `xml:"contents"`
 	ObjectComponentRefs []ObjectComponentRef `xml:"object_component_ref"`
+	LogicalGroupRefs    []LogicalGroupRef    `xml:"logical_group_ref"`
 }
 
 type PlacementMap struct {
@@ -86,6 +106,7 @@ type MemoryArea struct {
 	Display      string   `xml:"display,attr"`
 	Color        string   `xml:"color,attr"`
 	Name         string   `xml:"name"`
+	PageID       string   `xml:"page_id"`
 	Origin       string   `xml:"origin"`
 	Length       string   `xml:"length"`
 	UsedSpace    string   `xml:"used_space"`
@@ -95,8 +116,9 @@ type MemoryArea struct {
 }
 
 type UsageDetails struct {
-	XMLName        xml.Name `xml:"usage_details"`
-	AllocatedSpace AllocatedSpace
+	XMLName         xml.Name         `xml:"usage_details"`
+	AllocatedSpaces []AllocatedSpace `xml:"allocated_space"`
+	AvailableSpaces []AvailableSpace `xml:"available_space"`
 }
 
 type AllocatedSpace struct {
@@ -104,6 +126,14 @@ type AllocatedSpace struct {
 	StartAddress     string            `xml:"start_address"`
 	Size             string            `xml:"size"`
 	LogicalGroupRefs []LogicalGroupRef `xml:"logical_group_ref"`
+
+	Groups []*LogicalGroup `xml:"-"`
+}
+
+type AvailableSpace struct {
+	XMLName      xml.Name `xml:"available_space"`
+	StartAddress string   `xml:"start_address"`
+	Size         string   `xml:"size"`
 }
 
 type CRCTable struct {
@@ -128,3 +158,174 @@ type Symbol struct {
 	Name    string   `xml:"name"`
 	Value   string   `xml:"value"`
 }
+
+func Open(name string) (*File, error) {
+	r, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return Decode(r)
+}
+
+// Decode parses a link info file and resolves the id references between
+// the object components, input files and logical groups.
+func Decode(r io.Reader) (*File, error) {
+	f := &File{}
+	d := xml.NewDecoder(r)
+	d.CharsetReader = charsetReader
+	err := d.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("linkinfo: %v", err)
+	}
+	err = f.resolve()
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// charsetReader handles the ISO-8859-1 encoding the linker declares.
+func charsetReader(charset string, r io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1":
+		return &latin1Reader{r: bufio.NewReader(r)}, nil
+	}
+	return nil, fmt.Errorf("unsupported charset %q", charset)
+}
+
+type latin1Reader struct {
+	r   *bufio.Reader
+	buf []byte
+	tmp [utf8.UTFMax]byte
+}
+
+func (l *latin1Reader) Read(b []byte) (int, error) {
+	for len(l.buf) == 0 {
+		c, err := l.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		n := utf8.EncodeRune(l.tmp[:], rune(c))
+		l.buf = l.tmp[:n]
+	}
+	n := copy(b, l.buf)
+	l.buf = l.buf[n:]
+	return n, nil
+}
+
+func (f *File) resolve() error {
+	f.components = make(map[string]*ObjectComponent)
+	f.inputs = make(map[string]*InputFile)
+	f.groups = make(map[string]*LogicalGroup)
+	for i := range f.InputFiles {
+		p := &f.InputFiles[i]
+		f.inputs[p.ID] = p
+	}
+	for i := range f.ObjectComponents {
+		p := &f.ObjectComponents[i]
+		f.components[p.ID] = p
+	}
+	for i := range f.LogicalGroups {
+		p := &f.LogicalGroups[i]
+		f.groups[p.ID] = p
+	}
+
+	for i := range f.ObjectComponents {
+		p := &f.ObjectComponents[i]
+		ref := p.InputFileRef.IDRef
+		if ref == "" {
+			continue
+		}
+		p.Input = f.inputs[ref]
+		if p.Input == nil {
+			return fmt.Errorf("linkinfo: object component %q refers to unknown input file %q", p.ID, ref)
+		}
+	}
+
+	for i := range f.LogicalGroups {
+		p := &f.LogicalGroups[i]
+		p.Components, p.Groups = nil, nil
+		for _, r := range p.Contents.ObjectComponentRefs {
+			c := f.components[r.IDRef]
+			if c == nil {
+				return fmt.Errorf("linkinfo: logical group %q refers to unknown object component %q", p.ID, r.IDRef)
+			}
+			p.Components = append(p.Components, c)
+		}
+		for _, r := range p.Contents.LogicalGroupRefs {
+			g := f.groups[r.IDRef]
+			if g == nil {
+				return fmt.Errorf("linkinfo: logical group %q refers to unknown logical group %q", p.ID, r.IDRef)
+			}
+			p.Groups = append(p.Groups, g)
+		}
+	}
+
+	f.placed = make(map[*ObjectComponent][]*MemoryArea)
+	for i := range f.PlacementMap.MemoryAreas {
+		a := &f.PlacementMap.MemoryAreas[i]
+		for j := range a.UsageDetails.AllocatedSpaces {
+			s := &a.UsageDetails.AllocatedSpaces[j]
+			s.Groups = nil
+			for _, r := range s.LogicalGroupRefs {
+				g := f.groups[r.IDRef]
+				if g == nil {
+					return fmt.Errorf("linkinfo: memory area %q refers to unknown logical group %q", a.Name, r.IDRef)
+				}
+				s.Groups = append(s.Groups, g)
+				for _, c := range g.AllComponents() {
+					l := f.placed[c]
+					if len(l) == 0 || l[len(l)-1] != a {
+						f.placed[c] = append(l, a)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (f *File) Component(id string) *ObjectComponent {
+	return f.components[id]
+}
+
+func (f *File) InputFile(id string) *InputFile {
+	return f.inputs[id]
+}
+
+func (f *File) Group(id string) *LogicalGroup {
+	return f.groups[id]
+}
+
+// AllComponents returns the object components of the group and of the
+// groups nested in it.
+func (g *LogicalGroup) AllComponents() []*ObjectComponent {
+	var l []*ObjectComponent
+	seen := make(map[*LogicalGroup]bool)
+	var walk func(*LogicalGroup)
+	walk = func(g *LogicalGroup) {
+		if seen[g] {
+			return
+		}
+		seen[g] = true
+		l = append(l, g.Components...)
+		for _, p := range g.Groups {
+			walk(p)
+		}
+	}
+	walk(g)
+	return l
+}
+
+// Page returns the page of the memory area, targets with a single address
+// space put everything on page 0.
+func (a *MemoryArea) Page() uint64 {
+	return Number(a.PageID)
+}
+
+// Number parses the numeric fields, which are stored as C style literals.
+func Number(s string) uint64 {
+	v, _ := strconv.ParseUint(strings.TrimSpace(s), 0, 64)
+	return v
+}
@@ -0,0 +1,192 @@
+package linkinfo
+
+import (
+	"fmt"
+	"sort"
+)
+
+// AreaUsage is the allocation of a memory area, the sizes are in the
+// target's addressable units which are 16-bit words on the C28x.
+type AreaUsage struct {
+	Name   string
+	Origin uint64
+	Length uint64
+	Used   uint64
+}
+
+func (u AreaUsage) Free() uint64 {
+	if u.Used > u.Length {
+		return 0
+	}
+	return u.Length - u.Used
+}
+
+func (u AreaUsage) Percent() float64 {
+	if u.Length == 0 {
+		return 0
+	}
+	return 100 * float64(u.Used) / float64(u.Length)
+}
+
+// Contribution is the size an object file or library adds to the image,
+// split by memory area.
+type Contribution struct {
+	Name  string
+	Size  uint64
+	Areas map[string]uint64
+}
+
+// Usage returns the usage of the memory areas, the used space is taken from
+// the allocations when the file does not list it.
+func (f *File) Usage() []AreaUsage {
+	var l []AreaUsage
+	for i := range f.PlacementMap.MemoryAreas {
+		a := &f.PlacementMap.MemoryAreas[i]
+		u := AreaUsage{
+			Name:   a.Name,
+			Origin: Number(a.Origin),
+			Length: Number(a.Length),
+			Used:   Number(a.UsedSpace),
+		}
+		if a.UsedSpace == "" {
+			for _, s := range a.UsageDetails.AllocatedSpaces {
+				u.Used += Number(s.Size)
+			}
+		}
+		l = append(l, u)
+	}
+	return l
+}
+
+// CheckUsage returns an error for every memory area filled above a
+// percentage.
+func (f *File) CheckUsage(max float64) []error {
+	var errs []error
+	for _, u := range f.Usage() {
+		if u.Percent() > max {
+			errs = append(errs, fmt.Errorf("linkinfo: memory area %s is %.1f%% full (%#x of %#x)",
+				u.Name, u.Percent(), u.Used, u.Length))
+		}
+	}
+	return errs
+}
+
+// Area returns the memory area containing an address on a page, the C28x
+// puts program and data memory on pages 0 and 1 which can overlap.
+func (f *File) Area(page, addr uint64) *MemoryArea {
+	for i := range f.PlacementMap.MemoryAreas {
+		a := &f.PlacementMap.MemoryAreas[i]
+		start := Number(a.Origin)
+		if a.Page() == page && start <= addr && addr < start+Number(a.Length) {
+			return a
+		}
+	}
+	return nil
+}
+
+// ObjectName returns the input file of a component, archive members are
+// named library(member) and linker generated components have no name.
+func (c *ObjectComponent) ObjectName() string {
+	p := c.Input
+	switch {
+	case p == nil:
+		return ""
+	case p.Kind == "archive":
+		return fmt.Sprintf("%s(%s)", p.File, p.Name)
+	case p.Name != "":
+		return p.Name
+	}
+	return p.File
+}
+
+// areas returns the memory areas a component occupies, the load and run
+// areas differ for sections copied at startup. The areas come from the
+// logical groups allocated in them, components outside any group are
+// placed by address when only one page has memory there.
+func (f *File) areas(c *ObjectComponent) []string {
+	var l []string
+	for _, a := range f.placed[c] {
+		l = append(l, a.Name)
+	}
+	if len(l) > 0 {
+		return l
+	}
+	for _, s := range []string{c.LoadAddress, c.RunAddress} {
+		if s == "" {
+			continue
+		}
+		a := f.uniqueArea(Number(s))
+		if a != nil && (len(l) == 0 || l[0] != a.Name) {
+			l = append(l, a.Name)
+		}
+	}
+	return l
+}
+
+func (f *File) uniqueArea(addr uint64) *MemoryArea {
+	var r *MemoryArea
+	for i := range f.PlacementMap.MemoryAreas {
+		a := &f.PlacementMap.MemoryAreas[i]
+		start := Number(a.Origin)
+		if start <= addr && addr < start+Number(a.Length) {
+			if r != nil {
+				return nil
+			}
+			r = a
+		}
+	}
+	return r
+}
+
+// ObjectSizes returns the contribution of every object file sorted by
+// size, the size counts both the load and the run copy of a component.
+func (f *File) ObjectSizes() []Contribution {
+	return f.contributions(func(c *ObjectComponent) string {
+		return c.ObjectName()
+	})
+}
+
+// LibrarySizes returns the contribution of every archive sorted by size.
+func (f *File) LibrarySizes() []Contribution {
+	return f.contributions(func(c *ObjectComponent) string {
+		if c.Input == nil || c.Input.Kind != "archive" {
+			return ""
+		}
+		return c.Input.File
+	})
+}
+
+func (f *File) contributions(key func(*ObjectComponent) string) []Contribution {
+	m := make(map[string]*Contribution)
+	var l []*Contribution
+	for i := range f.ObjectComponents {
+		c := &f.ObjectComponents[i]
+		k := key(c)
+		if k == "" {
+			continue
+		}
+		p := m[k]
+		if p == nil {
+			p = &Contribution{Name: k, Areas: make(map[string]uint64)}
+			m[k] = p
+			l = append(l, p)
+		}
+		size := Number(c.Size)
+		for _, a := range f.areas(c) {
+			p.Areas[a] += size
+			p.Size += size
+		}
+	}
+
+	r := make([]Contribution, len(l))
+	for i := range l {
+		r[i] = *l[i]
+	}
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].Size != r[j].Size {
+			return r[i].Size > r[j].Size
+		}
+		return r[i].Name < r[j].Name
+	})
+	return r
+}
@@ -0,0 +1,1318 @@
+//go:build !cgo || purego
+// +build !cgo purego
+
+package xed
+
+import (
+	"fmt"
+	"strings"
+)
+
+const maxOperands = 12
+
+type DecodedInst struct {
+	mmode MachineMode
+	saw   AddressWidth
+
+	valid   bool
+	itext   [MAX_INSTRUCTION_BYTES]byte
+	length  uint
+	nprefix uint
+
+	mode  uint
+	pfx66 bool
+	pfx67 bool
+	lock  bool
+	rep   byte
+	seg   Reg
+
+	rex  byte
+	vex  byte
+	rexW uint8
+	rexR uint8
+	rexX uint8
+	rexB uint8
+	vvvv int
+	vexl uint
+	vexp int
+
+	mapn     int
+	opcode   byte
+	modrm    byte
+	hasModrm bool
+	sib      byte
+	hasSib   bool
+
+	osz uint
+	asz uint
+	ssz uint
+	vl  uint
+
+	disp        int64
+	dispWidth   uint
+	imm         uint64
+	immWidth    uint
+	immSigned   bool
+	imm1        uint8
+	nimm        int
+	brdisp      int32
+	brdispWidth uint
+
+	entry  *opEntry
+	iclass IClass
+	isa    ISASet
+
+	inst   Inst
+	mem    [2]memOp
+	nmem   uint
+	nreg   int
+	flags  bool
+	nomodr bool
+}
+
+type memOp struct {
+	seg       Reg
+	base      Reg
+	index     Reg
+	scale     uint
+	disp      int64
+	dispWidth uint
+	bits      uint
+	action    OperandAction
+	agen      bool
+}
+
+func (c *DecodedInst) NumOperands() uint {
+	return c.inst.noperands
+}
+
+func (c *DecodedInst) operand(i uint) *Operand {
+	if i >= c.inst.noperands {
+		return &Operand{}
+	}
+	return &c.inst.operands[i]
+}
+
+func (c *DecodedInst) OperandAction(operand_index uint) OperandAction {
+	return c.operand(operand_index).action
+}
+
+func (c *DecodedInst) OperandLength(operand_index uint) uint {
+	return c.operand(operand_index).bits / 8
+}
+
+func (c *DecodedInst) OperandLengthBits(operand_index uint) uint {
+	return c.operand(operand_index).bits
+}
+
+func (c *DecodedInst) OperandElements(operand_index uint) uint {
+	o := c.operand(operand_index)
+	if o.elem == 0 {
+		return 0
+	}
+	return o.bits / o.elem
+}
+
+func (c *DecodedInst) OperandElementSizeBits(operand_index uint) uint {
+	return c.operand(operand_index).elem
+}
+
+func (c *DecodedInst) Zero() {
+	*c = DecodedInst{}
+}
+
+func (c *DecodedInst) ISASet() ISASet {
+	return c.isa
+}
+
+func (c *DecodedInst) SetMode(mmode MachineMode, stack_addr_width AddressWidth) {
+	c.mmode = mmode
+	c.saw = stack_addr_width
+}
+
+func (c *DecodedInst) OperandsConst() *OperandValues {
+	return (*OperandValues)(c)
+}
+
+func (c *DecodedInst) Operands() *OperandValues {
+	return (*OperandValues)(c)
+}
+
+func (c *DecodedInst) SetScale(scale int) {
+	c.mem[0].scale = uint(scale)
+}
+
+func (c *DecodedInst) Valid() bool {
+	return c.valid
+}
+
+// Decode decodes an instruction in the machine mode set before, the mode is
+// kept for the next call.
+func (c *DecodedInst) Decode(itext []byte) error {
+	mmode, saw := c.mmode, c.saw
+	*c = DecodedInst{mmode: mmode, saw: saw}
+	err := c.decode(itext)
+	if err != ERROR_NONE {
+		c.valid = false
+		return xederror(err)
+	}
+	c.valid = true
+	return nil
+}
+
+func (c *DecodedInst) Length() uint {
+	return c.length
+}
+
+func (c *DecodedInst) Extension() Extension {
+	return isaExtension(c.isa)
+}
+
+func (c *DecodedInst) MachineModeBits() int {
+	switch c.mmode {
+	case MACHINE_MODE_LONG_64:
+		return 64
+	case MACHINE_MODE_LONG_COMPAT_32, MACHINE_MODE_LEGACY_32:
+		return 32
+	case MACHINE_MODE_LONG_COMPAT_16, MACHINE_MODE_LEGACY_16, MACHINE_MODE_REAL_16:
+		return 16
+	}
+	return 0
+}
+
+func (c *DecodedInst) MemopAddressWidth(memop_idx int) int {
+	return int(c.MemOpAddressWidth(uint(memop_idx)))
+}
+
+func (c *DecodedInst) Modrm() int {
+	return int(c.modrm)
+}
+
+// Reg returns the register of a register operand or of the base, index and
+// segment of the memory operands.
+func (c *DecodedInst) Reg(reg_operand OperandMode) Reg {
+	switch reg_operand {
+	case OPERAND_BASE0:
+		return c.BaseReg(0)
+	case OPERAND_BASE1:
+		return c.BaseReg(1)
+	case OPERAND_INDEX:
+		return c.IndexReg(0)
+	case OPERAND_SEG0:
+		return c.SegReg(0)
+	case OPERAND_SEG1:
+		return c.SegReg(1)
+	}
+	for i := uint(0); i < c.inst.noperands; i++ {
+		o := &c.inst.operands[i]
+		if o.name == reg_operand {
+			return o.reg
+		}
+	}
+	return REG_INVALID
+}
+
+// OperandWidth returns the effective operand width, 8 for byte operations.
+func (c *DecodedInst) OperandWidth() uint32 {
+	if c.inst.noperands > 0 {
+		o := &c.inst.operands[0]
+		if o.bits == 8 && (o.reg.Class() == REG_CLASS_GPR || o.isMem()) {
+			return 8
+		}
+	}
+	return uint32(c.osz)
+}
+
+func (c *DecodedInst) IClass() IClass {
+	return c.iclass
+}
+
+func (c *DecodedInst) memop(mem_idx uint) *memOp {
+	if mem_idx >= c.nmem {
+		return &memOp{}
+	}
+	return &c.mem[mem_idx]
+}
+
+func (c *DecodedInst) MemRead(mem_idx uint) bool {
+	m := c.memop(mem_idx)
+	if m.agen {
+		return false
+	}
+	switch m.action {
+	case OPERAND_ACTION_R, OPERAND_ACTION_RW, OPERAND_ACTION_CR, OPERAND_ACTION_CRW, OPERAND_ACTION_RCW:
+		return true
+	}
+	return false
+}
+
+func (c *DecodedInst) MemWritten(mem_idx uint) bool {
+	switch c.memop(mem_idx).action {
+	case OPERAND_ACTION_W, OPERAND_ACTION_RW, OPERAND_ACTION_CW, OPERAND_ACTION_CRW, OPERAND_ACTION_RCW:
+		return true
+	}
+	return false
+}
+
+func (c *DecodedInst) MemWrittenOnly(mem_idx uint) bool {
+	switch c.memop(mem_idx).action {
+	case OPERAND_ACTION_W, OPERAND_ACTION_CW:
+		return true
+	}
+	return false
+}
+
+func (c *DecodedInst) Merging() bool {
+	return false
+}
+
+func (c *DecodedInst) UsesRflags() bool {
+	return c.flags
+}
+
+func (c *DecodedInst) BaseReg(mem_idx uint) Reg {
+	return c.memop(mem_idx).base
+}
+
+func (c *DecodedInst) BranchDisplacement() int32 {
+	return c.brdisp
+}
+
+func (c *DecodedInst) BranchDisplacementWidth() uint {
+	return c.brdispWidth
+}
+
+func (c *DecodedInst) BranchDisplacementWidthBits() uint {
+	return c.brdispWidth * 8
+}
+
+func (c *DecodedInst) ImmediateWidthBits() uint {
+	return c.immWidth * 8
+}
+
+func (c *DecodedInst) ImmediateIsSigned() uint {
+	if c.immSigned {
+		return 1
+	}
+	return 0
+}
+
+func (c *DecodedInst) SignedImmediate() int32 {
+	return int32(SignExtendArbitraryTo64(c.imm, c.immWidth*8))
+}
+
+func (c *DecodedInst) UnsignedImmediate() uint64 {
+	return c.imm
+}
+
+func (c *DecodedInst) SecondImmediate() uint8 {
+	return c.imm1
+}
+
+func (c *DecodedInst) ZeroSetMode(state *State) {
+	*c = DecodedInst{mmode: state.mmode, saw: state.stack_addr_width}
+}
+
+func (c *DecodedInst) Zeroing() bool {
+	return false
+}
+
+func (c *DecodedInst) Category() Category {
+	return iclassCategory(c.entry, c.Extension())
+}
+
+func (c *DecodedInst) Inst() *Inst {
+	return &c.inst
+}
+
+func (c *DecodedInst) NumPrefixes() uint {
+	return c.nprefix
+}
+
+func (c *DecodedInst) IsBroadcast() bool {
+	return c.Category() == CATEGORY_BROADCAST
+}
+
+func (c *DecodedInst) ClassifySSE() bool {
+	switch c.Extension() {
+	case EXTENSION_SSE, EXTENSION_SSE2, EXTENSION_SSE3, EXTENSION_SSSE3, EXTENSION_SSE4, EXTENSION_SSE4A,
+		EXTENSION_AES, EXTENSION_PCLMULQDQ, EXTENSION_SHA:
+		return true
+	}
+	return false
+}
+
+func (c *DecodedInst) ClassifyAVX() bool {
+	switch c.Extension() {
+	case EXTENSION_AVX, EXTENSION_AVX2, EXTENSION_AVX2GATHER, EXTENSION_AVXAES, EXTENSION_FMA, EXTENSION_F16C:
+		return true
+	}
+	return false
+}
+
+func (c *DecodedInst) ClassifyAVX512() bool {
+	return false
+}
+
+func (c *DecodedInst) ClassifyAVX512MaskOp() bool {
+	return false
+}
+
+func (c *DecodedInst) MaskedVectorOperation() bool {
+	return false
+}
+
+func (c *DecodedInst) Byte(byte_index uint) uint8 {
+	if byte_index >= c.length {
+		return 0
+	}
+	return c.itext[byte_index]
+}
+
+func (c *DecodedInst) VectorLengthBits() uint {
+	return c.vl
+}
+
+func (c *DecodedInst) NumberOfMemoryOperands() uint {
+	return c.nmem
+}
+
+func (c *DecodedInst) SegReg(mem_idx uint) Reg {
+	return c.memop(mem_idx).seg
+}
+
+func (c *DecodedInst) IndexReg(mem_idx uint) Reg {
+	return c.memop(mem_idx).index
+}
+
+func (c *DecodedInst) Scale(mem_idx uint) uint {
+	return c.memop(mem_idx).scale
+}
+
+func (c *DecodedInst) MemoryDisplacement(mem_idx uint) uint {
+	return uint(c.memop(mem_idx).disp)
+}
+
+func (c *DecodedInst) MemoryDisplacementWidth(mem_idx uint) uint {
+	return c.memop(mem_idx).dispWidth
+}
+
+func (c *DecodedInst) MemoryDisplacementWidthBits(mem_idx uint) uint {
+	return c.memop(mem_idx).dispWidth * 8
+}
+
+func (c *DecodedInst) MemOpAddressWidth(mem_idx uint) uint {
+	m := c.memop(mem_idx)
+	if m.base == REG_STACKPUSH || m.base == REG_STACKPOP {
+		return c.ssz
+	}
+	return c.asz
+}
+
+func (c *DecodedInst) MemoryOperandLength(mem_idx uint) uint {
+	return c.memop(mem_idx).bits / 8
+}
+
+func (c *DecodedInst) Dump() string {
+	if !c.valid {
+		return "invalid instruction"
+	}
+	w := new(strings.Builder)
+	fmt.Fprintf(w, "%v %v %v %v ", c.iclass, c.Category(), c.Extension(), c.isa)
+	fmt.Fprintf(w, "MODE=%d EOSZ=%d EASZ=%d LENGTH=%d", c.mode, c.osz, c.asz, c.length)
+	for i := uint(0); i < c.inst.noperands; i++ {
+		o := &c.inst.operands[i]
+		fmt.Fprintf(w, "\n%d %v/%v/%v/%v ", i, o.name, o.action, o.width, o.vis)
+		switch {
+		case o.isMem():
+			m := &c.mem[o.mem]
+			fmt.Fprintf(w, "SEG=%v BASE=%v INDEX=%v SCALE=%d DISP=%#x", m.seg, m.base, m.index, m.scale, m.disp)
+		case o.name == OPERAND_IMM0 || o.name == OPERAND_IMM1 || o.name == OPERAND_RELBR || o.name == OPERAND_PTR:
+			fmt.Fprintf(w, "%#x", c.operandValue(o))
+		default:
+			fmt.Fprintf(w, "%v", o.reg)
+		}
+	}
+	return w.String()
+}
+
+// DumpXEDFormat returns the iclass followed by the operands with their
+// names.
+func (c *DecodedInst) DumpXEDFormat(runtime_address uint64) (string, bool) {
+	if !c.valid {
+		return "", false
+	}
+	w := new(strings.Builder)
+	w.WriteString(c.iclass.String())
+	for i := uint(0); i < c.inst.noperands; i++ {
+		o := &c.inst.operands[i]
+		if o.vis == OPVIS_SUPPRESSED {
+			continue
+		}
+		fmt.Fprintf(w, " %v=", o.name)
+		switch {
+		case o.isMem():
+			w.WriteString(strings.ToUpper(c.intelMem(o)))
+		case o.name == OPERAND_RELBR:
+			fmt.Fprintf(w, "%#x", c.branchTarget(runtime_address))
+		case o.name == OPERAND_IMM0 || o.name == OPERAND_IMM1 || o.name == OPERAND_PTR:
+			fmt.Fprintf(w, "%#x", c.operandValue(o))
+		default:
+			w.WriteString(o.reg.String())
+		}
+	}
+	return w.String(), true
+}
+
+func (c *DecodedInst) String() string {
+	str, _ := FormatContext(SYNTAX_XED, c, 0, nil)
+	return str
+}
+
+// fetch returns the next byte of the instruction.
+func (c *DecodedInst) fetch(b []byte) (byte, Error) {
+	if int(c.length) >= len(b) {
+		if len(b) >= MAX_INSTRUCTION_BYTES {
+			return 0, ERROR_INSTR_TOO_LONG
+		}
+		return 0, ERROR_BUFFER_TOO_SHORT
+	}
+	x := b[c.length]
+	c.length++
+	return x, ERROR_NONE
+}
+
+// fetchN returns the next n bytes of the instruction as a little endian
+// number.
+func (c *DecodedInst) fetchN(b []byte, n uint) (uint64, Error) {
+	var v uint64
+	for i := uint(0); i < n; i++ {
+		x, err := c.fetch(b)
+		if err != ERROR_NONE {
+			return 0, err
+		}
+		v |= uint64(x) << (8 * i)
+	}
+	return v, ERROR_NONE
+}
+
+func (c *DecodedInst) decode(itext []byte) Error {
+	c.mode = uint(c.MachineModeBits())
+	if c.mode == 0 {
+		return ERROR_INVALID_MODE
+	}
+	c.ssz = 64
+	if c.mode != 64 {
+		c.ssz = uint(c.saw.Bits())
+		if c.ssz == 0 {
+			c.ssz = c.mode
+		}
+	}
+
+	b := itext
+	if len(b) > MAX_INSTRUCTION_BYTES {
+		b = b[:MAX_INSTRUCTION_BYTES]
+	}
+	copy(c.itext[:], b)
+
+	var (
+		x   byte
+		err Error
+	)
+prefixes:
+	for {
+		x, err = c.fetch(b)
+		if err != ERROR_NONE {
+			return err
+		}
+		switch x {
+		case 0xF0:
+			c.lock = true
+		case 0xF2, 0xF3:
+			c.rep = x
+		case 0x66:
+			c.pfx66 = true
+		case 0x67:
+			c.pfx67 = true
+		case 0x26, 0x2E, 0x36, 0x3E:
+			c.seg = segRegs[x>>3&3]
+		case 0x64, 0x65:
+			c.seg = segRegs[x-0x60]
+		default:
+			if c.mode == 64 && x&0xF0 == 0x40 {
+				c.rex = x
+				continue
+			}
+			break prefixes
+		}
+		// a rex prefix is ignored if it isn't the last one
+		c.rex = 0
+	}
+	c.nprefix = c.length - 1
+	if c.rex != 0 {
+		c.rexW = c.rex >> 3 & 1
+		c.rexR = c.rex >> 2 & 1
+		c.rexX = c.rex >> 1 & 1
+		c.rexB = c.rex & 1
+	}
+
+	switch c.mode {
+	case 64:
+		c.asz = 64
+		if c.pfx67 {
+			c.asz = 32
+		}
+	case 32:
+		c.asz = 32
+		if c.pfx67 {
+			c.asz = 16
+		}
+	default:
+		c.asz = 16
+		if c.pfx67 {
+			c.asz = 32
+		}
+	}
+
+	if x == 0xC4 || x == 0xC5 {
+		// outside of 64 bit mode these are LES and LDS unless the
+		// modrm byte would be a register
+		if int(c.length) >= len(b) {
+			_, err = c.fetch(b)
+			return err
+		}
+		if c.mode == 64 || b[c.length]&0xC0 == 0xC0 {
+			err = c.decodeVEX(b, x)
+			if err != ERROR_NONE {
+				return err
+			}
+			x, err = c.fetch(b)
+			if err != ERROR_NONE {
+				return err
+			}
+		}
+	}
+	if c.vex == 0 && x == 0x0F {
+		c.mapn = 1
+		x, err = c.fetch(b)
+		if err != ERROR_NONE {
+			return err
+		}
+		if x == 0x38 || x == 0x3A {
+			c.mapn = 2
+			if x == 0x3A {
+				c.mapn = 3
+			}
+			x, err = c.fetch(b)
+			if err != ERROR_NONE {
+				return err
+			}
+		}
+	}
+	c.opcode = x
+
+	v := 0
+	c.vl = 128
+	if c.vex != 0 {
+		v = 1
+		c.vl = 128 << c.vexl
+	}
+	slot := opTable[v][c.mapn][c.opcode]
+	if len(slot) == 0 {
+		return ERROR_GENERAL_ERROR
+	}
+	if slot[0].modrm {
+		c.modrm, err = c.fetch(b)
+		if err != ERROR_NONE {
+			return err
+		}
+		c.hasModrm = true
+	}
+
+	e := c.match(slot)
+	if e == nil {
+		return ERROR_GENERAL_ERROR
+	}
+	c.entry = e
+	c.osz = c.opsize(e)
+	if c.lock && (e.attr&attrLock == 0 || c.modrm>>6 == 3) {
+		return ERROR_BAD_LOCK_PREFIX
+	}
+	if c.vex != 0 && c.vvvv != 0 && !e.hasVVVV() {
+		return ERROR_BAD_REGISTER
+	}
+
+	if c.hasModrm && c.modrm>>6 != 3 && e.attr&attrRmReg == 0 {
+		err = c.decodeModrm(b)
+		if err != ERROR_NONE {
+			return err
+		}
+	}
+	err = c.decodeImmediates(b, e)
+	if err != ERROR_NONE {
+		return err
+	}
+
+	for i := range e.ops {
+		err = c.addOperand(&e.ops[i])
+		if err != ERROR_NONE {
+			return err
+		}
+	}
+	if c.rep != 0 && e.attr&(attrRep|attrRepcc) != 0 {
+		c.addOperand(&opSpec{kind: kindSized, size: 'a', reg: 1, action: OPERAND_ACTION_RW, vis: OPVIS_SUPPRESSED})
+	}
+	if a, ok := flagActions[e.iclass]; ok {
+		c.addOperand(&opSpec{kind: kindFlags, action: a, vis: OPVIS_SUPPRESSED})
+	}
+	if c.gatherOverlap() {
+		return ERROR_GATHER_REGS
+	}
+
+	c.iclass = e.iclass
+	c.isa = e.isa[c.vexl]
+	name := e.iclass.String()
+	switch {
+	case e.attr&attrRep != 0 && c.rep == 0xF3:
+		c.iclass = iclassByName["REP_"+name]
+	case e.attr&attrRepcc != 0 && c.rep == 0xF3:
+		c.iclass = iclassByName["REPE_"+name]
+	case e.attr&attrRepcc != 0 && c.rep == 0xF2:
+		c.iclass = iclassByName["REPNE_"+name]
+	case c.lock:
+		if ic, ok := iclassByName[name+"_LOCK"]; ok {
+			c.iclass = ic
+		}
+	}
+	c.inst.iclass = c.iclass
+	return ERROR_NONE
+}
+
+func (c *DecodedInst) decodeVEX(b []byte, x byte) Error {
+	if c.pfx66 || c.rep != 0 || c.lock {
+		return ERROR_BAD_LEGACY_PREFIX
+	}
+	if c.rex != 0 {
+		return ERROR_BAD_REX_PREFIX
+	}
+	c.vex = x
+	v1, err := c.fetch(b)
+	if err != ERROR_NONE {
+		return err
+	}
+	v2 := v1
+	c.mapn = 1
+	c.rexR = v1>>7 ^ 1
+	if x == 0xC4 {
+		c.rexX = v1>>6&1 ^ 1
+		c.rexB = v1>>5&1 ^ 1
+		c.mapn = int(v1 & 0x1F)
+		v2, err = c.fetch(b)
+		if err != ERROR_NONE {
+			return err
+		}
+		c.rexW = v2 >> 7
+	}
+	c.vvvv = int(^v2>>3) & 15
+	c.vexl = uint(v2 >> 2 & 1)
+	c.vexp = int(v2 & 3)
+	if c.mode != 64 {
+		c.rexR, c.rexX, c.rexB = 0, 0, 0
+		c.vvvv &= 7
+	}
+	if c.mapn < 1 || c.mapn > 3 {
+		return ERROR_BAD_MAP
+	}
+	return ERROR_NONE
+}
+
+// match returns the entry of an opcode selected by the prefixes and the
+// modrm byte. Of the entries matching the one with the most specific
+// prefix is used, then the first one in the table.
+func (c *DecodedInst) match(slot []*opEntry) *opEntry {
+	var best *opEntry
+	prio := -1
+	for _, e := range slot {
+		p := c.prefixPriority(e)
+		if p > prio && c.matches(e) {
+			best, prio = e, p
+		}
+	}
+	return best
+}
+
+func (c *DecodedInst) prefixPriority(e *opEntry) int {
+	if c.vex != 0 {
+		if e.pfx == pfxNone+c.vexp {
+			return 0
+		}
+		return -1
+	}
+	switch e.pfx {
+	case pfxF3:
+		if c.rep == 0xF3 {
+			return 3
+		}
+	case pfxF2:
+		if c.rep == 0xF2 {
+			return 3
+		}
+	case pfx66:
+		if c.pfx66 && c.rep == 0 {
+			return 2
+		}
+	case pfxNone:
+		if !c.pfx66 && c.rep == 0 {
+			return 1
+		}
+	case pfxAny:
+		return 0
+	}
+	return -1
+}
+
+func (c *DecodedInst) matches(e *opEntry) bool {
+	mod := c.modrm >> 6
+	switch {
+	case e.reg >= 0 && int(c.modrm>>3&7) != e.reg,
+		e.rmbyte >= 0 && int(c.modrm) != e.rmbyte,
+		e.mod == modMem && mod == 3,
+		e.mod == modReg && mod != 3,
+		e.vexl >= 0 && uint(e.vexl) != c.vexl,
+		e.w >= 0 && int(c.rexW) != e.w,
+		e.attr&attrI64 != 0 && c.mode == 64,
+		e.attr&attrO64 != 0 && c.mode != 64,
+		e.attr&attrRexB0 != 0 && c.rexB != 0,
+		e.osz != 0 && c.opsize(e) != e.osz,
+		e.asz != 0 && c.asz != e.asz:
+		return false
+	}
+	return true
+}
+
+// opsize returns the effective operand size of an entry.
+func (c *DecodedInst) opsize(e *opEntry) uint {
+	if c.mode == 64 {
+		switch {
+		case c.rexW != 0, e.attr&attrF64 != 0:
+			return 64
+		case e.attr&attrD64 != 0 && !c.pfx66:
+			return 64
+		}
+	}
+	sz := uint(32)
+	if c.mode == 16 {
+		sz = 16
+	}
+	if c.pfx66 && e.pfx != pfx66 {
+		sz = 48 - sz
+	}
+	return sz
+}
+
+func (e *opEntry) hasVVVV() bool {
+	for _, o := range e.ops {
+		if o.kind == 'H' || o.kind == 'B' {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *DecodedInst) decodeModrm(b []byte) Error {
+	var err Error
+	mod, rm := c.modrm>>6, c.modrm&7
+	if c.asz == 16 {
+		switch {
+		case mod == 0 && rm == 6, mod == 2:
+			c.dispWidth = 2
+		case mod == 1:
+			c.dispWidth = 1
+		}
+	} else {
+		if rm == 4 {
+			c.sib, err = c.fetch(b)
+			if err != ERROR_NONE {
+				return err
+			}
+			c.hasSib = true
+		}
+		switch {
+		case mod == 0 && rm == 5, mod == 0 && rm == 4 && c.sib&7 == 5, mod == 2:
+			c.dispWidth = 4
+		case mod == 1:
+			c.dispWidth = 1
+		}
+	}
+	v, err := c.fetchN(b, c.dispWidth)
+	c.disp = SignExtendArbitraryTo64(v, c.dispWidth*8)
+	return err
+}
+
+func (c *DecodedInst) decodeImmediates(b []byte, e *opEntry) Error {
+	for _, o := range e.ops {
+		var (
+			v   uint64
+			n   uint
+			err Error
+		)
+		switch o.kind {
+		case 'I', 'L':
+			n = c.widthBits(o.width) / 8
+			if o.kind == 'L' {
+				n = 1
+			}
+			v, err = c.fetchN(b, n)
+			if c.nimm == 0 {
+				c.imm, c.immWidth, c.immSigned = v, n, o.signed
+			} else {
+				c.imm1 = uint8(v)
+			}
+			c.nimm++
+
+		case 'J':
+			n = c.widthBits(o.width) / 8
+			v, err = c.fetchN(b, n)
+			c.brdisp = int32(SignExtendArbitraryTo64(v, n*8))
+			c.brdispWidth = n
+
+		case 'A':
+			n = 4
+			if c.osz == 16 {
+				n = 2
+			}
+			v, err = c.fetchN(b, n)
+			if err != ERROR_NONE {
+				return err
+			}
+			c.brdisp = int32(v)
+			c.brdispWidth = n
+			c.imm, err = c.fetchN(b, 2)
+			c.immWidth = 2
+
+		case 'O':
+			c.dispWidth = c.asz / 8
+			v, err = c.fetchN(b, c.dispWidth)
+			c.disp = int64(v)
+		}
+		if err != ERROR_NONE {
+			return err
+		}
+	}
+	return ERROR_NONE
+}
+
+// widthBits returns the size of an operand width in bits.
+func (c *DecodedInst) widthBits(w int) uint {
+	switch w {
+	case widthB:
+		return 8
+	case widthW:
+		return 16
+	case widthD, widthSS:
+		return 32
+	case widthQ, widthSD:
+		return 64
+	case widthV:
+		return c.osz
+	case widthZ:
+		if c.osz == 16 {
+			return 16
+		}
+		return 32
+	case widthY:
+		if c.osz == 64 {
+			return 64
+		}
+		return 32
+	case widthDQ:
+		return 128
+	case widthQQ:
+		return 256
+	case widthX, widthPS, widthPD:
+		return c.vl
+	case widthH:
+		return c.vl / 2
+	case widthQT:
+		return c.vl / 4
+	case widthOT:
+		return c.vl / 8
+	case widthT:
+		return 80
+	case widthE:
+		if c.osz == 16 {
+			return 14 * 8
+		}
+		return 28 * 8
+	case widthF:
+		if c.osz == 16 {
+			return 94 * 8
+		}
+		return 108 * 8
+	case widthFX:
+		return 512 * 8
+	case widthXS:
+		return 576 * 8
+	case widthP:
+		return 16 + c.osz
+	case widthS:
+		if c.mode == 64 {
+			return 80
+		}
+		return 48
+	case widthA:
+		return 2 * c.osz
+	case widthN:
+		if c.mode == 64 {
+			return 64
+		}
+		return 32
+	}
+	return 0
+}
+
+// elemBits returns the size of the elements of an operand width.
+func elemBits(w int, bits uint) uint {
+	switch w {
+	case widthPS, widthSS:
+		return 32
+	case widthPD, widthSD:
+		return 64
+	}
+	return bits
+}
+
+func (c *DecodedInst) vecReg(w int, n int) Reg {
+	if w == widthQQ || c.vl == 256 && (w == widthX || w == widthPS || w == widthPD) {
+		return REG_YMM0 + Reg(n)
+	}
+	return REG_XMM0 + Reg(n)
+}
+
+// segment returns the segment of a memory operand with a default one, in
+// 64 bit mode only the FS and GS overrides are used.
+func (c *DecodedInst) segment(def Reg) Reg {
+	if c.mode == 64 {
+		if c.seg == REG_FS || c.seg == REG_GS {
+			return c.seg
+		}
+		return REG_INVALID
+	}
+	if c.seg != REG_INVALID {
+		return c.seg
+	}
+	return def
+}
+
+func (c *DecodedInst) addOperand(o *opSpec) Error {
+	if c.inst.noperands >= maxOperands {
+		return ERROR_GENERAL_ERROR
+	}
+	p := &c.inst.operands[c.inst.noperands]
+	*p = Operand{
+		width:  OperandWidth(o.width),
+		vis:    o.vis,
+		action: o.action,
+		kind:   o.kind,
+	}
+	p.bits = c.widthBits(o.width)
+	p.elem = elemBits(o.width, p.bits)
+
+	mod := c.modrm >> 6
+	reg := int(c.modrm>>3&7) | int(c.rexR)<<3
+	rm := int(c.modrm&7) | int(c.rexB)<<3
+	mem := c.hasModrm && mod != 3 && c.entry.attr&attrRmReg == 0
+	var err Error
+	switch o.kind {
+	case 'E', 'M', 'W', 'Q', 'T', kindAgen:
+		if mem {
+			err = c.addModrmMem(p, o)
+			break
+		}
+		switch o.kind {
+		case 'E':
+			err = c.addReg(p, gpr(p.bits, rm, c.rex != 0))
+		case 'W':
+			err = c.addReg(p, c.vecReg(o.width, rm))
+		case 'Q':
+			err = c.addReg(p, REG_MMX0+Reg(rm&7))
+		default:
+			err = ERROR_GENERAL_ERROR
+		}
+	case 'R':
+		err = c.addReg(p, gpr(p.bits, rm, c.rex != 0))
+	case 'U':
+		err = c.addReg(p, c.vecReg(o.width, rm))
+	case 'N':
+		err = c.addReg(p, REG_MMX0+Reg(rm&7))
+	case 'G':
+		err = c.addReg(p, gpr(p.bits, reg, c.rex != 0))
+	case 'V':
+		err = c.addReg(p, c.vecReg(o.width, reg))
+	case 'P':
+		err = c.addReg(p, REG_MMX0+Reg(reg&7))
+	case 'S':
+		n := reg & 7
+		if n >= len(segRegs) || segRegs[n] == REG_CS && o.action != OPERAND_ACTION_R {
+			return ERROR_BAD_REGISTER
+		}
+		err = c.addReg(p, segRegs[n])
+	case 'C':
+		switch reg {
+		case 0, 2, 3, 4, 8:
+		default:
+			return ERROR_BAD_REGISTER
+		}
+		err = c.addReg(p, REG_CR0+Reg(reg))
+	case 'D':
+		if reg >= 8 {
+			return ERROR_BAD_REGISTER
+		}
+		err = c.addReg(p, REG_DR0+Reg(reg))
+	case 'H':
+		err = c.addReg(p, c.vecReg(o.width, c.vvvv))
+	case 'B':
+		err = c.addReg(p, gpr(p.bits, c.vvvv, true))
+	case 'L':
+		n := int(c.imm >> 4)
+		if c.mode != 64 {
+			n &= 7
+		}
+		err = c.addReg(p, c.vecReg(o.width, n))
+	case 'Z':
+		err = c.addReg(p, gpr(p.bits, int(c.opcode&7)|int(c.rexB)<<3, c.rex != 0))
+	case kindSTi:
+		p.bits = 80
+		err = c.addReg(p, REG_ST0+Reg(c.modrm&7))
+	case kindReg:
+		p.bits = o.reg.bits()
+		err = c.addReg(p, o.reg)
+	case kindSized:
+		var bits uint
+		switch o.size {
+		case 'r':
+			bits = c.osz
+		case 'e':
+			bits = c.widthBits(widthZ)
+		case 'a':
+			bits = c.asz
+		case 's':
+			bits = c.ssz
+		case 'm':
+			bits = c.mode
+		}
+		p.bits = bits
+		if o.reg == 8 {
+			r := REG_RIP
+			switch bits {
+			case 16:
+				r = REG_IP
+			case 32:
+				r = REG_EIP
+			}
+			err = c.addReg(p, r)
+		} else {
+			err = c.addReg(p, gpr(bits, int(o.reg), true))
+		}
+	case kindFlags:
+		r := REG_RFLAGS
+		switch c.mode {
+		case 16:
+			r = REG_FLAGS
+		case 32:
+			r = REG_EFLAGS
+		}
+		p.bits = c.mode
+		c.flags = true
+		err = c.addReg(p, r)
+	case 'I':
+		p.name = OPERAND_IMM0
+		for i := uint(0); i < c.inst.noperands; i++ {
+			if c.inst.operands[i].name == OPERAND_IMM0 {
+				p.name = OPERAND_IMM1
+				p.bits = 8
+			}
+		}
+		c.inst.noperands++
+	case kindOne:
+		p.name = OPERAND_IMM0
+		p.bits = 8
+		c.imm = 1
+		c.inst.noperands++
+	case 'J':
+		p.name = OPERAND_RELBR
+		c.inst.noperands++
+	case 'A':
+		p.name = OPERAND_PTR
+		c.inst.noperands++
+	case 'O':
+		err = c.addMem(p, memOp{seg: c.segment(REG_DS), disp: c.disp, dispWidth: c.dispWidth, scale: 1})
+	case 'X':
+		err = c.addMem(p, memOp{seg: c.segment(REG_DS), base: gpr(c.asz, 6, true), scale: 1})
+	case kindDI:
+		err = c.addMem(p, memOp{seg: c.segment(REG_DS), base: gpr(c.asz, 7, true), scale: 1})
+	case kindXlat:
+		err = c.addMem(p, memOp{seg: c.segment(REG_DS), base: gpr(c.asz, 3, true), index: REG_AL, scale: 1})
+	case 'Y':
+		seg := REG_ES
+		if c.mode == 64 {
+			seg = REG_INVALID
+		}
+		err = c.addMem(p, memOp{seg: seg, base: gpr(c.asz, 7, true), scale: 1})
+	case 'K':
+		base := REG_STACKPUSH
+		if o.action == OPERAND_ACTION_R {
+			base = REG_STACKPOP
+		}
+		seg := REG_SS
+		if c.mode == 64 {
+			seg = REG_INVALID
+		}
+		c.nomodr = true
+		err = c.addMem(p, memOp{seg: seg, base: base, scale: 1})
+	default:
+		err = ERROR_GENERAL_ERROR
+	}
+	return err
+}
+
+func (c *DecodedInst) addReg(p *Operand, r Reg) Error {
+	if c.nreg > int(OPERAND_REG8-OPERAND_REG0) {
+		return ERROR_GENERAL_ERROR
+	}
+	p.name = OPERAND_REG0 + OperandMode(c.nreg)
+	p.reg = r
+	c.nreg++
+	c.inst.noperands++
+	return ERROR_NONE
+}
+
+func (c *DecodedInst) addMem(p *Operand, m memOp) Error {
+	if c.nmem >= uint(len(c.mem)) {
+		return ERROR_GENERAL_ERROR
+	}
+	if p.kind != 'K' && !c.hasModrm {
+		c.nomodr = true
+	}
+	m.bits = p.bits
+	m.action = p.action
+	p.name = OPERAND_MEM0 + OperandMode(c.nmem)
+	if m.agen {
+		p.name = OPERAND_AGEN
+	}
+	p.mem = c.nmem
+	c.mem[c.nmem] = m
+	c.nmem++
+	c.inst.noperands++
+	return ERROR_NONE
+}
+
+var (
+	modrm16Base  = [8]Reg{REG_BX, REG_BX, REG_BP, REG_BP, REG_SI, REG_DI, REG_BP, REG_BX}
+	modrm16Index = [8]Reg{REG_SI, REG_DI, REG_SI, REG_DI}
+)
+
+func (c *DecodedInst) addModrmMem(p *Operand, o *opSpec) Error {
+	m := memOp{
+		disp:      c.disp,
+		dispWidth: c.dispWidth,
+		scale:     1,
+		agen:      o.kind == kindAgen,
+	}
+	mod, rm := c.modrm>>6, c.modrm&7
+	def := REG_DS
+	if c.asz == 16 {
+		if o.kind == 'T' {
+			return ERROR_GENERAL_ERROR
+		}
+		if mod != 0 || rm != 6 {
+			m.base = modrm16Base[rm]
+			m.index = modrm16Index[rm]
+		}
+		if m.base == REG_BP {
+			def = REG_SS
+		}
+	} else {
+		switch {
+		case rm == 4:
+			m.scale = 1 << (c.sib >> 6)
+			index := int(c.sib>>3&7) | int(c.rexX)<<3
+			switch {
+			case o.kind == 'T':
+				w := widthX
+				if o.size == 'x' {
+					w = widthDQ
+				}
+				m.index = c.vecReg(w, index)
+			case index != 4:
+				m.index = gpr(c.asz, index, true)
+			}
+			if c.sib&7 != 5 || mod != 0 {
+				m.base = gpr(c.asz, int(c.sib&7)|int(c.rexB)<<3, true)
+			}
+		case o.kind == 'T':
+			return ERROR_GENERAL_ERROR
+		case mod == 0 && rm == 5:
+			if c.mode == 64 {
+				m.base = REG_RIP
+				if c.asz == 32 {
+					m.base = REG_EIP
+				}
+			}
+		default:
+			m.base = gpr(c.asz, int(rm)|int(c.rexB)<<3, true)
+		}
+		switch m.base {
+		case REG_ESP, REG_EBP, REG_RSP, REG_RBP:
+			def = REG_SS
+		}
+	}
+	m.seg = c.segment(def)
+	if m.agen {
+		m.seg = REG_INVALID
+	}
+	if o.kind == 'T' {
+		// a gather reads an element for every index, the odd opcodes
+		// and the ones with qword elements have qword indices
+		n := c.vl / 32
+		if c.opcode&1 != 0 || p.bits == 64 {
+			n = c.vl / 64
+		}
+		p.elem = p.bits
+		p.bits *= n
+	}
+	return c.addMem(p, m)
+}
+
+// gatherOverlap reports if the destination, index and mask registers of a
+// gather are not distinct.
+func (c *DecodedInst) gatherOverlap() bool {
+	if c.entry.isa[0] != ISA_SET_AVX2GATHER {
+		return false
+	}
+	dst := c.inst.operands[0].reg
+	mask := c.inst.operands[2].reg
+	index := c.mem[0].index
+	n := func(r Reg) Reg {
+		if r >= REG_YMM0 {
+			return r - REG_YMM0
+		}
+		return r - REG_XMM0
+	}
+	return n(dst) == n(mask) || n(dst) == n(index) || n(mask) == n(index)
+}
+
+// operandValue returns the value of an immediate or branch operand.
+func (c *DecodedInst) operandValue(o *Operand) uint64 {
+	switch o.name {
+	case OPERAND_IMM0:
+		return c.imm
+	case OPERAND_IMM1:
+		return uint64(c.imm1)
+	case OPERAND_RELBR:
+		return uint64(int64(c.brdisp))
+	case OPERAND_PTR:
+		return uint64(uint32(c.brdisp))
+	}
+	return 0
+}
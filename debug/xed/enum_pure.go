@@ -0,0 +1,4846 @@
+//go:build !cgo || purego
+// +build !cgo purego
+
+package xed
+
+const (
+	MAX_INSTRUCTION_BYTES      = 15
+	MAX_IMMEDIATE_BYTES        = 8
+	MAX_DISPLACEMENT_BYTES     = 8
+	MAX_CPUID_BITS_PER_ISA_SET = 4
+)
+
+const (
+	OPERAND_ACTION_INVALID OperandAction = iota
+	OPERAND_ACTION_RW
+	OPERAND_ACTION_R
+	OPERAND_ACTION_W
+	OPERAND_ACTION_RCW
+	OPERAND_ACTION_CW
+	OPERAND_ACTION_CRW
+	OPERAND_ACTION_CR
+	OPERAND_ACTION_LAST
+)
+
+const (
+	EXTENSION_INVALID Extension = iota
+	EXTENSION_3DNOW
+	EXTENSION_ADOX_ADCX
+	EXTENSION_AES
+	EXTENSION_AVX
+	EXTENSION_AVX2
+	EXTENSION_AVX2GATHER
+	EXTENSION_AVX512EVEX
+	EXTENSION_AVX512VEX
+	EXTENSION_AVXAES
+	EXTENSION_BASE
+	EXTENSION_BMI1
+	EXTENSION_BMI2
+	EXTENSION_CET
+	EXTENSION_CLDEMOTE
+	EXTENSION_CLFLUSHOPT
+	EXTENSION_CLFSH
+	EXTENSION_CLWB
+	EXTENSION_CLZERO
+	EXTENSION_F16C
+	EXTENSION_FMA
+	EXTENSION_FMA4
+	EXTENSION_GFNI
+	EXTENSION_INVPCID
+	EXTENSION_LONGMODE
+	EXTENSION_LZCNT
+	EXTENSION_MMX
+	EXTENSION_MONITOR
+	EXTENSION_MONITORX
+	EXTENSION_MOVBE
+	EXTENSION_MOVDIR
+	EXTENSION_MPX
+	EXTENSION_PAUSE
+	EXTENSION_PCLMULQDQ
+	EXTENSION_PCONFIG
+	EXTENSION_PKU
+	EXTENSION_PREFETCHWT1
+	EXTENSION_PT
+	EXTENSION_RDPID
+	EXTENSION_RDRAND
+	EXTENSION_RDSEED
+	EXTENSION_RDTSCP
+	EXTENSION_RDWRFSGS
+	EXTENSION_RTM
+	EXTENSION_SGX
+	EXTENSION_SGX_ENCLV
+	EXTENSION_SHA
+	EXTENSION_SMAP
+	EXTENSION_SMX
+	EXTENSION_SSE
+	EXTENSION_SSE2
+	EXTENSION_SSE3
+	EXTENSION_SSE4
+	EXTENSION_SSE4A
+	EXTENSION_SSSE3
+	EXTENSION_SVM
+	EXTENSION_TBM
+	EXTENSION_VAES
+	EXTENSION_VMFUNC
+	EXTENSION_VPCLMULQDQ
+	EXTENSION_VTX
+	EXTENSION_WAITPKG
+	EXTENSION_WBNOINVD
+	EXTENSION_X87
+	EXTENSION_XOP
+	EXTENSION_XSAVE
+	EXTENSION_XSAVEC
+	EXTENSION_XSAVEOPT
+	EXTENSION_XSAVES
+	EXTENSION_LAST
+)
+
+const (
+	ERROR_NONE Error = iota
+	ERROR_BUFFER_TOO_SHORT
+	ERROR_GENERAL_ERROR
+	ERROR_INVALID_FOR_CHIP
+	ERROR_BAD_REGISTER
+	ERROR_BAD_LOCK_PREFIX
+	ERROR_BAD_REP_PREFIX
+	ERROR_BAD_LEGACY_PREFIX
+	ERROR_BAD_REX_PREFIX
+	ERROR_BAD_EVEX_UBIT
+	ERROR_BAD_MAP
+	ERROR_BAD_EVEX_V_PRIME
+	ERROR_BAD_EVEX_Z_NO_MASKING
+	ERROR_NO_OUTPUT_POINTER
+	ERROR_NO_AGEN_CALL_BACK_REGISTERED
+	ERROR_BAD_MEMOP_INDEX
+	ERROR_CALLBACK_PROBLEM
+	ERROR_GATHER_REGS
+	ERROR_INSTR_TOO_LONG
+	ERROR_INVALID_MODE
+	ERROR_BAD_EVEX_LL
+	ERROR_LAST
+)
+
+const (
+	OPVIS_INVALID OperandVisibility = iota
+	OPVIS_EXPLICIT
+	OPVIS_IMPLICIT
+	OPVIS_SUPPRESSED
+	OPVIS_LAST
+)
+
+const (
+	MACHINE_MODE_INVALID MachineMode = iota
+	MACHINE_MODE_LONG_64
+	MACHINE_MODE_LONG_COMPAT_32
+	MACHINE_MODE_LONG_COMPAT_16
+	MACHINE_MODE_LEGACY_32
+	MACHINE_MODE_LEGACY_16
+	MACHINE_MODE_REAL_16
+	MACHINE_MODE_LAST
+)
+
+const (
+	ADDRESS_WIDTH_INVALID AddressWidth = iota
+	ADDRESS_WIDTH_16b
+	ADDRESS_WIDTH_32b
+	ADDRESS_WIDTH_64b
+	ADDRESS_WIDTH_LAST
+)
+
+const (
+	CATEGORY_INVALID Category = iota
+	CATEGORY_3DNOW
+	CATEGORY_ADOX_ADCX
+	CATEGORY_AES
+	CATEGORY_AVX
+	CATEGORY_AVX2
+	CATEGORY_AVX2GATHER
+	CATEGORY_AVX512
+	CATEGORY_AVX512_4FMAPS
+	CATEGORY_AVX512_4VNNIW
+	CATEGORY_AVX512_BITALG
+	CATEGORY_AVX512_VBMI
+	CATEGORY_BINARY
+	CATEGORY_BITBYTE
+	CATEGORY_BLEND
+	CATEGORY_BMI1
+	CATEGORY_BMI2
+	CATEGORY_BROADCAST
+	CATEGORY_CALL
+	CATEGORY_CET
+	CATEGORY_CLDEMOTE
+	CATEGORY_CLFLUSHOPT
+	CATEGORY_CLWB
+	CATEGORY_CLZERO
+	CATEGORY_CMOV
+	CATEGORY_COMPRESS
+	CATEGORY_COND_BR
+	CATEGORY_CONFLICT
+	CATEGORY_CONVERT
+	CATEGORY_DATAXFER
+	CATEGORY_DECIMAL
+	CATEGORY_EXPAND
+	CATEGORY_FCMOV
+	CATEGORY_FLAGOP
+	CATEGORY_FMA4
+	CATEGORY_GATHER
+	CATEGORY_GFNI
+	CATEGORY_IFMA
+	CATEGORY_INTERRUPT
+	CATEGORY_IO
+	CATEGORY_IOSTRINGOP
+	CATEGORY_KMASK
+	CATEGORY_LOGICAL
+	CATEGORY_LOGICAL_FP
+	CATEGORY_LZCNT
+	CATEGORY_MISC
+	CATEGORY_MMX
+	CATEGORY_MOVDIR
+	CATEGORY_MPX
+	CATEGORY_NOP
+	CATEGORY_PCLMULQDQ
+	CATEGORY_PCONFIG
+	CATEGORY_PKU
+	CATEGORY_POP
+	CATEGORY_PREFETCH
+	CATEGORY_PREFETCHWT1
+	CATEGORY_PT
+	CATEGORY_PUSH
+	CATEGORY_RDPID
+	CATEGORY_RDRAND
+	CATEGORY_RDSEED
+	CATEGORY_RDWRFSGS
+	CATEGORY_RET
+	CATEGORY_ROTATE
+	CATEGORY_SCATTER
+	CATEGORY_SEGOP
+	CATEGORY_SEMAPHORE
+	CATEGORY_SETCC
+	CATEGORY_SGX
+	CATEGORY_SHA
+	CATEGORY_SHIFT
+	CATEGORY_SMAP
+	CATEGORY_SSE
+	CATEGORY_STRINGOP
+	CATEGORY_STTNI
+	CATEGORY_SYSCALL
+	CATEGORY_SYSRET
+	CATEGORY_SYSTEM
+	CATEGORY_TBM
+	CATEGORY_UNCOND_BR
+	CATEGORY_VAES
+	CATEGORY_VBMI2
+	CATEGORY_VFMA
+	CATEGORY_VPCLMULQDQ
+	CATEGORY_VTX
+	CATEGORY_WAITPKG
+	CATEGORY_WIDENOP
+	CATEGORY_X87_ALU
+	CATEGORY_XOP
+	CATEGORY_XSAVE
+	CATEGORY_XSAVEOPT
+	CATEGORY_LAST
+)
+
+const (
+	OPERAND_INVALID OperandMode = iota
+	OPERAND_AGEN
+	OPERAND_AMD3DNOW
+	OPERAND_ASZ
+	OPERAND_BASE0
+	OPERAND_BASE1
+	OPERAND_BCAST
+	OPERAND_BCRC
+	OPERAND_BRDISP_WIDTH
+	OPERAND_CET
+	OPERAND_CHIP
+	OPERAND_CLDEMOTE
+	OPERAND_DEFAULT_SEG
+	OPERAND_DF32
+	OPERAND_DF64
+	OPERAND_DISP
+	OPERAND_DISP_WIDTH
+	OPERAND_DUMMY
+	OPERAND_EASZ
+	OPERAND_ELEMENT_SIZE
+	OPERAND_ENCODER_PREFERRED
+	OPERAND_EOSZ
+	OPERAND_ERROR
+	OPERAND_ESRC
+	OPERAND_FIRST_F2F3
+	OPERAND_HAS_MODRM
+	OPERAND_HAS_SIB
+	OPERAND_HINT
+	OPERAND_ICLASS
+	OPERAND_ILD_F2
+	OPERAND_ILD_F3
+	OPERAND_ILD_SEG
+	OPERAND_IMM0
+	OPERAND_IMM0SIGNED
+	OPERAND_IMM1
+	OPERAND_IMM1_BYTES
+	OPERAND_IMM_WIDTH
+	OPERAND_INDEX
+	OPERAND_LAST_F2F3
+	OPERAND_LLRC
+	OPERAND_LOCK
+	OPERAND_LZCNT
+	OPERAND_MAP
+	OPERAND_MASK
+	OPERAND_MAX_BYTES
+	OPERAND_MEM0
+	OPERAND_MEM1
+	OPERAND_MEM_WIDTH
+	OPERAND_MOD
+	OPERAND_MODE
+	OPERAND_MODEP5
+	OPERAND_MODEP55C
+	OPERAND_MODE_FIRST_PREFIX
+	OPERAND_MODRM_BYTE
+	OPERAND_MPXMODE
+	OPERAND_NEEDREX
+	OPERAND_NEED_MEMDISP
+	OPERAND_NELEM
+	OPERAND_NOMINAL_OPCODE
+	OPERAND_NOREX
+	OPERAND_NO_SCALE_DISP8
+	OPERAND_NPREFIXES
+	OPERAND_NREXES
+	OPERAND_NSEG_PREFIXES
+	OPERAND_OSZ
+	OPERAND_OUTREG
+	OPERAND_OUT_OF_BYTES
+	OPERAND_P4
+	OPERAND_POS_DISP
+	OPERAND_POS_IMM
+	OPERAND_POS_IMM1
+	OPERAND_POS_MODRM
+	OPERAND_POS_NOMINAL_OPCODE
+	OPERAND_POS_SIB
+	OPERAND_PREFIX66
+	OPERAND_PTR
+	OPERAND_REALMODE
+	OPERAND_REG
+	OPERAND_REG0
+	OPERAND_REG1
+	OPERAND_REG2
+	OPERAND_REG3
+	OPERAND_REG4
+	OPERAND_REG5
+	OPERAND_REG6
+	OPERAND_REG7
+	OPERAND_REG8
+	OPERAND_RELBR
+	OPERAND_REP
+	OPERAND_REX
+	OPERAND_REXB
+	OPERAND_REXR
+	OPERAND_REXRR
+	OPERAND_REXW
+	OPERAND_REXX
+	OPERAND_RM
+	OPERAND_ROUNDC
+	OPERAND_SAE
+	OPERAND_SCALE
+	OPERAND_SEG0
+	OPERAND_SEG1
+	OPERAND_SEG_OVD
+	OPERAND_SIB
+	OPERAND_SIBBASE
+	OPERAND_SIBINDEX
+	OPERAND_SIBSCALE
+	OPERAND_SMODE
+	OPERAND_SRM
+	OPERAND_TZCNT
+	OPERAND_UBIT
+	OPERAND_UIMM0
+	OPERAND_UIMM1
+	OPERAND_USING_DEFAULT_SEGMENT0
+	OPERAND_USING_DEFAULT_SEGMENT1
+	OPERAND_VEXDEST210
+	OPERAND_VEXDEST3
+	OPERAND_VEXDEST4
+	OPERAND_VEXVALID
+	OPERAND_VEX_C4
+	OPERAND_VEX_PREFIX
+	OPERAND_VL
+	OPERAND_WBNOINVD
+	OPERAND_ZEROING
+	OPERAND_LAST
+)
+
+const (
+	REG_INVALID Reg = iota
+	REG_BNDCFGU
+	REG_BNDSTATUS
+	REG_BND0
+	REG_BND1
+	REG_BND2
+	REG_BND3
+	REG_CR0
+	REG_CR1
+	REG_CR2
+	REG_CR3
+	REG_CR4
+	REG_CR5
+	REG_CR6
+	REG_CR7
+	REG_CR8
+	REG_CR9
+	REG_CR10
+	REG_CR11
+	REG_CR12
+	REG_CR13
+	REG_CR14
+	REG_CR15
+	REG_DR0
+	REG_DR1
+	REG_DR2
+	REG_DR3
+	REG_DR4
+	REG_DR5
+	REG_DR6
+	REG_DR7
+	REG_FLAGS
+	REG_EFLAGS
+	REG_RFLAGS
+	REG_AX
+	REG_CX
+	REG_DX
+	REG_BX
+	REG_SP
+	REG_BP
+	REG_SI
+	REG_DI
+	REG_R8W
+	REG_R9W
+	REG_R10W
+	REG_R11W
+	REG_R12W
+	REG_R13W
+	REG_R14W
+	REG_R15W
+	REG_EAX
+	REG_ECX
+	REG_EDX
+	REG_EBX
+	REG_ESP
+	REG_EBP
+	REG_ESI
+	REG_EDI
+	REG_R8D
+	REG_R9D
+	REG_R10D
+	REG_R11D
+	REG_R12D
+	REG_R13D
+	REG_R14D
+	REG_R15D
+	REG_RAX
+	REG_RCX
+	REG_RDX
+	REG_RBX
+	REG_RSP
+	REG_RBP
+	REG_RSI
+	REG_RDI
+	REG_R8
+	REG_R9
+	REG_R10
+	REG_R11
+	REG_R12
+	REG_R13
+	REG_R14
+	REG_R15
+	REG_AL
+	REG_CL
+	REG_DL
+	REG_BL
+	REG_SPL
+	REG_BPL
+	REG_SIL
+	REG_DIL
+	REG_R8B
+	REG_R9B
+	REG_R10B
+	REG_R11B
+	REG_R12B
+	REG_R13B
+	REG_R14B
+	REG_R15B
+	REG_AH
+	REG_CH
+	REG_DH
+	REG_BH
+	REG_ERROR
+	REG_RIP
+	REG_EIP
+	REG_IP
+	REG_K0
+	REG_K1
+	REG_K2
+	REG_K3
+	REG_K4
+	REG_K5
+	REG_K6
+	REG_K7
+	REG_MMX0
+	REG_MMX1
+	REG_MMX2
+	REG_MMX3
+	REG_MMX4
+	REG_MMX5
+	REG_MMX6
+	REG_MMX7
+	REG_SSP
+	REG_IA32_U_CET
+	REG_MXCSR
+	REG_STACKPUSH
+	REG_STACKPOP
+	REG_GDTR
+	REG_LDTR
+	REG_IDTR
+	REG_TR
+	REG_TSC
+	REG_TSCAUX
+	REG_MSRS
+	REG_FSBASE
+	REG_GSBASE
+	REG_X87CONTROL
+	REG_X87STATUS
+	REG_X87TAG
+	REG_X87PUSH
+	REG_X87POP
+	REG_X87POP2
+	REG_X87OPCODE
+	REG_X87LASTCS
+	REG_X87LASTIP
+	REG_X87LASTDS
+	REG_X87LASTDP
+	REG_CS
+	REG_DS
+	REG_ES
+	REG_SS
+	REG_FS
+	REG_GS
+	REG_TMP0
+	REG_TMP1
+	REG_TMP2
+	REG_TMP3
+	REG_TMP4
+	REG_TMP5
+	REG_TMP6
+	REG_TMP7
+	REG_TMP8
+	REG_TMP9
+	REG_TMP10
+	REG_TMP11
+	REG_TMP12
+	REG_TMP13
+	REG_TMP14
+	REG_TMP15
+	REG_ST0
+	REG_ST1
+	REG_ST2
+	REG_ST3
+	REG_ST4
+	REG_ST5
+	REG_ST6
+	REG_ST7
+	REG_XCR0
+	REG_XMM0
+	REG_XMM1
+	REG_XMM2
+	REG_XMM3
+	REG_XMM4
+	REG_XMM5
+	REG_XMM6
+	REG_XMM7
+	REG_XMM8
+	REG_XMM9
+	REG_XMM10
+	REG_XMM11
+	REG_XMM12
+	REG_XMM13
+	REG_XMM14
+	REG_XMM15
+	REG_XMM16
+	REG_XMM17
+	REG_XMM18
+	REG_XMM19
+	REG_XMM20
+	REG_XMM21
+	REG_XMM22
+	REG_XMM23
+	REG_XMM24
+	REG_XMM25
+	REG_XMM26
+	REG_XMM27
+	REG_XMM28
+	REG_XMM29
+	REG_XMM30
+	REG_XMM31
+	REG_YMM0
+	REG_YMM1
+	REG_YMM2
+	REG_YMM3
+	REG_YMM4
+	REG_YMM5
+	REG_YMM6
+	REG_YMM7
+	REG_YMM8
+	REG_YMM9
+	REG_YMM10
+	REG_YMM11
+	REG_YMM12
+	REG_YMM13
+	REG_YMM14
+	REG_YMM15
+	REG_YMM16
+	REG_YMM17
+	REG_YMM18
+	REG_YMM19
+	REG_YMM20
+	REG_YMM21
+	REG_YMM22
+	REG_YMM23
+	REG_YMM24
+	REG_YMM25
+	REG_YMM26
+	REG_YMM27
+	REG_YMM28
+	REG_YMM29
+	REG_YMM30
+	REG_YMM31
+	REG_ZMM0
+	REG_ZMM1
+	REG_ZMM2
+	REG_ZMM3
+	REG_ZMM4
+	REG_ZMM5
+	REG_ZMM6
+	REG_ZMM7
+	REG_ZMM8
+	REG_ZMM9
+	REG_ZMM10
+	REG_ZMM11
+	REG_ZMM12
+	REG_ZMM13
+	REG_ZMM14
+	REG_ZMM15
+	REG_ZMM16
+	REG_ZMM17
+	REG_ZMM18
+	REG_ZMM19
+	REG_ZMM20
+	REG_ZMM21
+	REG_ZMM22
+	REG_ZMM23
+	REG_ZMM24
+	REG_ZMM25
+	REG_ZMM26
+	REG_ZMM27
+	REG_ZMM28
+	REG_ZMM29
+	REG_ZMM30
+	REG_ZMM31
+	REG_LAST
+)
+
+const (
+	REG_BNDCFG_FIRST    = REG_BNDCFGU
+	REG_BNDCFG_LAST     = REG_BNDCFGU
+	REG_BNDSTAT_FIRST   = REG_BNDSTATUS
+	REG_BNDSTAT_LAST    = REG_BNDSTATUS
+	REG_BOUND_FIRST     = REG_BND0
+	REG_BOUND_LAST      = REG_BND3
+	REG_CR_FIRST        = REG_CR0
+	REG_CR_LAST         = REG_CR15
+	REG_DR_FIRST        = REG_DR0
+	REG_DR_LAST         = REG_DR7
+	REG_FLAGS_FIRST     = REG_FLAGS
+	REG_FLAGS_LAST      = REG_RFLAGS
+	REG_GPR16_FIRST     = REG_AX
+	REG_GPR16_LAST      = REG_R15W
+	REG_GPR32_FIRST     = REG_EAX
+	REG_GPR32_LAST      = REG_R15D
+	REG_GPR64_FIRST     = REG_RAX
+	REG_GPR64_LAST      = REG_R15
+	REG_GPR8_FIRST      = REG_AL
+	REG_GPR8_LAST       = REG_R15B
+	REG_GPR8h_FIRST     = REG_AH
+	REG_GPR8h_LAST      = REG_BH
+	REG_INVALID_FIRST   = REG_ERROR
+	REG_INVALID_LAST    = REG_ERROR
+	REG_IP_FIRST        = REG_RIP
+	REG_IP_LAST         = REG_IP
+	REG_MASK_FIRST      = REG_K0
+	REG_MASK_LAST       = REG_K7
+	REG_MMX_FIRST       = REG_MMX0
+	REG_MMX_LAST        = REG_MMX7
+	REG_MSR_FIRST       = REG_SSP
+	REG_MSR_LAST        = REG_IA32_U_CET
+	REG_MXCSR_FIRST     = REG_MXCSR
+	REG_MXCSR_LAST      = REG_MXCSR
+	REG_PSEUDO_FIRST    = REG_STACKPUSH
+	REG_PSEUDO_LAST     = REG_GSBASE
+	REG_PSEUDOX87_FIRST = REG_X87CONTROL
+	REG_PSEUDOX87_LAST  = REG_X87LASTDP
+	REG_SR_FIRST        = REG_CS
+	REG_SR_LAST         = REG_GS
+	REG_TMP_FIRST       = REG_TMP0
+	REG_TMP_LAST        = REG_TMP15
+	REG_X87_FIRST       = REG_ST0
+	REG_X87_LAST        = REG_ST7
+	REG_XCR_FIRST       = REG_XCR0
+	REG_XCR_LAST        = REG_XCR0
+	REG_XMM_FIRST       = REG_XMM0
+	REG_XMM_LAST        = REG_XMM31
+	REG_YMM_FIRST       = REG_YMM0
+	REG_YMM_LAST        = REG_YMM31
+	REG_ZMM_FIRST       = REG_ZMM0
+	REG_ZMM_LAST        = REG_ZMM31
+)
+
+const (
+	REG_CLASS_INVALID RegClass = iota
+	REG_CLASS_BNDCFG
+	REG_CLASS_BNDSTAT
+	REG_CLASS_BOUND
+	REG_CLASS_CR
+	REG_CLASS_DR
+	REG_CLASS_FLAGS
+	REG_CLASS_GPR
+	REG_CLASS_GPR16
+	REG_CLASS_GPR32
+	REG_CLASS_GPR64
+	REG_CLASS_GPR8
+	REG_CLASS_IP
+	REG_CLASS_MASK
+	REG_CLASS_MMX
+	REG_CLASS_MSR
+	REG_CLASS_MXCSR
+	REG_CLASS_PSEUDO
+	REG_CLASS_PSEUDOX87
+	REG_CLASS_SR
+	REG_CLASS_TMP
+	REG_CLASS_X87
+	REG_CLASS_XCR
+	REG_CLASS_XMM
+	REG_CLASS_YMM
+	REG_CLASS_ZMM
+	REG_CLASS_LAST
+)
+
+const (
+	ICLASS_INVALID IClass = iota
+	ICLASS_AAA
+	ICLASS_AAD
+	ICLASS_AAM
+	ICLASS_AAS
+	ICLASS_ADC
+	ICLASS_ADCX
+	ICLASS_ADC_LOCK
+	ICLASS_ADD
+	ICLASS_ADDPD
+	ICLASS_ADDPS
+	ICLASS_ADDSD
+	ICLASS_ADDSS
+	ICLASS_ADDSUBPD
+	ICLASS_ADDSUBPS
+	ICLASS_ADD_LOCK
+	ICLASS_ADOX
+	ICLASS_AESDEC
+	ICLASS_AESDECLAST
+	ICLASS_AESENC
+	ICLASS_AESENCLAST
+	ICLASS_AESIMC
+	ICLASS_AESKEYGENASSIST
+	ICLASS_AND
+	ICLASS_ANDN
+	ICLASS_ANDNPD
+	ICLASS_ANDNPS
+	ICLASS_ANDPD
+	ICLASS_ANDPS
+	ICLASS_AND_LOCK
+	ICLASS_ARPL
+	ICLASS_BEXTR
+	ICLASS_BEXTR_XOP
+	ICLASS_BLCFILL
+	ICLASS_BLCI
+	ICLASS_BLCIC
+	ICLASS_BLCMSK
+	ICLASS_BLCS
+	ICLASS_BLENDPD
+	ICLASS_BLENDPS
+	ICLASS_BLENDVPD
+	ICLASS_BLENDVPS
+	ICLASS_BLSFILL
+	ICLASS_BLSI
+	ICLASS_BLSIC
+	ICLASS_BLSMSK
+	ICLASS_BLSR
+	ICLASS_BNDCL
+	ICLASS_BNDCN
+	ICLASS_BNDCU
+	ICLASS_BNDLDX
+	ICLASS_BNDMK
+	ICLASS_BNDMOV
+	ICLASS_BNDSTX
+	ICLASS_BOUND
+	ICLASS_BSF
+	ICLASS_BSR
+	ICLASS_BSWAP
+	ICLASS_BT
+	ICLASS_BTC
+	ICLASS_BTC_LOCK
+	ICLASS_BTR
+	ICLASS_BTR_LOCK
+	ICLASS_BTS
+	ICLASS_BTS_LOCK
+	ICLASS_BZHI
+	ICLASS_CALL_FAR
+	ICLASS_CALL_NEAR
+	ICLASS_CBW
+	ICLASS_CDQ
+	ICLASS_CDQE
+	ICLASS_CLAC
+	ICLASS_CLC
+	ICLASS_CLD
+	ICLASS_CLDEMOTE
+	ICLASS_CLFLUSH
+	ICLASS_CLFLUSHOPT
+	ICLASS_CLGI
+	ICLASS_CLI
+	ICLASS_CLRSSBSY
+	ICLASS_CLTS
+	ICLASS_CLWB
+	ICLASS_CLZERO
+	ICLASS_CMC
+	ICLASS_CMOVB
+	ICLASS_CMOVBE
+	ICLASS_CMOVL
+	ICLASS_CMOVLE
+	ICLASS_CMOVNB
+	ICLASS_CMOVNBE
+	ICLASS_CMOVNL
+	ICLASS_CMOVNLE
+	ICLASS_CMOVNO
+	ICLASS_CMOVNP
+	ICLASS_CMOVNS
+	ICLASS_CMOVNZ
+	ICLASS_CMOVO
+	ICLASS_CMOVP
+	ICLASS_CMOVS
+	ICLASS_CMOVZ
+	ICLASS_CMP
+	ICLASS_CMPPD
+	ICLASS_CMPPS
+	ICLASS_CMPSB
+	ICLASS_CMPSD
+	ICLASS_CMPSD_XMM
+	ICLASS_CMPSQ
+	ICLASS_CMPSS
+	ICLASS_CMPSW
+	ICLASS_CMPXCHG
+	ICLASS_CMPXCHG16B
+	ICLASS_CMPXCHG16B_LOCK
+	ICLASS_CMPXCHG8B
+	ICLASS_CMPXCHG8B_LOCK
+	ICLASS_CMPXCHG_LOCK
+	ICLASS_COMISD
+	ICLASS_COMISS
+	ICLASS_CPUID
+	ICLASS_CQO
+	ICLASS_CRC32
+	ICLASS_CVTDQ2PD
+	ICLASS_CVTDQ2PS
+	ICLASS_CVTPD2DQ
+	ICLASS_CVTPD2PI
+	ICLASS_CVTPD2PS
+	ICLASS_CVTPI2PD
+	ICLASS_CVTPI2PS
+	ICLASS_CVTPS2DQ
+	ICLASS_CVTPS2PD
+	ICLASS_CVTPS2PI
+	ICLASS_CVTSD2SI
+	ICLASS_CVTSD2SS
+	ICLASS_CVTSI2SD
+	ICLASS_CVTSI2SS
+	ICLASS_CVTSS2SD
+	ICLASS_CVTSS2SI
+	ICLASS_CVTTPD2DQ
+	ICLASS_CVTTPD2PI
+	ICLASS_CVTTPS2DQ
+	ICLASS_CVTTPS2PI
+	ICLASS_CVTTSD2SI
+	ICLASS_CVTTSS2SI
+	ICLASS_CWD
+	ICLASS_CWDE
+	ICLASS_DAA
+	ICLASS_DAS
+	ICLASS_DEC
+	ICLASS_DEC_LOCK
+	ICLASS_DIV
+	ICLASS_DIVPD
+	ICLASS_DIVPS
+	ICLASS_DIVSD
+	ICLASS_DIVSS
+	ICLASS_DPPD
+	ICLASS_DPPS
+	ICLASS_EMMS
+	ICLASS_ENCLS
+	ICLASS_ENCLU
+	ICLASS_ENCLV
+	ICLASS_ENDBR32
+	ICLASS_ENDBR64
+	ICLASS_ENTER
+	ICLASS_EXTRACTPS
+	ICLASS_EXTRQ
+	ICLASS_F2XM1
+	ICLASS_FABS
+	ICLASS_FADD
+	ICLASS_FADDP
+	ICLASS_FBLD
+	ICLASS_FBSTP
+	ICLASS_FCHS
+	ICLASS_FCMOVB
+	ICLASS_FCMOVBE
+	ICLASS_FCMOVE
+	ICLASS_FCMOVNB
+	ICLASS_FCMOVNBE
+	ICLASS_FCMOVNE
+	ICLASS_FCMOVNU
+	ICLASS_FCMOVU
+	ICLASS_FCOM
+	ICLASS_FCOMI
+	ICLASS_FCOMIP
+	ICLASS_FCOMP
+	ICLASS_FCOMPP
+	ICLASS_FCOS
+	ICLASS_FDECSTP
+	ICLASS_FDISI8087_NOP
+	ICLASS_FDIV
+	ICLASS_FDIVP
+	ICLASS_FDIVR
+	ICLASS_FDIVRP
+	ICLASS_FEMMS
+	ICLASS_FENI8087_NOP
+	ICLASS_FFREE
+	ICLASS_FFREEP
+	ICLASS_FIADD
+	ICLASS_FICOM
+	ICLASS_FICOMP
+	ICLASS_FIDIV
+	ICLASS_FIDIVR
+	ICLASS_FILD
+	ICLASS_FIMUL
+	ICLASS_FINCSTP
+	ICLASS_FIST
+	ICLASS_FISTP
+	ICLASS_FISTTP
+	ICLASS_FISUB
+	ICLASS_FISUBR
+	ICLASS_FLD
+	ICLASS_FLD1
+	ICLASS_FLDCW
+	ICLASS_FLDENV
+	ICLASS_FLDL2E
+	ICLASS_FLDL2T
+	ICLASS_FLDLG2
+	ICLASS_FLDLN2
+	ICLASS_FLDPI
+	ICLASS_FLDZ
+	ICLASS_FMUL
+	ICLASS_FMULP
+	ICLASS_FNCLEX
+	ICLASS_FNINIT
+	ICLASS_FNOP
+	ICLASS_FNSAVE
+	ICLASS_FNSTCW
+	ICLASS_FNSTENV
+	ICLASS_FNSTSW
+	ICLASS_FPATAN
+	ICLASS_FPREM
+	ICLASS_FPREM1
+	ICLASS_FPTAN
+	ICLASS_FRNDINT
+	ICLASS_FRSTOR
+	ICLASS_FSCALE
+	ICLASS_FSETPM287_NOP
+	ICLASS_FSIN
+	ICLASS_FSINCOS
+	ICLASS_FSQRT
+	ICLASS_FST
+	ICLASS_FSTP
+	ICLASS_FSTPNCE
+	ICLASS_FSUB
+	ICLASS_FSUBP
+	ICLASS_FSUBR
+	ICLASS_FSUBRP
+	ICLASS_FTST
+	ICLASS_FUCOM
+	ICLASS_FUCOMI
+	ICLASS_FUCOMIP
+	ICLASS_FUCOMP
+	ICLASS_FUCOMPP
+	ICLASS_FWAIT
+	ICLASS_FXAM
+	ICLASS_FXCH
+	ICLASS_FXRSTOR
+	ICLASS_FXRSTOR64
+	ICLASS_FXSAVE
+	ICLASS_FXSAVE64
+	ICLASS_FXTRACT
+	ICLASS_FYL2X
+	ICLASS_FYL2XP1
+	ICLASS_GETSEC
+	ICLASS_GF2P8AFFINEINVQB
+	ICLASS_GF2P8AFFINEQB
+	ICLASS_GF2P8MULB
+	ICLASS_HADDPD
+	ICLASS_HADDPS
+	ICLASS_HLT
+	ICLASS_HSUBPD
+	ICLASS_HSUBPS
+	ICLASS_IDIV
+	ICLASS_IMUL
+	ICLASS_IN
+	ICLASS_INC
+	ICLASS_INCSSPD
+	ICLASS_INCSSPQ
+	ICLASS_INC_LOCK
+	ICLASS_INSB
+	ICLASS_INSD
+	ICLASS_INSERTPS
+	ICLASS_INSERTQ
+	ICLASS_INSW
+	ICLASS_INT
+	ICLASS_INT1
+	ICLASS_INT3
+	ICLASS_INTO
+	ICLASS_INVD
+	ICLASS_INVEPT
+	ICLASS_INVLPG
+	ICLASS_INVLPGA
+	ICLASS_INVPCID
+	ICLASS_INVVPID
+	ICLASS_IRET
+	ICLASS_IRETD
+	ICLASS_IRETQ
+	ICLASS_JB
+	ICLASS_JBE
+	ICLASS_JCXZ
+	ICLASS_JECXZ
+	ICLASS_JL
+	ICLASS_JLE
+	ICLASS_JMP
+	ICLASS_JMP_FAR
+	ICLASS_JNB
+	ICLASS_JNBE
+	ICLASS_JNL
+	ICLASS_JNLE
+	ICLASS_JNO
+	ICLASS_JNP
+	ICLASS_JNS
+	ICLASS_JNZ
+	ICLASS_JO
+	ICLASS_JP
+	ICLASS_JRCXZ
+	ICLASS_JS
+	ICLASS_JZ
+	ICLASS_KADDB
+	ICLASS_KADDD
+	ICLASS_KADDQ
+	ICLASS_KADDW
+	ICLASS_KANDB
+	ICLASS_KANDD
+	ICLASS_KANDNB
+	ICLASS_KANDND
+	ICLASS_KANDNQ
+	ICLASS_KANDNW
+	ICLASS_KANDQ
+	ICLASS_KANDW
+	ICLASS_KMOVB
+	ICLASS_KMOVD
+	ICLASS_KMOVQ
+	ICLASS_KMOVW
+	ICLASS_KNOTB
+	ICLASS_KNOTD
+	ICLASS_KNOTQ
+	ICLASS_KNOTW
+	ICLASS_KORB
+	ICLASS_KORD
+	ICLASS_KORQ
+	ICLASS_KORTESTB
+	ICLASS_KORTESTD
+	ICLASS_KORTESTQ
+	ICLASS_KORTESTW
+	ICLASS_KORW
+	ICLASS_KSHIFTLB
+	ICLASS_KSHIFTLD
+	ICLASS_KSHIFTLQ
+	ICLASS_KSHIFTLW
+	ICLASS_KSHIFTRB
+	ICLASS_KSHIFTRD
+	ICLASS_KSHIFTRQ
+	ICLASS_KSHIFTRW
+	ICLASS_KTESTB
+	ICLASS_KTESTD
+	ICLASS_KTESTQ
+	ICLASS_KTESTW
+	ICLASS_KUNPCKBW
+	ICLASS_KUNPCKDQ
+	ICLASS_KUNPCKWD
+	ICLASS_KXNORB
+	ICLASS_KXNORD
+	ICLASS_KXNORQ
+	ICLASS_KXNORW
+	ICLASS_KXORB
+	ICLASS_KXORD
+	ICLASS_KXORQ
+	ICLASS_KXORW
+	ICLASS_LAHF
+	ICLASS_LAR
+	ICLASS_LDDQU
+	ICLASS_LDMXCSR
+	ICLASS_LDS
+	ICLASS_LEA
+	ICLASS_LEAVE
+	ICLASS_LES
+	ICLASS_LFENCE
+	ICLASS_LFS
+	ICLASS_LGDT
+	ICLASS_LGS
+	ICLASS_LIDT
+	ICLASS_LLDT
+	ICLASS_LLWPCB
+	ICLASS_LMSW
+	ICLASS_LODSB
+	ICLASS_LODSD
+	ICLASS_LODSQ
+	ICLASS_LODSW
+	ICLASS_LOOP
+	ICLASS_LOOPE
+	ICLASS_LOOPNE
+	ICLASS_LSL
+	ICLASS_LSS
+	ICLASS_LTR
+	ICLASS_LWPINS
+	ICLASS_LWPVAL
+	ICLASS_LZCNT
+	ICLASS_MASKMOVDQU
+	ICLASS_MASKMOVQ
+	ICLASS_MAXPD
+	ICLASS_MAXPS
+	ICLASS_MAXSD
+	ICLASS_MAXSS
+	ICLASS_MFENCE
+	ICLASS_MINPD
+	ICLASS_MINPS
+	ICLASS_MINSD
+	ICLASS_MINSS
+	ICLASS_MONITOR
+	ICLASS_MONITORX
+	ICLASS_MOV
+	ICLASS_MOVAPD
+	ICLASS_MOVAPS
+	ICLASS_MOVBE
+	ICLASS_MOVD
+	ICLASS_MOVDDUP
+	ICLASS_MOVDIR64B
+	ICLASS_MOVDIRI
+	ICLASS_MOVDQ2Q
+	ICLASS_MOVDQA
+	ICLASS_MOVDQU
+	ICLASS_MOVHLPS
+	ICLASS_MOVHPD
+	ICLASS_MOVHPS
+	ICLASS_MOVLHPS
+	ICLASS_MOVLPD
+	ICLASS_MOVLPS
+	ICLASS_MOVMSKPD
+	ICLASS_MOVMSKPS
+	ICLASS_MOVNTDQ
+	ICLASS_MOVNTDQA
+	ICLASS_MOVNTI
+	ICLASS_MOVNTPD
+	ICLASS_MOVNTPS
+	ICLASS_MOVNTQ
+	ICLASS_MOVNTSD
+	ICLASS_MOVNTSS
+	ICLASS_MOVQ
+	ICLASS_MOVQ2DQ
+	ICLASS_MOVSB
+	ICLASS_MOVSD
+	ICLASS_MOVSD_XMM
+	ICLASS_MOVSHDUP
+	ICLASS_MOVSLDUP
+	ICLASS_MOVSQ
+	ICLASS_MOVSS
+	ICLASS_MOVSW
+	ICLASS_MOVSX
+	ICLASS_MOVSXD
+	ICLASS_MOVUPD
+	ICLASS_MOVUPS
+	ICLASS_MOVZX
+	ICLASS_MOV_CR
+	ICLASS_MOV_DR
+	ICLASS_MPSADBW
+	ICLASS_MUL
+	ICLASS_MULPD
+	ICLASS_MULPS
+	ICLASS_MULSD
+	ICLASS_MULSS
+	ICLASS_MULX
+	ICLASS_MWAIT
+	ICLASS_MWAITX
+	ICLASS_NEG
+	ICLASS_NEG_LOCK
+	ICLASS_NOP
+	ICLASS_NOP2
+	ICLASS_NOP3
+	ICLASS_NOP4
+	ICLASS_NOP5
+	ICLASS_NOP6
+	ICLASS_NOP7
+	ICLASS_NOP8
+	ICLASS_NOP9
+	ICLASS_NOT
+	ICLASS_NOT_LOCK
+	ICLASS_OR
+	ICLASS_ORPD
+	ICLASS_ORPS
+	ICLASS_OR_LOCK
+	ICLASS_OUT
+	ICLASS_OUTSB
+	ICLASS_OUTSD
+	ICLASS_OUTSW
+	ICLASS_PABSB
+	ICLASS_PABSD
+	ICLASS_PABSW
+	ICLASS_PACKSSDW
+	ICLASS_PACKSSWB
+	ICLASS_PACKUSDW
+	ICLASS_PACKUSWB
+	ICLASS_PADDB
+	ICLASS_PADDD
+	ICLASS_PADDQ
+	ICLASS_PADDSB
+	ICLASS_PADDSW
+	ICLASS_PADDUSB
+	ICLASS_PADDUSW
+	ICLASS_PADDW
+	ICLASS_PALIGNR
+	ICLASS_PAND
+	ICLASS_PANDN
+	ICLASS_PAUSE
+	ICLASS_PAVGB
+	ICLASS_PAVGUSB
+	ICLASS_PAVGW
+	ICLASS_PBLENDVB
+	ICLASS_PBLENDW
+	ICLASS_PCLMULQDQ
+	ICLASS_PCMPEQB
+	ICLASS_PCMPEQD
+	ICLASS_PCMPEQQ
+	ICLASS_PCMPEQW
+	ICLASS_PCMPESTRI
+	ICLASS_PCMPESTRM
+	ICLASS_PCMPGTB
+	ICLASS_PCMPGTD
+	ICLASS_PCMPGTQ
+	ICLASS_PCMPGTW
+	ICLASS_PCMPISTRI
+	ICLASS_PCMPISTRM
+	ICLASS_PCONFIG
+	ICLASS_PDEP
+	ICLASS_PEXT
+	ICLASS_PEXTRB
+	ICLASS_PEXTRD
+	ICLASS_PEXTRQ
+	ICLASS_PEXTRW
+	ICLASS_PEXTRW_SSE4
+	ICLASS_PF2ID
+	ICLASS_PF2IW
+	ICLASS_PFACC
+	ICLASS_PFADD
+	ICLASS_PFCMPEQ
+	ICLASS_PFCMPGE
+	ICLASS_PFCMPGT
+	ICLASS_PFMAX
+	ICLASS_PFMIN
+	ICLASS_PFMUL
+	ICLASS_PFNACC
+	ICLASS_PFPNACC
+	ICLASS_PFRCP
+	ICLASS_PFRCPIT2
+	ICLASS_PFRSQIT1
+	ICLASS_PFSUB
+	ICLASS_PFSUBR
+	ICLASS_PHADDD
+	ICLASS_PHADDSW
+	ICLASS_PHADDW
+	ICLASS_PHMINPOSUW
+	ICLASS_PHSUBD
+	ICLASS_PHSUBSW
+	ICLASS_PHSUBW
+	ICLASS_PI2FD
+	ICLASS_PI2FW
+	ICLASS_PINSRB
+	ICLASS_PINSRD
+	ICLASS_PINSRQ
+	ICLASS_PINSRW
+	ICLASS_PMADDUBSW
+	ICLASS_PMADDWD
+	ICLASS_PMAXSB
+	ICLASS_PMAXSD
+	ICLASS_PMAXSW
+	ICLASS_PMAXUB
+	ICLASS_PMAXUD
+	ICLASS_PMAXUW
+	ICLASS_PMINSB
+	ICLASS_PMINSD
+	ICLASS_PMINSW
+	ICLASS_PMINUB
+	ICLASS_PMINUD
+	ICLASS_PMINUW
+	ICLASS_PMOVMSKB
+	ICLASS_PMOVSXBD
+	ICLASS_PMOVSXBQ
+	ICLASS_PMOVSXBW
+	ICLASS_PMOVSXDQ
+	ICLASS_PMOVSXWD
+	ICLASS_PMOVSXWQ
+	ICLASS_PMOVZXBD
+	ICLASS_PMOVZXBQ
+	ICLASS_PMOVZXBW
+	ICLASS_PMOVZXDQ
+	ICLASS_PMOVZXWD
+	ICLASS_PMOVZXWQ
+	ICLASS_PMULDQ
+	ICLASS_PMULHRSW
+	ICLASS_PMULHRW
+	ICLASS_PMULHUW
+	ICLASS_PMULHW
+	ICLASS_PMULLD
+	ICLASS_PMULLW
+	ICLASS_PMULUDQ
+	ICLASS_POP
+	ICLASS_POPA
+	ICLASS_POPAD
+	ICLASS_POPCNT
+	ICLASS_POPF
+	ICLASS_POPFD
+	ICLASS_POPFQ
+	ICLASS_POR
+	ICLASS_PREFETCHNTA
+	ICLASS_PREFETCHT0
+	ICLASS_PREFETCHT1
+	ICLASS_PREFETCHT2
+	ICLASS_PREFETCHW
+	ICLASS_PREFETCHWT1
+	ICLASS_PREFETCH_EXCLUSIVE
+	ICLASS_PREFETCH_RESERVED
+	ICLASS_PSADBW
+	ICLASS_PSHUFB
+	ICLASS_PSHUFD
+	ICLASS_PSHUFHW
+	ICLASS_PSHUFLW
+	ICLASS_PSHUFW
+	ICLASS_PSIGNB
+	ICLASS_PSIGND
+	ICLASS_PSIGNW
+	ICLASS_PSLLD
+	ICLASS_PSLLDQ
+	ICLASS_PSLLQ
+	ICLASS_PSLLW
+	ICLASS_PSRAD
+	ICLASS_PSRAW
+	ICLASS_PSRLD
+	ICLASS_PSRLDQ
+	ICLASS_PSRLQ
+	ICLASS_PSRLW
+	ICLASS_PSUBB
+	ICLASS_PSUBD
+	ICLASS_PSUBQ
+	ICLASS_PSUBSB
+	ICLASS_PSUBSW
+	ICLASS_PSUBUSB
+	ICLASS_PSUBUSW
+	ICLASS_PSUBW
+	ICLASS_PSWAPD
+	ICLASS_PTEST
+	ICLASS_PTWRITE
+	ICLASS_PUNPCKHBW
+	ICLASS_PUNPCKHDQ
+	ICLASS_PUNPCKHQDQ
+	ICLASS_PUNPCKHWD
+	ICLASS_PUNPCKLBW
+	ICLASS_PUNPCKLDQ
+	ICLASS_PUNPCKLQDQ
+	ICLASS_PUNPCKLWD
+	ICLASS_PUSH
+	ICLASS_PUSHA
+	ICLASS_PUSHAD
+	ICLASS_PUSHF
+	ICLASS_PUSHFD
+	ICLASS_PUSHFQ
+	ICLASS_PXOR
+	ICLASS_RCL
+	ICLASS_RCPPS
+	ICLASS_RCPSS
+	ICLASS_RCR
+	ICLASS_RDFSBASE
+	ICLASS_RDGSBASE
+	ICLASS_RDMSR
+	ICLASS_RDPID
+	ICLASS_RDPKRU
+	ICLASS_RDPMC
+	ICLASS_RDRAND
+	ICLASS_RDSEED
+	ICLASS_RDSSPD
+	ICLASS_RDSSPQ
+	ICLASS_RDTSC
+	ICLASS_RDTSCP
+	ICLASS_REPE_CMPSB
+	ICLASS_REPE_CMPSD
+	ICLASS_REPE_CMPSQ
+	ICLASS_REPE_CMPSW
+	ICLASS_REPE_SCASB
+	ICLASS_REPE_SCASD
+	ICLASS_REPE_SCASQ
+	ICLASS_REPE_SCASW
+	ICLASS_REPNE_CMPSB
+	ICLASS_REPNE_CMPSD
+	ICLASS_REPNE_CMPSQ
+	ICLASS_REPNE_CMPSW
+	ICLASS_REPNE_SCASB
+	ICLASS_REPNE_SCASD
+	ICLASS_REPNE_SCASQ
+	ICLASS_REPNE_SCASW
+	ICLASS_REP_INSB
+	ICLASS_REP_INSD
+	ICLASS_REP_INSW
+	ICLASS_REP_LODSB
+	ICLASS_REP_LODSD
+	ICLASS_REP_LODSQ
+	ICLASS_REP_LODSW
+	ICLASS_REP_MOVSB
+	ICLASS_REP_MOVSD
+	ICLASS_REP_MOVSQ
+	ICLASS_REP_MOVSW
+	ICLASS_REP_OUTSB
+	ICLASS_REP_OUTSD
+	ICLASS_REP_OUTSW
+	ICLASS_REP_STOSB
+	ICLASS_REP_STOSD
+	ICLASS_REP_STOSQ
+	ICLASS_REP_STOSW
+	ICLASS_RET_FAR
+	ICLASS_RET_NEAR
+	ICLASS_ROL
+	ICLASS_ROR
+	ICLASS_RORX
+	ICLASS_ROUNDPD
+	ICLASS_ROUNDPS
+	ICLASS_ROUNDSD
+	ICLASS_ROUNDSS
+	ICLASS_RSM
+	ICLASS_RSQRTPS
+	ICLASS_RSQRTSS
+	ICLASS_RSTORSSP
+	ICLASS_SAHF
+	ICLASS_SALC
+	ICLASS_SAR
+	ICLASS_SARX
+	ICLASS_SAVEPREVSSP
+	ICLASS_SBB
+	ICLASS_SBB_LOCK
+	ICLASS_SCASB
+	ICLASS_SCASD
+	ICLASS_SCASQ
+	ICLASS_SCASW
+	ICLASS_SETB
+	ICLASS_SETBE
+	ICLASS_SETL
+	ICLASS_SETLE
+	ICLASS_SETNB
+	ICLASS_SETNBE
+	ICLASS_SETNL
+	ICLASS_SETNLE
+	ICLASS_SETNO
+	ICLASS_SETNP
+	ICLASS_SETNS
+	ICLASS_SETNZ
+	ICLASS_SETO
+	ICLASS_SETP
+	ICLASS_SETS
+	ICLASS_SETSSBSY
+	ICLASS_SETZ
+	ICLASS_SFENCE
+	ICLASS_SGDT
+	ICLASS_SHA1MSG1
+	ICLASS_SHA1MSG2
+	ICLASS_SHA1NEXTE
+	ICLASS_SHA1RNDS4
+	ICLASS_SHA256MSG1
+	ICLASS_SHA256MSG2
+	ICLASS_SHA256RNDS2
+	ICLASS_SHL
+	ICLASS_SHLD
+	ICLASS_SHLX
+	ICLASS_SHR
+	ICLASS_SHRD
+	ICLASS_SHRX
+	ICLASS_SHUFPD
+	ICLASS_SHUFPS
+	ICLASS_SIDT
+	ICLASS_SKINIT
+	ICLASS_SLDT
+	ICLASS_SLWPCB
+	ICLASS_SMSW
+	ICLASS_SQRTPD
+	ICLASS_SQRTPS
+	ICLASS_SQRTSD
+	ICLASS_SQRTSS
+	ICLASS_STAC
+	ICLASS_STC
+	ICLASS_STD
+	ICLASS_STGI
+	ICLASS_STI
+	ICLASS_STMXCSR
+	ICLASS_STOSB
+	ICLASS_STOSD
+	ICLASS_STOSQ
+	ICLASS_STOSW
+	ICLASS_STR
+	ICLASS_SUB
+	ICLASS_SUBPD
+	ICLASS_SUBPS
+	ICLASS_SUBSD
+	ICLASS_SUBSS
+	ICLASS_SUB_LOCK
+	ICLASS_SWAPGS
+	ICLASS_SYSCALL
+	ICLASS_SYSCALL_AMD
+	ICLASS_SYSENTER
+	ICLASS_SYSEXIT
+	ICLASS_SYSRET
+	ICLASS_SYSRET_AMD
+	ICLASS_T1MSKC
+	ICLASS_TEST
+	ICLASS_TPAUSE
+	ICLASS_TZCNT
+	ICLASS_TZMSK
+	ICLASS_UCOMISD
+	ICLASS_UCOMISS
+	ICLASS_UD0
+	ICLASS_UD1
+	ICLASS_UD2
+	ICLASS_UMONITOR
+	ICLASS_UMWAIT
+	ICLASS_UNPCKHPD
+	ICLASS_UNPCKHPS
+	ICLASS_UNPCKLPD
+	ICLASS_UNPCKLPS
+	ICLASS_V4FMADDPS
+	ICLASS_V4FMADDSS
+	ICLASS_V4FNMADDPS
+	ICLASS_V4FNMADDSS
+	ICLASS_VADDPD
+	ICLASS_VADDPS
+	ICLASS_VADDSD
+	ICLASS_VADDSS
+	ICLASS_VADDSUBPD
+	ICLASS_VADDSUBPS
+	ICLASS_VAESDEC
+	ICLASS_VAESDECLAST
+	ICLASS_VAESENC
+	ICLASS_VAESENCLAST
+	ICLASS_VAESIMC
+	ICLASS_VAESKEYGENASSIST
+	ICLASS_VALIGND
+	ICLASS_VALIGNQ
+	ICLASS_VANDNPD
+	ICLASS_VANDNPS
+	ICLASS_VANDPD
+	ICLASS_VANDPS
+	ICLASS_VBLENDMPD
+	ICLASS_VBLENDMPS
+	ICLASS_VBLENDPD
+	ICLASS_VBLENDPS
+	ICLASS_VBLENDVPD
+	ICLASS_VBLENDVPS
+	ICLASS_VBROADCASTF128
+	ICLASS_VBROADCASTF32X2
+	ICLASS_VBROADCASTF32X4
+	ICLASS_VBROADCASTF32X8
+	ICLASS_VBROADCASTF64X2
+	ICLASS_VBROADCASTF64X4
+	ICLASS_VBROADCASTI128
+	ICLASS_VBROADCASTI32X2
+	ICLASS_VBROADCASTI32X4
+	ICLASS_VBROADCASTI32X8
+	ICLASS_VBROADCASTI64X2
+	ICLASS_VBROADCASTI64X4
+	ICLASS_VBROADCASTSD
+	ICLASS_VBROADCASTSS
+	ICLASS_VCMPPD
+	ICLASS_VCMPPS
+	ICLASS_VCMPSD
+	ICLASS_VCMPSS
+	ICLASS_VCOMISD
+	ICLASS_VCOMISS
+	ICLASS_VCOMPRESSPD
+	ICLASS_VCOMPRESSPS
+	ICLASS_VCVTDQ2PD
+	ICLASS_VCVTDQ2PS
+	ICLASS_VCVTPD2DQ
+	ICLASS_VCVTPD2PS
+	ICLASS_VCVTPD2QQ
+	ICLASS_VCVTPD2UDQ
+	ICLASS_VCVTPD2UQQ
+	ICLASS_VCVTPH2PS
+	ICLASS_VCVTPS2DQ
+	ICLASS_VCVTPS2PD
+	ICLASS_VCVTPS2PH
+	ICLASS_VCVTPS2QQ
+	ICLASS_VCVTPS2UDQ
+	ICLASS_VCVTPS2UQQ
+	ICLASS_VCVTQQ2PD
+	ICLASS_VCVTQQ2PS
+	ICLASS_VCVTSD2SI
+	ICLASS_VCVTSD2SS
+	ICLASS_VCVTSD2USI
+	ICLASS_VCVTSI2SD
+	ICLASS_VCVTSI2SS
+	ICLASS_VCVTSS2SD
+	ICLASS_VCVTSS2SI
+	ICLASS_VCVTSS2USI
+	ICLASS_VCVTTPD2DQ
+	ICLASS_VCVTTPD2QQ
+	ICLASS_VCVTTPD2UDQ
+	ICLASS_VCVTTPD2UQQ
+	ICLASS_VCVTTPS2DQ
+	ICLASS_VCVTTPS2QQ
+	ICLASS_VCVTTPS2UDQ
+	ICLASS_VCVTTPS2UQQ
+	ICLASS_VCVTTSD2SI
+	ICLASS_VCVTTSD2USI
+	ICLASS_VCVTTSS2SI
+	ICLASS_VCVTTSS2USI
+	ICLASS_VCVTUDQ2PD
+	ICLASS_VCVTUDQ2PS
+	ICLASS_VCVTUQQ2PD
+	ICLASS_VCVTUQQ2PS
+	ICLASS_VCVTUSI2SD
+	ICLASS_VCVTUSI2SS
+	ICLASS_VDBPSADBW
+	ICLASS_VDIVPD
+	ICLASS_VDIVPS
+	ICLASS_VDIVSD
+	ICLASS_VDIVSS
+	ICLASS_VDPPD
+	ICLASS_VDPPS
+	ICLASS_VERR
+	ICLASS_VERW
+	ICLASS_VEXP2PD
+	ICLASS_VEXP2PS
+	ICLASS_VEXPANDPD
+	ICLASS_VEXPANDPS
+	ICLASS_VEXTRACTF128
+	ICLASS_VEXTRACTF32X4
+	ICLASS_VEXTRACTF32X8
+	ICLASS_VEXTRACTF64X2
+	ICLASS_VEXTRACTF64X4
+	ICLASS_VEXTRACTI128
+	ICLASS_VEXTRACTI32X4
+	ICLASS_VEXTRACTI32X8
+	ICLASS_VEXTRACTI64X2
+	ICLASS_VEXTRACTI64X4
+	ICLASS_VEXTRACTPS
+	ICLASS_VFIXUPIMMPD
+	ICLASS_VFIXUPIMMPS
+	ICLASS_VFIXUPIMMSD
+	ICLASS_VFIXUPIMMSS
+	ICLASS_VFMADD132PD
+	ICLASS_VFMADD132PS
+	ICLASS_VFMADD132SD
+	ICLASS_VFMADD132SS
+	ICLASS_VFMADD213PD
+	ICLASS_VFMADD213PS
+	ICLASS_VFMADD213SD
+	ICLASS_VFMADD213SS
+	ICLASS_VFMADD231PD
+	ICLASS_VFMADD231PS
+	ICLASS_VFMADD231SD
+	ICLASS_VFMADD231SS
+	ICLASS_VFMADDPD
+	ICLASS_VFMADDPS
+	ICLASS_VFMADDSD
+	ICLASS_VFMADDSS
+	ICLASS_VFMADDSUB132PD
+	ICLASS_VFMADDSUB132PS
+	ICLASS_VFMADDSUB213PD
+	ICLASS_VFMADDSUB213PS
+	ICLASS_VFMADDSUB231PD
+	ICLASS_VFMADDSUB231PS
+	ICLASS_VFMADDSUBPD
+	ICLASS_VFMADDSUBPS
+	ICLASS_VFMSUB132PD
+	ICLASS_VFMSUB132PS
+	ICLASS_VFMSUB132SD
+	ICLASS_VFMSUB132SS
+	ICLASS_VFMSUB213PD
+	ICLASS_VFMSUB213PS
+	ICLASS_VFMSUB213SD
+	ICLASS_VFMSUB213SS
+	ICLASS_VFMSUB231PD
+	ICLASS_VFMSUB231PS
+	ICLASS_VFMSUB231SD
+	ICLASS_VFMSUB231SS
+	ICLASS_VFMSUBADD132PD
+	ICLASS_VFMSUBADD132PS
+	ICLASS_VFMSUBADD213PD
+	ICLASS_VFMSUBADD213PS
+	ICLASS_VFMSUBADD231PD
+	ICLASS_VFMSUBADD231PS
+	ICLASS_VFMSUBADDPD
+	ICLASS_VFMSUBADDPS
+	ICLASS_VFMSUBPD
+	ICLASS_VFMSUBPS
+	ICLASS_VFMSUBSD
+	ICLASS_VFMSUBSS
+	ICLASS_VFNMADD132PD
+	ICLASS_VFNMADD132PS
+	ICLASS_VFNMADD132SD
+	ICLASS_VFNMADD132SS
+	ICLASS_VFNMADD213PD
+	ICLASS_VFNMADD213PS
+	ICLASS_VFNMADD213SD
+	ICLASS_VFNMADD213SS
+	ICLASS_VFNMADD231PD
+	ICLASS_VFNMADD231PS
+	ICLASS_VFNMADD231SD
+	ICLASS_VFNMADD231SS
+	ICLASS_VFNMADDPD
+	ICLASS_VFNMADDPS
+	ICLASS_VFNMADDSD
+	ICLASS_VFNMADDSS
+	ICLASS_VFNMSUB132PD
+	ICLASS_VFNMSUB132PS
+	ICLASS_VFNMSUB132SD
+	ICLASS_VFNMSUB132SS
+	ICLASS_VFNMSUB213PD
+	ICLASS_VFNMSUB213PS
+	ICLASS_VFNMSUB213SD
+	ICLASS_VFNMSUB213SS
+	ICLASS_VFNMSUB231PD
+	ICLASS_VFNMSUB231PS
+	ICLASS_VFNMSUB231SD
+	ICLASS_VFNMSUB231SS
+	ICLASS_VFNMSUBPD
+	ICLASS_VFNMSUBPS
+	ICLASS_VFNMSUBSD
+	ICLASS_VFNMSUBSS
+	ICLASS_VFPCLASSPD
+	ICLASS_VFPCLASSPS
+	ICLASS_VFPCLASSSD
+	ICLASS_VFPCLASSSS
+	ICLASS_VFRCZPD
+	ICLASS_VFRCZPS
+	ICLASS_VFRCZSD
+	ICLASS_VFRCZSS
+	ICLASS_VGATHERDPD
+	ICLASS_VGATHERDPS
+	ICLASS_VGATHERPF0DPD
+	ICLASS_VGATHERPF0DPS
+	ICLASS_VGATHERPF0QPD
+	ICLASS_VGATHERPF0QPS
+	ICLASS_VGATHERPF1DPD
+	ICLASS_VGATHERPF1DPS
+	ICLASS_VGATHERPF1QPD
+	ICLASS_VGATHERPF1QPS
+	ICLASS_VGATHERQPD
+	ICLASS_VGATHERQPS
+	ICLASS_VGETEXPPD
+	ICLASS_VGETEXPPS
+	ICLASS_VGETEXPSD
+	ICLASS_VGETEXPSS
+	ICLASS_VGETMANTPD
+	ICLASS_VGETMANTPS
+	ICLASS_VGETMANTSD
+	ICLASS_VGETMANTSS
+	ICLASS_VGF2P8AFFINEINVQB
+	ICLASS_VGF2P8AFFINEQB
+	ICLASS_VGF2P8MULB
+	ICLASS_VHADDPD
+	ICLASS_VHADDPS
+	ICLASS_VHSUBPD
+	ICLASS_VHSUBPS
+	ICLASS_VINSERTF128
+	ICLASS_VINSERTF32X4
+	ICLASS_VINSERTF32X8
+	ICLASS_VINSERTF64X2
+	ICLASS_VINSERTF64X4
+	ICLASS_VINSERTI128
+	ICLASS_VINSERTI32X4
+	ICLASS_VINSERTI32X8
+	ICLASS_VINSERTI64X2
+	ICLASS_VINSERTI64X4
+	ICLASS_VINSERTPS
+	ICLASS_VLDDQU
+	ICLASS_VLDMXCSR
+	ICLASS_VMASKMOVDQU
+	ICLASS_VMASKMOVPD
+	ICLASS_VMASKMOVPS
+	ICLASS_VMAXPD
+	ICLASS_VMAXPS
+	ICLASS_VMAXSD
+	ICLASS_VMAXSS
+	ICLASS_VMCALL
+	ICLASS_VMCLEAR
+	ICLASS_VMFUNC
+	ICLASS_VMINPD
+	ICLASS_VMINPS
+	ICLASS_VMINSD
+	ICLASS_VMINSS
+	ICLASS_VMLAUNCH
+	ICLASS_VMLOAD
+	ICLASS_VMMCALL
+	ICLASS_VMOVAPD
+	ICLASS_VMOVAPS
+	ICLASS_VMOVD
+	ICLASS_VMOVDDUP
+	ICLASS_VMOVDQA
+	ICLASS_VMOVDQA32
+	ICLASS_VMOVDQA64
+	ICLASS_VMOVDQU
+	ICLASS_VMOVDQU16
+	ICLASS_VMOVDQU32
+	ICLASS_VMOVDQU64
+	ICLASS_VMOVDQU8
+	ICLASS_VMOVHLPS
+	ICLASS_VMOVHPD
+	ICLASS_VMOVHPS
+	ICLASS_VMOVLHPS
+	ICLASS_VMOVLPD
+	ICLASS_VMOVLPS
+	ICLASS_VMOVMSKPD
+	ICLASS_VMOVMSKPS
+	ICLASS_VMOVNTDQ
+	ICLASS_VMOVNTDQA
+	ICLASS_VMOVNTPD
+	ICLASS_VMOVNTPS
+	ICLASS_VMOVQ
+	ICLASS_VMOVSD
+	ICLASS_VMOVSHDUP
+	ICLASS_VMOVSLDUP
+	ICLASS_VMOVSS
+	ICLASS_VMOVUPD
+	ICLASS_VMOVUPS
+	ICLASS_VMPSADBW
+	ICLASS_VMPTRLD
+	ICLASS_VMPTRST
+	ICLASS_VMREAD
+	ICLASS_VMRESUME
+	ICLASS_VMRUN
+	ICLASS_VMSAVE
+	ICLASS_VMULPD
+	ICLASS_VMULPS
+	ICLASS_VMULSD
+	ICLASS_VMULSS
+	ICLASS_VMWRITE
+	ICLASS_VMXOFF
+	ICLASS_VMXON
+	ICLASS_VORPD
+	ICLASS_VORPS
+	ICLASS_VP4DPWSSD
+	ICLASS_VP4DPWSSDS
+	ICLASS_VPABSB
+	ICLASS_VPABSD
+	ICLASS_VPABSQ
+	ICLASS_VPABSW
+	ICLASS_VPACKSSDW
+	ICLASS_VPACKSSWB
+	ICLASS_VPACKUSDW
+	ICLASS_VPACKUSWB
+	ICLASS_VPADDB
+	ICLASS_VPADDD
+	ICLASS_VPADDQ
+	ICLASS_VPADDSB
+	ICLASS_VPADDSW
+	ICLASS_VPADDUSB
+	ICLASS_VPADDUSW
+	ICLASS_VPADDW
+	ICLASS_VPALIGNR
+	ICLASS_VPAND
+	ICLASS_VPANDD
+	ICLASS_VPANDN
+	ICLASS_VPANDND
+	ICLASS_VPANDNQ
+	ICLASS_VPANDQ
+	ICLASS_VPAVGB
+	ICLASS_VPAVGW
+	ICLASS_VPBLENDD
+	ICLASS_VPBLENDMB
+	ICLASS_VPBLENDMD
+	ICLASS_VPBLENDMQ
+	ICLASS_VPBLENDMW
+	ICLASS_VPBLENDVB
+	ICLASS_VPBLENDW
+	ICLASS_VPBROADCASTB
+	ICLASS_VPBROADCASTD
+	ICLASS_VPBROADCASTMB2Q
+	ICLASS_VPBROADCASTMW2D
+	ICLASS_VPBROADCASTQ
+	ICLASS_VPBROADCASTW
+	ICLASS_VPCLMULQDQ
+	ICLASS_VPCMOV
+	ICLASS_VPCMPB
+	ICLASS_VPCMPD
+	ICLASS_VPCMPEQB
+	ICLASS_VPCMPEQD
+	ICLASS_VPCMPEQQ
+	ICLASS_VPCMPEQW
+	ICLASS_VPCMPESTRI
+	ICLASS_VPCMPESTRM
+	ICLASS_VPCMPGTB
+	ICLASS_VPCMPGTD
+	ICLASS_VPCMPGTQ
+	ICLASS_VPCMPGTW
+	ICLASS_VPCMPISTRI
+	ICLASS_VPCMPISTRM
+	ICLASS_VPCMPQ
+	ICLASS_VPCMPUB
+	ICLASS_VPCMPUD
+	ICLASS_VPCMPUQ
+	ICLASS_VPCMPUW
+	ICLASS_VPCMPW
+	ICLASS_VPCOMB
+	ICLASS_VPCOMD
+	ICLASS_VPCOMPRESSB
+	ICLASS_VPCOMPRESSD
+	ICLASS_VPCOMPRESSQ
+	ICLASS_VPCOMPRESSW
+	ICLASS_VPCOMQ
+	ICLASS_VPCOMUB
+	ICLASS_VPCOMUD
+	ICLASS_VPCOMUQ
+	ICLASS_VPCOMUW
+	ICLASS_VPCOMW
+	ICLASS_VPCONFLICTD
+	ICLASS_VPCONFLICTQ
+	ICLASS_VPDPBUSD
+	ICLASS_VPDPBUSDS
+	ICLASS_VPDPWSSD
+	ICLASS_VPDPWSSDS
+	ICLASS_VPERM2F128
+	ICLASS_VPERM2I128
+	ICLASS_VPERMB
+	ICLASS_VPERMD
+	ICLASS_VPERMI2B
+	ICLASS_VPERMI2D
+	ICLASS_VPERMI2PD
+	ICLASS_VPERMI2PS
+	ICLASS_VPERMI2Q
+	ICLASS_VPERMI2W
+	ICLASS_VPERMIL2PD
+	ICLASS_VPERMIL2PS
+	ICLASS_VPERMILPD
+	ICLASS_VPERMILPS
+	ICLASS_VPERMPD
+	ICLASS_VPERMPS
+	ICLASS_VPERMQ
+	ICLASS_VPERMT2B
+	ICLASS_VPERMT2D
+	ICLASS_VPERMT2PD
+	ICLASS_VPERMT2PS
+	ICLASS_VPERMT2Q
+	ICLASS_VPERMT2W
+	ICLASS_VPERMW
+	ICLASS_VPEXPANDB
+	ICLASS_VPEXPANDD
+	ICLASS_VPEXPANDQ
+	ICLASS_VPEXPANDW
+	ICLASS_VPEXTRB
+	ICLASS_VPEXTRD
+	ICLASS_VPEXTRQ
+	ICLASS_VPEXTRW
+	ICLASS_VPEXTRW_C5
+	ICLASS_VPGATHERDD
+	ICLASS_VPGATHERDQ
+	ICLASS_VPGATHERQD
+	ICLASS_VPGATHERQQ
+	ICLASS_VPHADDBD
+	ICLASS_VPHADDBQ
+	ICLASS_VPHADDBW
+	ICLASS_VPHADDD
+	ICLASS_VPHADDDQ
+	ICLASS_VPHADDSW
+	ICLASS_VPHADDUBD
+	ICLASS_VPHADDUBQ
+	ICLASS_VPHADDUBW
+	ICLASS_VPHADDUDQ
+	ICLASS_VPHADDUWD
+	ICLASS_VPHADDUWQ
+	ICLASS_VPHADDW
+	ICLASS_VPHADDWD
+	ICLASS_VPHADDWQ
+	ICLASS_VPHMINPOSUW
+	ICLASS_VPHSUBBW
+	ICLASS_VPHSUBD
+	ICLASS_VPHSUBDQ
+	ICLASS_VPHSUBSW
+	ICLASS_VPHSUBW
+	ICLASS_VPHSUBWD
+	ICLASS_VPINSRB
+	ICLASS_VPINSRD
+	ICLASS_VPINSRQ
+	ICLASS_VPINSRW
+	ICLASS_VPLZCNTD
+	ICLASS_VPLZCNTQ
+	ICLASS_VPMACSDD
+	ICLASS_VPMACSDQH
+	ICLASS_VPMACSDQL
+	ICLASS_VPMACSSDD
+	ICLASS_VPMACSSDQH
+	ICLASS_VPMACSSDQL
+	ICLASS_VPMACSSWD
+	ICLASS_VPMACSSWW
+	ICLASS_VPMACSWD
+	ICLASS_VPMACSWW
+	ICLASS_VPMADCSSWD
+	ICLASS_VPMADCSWD
+	ICLASS_VPMADD52HUQ
+	ICLASS_VPMADD52LUQ
+	ICLASS_VPMADDUBSW
+	ICLASS_VPMADDWD
+	ICLASS_VPMASKMOVD
+	ICLASS_VPMASKMOVQ
+	ICLASS_VPMAXSB
+	ICLASS_VPMAXSD
+	ICLASS_VPMAXSQ
+	ICLASS_VPMAXSW
+	ICLASS_VPMAXUB
+	ICLASS_VPMAXUD
+	ICLASS_VPMAXUQ
+	ICLASS_VPMAXUW
+	ICLASS_VPMINSB
+	ICLASS_VPMINSD
+	ICLASS_VPMINSQ
+	ICLASS_VPMINSW
+	ICLASS_VPMINUB
+	ICLASS_VPMINUD
+	ICLASS_VPMINUQ
+	ICLASS_VPMINUW
+	ICLASS_VPMOVB2M
+	ICLASS_VPMOVD2M
+	ICLASS_VPMOVDB
+	ICLASS_VPMOVDW
+	ICLASS_VPMOVM2B
+	ICLASS_VPMOVM2D
+	ICLASS_VPMOVM2Q
+	ICLASS_VPMOVM2W
+	ICLASS_VPMOVMSKB
+	ICLASS_VPMOVQ2M
+	ICLASS_VPMOVQB
+	ICLASS_VPMOVQD
+	ICLASS_VPMOVQW
+	ICLASS_VPMOVSDB
+	ICLASS_VPMOVSDW
+	ICLASS_VPMOVSQB
+	ICLASS_VPMOVSQD
+	ICLASS_VPMOVSQW
+	ICLASS_VPMOVSWB
+	ICLASS_VPMOVSXBD
+	ICLASS_VPMOVSXBQ
+	ICLASS_VPMOVSXBW
+	ICLASS_VPMOVSXDQ
+	ICLASS_VPMOVSXWD
+	ICLASS_VPMOVSXWQ
+	ICLASS_VPMOVUSDB
+	ICLASS_VPMOVUSDW
+	ICLASS_VPMOVUSQB
+	ICLASS_VPMOVUSQD
+	ICLASS_VPMOVUSQW
+	ICLASS_VPMOVUSWB
+	ICLASS_VPMOVW2M
+	ICLASS_VPMOVWB
+	ICLASS_VPMOVZXBD
+	ICLASS_VPMOVZXBQ
+	ICLASS_VPMOVZXBW
+	ICLASS_VPMOVZXDQ
+	ICLASS_VPMOVZXWD
+	ICLASS_VPMOVZXWQ
+	ICLASS_VPMULDQ
+	ICLASS_VPMULHRSW
+	ICLASS_VPMULHUW
+	ICLASS_VPMULHW
+	ICLASS_VPMULLD
+	ICLASS_VPMULLQ
+	ICLASS_VPMULLW
+	ICLASS_VPMULTISHIFTQB
+	ICLASS_VPMULUDQ
+	ICLASS_VPOPCNTB
+	ICLASS_VPOPCNTD
+	ICLASS_VPOPCNTQ
+	ICLASS_VPOPCNTW
+	ICLASS_VPOR
+	ICLASS_VPORD
+	ICLASS_VPORQ
+	ICLASS_VPPERM
+	ICLASS_VPROLD
+	ICLASS_VPROLQ
+	ICLASS_VPROLVD
+	ICLASS_VPROLVQ
+	ICLASS_VPRORD
+	ICLASS_VPRORQ
+	ICLASS_VPRORVD
+	ICLASS_VPRORVQ
+	ICLASS_VPROTB
+	ICLASS_VPROTD
+	ICLASS_VPROTQ
+	ICLASS_VPROTW
+	ICLASS_VPSADBW
+	ICLASS_VPSCATTERDD
+	ICLASS_VPSCATTERDQ
+	ICLASS_VPSCATTERQD
+	ICLASS_VPSCATTERQQ
+	ICLASS_VPSHAB
+	ICLASS_VPSHAD
+	ICLASS_VPSHAQ
+	ICLASS_VPSHAW
+	ICLASS_VPSHLB
+	ICLASS_VPSHLD
+	ICLASS_VPSHLDD
+	ICLASS_VPSHLDQ
+	ICLASS_VPSHLDVD
+	ICLASS_VPSHLDVQ
+	ICLASS_VPSHLDVW
+	ICLASS_VPSHLDW
+	ICLASS_VPSHLQ
+	ICLASS_VPSHLW
+	ICLASS_VPSHRDD
+	ICLASS_VPSHRDQ
+	ICLASS_VPSHRDVD
+	ICLASS_VPSHRDVQ
+	ICLASS_VPSHRDVW
+	ICLASS_VPSHRDW
+	ICLASS_VPSHUFB
+	ICLASS_VPSHUFBITQMB
+	ICLASS_VPSHUFD
+	ICLASS_VPSHUFHW
+	ICLASS_VPSHUFLW
+	ICLASS_VPSIGNB
+	ICLASS_VPSIGND
+	ICLASS_VPSIGNW
+	ICLASS_VPSLLD
+	ICLASS_VPSLLDQ
+	ICLASS_VPSLLQ
+	ICLASS_VPSLLVD
+	ICLASS_VPSLLVQ
+	ICLASS_VPSLLVW
+	ICLASS_VPSLLW
+	ICLASS_VPSRAD
+	ICLASS_VPSRAQ
+	ICLASS_VPSRAVD
+	ICLASS_VPSRAVQ
+	ICLASS_VPSRAVW
+	ICLASS_VPSRAW
+	ICLASS_VPSRLD
+	ICLASS_VPSRLDQ
+	ICLASS_VPSRLQ
+	ICLASS_VPSRLVD
+	ICLASS_VPSRLVQ
+	ICLASS_VPSRLVW
+	ICLASS_VPSRLW
+	ICLASS_VPSUBB
+	ICLASS_VPSUBD
+	ICLASS_VPSUBQ
+	ICLASS_VPSUBSB
+	ICLASS_VPSUBSW
+	ICLASS_VPSUBUSB
+	ICLASS_VPSUBUSW
+	ICLASS_VPSUBW
+	ICLASS_VPTERNLOGD
+	ICLASS_VPTERNLOGQ
+	ICLASS_VPTEST
+	ICLASS_VPTESTMB
+	ICLASS_VPTESTMD
+	ICLASS_VPTESTMQ
+	ICLASS_VPTESTMW
+	ICLASS_VPTESTNMB
+	ICLASS_VPTESTNMD
+	ICLASS_VPTESTNMQ
+	ICLASS_VPTESTNMW
+	ICLASS_VPUNPCKHBW
+	ICLASS_VPUNPCKHDQ
+	ICLASS_VPUNPCKHQDQ
+	ICLASS_VPUNPCKHWD
+	ICLASS_VPUNPCKLBW
+	ICLASS_VPUNPCKLDQ
+	ICLASS_VPUNPCKLQDQ
+	ICLASS_VPUNPCKLWD
+	ICLASS_VPXOR
+	ICLASS_VPXORD
+	ICLASS_VPXORQ
+	ICLASS_VRANGEPD
+	ICLASS_VRANGEPS
+	ICLASS_VRANGESD
+	ICLASS_VRANGESS
+	ICLASS_VRCP14PD
+	ICLASS_VRCP14PS
+	ICLASS_VRCP14SD
+	ICLASS_VRCP14SS
+	ICLASS_VRCP28PD
+	ICLASS_VRCP28PS
+	ICLASS_VRCP28SD
+	ICLASS_VRCP28SS
+	ICLASS_VRCPPS
+	ICLASS_VRCPSS
+	ICLASS_VREDUCEPD
+	ICLASS_VREDUCEPS
+	ICLASS_VREDUCESD
+	ICLASS_VREDUCESS
+	ICLASS_VRNDSCALEPD
+	ICLASS_VRNDSCALEPS
+	ICLASS_VRNDSCALESD
+	ICLASS_VRNDSCALESS
+	ICLASS_VROUNDPD
+	ICLASS_VROUNDPS
+	ICLASS_VROUNDSD
+	ICLASS_VROUNDSS
+	ICLASS_VRSQRT14PD
+	ICLASS_VRSQRT14PS
+	ICLASS_VRSQRT14SD
+	ICLASS_VRSQRT14SS
+	ICLASS_VRSQRT28PD
+	ICLASS_VRSQRT28PS
+	ICLASS_VRSQRT28SD
+	ICLASS_VRSQRT28SS
+	ICLASS_VRSQRTPS
+	ICLASS_VRSQRTSS
+	ICLASS_VSCALEFPD
+	ICLASS_VSCALEFPS
+	ICLASS_VSCALEFSD
+	ICLASS_VSCALEFSS
+	ICLASS_VSCATTERDPD
+	ICLASS_VSCATTERDPS
+	ICLASS_VSCATTERPF0DPD
+	ICLASS_VSCATTERPF0DPS
+	ICLASS_VSCATTERPF0QPD
+	ICLASS_VSCATTERPF0QPS
+	ICLASS_VSCATTERPF1DPD
+	ICLASS_VSCATTERPF1DPS
+	ICLASS_VSCATTERPF1QPD
+	ICLASS_VSCATTERPF1QPS
+	ICLASS_VSCATTERQPD
+	ICLASS_VSCATTERQPS
+	ICLASS_VSHUFF32X4
+	ICLASS_VSHUFF64X2
+	ICLASS_VSHUFI32X4
+	ICLASS_VSHUFI64X2
+	ICLASS_VSHUFPD
+	ICLASS_VSHUFPS
+	ICLASS_VSQRTPD
+	ICLASS_VSQRTPS
+	ICLASS_VSQRTSD
+	ICLASS_VSQRTSS
+	ICLASS_VSTMXCSR
+	ICLASS_VSUBPD
+	ICLASS_VSUBPS
+	ICLASS_VSUBSD
+	ICLASS_VSUBSS
+	ICLASS_VTESTPD
+	ICLASS_VTESTPS
+	ICLASS_VUCOMISD
+	ICLASS_VUCOMISS
+	ICLASS_VUNPCKHPD
+	ICLASS_VUNPCKHPS
+	ICLASS_VUNPCKLPD
+	ICLASS_VUNPCKLPS
+	ICLASS_VXORPD
+	ICLASS_VXORPS
+	ICLASS_VZEROALL
+	ICLASS_VZEROUPPER
+	ICLASS_WBINVD
+	ICLASS_WBNOINVD
+	ICLASS_WRFSBASE
+	ICLASS_WRGSBASE
+	ICLASS_WRMSR
+	ICLASS_WRPKRU
+	ICLASS_WRSSD
+	ICLASS_WRSSQ
+	ICLASS_WRUSSD
+	ICLASS_WRUSSQ
+	ICLASS_XABORT
+	ICLASS_XADD
+	ICLASS_XADD_LOCK
+	ICLASS_XBEGIN
+	ICLASS_XCHG
+	ICLASS_XEND
+	ICLASS_XGETBV
+	ICLASS_XLAT
+	ICLASS_XOR
+	ICLASS_XORPD
+	ICLASS_XORPS
+	ICLASS_XOR_LOCK
+	ICLASS_XRSTOR
+	ICLASS_XRSTOR64
+	ICLASS_XRSTORS
+	ICLASS_XRSTORS64
+	ICLASS_XSAVE
+	ICLASS_XSAVE64
+	ICLASS_XSAVEC
+	ICLASS_XSAVEC64
+	ICLASS_XSAVEOPT
+	ICLASS_XSAVEOPT64
+	ICLASS_XSAVES
+	ICLASS_XSAVES64
+	ICLASS_XSETBV
+	ICLASS_XTEST
+	ICLASS_LAST
+)
+
+const (
+	ISA_SET_INVALID ISASet = iota
+	ISA_SET_3DNOW
+	ISA_SET_ADOX_ADCX
+	ISA_SET_AES
+	ISA_SET_AMD
+	ISA_SET_AVX
+	ISA_SET_AVX2
+	ISA_SET_AVX2GATHER
+	ISA_SET_AVX512BW_128
+	ISA_SET_AVX512BW_128N
+	ISA_SET_AVX512BW_256
+	ISA_SET_AVX512BW_512
+	ISA_SET_AVX512BW_KOP
+	ISA_SET_AVX512CD_128
+	ISA_SET_AVX512CD_256
+	ISA_SET_AVX512CD_512
+	ISA_SET_AVX512DQ_128
+	ISA_SET_AVX512DQ_128N
+	ISA_SET_AVX512DQ_256
+	ISA_SET_AVX512DQ_512
+	ISA_SET_AVX512DQ_KOP
+	ISA_SET_AVX512DQ_SCALAR
+	ISA_SET_AVX512ER_512
+	ISA_SET_AVX512ER_SCALAR
+	ISA_SET_AVX512F_128
+	ISA_SET_AVX512F_128N
+	ISA_SET_AVX512F_256
+	ISA_SET_AVX512F_512
+	ISA_SET_AVX512F_KOP
+	ISA_SET_AVX512F_SCALAR
+	ISA_SET_AVX512PF_512
+	ISA_SET_AVX512_4FMAPS_512
+	ISA_SET_AVX512_4FMAPS_SCALAR
+	ISA_SET_AVX512_4VNNIW_512
+	ISA_SET_AVX512_BITALG_128
+	ISA_SET_AVX512_BITALG_256
+	ISA_SET_AVX512_BITALG_512
+	ISA_SET_AVX512_GFNI_128
+	ISA_SET_AVX512_GFNI_256
+	ISA_SET_AVX512_GFNI_512
+	ISA_SET_AVX512_IFMA_128
+	ISA_SET_AVX512_IFMA_256
+	ISA_SET_AVX512_IFMA_512
+	ISA_SET_AVX512_VAES_128
+	ISA_SET_AVX512_VAES_256
+	ISA_SET_AVX512_VAES_512
+	ISA_SET_AVX512_VBMI2_128
+	ISA_SET_AVX512_VBMI2_256
+	ISA_SET_AVX512_VBMI2_512
+	ISA_SET_AVX512_VBMI_128
+	ISA_SET_AVX512_VBMI_256
+	ISA_SET_AVX512_VBMI_512
+	ISA_SET_AVX512_VNNI_128
+	ISA_SET_AVX512_VNNI_256
+	ISA_SET_AVX512_VNNI_512
+	ISA_SET_AVX512_VPCLMULQDQ_128
+	ISA_SET_AVX512_VPCLMULQDQ_256
+	ISA_SET_AVX512_VPCLMULQDQ_512
+	ISA_SET_AVX512_VPOPCNTDQ_128
+	ISA_SET_AVX512_VPOPCNTDQ_256
+	ISA_SET_AVX512_VPOPCNTDQ_512
+	ISA_SET_AVXAES
+	ISA_SET_AVX_GFNI
+	ISA_SET_BMI1
+	ISA_SET_BMI2
+	ISA_SET_CET
+	ISA_SET_CLDEMOTE
+	ISA_SET_CLFLUSHOPT
+	ISA_SET_CLFSH
+	ISA_SET_CLWB
+	ISA_SET_CLZERO
+	ISA_SET_CMOV
+	ISA_SET_CMPXCHG16B
+	ISA_SET_F16C
+	ISA_SET_FAT_NOP
+	ISA_SET_FCMOV
+	ISA_SET_FMA
+	ISA_SET_FMA4
+	ISA_SET_FXSAVE
+	ISA_SET_FXSAVE64
+	ISA_SET_GFNI
+	ISA_SET_I186
+	ISA_SET_I286PROTECTED
+	ISA_SET_I286REAL
+	ISA_SET_I386
+	ISA_SET_I486
+	ISA_SET_I486REAL
+	ISA_SET_I86
+	ISA_SET_INVPCID
+	ISA_SET_LAHF
+	ISA_SET_LONGMODE
+	ISA_SET_LZCNT
+	ISA_SET_MONITOR
+	ISA_SET_MONITORX
+	ISA_SET_MOVBE
+	ISA_SET_MOVDIR
+	ISA_SET_MPX
+	ISA_SET_PAUSE
+	ISA_SET_PCLMULQDQ
+	ISA_SET_PCONFIG
+	ISA_SET_PENTIUMMMX
+	ISA_SET_PENTIUMREAL
+	ISA_SET_PKU
+	ISA_SET_POPCNT
+	ISA_SET_PPRO
+	ISA_SET_PREFETCHW
+	ISA_SET_PREFETCHWT1
+	ISA_SET_PREFETCH_NOP
+	ISA_SET_PT
+	ISA_SET_RDPID
+	ISA_SET_RDPMC
+	ISA_SET_RDRAND
+	ISA_SET_RDSEED
+	ISA_SET_RDTSCP
+	ISA_SET_RDWRFSGS
+	ISA_SET_RTM
+	ISA_SET_SGX
+	ISA_SET_SGX_ENCLV
+	ISA_SET_SHA
+	ISA_SET_SMAP
+	ISA_SET_SMX
+	ISA_SET_SSE
+	ISA_SET_SSE2
+	ISA_SET_SSE2MMX
+	ISA_SET_SSE3
+	ISA_SET_SSE3X87
+	ISA_SET_SSE4
+	ISA_SET_SSE42
+	ISA_SET_SSE4A
+	ISA_SET_SSEMXCSR
+	ISA_SET_SSE_PREFETCH
+	ISA_SET_SSSE3
+	ISA_SET_SSSE3MMX
+	ISA_SET_SVM
+	ISA_SET_TBM
+	ISA_SET_VAES
+	ISA_SET_VMFUNC
+	ISA_SET_VPCLMULQDQ
+	ISA_SET_VTX
+	ISA_SET_WAITPKG
+	ISA_SET_WBNOINVD
+	ISA_SET_X87
+	ISA_SET_XOP
+	ISA_SET_XSAVE
+	ISA_SET_XSAVEC
+	ISA_SET_XSAVEOPT
+	ISA_SET_XSAVES
+	ISA_SET_LAST
+)
+
+var operandActionNames = [...]string{
+	"INVALID",
+	"RW",
+	"R",
+	"W",
+	"RCW",
+	"CW",
+	"CRW",
+	"CR",
+	"LAST",
+}
+
+var extensionNames = [...]string{
+	"INVALID",
+	"3DNOW",
+	"ADOX_ADCX",
+	"AES",
+	"AVX",
+	"AVX2",
+	"AVX2GATHER",
+	"AVX512EVEX",
+	"AVX512VEX",
+	"AVXAES",
+	"BASE",
+	"BMI1",
+	"BMI2",
+	"CET",
+	"CLDEMOTE",
+	"CLFLUSHOPT",
+	"CLFSH",
+	"CLWB",
+	"CLZERO",
+	"F16C",
+	"FMA",
+	"FMA4",
+	"GFNI",
+	"INVPCID",
+	"LONGMODE",
+	"LZCNT",
+	"MMX",
+	"MONITOR",
+	"MONITORX",
+	"MOVBE",
+	"MOVDIR",
+	"MPX",
+	"PAUSE",
+	"PCLMULQDQ",
+	"PCONFIG",
+	"PKU",
+	"PREFETCHWT1",
+	"PT",
+	"RDPID",
+	"RDRAND",
+	"RDSEED",
+	"RDTSCP",
+	"RDWRFSGS",
+	"RTM",
+	"SGX",
+	"SGX_ENCLV",
+	"SHA",
+	"SMAP",
+	"SMX",
+	"SSE",
+	"SSE2",
+	"SSE3",
+	"SSE4",
+	"SSE4A",
+	"SSSE3",
+	"SVM",
+	"TBM",
+	"VAES",
+	"VMFUNC",
+	"VPCLMULQDQ",
+	"VTX",
+	"WAITPKG",
+	"WBNOINVD",
+	"X87",
+	"XOP",
+	"XSAVE",
+	"XSAVEC",
+	"XSAVEOPT",
+	"XSAVES",
+	"LAST",
+}
+
+var errorNames = [...]string{
+	"NONE",
+	"BUFFER_TOO_SHORT",
+	"GENERAL_ERROR",
+	"INVALID_FOR_CHIP",
+	"BAD_REGISTER",
+	"BAD_LOCK_PREFIX",
+	"BAD_REP_PREFIX",
+	"BAD_LEGACY_PREFIX",
+	"BAD_REX_PREFIX",
+	"BAD_EVEX_UBIT",
+	"BAD_MAP",
+	"BAD_EVEX_V_PRIME",
+	"BAD_EVEX_Z_NO_MASKING",
+	"NO_OUTPUT_POINTER",
+	"NO_AGEN_CALL_BACK_REGISTERED",
+	"BAD_MEMOP_INDEX",
+	"CALLBACK_PROBLEM",
+	"GATHER_REGS",
+	"INSTR_TOO_LONG",
+	"INVALID_MODE",
+	"BAD_EVEX_LL",
+	"LAST",
+}
+
+var opvisNames = [...]string{
+	"INVALID",
+	"EXPLICIT",
+	"IMPLICIT",
+	"SUPPRESSED",
+	"LAST",
+}
+
+var machineModeNames = [...]string{
+	"INVALID",
+	"LONG_64",
+	"LONG_COMPAT_32",
+	"LONG_COMPAT_16",
+	"LEGACY_32",
+	"LEGACY_16",
+	"REAL_16",
+	"LAST",
+}
+
+var addressWidthNames = [...]string{
+	"INVALID",
+	"16b",
+	"32b",
+	"64b",
+	"LAST",
+}
+
+var categoryNames = [...]string{
+	"INVALID",
+	"3DNOW",
+	"ADOX_ADCX",
+	"AES",
+	"AVX",
+	"AVX2",
+	"AVX2GATHER",
+	"AVX512",
+	"AVX512_4FMAPS",
+	"AVX512_4VNNIW",
+	"AVX512_BITALG",
+	"AVX512_VBMI",
+	"BINARY",
+	"BITBYTE",
+	"BLEND",
+	"BMI1",
+	"BMI2",
+	"BROADCAST",
+	"CALL",
+	"CET",
+	"CLDEMOTE",
+	"CLFLUSHOPT",
+	"CLWB",
+	"CLZERO",
+	"CMOV",
+	"COMPRESS",
+	"COND_BR",
+	"CONFLICT",
+	"CONVERT",
+	"DATAXFER",
+	"DECIMAL",
+	"EXPAND",
+	"FCMOV",
+	"FLAGOP",
+	"FMA4",
+	"GATHER",
+	"GFNI",
+	"IFMA",
+	"INTERRUPT",
+	"IO",
+	"IOSTRINGOP",
+	"KMASK",
+	"LOGICAL",
+	"LOGICAL_FP",
+	"LZCNT",
+	"MISC",
+	"MMX",
+	"MOVDIR",
+	"MPX",
+	"NOP",
+	"PCLMULQDQ",
+	"PCONFIG",
+	"PKU",
+	"POP",
+	"PREFETCH",
+	"PREFETCHWT1",
+	"PT",
+	"PUSH",
+	"RDPID",
+	"RDRAND",
+	"RDSEED",
+	"RDWRFSGS",
+	"RET",
+	"ROTATE",
+	"SCATTER",
+	"SEGOP",
+	"SEMAPHORE",
+	"SETCC",
+	"SGX",
+	"SHA",
+	"SHIFT",
+	"SMAP",
+	"SSE",
+	"STRINGOP",
+	"STTNI",
+	"SYSCALL",
+	"SYSRET",
+	"SYSTEM",
+	"TBM",
+	"UNCOND_BR",
+	"VAES",
+	"VBMI2",
+	"VFMA",
+	"VPCLMULQDQ",
+	"VTX",
+	"WAITPKG",
+	"WIDENOP",
+	"X87_ALU",
+	"XOP",
+	"XSAVE",
+	"XSAVEOPT",
+	"LAST",
+}
+
+var operandNames = [...]string{
+	"INVALID",
+	"AGEN",
+	"AMD3DNOW",
+	"ASZ",
+	"BASE0",
+	"BASE1",
+	"BCAST",
+	"BCRC",
+	"BRDISP_WIDTH",
+	"CET",
+	"CHIP",
+	"CLDEMOTE",
+	"DEFAULT_SEG",
+	"DF32",
+	"DF64",
+	"DISP",
+	"DISP_WIDTH",
+	"DUMMY",
+	"EASZ",
+	"ELEMENT_SIZE",
+	"ENCODER_PREFERRED",
+	"EOSZ",
+	"ERROR",
+	"ESRC",
+	"FIRST_F2F3",
+	"HAS_MODRM",
+	"HAS_SIB",
+	"HINT",
+	"ICLASS",
+	"ILD_F2",
+	"ILD_F3",
+	"ILD_SEG",
+	"IMM0",
+	"IMM0SIGNED",
+	"IMM1",
+	"IMM1_BYTES",
+	"IMM_WIDTH",
+	"INDEX",
+	"LAST_F2F3",
+	"LLRC",
+	"LOCK",
+	"LZCNT",
+	"MAP",
+	"MASK",
+	"MAX_BYTES",
+	"MEM0",
+	"MEM1",
+	"MEM_WIDTH",
+	"MOD",
+	"MODE",
+	"MODEP5",
+	"MODEP55C",
+	"MODE_FIRST_PREFIX",
+	"MODRM_BYTE",
+	"MPXMODE",
+	"NEEDREX",
+	"NEED_MEMDISP",
+	"NELEM",
+	"NOMINAL_OPCODE",
+	"NOREX",
+	"NO_SCALE_DISP8",
+	"NPREFIXES",
+	"NREXES",
+	"NSEG_PREFIXES",
+	"OSZ",
+	"OUTREG",
+	"OUT_OF_BYTES",
+	"P4",
+	"POS_DISP",
+	"POS_IMM",
+	"POS_IMM1",
+	"POS_MODRM",
+	"POS_NOMINAL_OPCODE",
+	"POS_SIB",
+	"PREFIX66",
+	"PTR",
+	"REALMODE",
+	"REG",
+	"REG0",
+	"REG1",
+	"REG2",
+	"REG3",
+	"REG4",
+	"REG5",
+	"REG6",
+	"REG7",
+	"REG8",
+	"RELBR",
+	"REP",
+	"REX",
+	"REXB",
+	"REXR",
+	"REXRR",
+	"REXW",
+	"REXX",
+	"RM",
+	"ROUNDC",
+	"SAE",
+	"SCALE",
+	"SEG0",
+	"SEG1",
+	"SEG_OVD",
+	"SIB",
+	"SIBBASE",
+	"SIBINDEX",
+	"SIBSCALE",
+	"SMODE",
+	"SRM",
+	"TZCNT",
+	"UBIT",
+	"UIMM0",
+	"UIMM1",
+	"USING_DEFAULT_SEGMENT0",
+	"USING_DEFAULT_SEGMENT1",
+	"VEXDEST210",
+	"VEXDEST3",
+	"VEXDEST4",
+	"VEXVALID",
+	"VEX_C4",
+	"VEX_PREFIX",
+	"VL",
+	"WBNOINVD",
+	"ZEROING",
+	"LAST",
+}
+
+var regNames = [...]string{
+	"INVALID",
+	"BNDCFGU",
+	"BNDSTATUS",
+	"BND0",
+	"BND1",
+	"BND2",
+	"BND3",
+	"CR0",
+	"CR1",
+	"CR2",
+	"CR3",
+	"CR4",
+	"CR5",
+	"CR6",
+	"CR7",
+	"CR8",
+	"CR9",
+	"CR10",
+	"CR11",
+	"CR12",
+	"CR13",
+	"CR14",
+	"CR15",
+	"DR0",
+	"DR1",
+	"DR2",
+	"DR3",
+	"DR4",
+	"DR5",
+	"DR6",
+	"DR7",
+	"FLAGS",
+	"EFLAGS",
+	"RFLAGS",
+	"AX",
+	"CX",
+	"DX",
+	"BX",
+	"SP",
+	"BP",
+	"SI",
+	"DI",
+	"R8W",
+	"R9W",
+	"R10W",
+	"R11W",
+	"R12W",
+	"R13W",
+	"R14W",
+	"R15W",
+	"EAX",
+	"ECX",
+	"EDX",
+	"EBX",
+	"ESP",
+	"EBP",
+	"ESI",
+	"EDI",
+	"R8D",
+	"R9D",
+	"R10D",
+	"R11D",
+	"R12D",
+	"R13D",
+	"R14D",
+	"R15D",
+	"RAX",
+	"RCX",
+	"RDX",
+	"RBX",
+	"RSP",
+	"RBP",
+	"RSI",
+	"RDI",
+	"R8",
+	"R9",
+	"R10",
+	"R11",
+	"R12",
+	"R13",
+	"R14",
+	"R15",
+	"AL",
+	"CL",
+	"DL",
+	"BL",
+	"SPL",
+	"BPL",
+	"SIL",
+	"DIL",
+	"R8B",
+	"R9B",
+	"R10B",
+	"R11B",
+	"R12B",
+	"R13B",
+	"R14B",
+	"R15B",
+	"AH",
+	"CH",
+	"DH",
+	"BH",
+	"ERROR",
+	"RIP",
+	"EIP",
+	"IP",
+	"K0",
+	"K1",
+	"K2",
+	"K3",
+	"K4",
+	"K5",
+	"K6",
+	"K7",
+	"MMX0",
+	"MMX1",
+	"MMX2",
+	"MMX3",
+	"MMX4",
+	"MMX5",
+	"MMX6",
+	"MMX7",
+	"SSP",
+	"IA32_U_CET",
+	"MXCSR",
+	"STACKPUSH",
+	"STACKPOP",
+	"GDTR",
+	"LDTR",
+	"IDTR",
+	"TR",
+	"TSC",
+	"TSCAUX",
+	"MSRS",
+	"FSBASE",
+	"GSBASE",
+	"X87CONTROL",
+	"X87STATUS",
+	"X87TAG",
+	"X87PUSH",
+	"X87POP",
+	"X87POP2",
+	"X87OPCODE",
+	"X87LASTCS",
+	"X87LASTIP",
+	"X87LASTDS",
+	"X87LASTDP",
+	"CS",
+	"DS",
+	"ES",
+	"SS",
+	"FS",
+	"GS",
+	"TMP0",
+	"TMP1",
+	"TMP2",
+	"TMP3",
+	"TMP4",
+	"TMP5",
+	"TMP6",
+	"TMP7",
+	"TMP8",
+	"TMP9",
+	"TMP10",
+	"TMP11",
+	"TMP12",
+	"TMP13",
+	"TMP14",
+	"TMP15",
+	"ST0",
+	"ST1",
+	"ST2",
+	"ST3",
+	"ST4",
+	"ST5",
+	"ST6",
+	"ST7",
+	"XCR0",
+	"XMM0",
+	"XMM1",
+	"XMM2",
+	"XMM3",
+	"XMM4",
+	"XMM5",
+	"XMM6",
+	"XMM7",
+	"XMM8",
+	"XMM9",
+	"XMM10",
+	"XMM11",
+	"XMM12",
+	"XMM13",
+	"XMM14",
+	"XMM15",
+	"XMM16",
+	"XMM17",
+	"XMM18",
+	"XMM19",
+	"XMM20",
+	"XMM21",
+	"XMM22",
+	"XMM23",
+	"XMM24",
+	"XMM25",
+	"XMM26",
+	"XMM27",
+	"XMM28",
+	"XMM29",
+	"XMM30",
+	"XMM31",
+	"YMM0",
+	"YMM1",
+	"YMM2",
+	"YMM3",
+	"YMM4",
+	"YMM5",
+	"YMM6",
+	"YMM7",
+	"YMM8",
+	"YMM9",
+	"YMM10",
+	"YMM11",
+	"YMM12",
+	"YMM13",
+	"YMM14",
+	"YMM15",
+	"YMM16",
+	"YMM17",
+	"YMM18",
+	"YMM19",
+	"YMM20",
+	"YMM21",
+	"YMM22",
+	"YMM23",
+	"YMM24",
+	"YMM25",
+	"YMM26",
+	"YMM27",
+	"YMM28",
+	"YMM29",
+	"YMM30",
+	"YMM31",
+	"ZMM0",
+	"ZMM1",
+	"ZMM2",
+	"ZMM3",
+	"ZMM4",
+	"ZMM5",
+	"ZMM6",
+	"ZMM7",
+	"ZMM8",
+	"ZMM9",
+	"ZMM10",
+	"ZMM11",
+	"ZMM12",
+	"ZMM13",
+	"ZMM14",
+	"ZMM15",
+	"ZMM16",
+	"ZMM17",
+	"ZMM18",
+	"ZMM19",
+	"ZMM20",
+	"ZMM21",
+	"ZMM22",
+	"ZMM23",
+	"ZMM24",
+	"ZMM25",
+	"ZMM26",
+	"ZMM27",
+	"ZMM28",
+	"ZMM29",
+	"ZMM30",
+	"ZMM31",
+	"LAST",
+}
+
+var regClassNames = [...]string{
+	"INVALID",
+	"BNDCFG",
+	"BNDSTAT",
+	"BOUND",
+	"CR",
+	"DR",
+	"FLAGS",
+	"GPR",
+	"GPR16",
+	"GPR32",
+	"GPR64",
+	"GPR8",
+	"IP",
+	"MASK",
+	"MMX",
+	"MSR",
+	"MXCSR",
+	"PSEUDO",
+	"PSEUDOX87",
+	"SR",
+	"TMP",
+	"X87",
+	"XCR",
+	"XMM",
+	"YMM",
+	"ZMM",
+	"LAST",
+}
+
+var iclassNames = [...]string{
+	"INVALID",
+	"AAA",
+	"AAD",
+	"AAM",
+	"AAS",
+	"ADC",
+	"ADCX",
+	"ADC_LOCK",
+	"ADD",
+	"ADDPD",
+	"ADDPS",
+	"ADDSD",
+	"ADDSS",
+	"ADDSUBPD",
+	"ADDSUBPS",
+	"ADD_LOCK",
+	"ADOX",
+	"AESDEC",
+	"AESDECLAST",
+	"AESENC",
+	"AESENCLAST",
+	"AESIMC",
+	"AESKEYGENASSIST",
+	"AND",
+	"ANDN",
+	"ANDNPD",
+	"ANDNPS",
+	"ANDPD",
+	"ANDPS",
+	"AND_LOCK",
+	"ARPL",
+	"BEXTR",
+	"BEXTR_XOP",
+	"BLCFILL",
+	"BLCI",
+	"BLCIC",
+	"BLCMSK",
+	"BLCS",
+	"BLENDPD",
+	"BLENDPS",
+	"BLENDVPD",
+	"BLENDVPS",
+	"BLSFILL",
+	"BLSI",
+	"BLSIC",
+	"BLSMSK",
+	"BLSR",
+	"BNDCL",
+	"BNDCN",
+	"BNDCU",
+	"BNDLDX",
+	"BNDMK",
+	"BNDMOV",
+	"BNDSTX",
+	"BOUND",
+	"BSF",
+	"BSR",
+	"BSWAP",
+	"BT",
+	"BTC",
+	"BTC_LOCK",
+	"BTR",
+	"BTR_LOCK",
+	"BTS",
+	"BTS_LOCK",
+	"BZHI",
+	"CALL_FAR",
+	"CALL_NEAR",
+	"CBW",
+	"CDQ",
+	"CDQE",
+	"CLAC",
+	"CLC",
+	"CLD",
+	"CLDEMOTE",
+	"CLFLUSH",
+	"CLFLUSHOPT",
+	"CLGI",
+	"CLI",
+	"CLRSSBSY",
+	"CLTS",
+	"CLWB",
+	"CLZERO",
+	"CMC",
+	"CMOVB",
+	"CMOVBE",
+	"CMOVL",
+	"CMOVLE",
+	"CMOVNB",
+	"CMOVNBE",
+	"CMOVNL",
+	"CMOVNLE",
+	"CMOVNO",
+	"CMOVNP",
+	"CMOVNS",
+	"CMOVNZ",
+	"CMOVO",
+	"CMOVP",
+	"CMOVS",
+	"CMOVZ",
+	"CMP",
+	"CMPPD",
+	"CMPPS",
+	"CMPSB",
+	"CMPSD",
+	"CMPSD_XMM",
+	"CMPSQ",
+	"CMPSS",
+	"CMPSW",
+	"CMPXCHG",
+	"CMPXCHG16B",
+	"CMPXCHG16B_LOCK",
+	"CMPXCHG8B",
+	"CMPXCHG8B_LOCK",
+	"CMPXCHG_LOCK",
+	"COMISD",
+	"COMISS",
+	"CPUID",
+	"CQO",
+	"CRC32",
+	"CVTDQ2PD",
+	"CVTDQ2PS",
+	"CVTPD2DQ",
+	"CVTPD2PI",
+	"CVTPD2PS",
+	"CVTPI2PD",
+	"CVTPI2PS",
+	"CVTPS2DQ",
+	"CVTPS2PD",
+	"CVTPS2PI",
+	"CVTSD2SI",
+	"CVTSD2SS",
+	"CVTSI2SD",
+	"CVTSI2SS",
+	"CVTSS2SD",
+	"CVTSS2SI",
+	"CVTTPD2DQ",
+	"CVTTPD2PI",
+	"CVTTPS2DQ",
+	"CVTTPS2PI",
+	"CVTTSD2SI",
+	"CVTTSS2SI",
+	"CWD",
+	"CWDE",
+	"DAA",
+	"DAS",
+	"DEC",
+	"DEC_LOCK",
+	"DIV",
+	"DIVPD",
+	"DIVPS",
+	"DIVSD",
+	"DIVSS",
+	"DPPD",
+	"DPPS",
+	"EMMS",
+	"ENCLS",
+	"ENCLU",
+	"ENCLV",
+	"ENDBR32",
+	"ENDBR64",
+	"ENTER",
+	"EXTRACTPS",
+	"EXTRQ",
+	"F2XM1",
+	"FABS",
+	"FADD",
+	"FADDP",
+	"FBLD",
+	"FBSTP",
+	"FCHS",
+	"FCMOVB",
+	"FCMOVBE",
+	"FCMOVE",
+	"FCMOVNB",
+	"FCMOVNBE",
+	"FCMOVNE",
+	"FCMOVNU",
+	"FCMOVU",
+	"FCOM",
+	"FCOMI",
+	"FCOMIP",
+	"FCOMP",
+	"FCOMPP",
+	"FCOS",
+	"FDECSTP",
+	"FDISI8087_NOP",
+	"FDIV",
+	"FDIVP",
+	"FDIVR",
+	"FDIVRP",
+	"FEMMS",
+	"FENI8087_NOP",
+	"FFREE",
+	"FFREEP",
+	"FIADD",
+	"FICOM",
+	"FICOMP",
+	"FIDIV",
+	"FIDIVR",
+	"FILD",
+	"FIMUL",
+	"FINCSTP",
+	"FIST",
+	"FISTP",
+	"FISTTP",
+	"FISUB",
+	"FISUBR",
+	"FLD",
+	"FLD1",
+	"FLDCW",
+	"FLDENV",
+	"FLDL2E",
+	"FLDL2T",
+	"FLDLG2",
+	"FLDLN2",
+	"FLDPI",
+	"FLDZ",
+	"FMUL",
+	"FMULP",
+	"FNCLEX",
+	"FNINIT",
+	"FNOP",
+	"FNSAVE",
+	"FNSTCW",
+	"FNSTENV",
+	"FNSTSW",
+	"FPATAN",
+	"FPREM",
+	"FPREM1",
+	"FPTAN",
+	"FRNDINT",
+	"FRSTOR",
+	"FSCALE",
+	"FSETPM287_NOP",
+	"FSIN",
+	"FSINCOS",
+	"FSQRT",
+	"FST",
+	"FSTP",
+	"FSTPNCE",
+	"FSUB",
+	"FSUBP",
+	"FSUBR",
+	"FSUBRP",
+	"FTST",
+	"FUCOM",
+	"FUCOMI",
+	"FUCOMIP",
+	"FUCOMP",
+	"FUCOMPP",
+	"FWAIT",
+	"FXAM",
+	"FXCH",
+	"FXRSTOR",
+	"FXRSTOR64",
+	"FXSAVE",
+	"FXSAVE64",
+	"FXTRACT",
+	"FYL2X",
+	"FYL2XP1",
+	"GETSEC",
+	"GF2P8AFFINEINVQB",
+	"GF2P8AFFINEQB",
+	"GF2P8MULB",
+	"HADDPD",
+	"HADDPS",
+	"HLT",
+	"HSUBPD",
+	"HSUBPS",
+	"IDIV",
+	"IMUL",
+	"IN",
+	"INC",
+	"INCSSPD",
+	"INCSSPQ",
+	"INC_LOCK",
+	"INSB",
+	"INSD",
+	"INSERTPS",
+	"INSERTQ",
+	"INSW",
+	"INT",
+	"INT1",
+	"INT3",
+	"INTO",
+	"INVD",
+	"INVEPT",
+	"INVLPG",
+	"INVLPGA",
+	"INVPCID",
+	"INVVPID",
+	"IRET",
+	"IRETD",
+	"IRETQ",
+	"JB",
+	"JBE",
+	"JCXZ",
+	"JECXZ",
+	"JL",
+	"JLE",
+	"JMP",
+	"JMP_FAR",
+	"JNB",
+	"JNBE",
+	"JNL",
+	"JNLE",
+	"JNO",
+	"JNP",
+	"JNS",
+	"JNZ",
+	"JO",
+	"JP",
+	"JRCXZ",
+	"JS",
+	"JZ",
+	"KADDB",
+	"KADDD",
+	"KADDQ",
+	"KADDW",
+	"KANDB",
+	"KANDD",
+	"KANDNB",
+	"KANDND",
+	"KANDNQ",
+	"KANDNW",
+	"KANDQ",
+	"KANDW",
+	"KMOVB",
+	"KMOVD",
+	"KMOVQ",
+	"KMOVW",
+	"KNOTB",
+	"KNOTD",
+	"KNOTQ",
+	"KNOTW",
+	"KORB",
+	"KORD",
+	"KORQ",
+	"KORTESTB",
+	"KORTESTD",
+	"KORTESTQ",
+	"KORTESTW",
+	"KORW",
+	"KSHIFTLB",
+	"KSHIFTLD",
+	"KSHIFTLQ",
+	"KSHIFTLW",
+	"KSHIFTRB",
+	"KSHIFTRD",
+	"KSHIFTRQ",
+	"KSHIFTRW",
+	"KTESTB",
+	"KTESTD",
+	"KTESTQ",
+	"KTESTW",
+	"KUNPCKBW",
+	"KUNPCKDQ",
+	"KUNPCKWD",
+	"KXNORB",
+	"KXNORD",
+	"KXNORQ",
+	"KXNORW",
+	"KXORB",
+	"KXORD",
+	"KXORQ",
+	"KXORW",
+	"LAHF",
+	"LAR",
+	"LDDQU",
+	"LDMXCSR",
+	"LDS",
+	"LEA",
+	"LEAVE",
+	"LES",
+	"LFENCE",
+	"LFS",
+	"LGDT",
+	"LGS",
+	"LIDT",
+	"LLDT",
+	"LLWPCB",
+	"LMSW",
+	"LODSB",
+	"LODSD",
+	"LODSQ",
+	"LODSW",
+	"LOOP",
+	"LOOPE",
+	"LOOPNE",
+	"LSL",
+	"LSS",
+	"LTR",
+	"LWPINS",
+	"LWPVAL",
+	"LZCNT",
+	"MASKMOVDQU",
+	"MASKMOVQ",
+	"MAXPD",
+	"MAXPS",
+	"MAXSD",
+	"MAXSS",
+	"MFENCE",
+	"MINPD",
+	"MINPS",
+	"MINSD",
+	"MINSS",
+	"MONITOR",
+	"MONITORX",
+	"MOV",
+	"MOVAPD",
+	"MOVAPS",
+	"MOVBE",
+	"MOVD",
+	"MOVDDUP",
+	"MOVDIR64B",
+	"MOVDIRI",
+	"MOVDQ2Q",
+	"MOVDQA",
+	"MOVDQU",
+	"MOVHLPS",
+	"MOVHPD",
+	"MOVHPS",
+	"MOVLHPS",
+	"MOVLPD",
+	"MOVLPS",
+	"MOVMSKPD",
+	"MOVMSKPS",
+	"MOVNTDQ",
+	"MOVNTDQA",
+	"MOVNTI",
+	"MOVNTPD",
+	"MOVNTPS",
+	"MOVNTQ",
+	"MOVNTSD",
+	"MOVNTSS",
+	"MOVQ",
+	"MOVQ2DQ",
+	"MOVSB",
+	"MOVSD",
+	"MOVSD_XMM",
+	"MOVSHDUP",
+	"MOVSLDUP",
+	"MOVSQ",
+	"MOVSS",
+	"MOVSW",
+	"MOVSX",
+	"MOVSXD",
+	"MOVUPD",
+	"MOVUPS",
+	"MOVZX",
+	"MOV_CR",
+	"MOV_DR",
+	"MPSADBW",
+	"MUL",
+	"MULPD",
+	"MULPS",
+	"MULSD",
+	"MULSS",
+	"MULX",
+	"MWAIT",
+	"MWAITX",
+	"NEG",
+	"NEG_LOCK",
+	"NOP",
+	"NOP2",
+	"NOP3",
+	"NOP4",
+	"NOP5",
+	"NOP6",
+	"NOP7",
+	"NOP8",
+	"NOP9",
+	"NOT",
+	"NOT_LOCK",
+	"OR",
+	"ORPD",
+	"ORPS",
+	"OR_LOCK",
+	"OUT",
+	"OUTSB",
+	"OUTSD",
+	"OUTSW",
+	"PABSB",
+	"PABSD",
+	"PABSW",
+	"PACKSSDW",
+	"PACKSSWB",
+	"PACKUSDW",
+	"PACKUSWB",
+	"PADDB",
+	"PADDD",
+	"PADDQ",
+	"PADDSB",
+	"PADDSW",
+	"PADDUSB",
+	"PADDUSW",
+	"PADDW",
+	"PALIGNR",
+	"PAND",
+	"PANDN",
+	"PAUSE",
+	"PAVGB",
+	"PAVGUSB",
+	"PAVGW",
+	"PBLENDVB",
+	"PBLENDW",
+	"PCLMULQDQ",
+	"PCMPEQB",
+	"PCMPEQD",
+	"PCMPEQQ",
+	"PCMPEQW",
+	"PCMPESTRI",
+	"PCMPESTRM",
+	"PCMPGTB",
+	"PCMPGTD",
+	"PCMPGTQ",
+	"PCMPGTW",
+	"PCMPISTRI",
+	"PCMPISTRM",
+	"PCONFIG",
+	"PDEP",
+	"PEXT",
+	"PEXTRB",
+	"PEXTRD",
+	"PEXTRQ",
+	"PEXTRW",
+	"PEXTRW_SSE4",
+	"PF2ID",
+	"PF2IW",
+	"PFACC",
+	"PFADD",
+	"PFCMPEQ",
+	"PFCMPGE",
+	"PFCMPGT",
+	"PFMAX",
+	"PFMIN",
+	"PFMUL",
+	"PFNACC",
+	"PFPNACC",
+	"PFRCP",
+	"PFRCPIT2",
+	"PFRSQIT1",
+	"PFSUB",
+	"PFSUBR",
+	"PHADDD",
+	"PHADDSW",
+	"PHADDW",
+	"PHMINPOSUW",
+	"PHSUBD",
+	"PHSUBSW",
+	"PHSUBW",
+	"PI2FD",
+	"PI2FW",
+	"PINSRB",
+	"PINSRD",
+	"PINSRQ",
+	"PINSRW",
+	"PMADDUBSW",
+	"PMADDWD",
+	"PMAXSB",
+	"PMAXSD",
+	"PMAXSW",
+	"PMAXUB",
+	"PMAXUD",
+	"PMAXUW",
+	"PMINSB",
+	"PMINSD",
+	"PMINSW",
+	"PMINUB",
+	"PMINUD",
+	"PMINUW",
+	"PMOVMSKB",
+	"PMOVSXBD",
+	"PMOVSXBQ",
+	"PMOVSXBW",
+	"PMOVSXDQ",
+	"PMOVSXWD",
+	"PMOVSXWQ",
+	"PMOVZXBD",
+	"PMOVZXBQ",
+	"PMOVZXBW",
+	"PMOVZXDQ",
+	"PMOVZXWD",
+	"PMOVZXWQ",
+	"PMULDQ",
+	"PMULHRSW",
+	"PMULHRW",
+	"PMULHUW",
+	"PMULHW",
+	"PMULLD",
+	"PMULLW",
+	"PMULUDQ",
+	"POP",
+	"POPA",
+	"POPAD",
+	"POPCNT",
+	"POPF",
+	"POPFD",
+	"POPFQ",
+	"POR",
+	"PREFETCHNTA",
+	"PREFETCHT0",
+	"PREFETCHT1",
+	"PREFETCHT2",
+	"PREFETCHW",
+	"PREFETCHWT1",
+	"PREFETCH_EXCLUSIVE",
+	"PREFETCH_RESERVED",
+	"PSADBW",
+	"PSHUFB",
+	"PSHUFD",
+	"PSHUFHW",
+	"PSHUFLW",
+	"PSHUFW",
+	"PSIGNB",
+	"PSIGND",
+	"PSIGNW",
+	"PSLLD",
+	"PSLLDQ",
+	"PSLLQ",
+	"PSLLW",
+	"PSRAD",
+	"PSRAW",
+	"PSRLD",
+	"PSRLDQ",
+	"PSRLQ",
+	"PSRLW",
+	"PSUBB",
+	"PSUBD",
+	"PSUBQ",
+	"PSUBSB",
+	"PSUBSW",
+	"PSUBUSB",
+	"PSUBUSW",
+	"PSUBW",
+	"PSWAPD",
+	"PTEST",
+	"PTWRITE",
+	"PUNPCKHBW",
+	"PUNPCKHDQ",
+	"PUNPCKHQDQ",
+	"PUNPCKHWD",
+	"PUNPCKLBW",
+	"PUNPCKLDQ",
+	"PUNPCKLQDQ",
+	"PUNPCKLWD",
+	"PUSH",
+	"PUSHA",
+	"PUSHAD",
+	"PUSHF",
+	"PUSHFD",
+	"PUSHFQ",
+	"PXOR",
+	"RCL",
+	"RCPPS",
+	"RCPSS",
+	"RCR",
+	"RDFSBASE",
+	"RDGSBASE",
+	"RDMSR",
+	"RDPID",
+	"RDPKRU",
+	"RDPMC",
+	"RDRAND",
+	"RDSEED",
+	"RDSSPD",
+	"RDSSPQ",
+	"RDTSC",
+	"RDTSCP",
+	"REPE_CMPSB",
+	"REPE_CMPSD",
+	"REPE_CMPSQ",
+	"REPE_CMPSW",
+	"REPE_SCASB",
+	"REPE_SCASD",
+	"REPE_SCASQ",
+	"REPE_SCASW",
+	"REPNE_CMPSB",
+	"REPNE_CMPSD",
+	"REPNE_CMPSQ",
+	"REPNE_CMPSW",
+	"REPNE_SCASB",
+	"REPNE_SCASD",
+	"REPNE_SCASQ",
+	"REPNE_SCASW",
+	"REP_INSB",
+	"REP_INSD",
+	"REP_INSW",
+	"REP_LODSB",
+	"REP_LODSD",
+	"REP_LODSQ",
+	"REP_LODSW",
+	"REP_MOVSB",
+	"REP_MOVSD",
+	"REP_MOVSQ",
+	"REP_MOVSW",
+	"REP_OUTSB",
+	"REP_OUTSD",
+	"REP_OUTSW",
+	"REP_STOSB",
+	"REP_STOSD",
+	"REP_STOSQ",
+	"REP_STOSW",
+	"RET_FAR",
+	"RET_NEAR",
+	"ROL",
+	"ROR",
+	"RORX",
+	"ROUNDPD",
+	"ROUNDPS",
+	"ROUNDSD",
+	"ROUNDSS",
+	"RSM",
+	"RSQRTPS",
+	"RSQRTSS",
+	"RSTORSSP",
+	"SAHF",
+	"SALC",
+	"SAR",
+	"SARX",
+	"SAVEPREVSSP",
+	"SBB",
+	"SBB_LOCK",
+	"SCASB",
+	"SCASD",
+	"SCASQ",
+	"SCASW",
+	"SETB",
+	"SETBE",
+	"SETL",
+	"SETLE",
+	"SETNB",
+	"SETNBE",
+	"SETNL",
+	"SETNLE",
+	"SETNO",
+	"SETNP",
+	"SETNS",
+	"SETNZ",
+	"SETO",
+	"SETP",
+	"SETS",
+	"SETSSBSY",
+	"SETZ",
+	"SFENCE",
+	"SGDT",
+	"SHA1MSG1",
+	"SHA1MSG2",
+	"SHA1NEXTE",
+	"SHA1RNDS4",
+	"SHA256MSG1",
+	"SHA256MSG2",
+	"SHA256RNDS2",
+	"SHL",
+	"SHLD",
+	"SHLX",
+	"SHR",
+	"SHRD",
+	"SHRX",
+	"SHUFPD",
+	"SHUFPS",
+	"SIDT",
+	"SKINIT",
+	"SLDT",
+	"SLWPCB",
+	"SMSW",
+	"SQRTPD",
+	"SQRTPS",
+	"SQRTSD",
+	"SQRTSS",
+	"STAC",
+	"STC",
+	"STD",
+	"STGI",
+	"STI",
+	"STMXCSR",
+	"STOSB",
+	"STOSD",
+	"STOSQ",
+	"STOSW",
+	"STR",
+	"SUB",
+	"SUBPD",
+	"SUBPS",
+	"SUBSD",
+	"SUBSS",
+	"SUB_LOCK",
+	"SWAPGS",
+	"SYSCALL",
+	"SYSCALL_AMD",
+	"SYSENTER",
+	"SYSEXIT",
+	"SYSRET",
+	"SYSRET_AMD",
+	"T1MSKC",
+	"TEST",
+	"TPAUSE",
+	"TZCNT",
+	"TZMSK",
+	"UCOMISD",
+	"UCOMISS",
+	"UD0",
+	"UD1",
+	"UD2",
+	"UMONITOR",
+	"UMWAIT",
+	"UNPCKHPD",
+	"UNPCKHPS",
+	"UNPCKLPD",
+	"UNPCKLPS",
+	"V4FMADDPS",
+	"V4FMADDSS",
+	"V4FNMADDPS",
+	"V4FNMADDSS",
+	"VADDPD",
+	"VADDPS",
+	"VADDSD",
+	"VADDSS",
+	"VADDSUBPD",
+	"VADDSUBPS",
+	"VAESDEC",
+	"VAESDECLAST",
+	"VAESENC",
+	"VAESENCLAST",
+	"VAESIMC",
+	"VAESKEYGENASSIST",
+	"VALIGND",
+	"VALIGNQ",
+	"VANDNPD",
+	"VANDNPS",
+	"VANDPD",
+	"VANDPS",
+	"VBLENDMPD",
+	"VBLENDMPS",
+	"VBLENDPD",
+	"VBLENDPS",
+	"VBLENDVPD",
+	"VBLENDVPS",
+	"VBROADCASTF128",
+	"VBROADCASTF32X2",
+	"VBROADCASTF32X4",
+	"VBROADCASTF32X8",
+	"VBROADCASTF64X2",
+	"VBROADCASTF64X4",
+	"VBROADCASTI128",
+	"VBROADCASTI32X2",
+	"VBROADCASTI32X4",
+	"VBROADCASTI32X8",
+	"VBROADCASTI64X2",
+	"VBROADCASTI64X4",
+	"VBROADCASTSD",
+	"VBROADCASTSS",
+	"VCMPPD",
+	"VCMPPS",
+	"VCMPSD",
+	"VCMPSS",
+	"VCOMISD",
+	"VCOMISS",
+	"VCOMPRESSPD",
+	"VCOMPRESSPS",
+	"VCVTDQ2PD",
+	"VCVTDQ2PS",
+	"VCVTPD2DQ",
+	"VCVTPD2PS",
+	"VCVTPD2QQ",
+	"VCVTPD2UDQ",
+	"VCVTPD2UQQ",
+	"VCVTPH2PS",
+	"VCVTPS2DQ",
+	"VCVTPS2PD",
+	"VCVTPS2PH",
+	"VCVTPS2QQ",
+	"VCVTPS2UDQ",
+	"VCVTPS2UQQ",
+	"VCVTQQ2PD",
+	"VCVTQQ2PS",
+	"VCVTSD2SI",
+	"VCVTSD2SS",
+	"VCVTSD2USI",
+	"VCVTSI2SD",
+	"VCVTSI2SS",
+	"VCVTSS2SD",
+	"VCVTSS2SI",
+	"VCVTSS2USI",
+	"VCVTTPD2DQ",
+	"VCVTTPD2QQ",
+	"VCVTTPD2UDQ",
+	"VCVTTPD2UQQ",
+	"VCVTTPS2DQ",
+	"VCVTTPS2QQ",
+	"VCVTTPS2UDQ",
+	"VCVTTPS2UQQ",
+	"VCVTTSD2SI",
+	"VCVTTSD2USI",
+	"VCVTTSS2SI",
+	"VCVTTSS2USI",
+	"VCVTUDQ2PD",
+	"VCVTUDQ2PS",
+	"VCVTUQQ2PD",
+	"VCVTUQQ2PS",
+	"VCVTUSI2SD",
+	"VCVTUSI2SS",
+	"VDBPSADBW",
+	"VDIVPD",
+	"VDIVPS",
+	"VDIVSD",
+	"VDIVSS",
+	"VDPPD",
+	"VDPPS",
+	"VERR",
+	"VERW",
+	"VEXP2PD",
+	"VEXP2PS",
+	"VEXPANDPD",
+	"VEXPANDPS",
+	"VEXTRACTF128",
+	"VEXTRACTF32X4",
+	"VEXTRACTF32X8",
+	"VEXTRACTF64X2",
+	"VEXTRACTF64X4",
+	"VEXTRACTI128",
+	"VEXTRACTI32X4",
+	"VEXTRACTI32X8",
+	"VEXTRACTI64X2",
+	"VEXTRACTI64X4",
+	"VEXTRACTPS",
+	"VFIXUPIMMPD",
+	"VFIXUPIMMPS",
+	"VFIXUPIMMSD",
+	"VFIXUPIMMSS",
+	"VFMADD132PD",
+	"VFMADD132PS",
+	"VFMADD132SD",
+	"VFMADD132SS",
+	"VFMADD213PD",
+	"VFMADD213PS",
+	"VFMADD213SD",
+	"VFMADD213SS",
+	"VFMADD231PD",
+	"VFMADD231PS",
+	"VFMADD231SD",
+	"VFMADD231SS",
+	"VFMADDPD",
+	"VFMADDPS",
+	"VFMADDSD",
+	"VFMADDSS",
+	"VFMADDSUB132PD",
+	"VFMADDSUB132PS",
+	"VFMADDSUB213PD",
+	"VFMADDSUB213PS",
+	"VFMADDSUB231PD",
+	"VFMADDSUB231PS",
+	"VFMADDSUBPD",
+	"VFMADDSUBPS",
+	"VFMSUB132PD",
+	"VFMSUB132PS",
+	"VFMSUB132SD",
+	"VFMSUB132SS",
+	"VFMSUB213PD",
+	"VFMSUB213PS",
+	"VFMSUB213SD",
+	"VFMSUB213SS",
+	"VFMSUB231PD",
+	"VFMSUB231PS",
+	"VFMSUB231SD",
+	"VFMSUB231SS",
+	"VFMSUBADD132PD",
+	"VFMSUBADD132PS",
+	"VFMSUBADD213PD",
+	"VFMSUBADD213PS",
+	"VFMSUBADD231PD",
+	"VFMSUBADD231PS",
+	"VFMSUBADDPD",
+	"VFMSUBADDPS",
+	"VFMSUBPD",
+	"VFMSUBPS",
+	"VFMSUBSD",
+	"VFMSUBSS",
+	"VFNMADD132PD",
+	"VFNMADD132PS",
+	"VFNMADD132SD",
+	"VFNMADD132SS",
+	"VFNMADD213PD",
+	"VFNMADD213PS",
+	"VFNMADD213SD",
+	"VFNMADD213SS",
+	"VFNMADD231PD",
+	"VFNMADD231PS",
+	"VFNMADD231SD",
+	"VFNMADD231SS",
+	"VFNMADDPD",
+	"VFNMADDPS",
+	"VFNMADDSD",
+	"VFNMADDSS",
+	"VFNMSUB132PD",
+	"VFNMSUB132PS",
+	"VFNMSUB132SD",
+	"VFNMSUB132SS",
+	"VFNMSUB213PD",
+	"VFNMSUB213PS",
+	"VFNMSUB213SD",
+	"VFNMSUB213SS",
+	"VFNMSUB231PD",
+	"VFNMSUB231PS",
+	"VFNMSUB231SD",
+	"VFNMSUB231SS",
+	"VFNMSUBPD",
+	"VFNMSUBPS",
+	"VFNMSUBSD",
+	"VFNMSUBSS",
+	"VFPCLASSPD",
+	"VFPCLASSPS",
+	"VFPCLASSSD",
+	"VFPCLASSSS",
+	"VFRCZPD",
+	"VFRCZPS",
+	"VFRCZSD",
+	"VFRCZSS",
+	"VGATHERDPD",
+	"VGATHERDPS",
+	"VGATHERPF0DPD",
+	"VGATHERPF0DPS",
+	"VGATHERPF0QPD",
+	"VGATHERPF0QPS",
+	"VGATHERPF1DPD",
+	"VGATHERPF1DPS",
+	"VGATHERPF1QPD",
+	"VGATHERPF1QPS",
+	"VGATHERQPD",
+	"VGATHERQPS",
+	"VGETEXPPD",
+	"VGETEXPPS",
+	"VGETEXPSD",
+	"VGETEXPSS",
+	"VGETMANTPD",
+	"VGETMANTPS",
+	"VGETMANTSD",
+	"VGETMANTSS",
+	"VGF2P8AFFINEINVQB",
+	"VGF2P8AFFINEQB",
+	"VGF2P8MULB",
+	"VHADDPD",
+	"VHADDPS",
+	"VHSUBPD",
+	"VHSUBPS",
+	"VINSERTF128",
+	"VINSERTF32X4",
+	"VINSERTF32X8",
+	"VINSERTF64X2",
+	"VINSERTF64X4",
+	"VINSERTI128",
+	"VINSERTI32X4",
+	"VINSERTI32X8",
+	"VINSERTI64X2",
+	"VINSERTI64X4",
+	"VINSERTPS",
+	"VLDDQU",
+	"VLDMXCSR",
+	"VMASKMOVDQU",
+	"VMASKMOVPD",
+	"VMASKMOVPS",
+	"VMAXPD",
+	"VMAXPS",
+	"VMAXSD",
+	"VMAXSS",
+	"VMCALL",
+	"VMCLEAR",
+	"VMFUNC",
+	"VMINPD",
+	"VMINPS",
+	"VMINSD",
+	"VMINSS",
+	"VMLAUNCH",
+	"VMLOAD",
+	"VMMCALL",
+	"VMOVAPD",
+	"VMOVAPS",
+	"VMOVD",
+	"VMOVDDUP",
+	"VMOVDQA",
+	"VMOVDQA32",
+	"VMOVDQA64",
+	"VMOVDQU",
+	"VMOVDQU16",
+	"VMOVDQU32",
+	"VMOVDQU64",
+	"VMOVDQU8",
+	"VMOVHLPS",
+	"VMOVHPD",
+	"VMOVHPS",
+	"VMOVLHPS",
+	"VMOVLPD",
+	"VMOVLPS",
+	"VMOVMSKPD",
+	"VMOVMSKPS",
+	"VMOVNTDQ",
+	"VMOVNTDQA",
+	"VMOVNTPD",
+	"VMOVNTPS",
+	"VMOVQ",
+	"VMOVSD",
+	"VMOVSHDUP",
+	"VMOVSLDUP",
+	"VMOVSS",
+	"VMOVUPD",
+	"VMOVUPS",
+	"VMPSADBW",
+	"VMPTRLD",
+	"VMPTRST",
+	"VMREAD",
+	"VMRESUME",
+	"VMRUN",
+	"VMSAVE",
+	"VMULPD",
+	"VMULPS",
+	"VMULSD",
+	"VMULSS",
+	"VMWRITE",
+	"VMXOFF",
+	"VMXON",
+	"VORPD",
+	"VORPS",
+	"VP4DPWSSD",
+	"VP4DPWSSDS",
+	"VPABSB",
+	"VPABSD",
+	"VPABSQ",
+	"VPABSW",
+	"VPACKSSDW",
+	"VPACKSSWB",
+	"VPACKUSDW",
+	"VPACKUSWB",
+	"VPADDB",
+	"VPADDD",
+	"VPADDQ",
+	"VPADDSB",
+	"VPADDSW",
+	"VPADDUSB",
+	"VPADDUSW",
+	"VPADDW",
+	"VPALIGNR",
+	"VPAND",
+	"VPANDD",
+	"VPANDN",
+	"VPANDND",
+	"VPANDNQ",
+	"VPANDQ",
+	"VPAVGB",
+	"VPAVGW",
+	"VPBLENDD",
+	"VPBLENDMB",
+	"VPBLENDMD",
+	"VPBLENDMQ",
+	"VPBLENDMW",
+	"VPBLENDVB",
+	"VPBLENDW",
+	"VPBROADCASTB",
+	"VPBROADCASTD",
+	"VPBROADCASTMB2Q",
+	"VPBROADCASTMW2D",
+	"VPBROADCASTQ",
+	"VPBROADCASTW",
+	"VPCLMULQDQ",
+	"VPCMOV",
+	"VPCMPB",
+	"VPCMPD",
+	"VPCMPEQB",
+	"VPCMPEQD",
+	"VPCMPEQQ",
+	"VPCMPEQW",
+	"VPCMPESTRI",
+	"VPCMPESTRM",
+	"VPCMPGTB",
+	"VPCMPGTD",
+	"VPCMPGTQ",
+	"VPCMPGTW",
+	"VPCMPISTRI",
+	"VPCMPISTRM",
+	"VPCMPQ",
+	"VPCMPUB",
+	"VPCMPUD",
+	"VPCMPUQ",
+	"VPCMPUW",
+	"VPCMPW",
+	"VPCOMB",
+	"VPCOMD",
+	"VPCOMPRESSB",
+	"VPCOMPRESSD",
+	"VPCOMPRESSQ",
+	"VPCOMPRESSW",
+	"VPCOMQ",
+	"VPCOMUB",
+	"VPCOMUD",
+	"VPCOMUQ",
+	"VPCOMUW",
+	"VPCOMW",
+	"VPCONFLICTD",
+	"VPCONFLICTQ",
+	"VPDPBUSD",
+	"VPDPBUSDS",
+	"VPDPWSSD",
+	"VPDPWSSDS",
+	"VPERM2F128",
+	"VPERM2I128",
+	"VPERMB",
+	"VPERMD",
+	"VPERMI2B",
+	"VPERMI2D",
+	"VPERMI2PD",
+	"VPERMI2PS",
+	"VPERMI2Q",
+	"VPERMI2W",
+	"VPERMIL2PD",
+	"VPERMIL2PS",
+	"VPERMILPD",
+	"VPERMILPS",
+	"VPERMPD",
+	"VPERMPS",
+	"VPERMQ",
+	"VPERMT2B",
+	"VPERMT2D",
+	"VPERMT2PD",
+	"VPERMT2PS",
+	"VPERMT2Q",
+	"VPERMT2W",
+	"VPERMW",
+	"VPEXPANDB",
+	"VPEXPANDD",
+	"VPEXPANDQ",
+	"VPEXPANDW",
+	"VPEXTRB",
+	"VPEXTRD",
+	"VPEXTRQ",
+	"VPEXTRW",
+	"VPEXTRW_C5",
+	"VPGATHERDD",
+	"VPGATHERDQ",
+	"VPGATHERQD",
+	"VPGATHERQQ",
+	"VPHADDBD",
+	"VPHADDBQ",
+	"VPHADDBW",
+	"VPHADDD",
+	"VPHADDDQ",
+	"VPHADDSW",
+	"VPHADDUBD",
+	"VPHADDUBQ",
+	"VPHADDUBW",
+	"VPHADDUDQ",
+	"VPHADDUWD",
+	"VPHADDUWQ",
+	"VPHADDW",
+	"VPHADDWD",
+	"VPHADDWQ",
+	"VPHMINPOSUW",
+	"VPHSUBBW",
+	"VPHSUBD",
+	"VPHSUBDQ",
+	"VPHSUBSW",
+	"VPHSUBW",
+	"VPHSUBWD",
+	"VPINSRB",
+	"VPINSRD",
+	"VPINSRQ",
+	"VPINSRW",
+	"VPLZCNTD",
+	"VPLZCNTQ",
+	"VPMACSDD",
+	"VPMACSDQH",
+	"VPMACSDQL",
+	"VPMACSSDD",
+	"VPMACSSDQH",
+	"VPMACSSDQL",
+	"VPMACSSWD",
+	"VPMACSSWW",
+	"VPMACSWD",
+	"VPMACSWW",
+	"VPMADCSSWD",
+	"VPMADCSWD",
+	"VPMADD52HUQ",
+	"VPMADD52LUQ",
+	"VPMADDUBSW",
+	"VPMADDWD",
+	"VPMASKMOVD",
+	"VPMASKMOVQ",
+	"VPMAXSB",
+	"VPMAXSD",
+	"VPMAXSQ",
+	"VPMAXSW",
+	"VPMAXUB",
+	"VPMAXUD",
+	"VPMAXUQ",
+	"VPMAXUW",
+	"VPMINSB",
+	"VPMINSD",
+	"VPMINSQ",
+	"VPMINSW",
+	"VPMINUB",
+	"VPMINUD",
+	"VPMINUQ",
+	"VPMINUW",
+	"VPMOVB2M",
+	"VPMOVD2M",
+	"VPMOVDB",
+	"VPMOVDW",
+	"VPMOVM2B",
+	"VPMOVM2D",
+	"VPMOVM2Q",
+	"VPMOVM2W",
+	"VPMOVMSKB",
+	"VPMOVQ2M",
+	"VPMOVQB",
+	"VPMOVQD",
+	"VPMOVQW",
+	"VPMOVSDB",
+	"VPMOVSDW",
+	"VPMOVSQB",
+	"VPMOVSQD",
+	"VPMOVSQW",
+	"VPMOVSWB",
+	"VPMOVSXBD",
+	"VPMOVSXBQ",
+	"VPMOVSXBW",
+	"VPMOVSXDQ",
+	"VPMOVSXWD",
+	"VPMOVSXWQ",
+	"VPMOVUSDB",
+	"VPMOVUSDW",
+	"VPMOVUSQB",
+	"VPMOVUSQD",
+	"VPMOVUSQW",
+	"VPMOVUSWB",
+	"VPMOVW2M",
+	"VPMOVWB",
+	"VPMOVZXBD",
+	"VPMOVZXBQ",
+	"VPMOVZXBW",
+	"VPMOVZXDQ",
+	"VPMOVZXWD",
+	"VPMOVZXWQ",
+	"VPMULDQ",
+	"VPMULHRSW",
+	"VPMULHUW",
+	"VPMULHW",
+	"VPMULLD",
+	"VPMULLQ",
+	"VPMULLW",
+	"VPMULTISHIFTQB",
+	"VPMULUDQ",
+	"VPOPCNTB",
+	"VPOPCNTD",
+	"VPOPCNTQ",
+	"VPOPCNTW",
+	"VPOR",
+	"VPORD",
+	"VPORQ",
+	"VPPERM",
+	"VPROLD",
+	"VPROLQ",
+	"VPROLVD",
+	"VPROLVQ",
+	"VPRORD",
+	"VPRORQ",
+	"VPRORVD",
+	"VPRORVQ",
+	"VPROTB",
+	"VPROTD",
+	"VPROTQ",
+	"VPROTW",
+	"VPSADBW",
+	"VPSCATTERDD",
+	"VPSCATTERDQ",
+	"VPSCATTERQD",
+	"VPSCATTERQQ",
+	"VPSHAB",
+	"VPSHAD",
+	"VPSHAQ",
+	"VPSHAW",
+	"VPSHLB",
+	"VPSHLD",
+	"VPSHLDD",
+	"VPSHLDQ",
+	"VPSHLDVD",
+	"VPSHLDVQ",
+	"VPSHLDVW",
+	"VPSHLDW",
+	"VPSHLQ",
+	"VPSHLW",
+	"VPSHRDD",
+	"VPSHRDQ",
+	"VPSHRDVD",
+	"VPSHRDVQ",
+	"VPSHRDVW",
+	"VPSHRDW",
+	"VPSHUFB",
+	"VPSHUFBITQMB",
+	"VPSHUFD",
+	"VPSHUFHW",
+	"VPSHUFLW",
+	"VPSIGNB",
+	"VPSIGND",
+	"VPSIGNW",
+	"VPSLLD",
+	"VPSLLDQ",
+	"VPSLLQ",
+	"VPSLLVD",
+	"VPSLLVQ",
+	"VPSLLVW",
+	"VPSLLW",
+	"VPSRAD",
+	"VPSRAQ",
+	"VPSRAVD",
+	"VPSRAVQ",
+	"VPSRAVW",
+	"VPSRAW",
+	"VPSRLD",
+	"VPSRLDQ",
+	"VPSRLQ",
+	"VPSRLVD",
+	"VPSRLVQ",
+	"VPSRLVW",
+	"VPSRLW",
+	"VPSUBB",
+	"VPSUBD",
+	"VPSUBQ",
+	"VPSUBSB",
+	"VPSUBSW",
+	"VPSUBUSB",
+	"VPSUBUSW",
+	"VPSUBW",
+	"VPTERNLOGD",
+	"VPTERNLOGQ",
+	"VPTEST",
+	"VPTESTMB",
+	"VPTESTMD",
+	"VPTESTMQ",
+	"VPTESTMW",
+	"VPTESTNMB",
+	"VPTESTNMD",
+	"VPTESTNMQ",
+	"VPTESTNMW",
+	"VPUNPCKHBW",
+	"VPUNPCKHDQ",
+	"VPUNPCKHQDQ",
+	"VPUNPCKHWD",
+	"VPUNPCKLBW",
+	"VPUNPCKLDQ",
+	"VPUNPCKLQDQ",
+	"VPUNPCKLWD",
+	"VPXOR",
+	"VPXORD",
+	"VPXORQ",
+	"VRANGEPD",
+	"VRANGEPS",
+	"VRANGESD",
+	"VRANGESS",
+	"VRCP14PD",
+	"VRCP14PS",
+	"VRCP14SD",
+	"VRCP14SS",
+	"VRCP28PD",
+	"VRCP28PS",
+	"VRCP28SD",
+	"VRCP28SS",
+	"VRCPPS",
+	"VRCPSS",
+	"VREDUCEPD",
+	"VREDUCEPS",
+	"VREDUCESD",
+	"VREDUCESS",
+	"VRNDSCALEPD",
+	"VRNDSCALEPS",
+	"VRNDSCALESD",
+	"VRNDSCALESS",
+	"VROUNDPD",
+	"VROUNDPS",
+	"VROUNDSD",
+	"VROUNDSS",
+	"VRSQRT14PD",
+	"VRSQRT14PS",
+	"VRSQRT14SD",
+	"VRSQRT14SS",
+	"VRSQRT28PD",
+	"VRSQRT28PS",
+	"VRSQRT28SD",
+	"VRSQRT28SS",
+	"VRSQRTPS",
+	"VRSQRTSS",
+	"VSCALEFPD",
+	"VSCALEFPS",
+	"VSCALEFSD",
+	"VSCALEFSS",
+	"VSCATTERDPD",
+	"VSCATTERDPS",
+	"VSCATTERPF0DPD",
+	"VSCATTERPF0DPS",
+	"VSCATTERPF0QPD",
+	"VSCATTERPF0QPS",
+	"VSCATTERPF1DPD",
+	"VSCATTERPF1DPS",
+	"VSCATTERPF1QPD",
+	"VSCATTERPF1QPS",
+	"VSCATTERQPD",
+	"VSCATTERQPS",
+	"VSHUFF32X4",
+	"VSHUFF64X2",
+	"VSHUFI32X4",
+	"VSHUFI64X2",
+	"VSHUFPD",
+	"VSHUFPS",
+	"VSQRTPD",
+	"VSQRTPS",
+	"VSQRTSD",
+	"VSQRTSS",
+	"VSTMXCSR",
+	"VSUBPD",
+	"VSUBPS",
+	"VSUBSD",
+	"VSUBSS",
+	"VTESTPD",
+	"VTESTPS",
+	"VUCOMISD",
+	"VUCOMISS",
+	"VUNPCKHPD",
+	"VUNPCKHPS",
+	"VUNPCKLPD",
+	"VUNPCKLPS",
+	"VXORPD",
+	"VXORPS",
+	"VZEROALL",
+	"VZEROUPPER",
+	"WBINVD",
+	"WBNOINVD",
+	"WRFSBASE",
+	"WRGSBASE",
+	"WRMSR",
+	"WRPKRU",
+	"WRSSD",
+	"WRSSQ",
+	"WRUSSD",
+	"WRUSSQ",
+	"XABORT",
+	"XADD",
+	"XADD_LOCK",
+	"XBEGIN",
+	"XCHG",
+	"XEND",
+	"XGETBV",
+	"XLAT",
+	"XOR",
+	"XORPD",
+	"XORPS",
+	"XOR_LOCK",
+	"XRSTOR",
+	"XRSTOR64",
+	"XRSTORS",
+	"XRSTORS64",
+	"XSAVE",
+	"XSAVE64",
+	"XSAVEC",
+	"XSAVEC64",
+	"XSAVEOPT",
+	"XSAVEOPT64",
+	"XSAVES",
+	"XSAVES64",
+	"XSETBV",
+	"XTEST",
+	"LAST",
+}
+
+var isaSetNames = [...]string{
+	"INVALID",
+	"3DNOW",
+	"ADOX_ADCX",
+	"AES",
+	"AMD",
+	"AVX",
+	"AVX2",
+	"AVX2GATHER",
+	"AVX512BW_128",
+	"AVX512BW_128N",
+	"AVX512BW_256",
+	"AVX512BW_512",
+	"AVX512BW_KOP",
+	"AVX512CD_128",
+	"AVX512CD_256",
+	"AVX512CD_512",
+	"AVX512DQ_128",
+	"AVX512DQ_128N",
+	"AVX512DQ_256",
+	"AVX512DQ_512",
+	"AVX512DQ_KOP",
+	"AVX512DQ_SCALAR",
+	"AVX512ER_512",
+	"AVX512ER_SCALAR",
+	"AVX512F_128",
+	"AVX512F_128N",
+	"AVX512F_256",
+	"AVX512F_512",
+	"AVX512F_KOP",
+	"AVX512F_SCALAR",
+	"AVX512PF_512",
+	"AVX512_4FMAPS_512",
+	"AVX512_4FMAPS_SCALAR",
+	"AVX512_4VNNIW_512",
+	"AVX512_BITALG_128",
+	"AVX512_BITALG_256",
+	"AVX512_BITALG_512",
+	"AVX512_GFNI_128",
+	"AVX512_GFNI_256",
+	"AVX512_GFNI_512",
+	"AVX512_IFMA_128",
+	"AVX512_IFMA_256",
+	"AVX512_IFMA_512",
+	"AVX512_VAES_128",
+	"AVX512_VAES_256",
+	"AVX512_VAES_512",
+	"AVX512_VBMI2_128",
+	"AVX512_VBMI2_256",
+	"AVX512_VBMI2_512",
+	"AVX512_VBMI_128",
+	"AVX512_VBMI_256",
+	"AVX512_VBMI_512",
+	"AVX512_VNNI_128",
+	"AVX512_VNNI_256",
+	"AVX512_VNNI_512",
+	"AVX512_VPCLMULQDQ_128",
+	"AVX512_VPCLMULQDQ_256",
+	"AVX512_VPCLMULQDQ_512",
+	"AVX512_VPOPCNTDQ_128",
+	"AVX512_VPOPCNTDQ_256",
+	"AVX512_VPOPCNTDQ_512",
+	"AVXAES",
+	"AVX_GFNI",
+	"BMI1",
+	"BMI2",
+	"CET",
+	"CLDEMOTE",
+	"CLFLUSHOPT",
+	"CLFSH",
+	"CLWB",
+	"CLZERO",
+	"CMOV",
+	"CMPXCHG16B",
+	"F16C",
+	"FAT_NOP",
+	"FCMOV",
+	"FMA",
+	"FMA4",
+	"FXSAVE",
+	"FXSAVE64",
+	"GFNI",
+	"I186",
+	"I286PROTECTED",
+	"I286REAL",
+	"I386",
+	"I486",
+	"I486REAL",
+	"I86",
+	"INVPCID",
+	"LAHF",
+	"LONGMODE",
+	"LZCNT",
+	"MONITOR",
+	"MONITORX",
+	"MOVBE",
+	"MOVDIR",
+	"MPX",
+	"PAUSE",
+	"PCLMULQDQ",
+	"PCONFIG",
+	"PENTIUMMMX",
+	"PENTIUMREAL",
+	"PKU",
+	"POPCNT",
+	"PPRO",
+	"PREFETCHW",
+	"PREFETCHWT1",
+	"PREFETCH_NOP",
+	"PT",
+	"RDPID",
+	"RDPMC",
+	"RDRAND",
+	"RDSEED",
+	"RDTSCP",
+	"RDWRFSGS",
+	"RTM",
+	"SGX",
+	"SGX_ENCLV",
+	"SHA",
+	"SMAP",
+	"SMX",
+	"SSE",
+	"SSE2",
+	"SSE2MMX",
+	"SSE3",
+	"SSE3X87",
+	"SSE4",
+	"SSE42",
+	"SSE4A",
+	"SSEMXCSR",
+	"SSE_PREFETCH",
+	"SSSE3",
+	"SSSE3MMX",
+	"SVM",
+	"TBM",
+	"VAES",
+	"VMFUNC",
+	"VPCLMULQDQ",
+	"VTX",
+	"WAITPKG",
+	"WBNOINVD",
+	"X87",
+	"XOP",
+	"XSAVE",
+	"XSAVEC",
+	"XSAVEOPT",
+	"XSAVES",
+	"LAST",
+}
@@ -0,0 +1,228 @@
+//go:build !cgo || purego
+// +build !cgo purego
+
+package xed
+
+import (
+	"fmt"
+	"strings"
+)
+
+// flagTable lists how instructions use the flags register, each line is an
+// action followed by iclasses.
+const flagTable = `
+r   JB JBE JL JLE JNB JNBE JNL JNLE JNO JNP JNS JNZ JO JP JS JZ LOOPE LOOPNE
+r   CMOVB CMOVBE CMOVL CMOVLE CMOVNB CMOVNBE CMOVNL CMOVNLE CMOVNO CMOVNP CMOVNS CMOVNZ CMOVO CMOVP CMOVS CMOVZ
+r   SETB SETBE SETL SETLE SETNB SETNBE SETNL SETNLE SETNO SETNP SETNS SETNZ SETO SETP SETS SETZ
+r   FCMOVB FCMOVBE FCMOVE FCMOVNB FCMOVNBE FCMOVNE FCMOVNU FCMOVU
+r   LAHF INTO SALC MOVSB MOVSW MOVSD MOVSQ LODSB LODSW LODSD LODSQ STOSB STOSW STOSD STOSQ
+r   INSB INSW INSD OUTSB OUTSW OUTSD
+rw  ADC SBB RCL RCR CMC DAA DAS AAA AAS ADCX ADOX CMPSB CMPSW CMPSD CMPSQ SCASB SCASW SCASD SCASQ
+w   ADD SUB CMP AND OR XOR TEST INC DEC NEG MUL IMUL DIV IDIV SHL SHR SAR ROL ROR SHLD SHRD
+w   BT BTS BTR BTC BSF BSR CMPXCHG CMPXCHG8B CMPXCHG16B XADD POPCNT LZCNT TZCNT AAM AAD SAHF
+w   CLC STC CLD STD CLI STI CLAC STAC ARPL LAR LSL VERR VERW RDRAND RDSEED XTEST
+w   COMISS COMISD UCOMISS UCOMISD VCOMISS VCOMISD VUCOMISS VUCOMISD PTEST VPTEST VTESTPS VTESTPD
+w   PCMPESTRI PCMPESTRM PCMPISTRI PCMPISTRM VPCMPESTRI VPCMPESTRM VPCMPISTRI VPCMPISTRM
+w   FCOMI FCOMIP FUCOMI FUCOMIP ANDN BEXTR BLSI BLSMSK BLSR BZHI
+`
+
+// categoryTable lists the category of instructions that aren't derived from
+// their name or extension.
+const categoryTable = `
+BINARY      ADD ADC SUB SBB CMP INC DEC NEG MUL IMUL DIV IDIV
+LOGICAL     AND OR XOR NOT TEST PAND PANDN POR PXOR VPAND VPANDN VPOR VPXOR
+SHIFT       SHL SHR SAR SHLD SHRD
+ROTATE      ROL ROR RCL RCR
+DATAXFER    MOV MOVZX MOVSX MOVSXD XCHG BSWAP MOV_CR MOV_DR MOVBE MOVNTI
+BITBYTE     BT BTS BTR BTC BSF BSR
+COND_BR     JB JBE JL JLE JNB JNBE JNL JNLE JNO JNP JNS JNZ JO JP JS JZ JCXZ JECXZ JRCXZ LOOP LOOPE LOOPNE XBEGIN
+UNCOND_BR   JMP JMP_FAR XABORT
+CALL        CALL_NEAR CALL_FAR
+RET         RET_NEAR RET_FAR IRET IRETD IRETQ
+PUSH        PUSH PUSHA PUSHAD PUSHF PUSHFD PUSHFQ
+POP         POP POPA POPAD POPF POPFD POPFQ
+STRINGOP    MOVSB MOVSW MOVSD MOVSQ CMPSB CMPSW CMPSD CMPSQ STOSB STOSW STOSD STOSQ LODSB LODSW LODSD LODSQ SCASB SCASW SCASD SCASQ
+IOSTRINGOP  INSB INSW INSD OUTSB OUTSW OUTSD
+IO          IN OUT
+FLAGOP      CLC STC CMC CLD STD CLI STI SAHF LAHF
+CONVERT     CBW CWDE CDQE CWD CDQ CQO
+DECIMAL     DAA DAS AAA AAS AAM AAD
+INTERRUPT   INT INT1 INT3 INTO BOUND
+SEMAPHORE   CMPXCHG CMPXCHG8B CMPXCHG16B XADD
+SYSCALL     SYSCALL SYSCALL_AMD SYSENTER
+SYSRET      SYSRET SYSRET_AMD SYSEXIT
+SYSTEM      LGDT SGDT LIDT SIDT LLDT SLDT LTR STR LMSW SMSW CLTS INVD WBINVD WBNOINVD INVLPG HLT
+SYSTEM      RDMSR WRMSR RDTSC RDPMC LAR LSL VERR VERW ARPL RSM SWAPGS
+SEGOP       LDS LES LFS LGS LSS
+NOP         NOP FNOP
+WIDENOP     ENDBR32 ENDBR64
+FCMOV       FCMOVB FCMOVBE FCMOVE FCMOVNB FCMOVNBE FCMOVNE FCMOVNU FCMOVU
+XSAVE       XSAVE XSAVE64 XRSTOR XRSTOR64 XSAVEC XSAVEC64 XSAVES XSAVES64 XRSTORS XRSTORS64 XGETBV XSETBV
+XSAVEOPT    XSAVEOPT XSAVEOPT64
+MISC        CPUID LEA ENTER LEAVE XLAT UD0 UD1 UD2 SALC PAUSE LFENCE MFENCE SFENCE CLFLUSH MONITOR MWAIT
+MISC        LDMXCSR STMXCSR VLDMXCSR VSTMXCSR FXSAVE FXSAVE64 FXRSTOR FXRSTOR64 INVPCID
+STTNI       PCMPESTRI PCMPESTRM PCMPISTRI PCMPISTRM VPCMPESTRI VPCMPESTRM VPCMPISTRI VPCMPISTRM
+CMOV        CMOVB CMOVBE CMOVL CMOVLE CMOVNB CMOVNBE CMOVNL CMOVNLE CMOVNO CMOVNP CMOVNS CMOVNZ CMOVO CMOVP CMOVS CMOVZ
+SETCC       SETB SETBE SETL SETLE SETNB SETNBE SETNL SETNLE SETNO SETNP SETNS SETNZ SETO SETP SETS SETZ
+`
+
+var (
+	flagActions      = make(map[IClass]OperandAction)
+	categoryByIClass = make(map[IClass]Category)
+)
+
+func initInfo() {
+	cats := make(map[string]Category)
+	for i, n := range categoryNames {
+		cats[n] = Category(i)
+	}
+	for _, l := range strings.Split(flagTable, "\n") {
+		f := strings.Fields(l)
+		if len(f) == 0 {
+			continue
+		}
+		a, ok := opActions[f[0]]
+		if !ok {
+			panic(fmt.Errorf("xed: unknown flag action %q", f[0]))
+		}
+		for _, n := range f[1:] {
+			flagActions[lookupIClass(n)] = a
+		}
+	}
+	for _, l := range strings.Split(categoryTable, "\n") {
+		f := strings.Fields(l)
+		if len(f) == 0 {
+			continue
+		}
+		c, ok := cats[f[0]]
+		if !ok {
+			panic(fmt.Errorf("xed: unknown category %q", f[0]))
+		}
+		for _, n := range f[1:] {
+			categoryByIClass[lookupIClass(n)] = c
+		}
+	}
+}
+
+func lookupIClass(name string) IClass {
+	ic, ok := iclassByName[name]
+	if !ok {
+		panic(fmt.Errorf("xed: unknown iclass %q", name))
+	}
+	return ic
+}
+
+// isaExtension returns the extension an isa set belongs to.
+func isaExtension(isa ISASet) Extension {
+	switch isa {
+	case ISA_SET_INVALID:
+		return EXTENSION_INVALID
+	case ISA_SET_X87, ISA_SET_FCMOV:
+		return EXTENSION_X87
+	case ISA_SET_PENTIUMMMX, ISA_SET_SSE2MMX:
+		return EXTENSION_MMX
+	case ISA_SET_SSE3X87:
+		return EXTENSION_SSE3
+	case ISA_SET_SSSE3MMX:
+		return EXTENSION_SSSE3
+	case ISA_SET_SSE42:
+		return EXTENSION_SSE4
+	case ISA_SET_SSEMXCSR, ISA_SET_SSE_PREFETCH:
+		return EXTENSION_SSE
+	case ISA_SET_CLFSH:
+		return EXTENSION_CLFSH
+	}
+	n := isa.String()
+	for i, e := range extensionNames {
+		if e == n {
+			return Extension(i)
+		}
+	}
+	return EXTENSION_BASE
+}
+
+// iclassCategory returns the category of a decoded entry, if it isn't
+// listed it is derived from the name and the extension.
+func iclassCategory(e *opEntry, ext Extension) Category {
+	if e == nil {
+		return CATEGORY_INVALID
+	}
+	if c, ok := categoryByIClass[e.iclass]; ok {
+		return c
+	}
+
+	n := e.iclass.String()
+	if e.vex {
+		n = strings.TrimPrefix(n, "V")
+	}
+	switch {
+	case strings.Contains(n, "GATHER"):
+		return CATEGORY_AVX2GATHER
+	case strings.Contains(n, "BROADCAST"):
+		return CATEGORY_BROADCAST
+	case strings.HasPrefix(n, "PREFETCH"):
+		return CATEGORY_PREFETCH
+	case strings.HasPrefix(n, "CVT"):
+		return CATEGORY_CONVERT
+	case strings.HasPrefix(n, "MOV") && ext != EXTENSION_BASE:
+		return CATEGORY_DATAXFER
+	case strings.HasPrefix(n, "AES"):
+		return CATEGORY_AES
+	case strings.HasPrefix(n, "SHA"):
+		return CATEGORY_SHA
+	case n == "PCLMULQDQ":
+		return CATEGORY_PCLMULQDQ
+	case strings.HasPrefix(n, "BLEND") || strings.HasPrefix(n, "PBLEND"):
+		return CATEGORY_BLEND
+	}
+	switch n {
+	case "ANDPS", "ANDPD", "ANDNPS", "ANDNPD", "ORPS", "ORPD", "XORPS", "XORPD", "TESTPS", "TESTPD":
+		return CATEGORY_LOGICAL_FP
+	}
+
+	switch ext {
+	case EXTENSION_X87:
+		return CATEGORY_X87_ALU
+	case EXTENSION_MMX:
+		return CATEGORY_MMX
+	case EXTENSION_SSE, EXTENSION_SSE2, EXTENSION_SSE3, EXTENSION_SSSE3, EXTENSION_SSE4:
+		return CATEGORY_SSE
+	case EXTENSION_AVX, EXTENSION_AVXAES:
+		return CATEGORY_AVX
+	case EXTENSION_AVX2:
+		return CATEGORY_AVX2
+	case EXTENSION_FMA:
+		return CATEGORY_VFMA
+	case EXTENSION_F16C:
+		return CATEGORY_CONVERT
+	case EXTENSION_BMI1:
+		return CATEGORY_BMI1
+	case EXTENSION_BMI2:
+		return CATEGORY_BMI2
+	case EXTENSION_LZCNT:
+		return CATEGORY_LZCNT
+	case EXTENSION_ADOX_ADCX:
+		return CATEGORY_ADOX_ADCX
+	case EXTENSION_RDRAND:
+		return CATEGORY_RDRAND
+	case EXTENSION_RDSEED:
+		return CATEGORY_RDSEED
+	case EXTENSION_RDPID:
+		return CATEGORY_RDPID
+	case EXTENSION_RDWRFSGS:
+		return CATEGORY_RDWRFSGS
+	case EXTENSION_PKU:
+		return CATEGORY_PKU
+	case EXTENSION_SMAP:
+		return CATEGORY_SMAP
+	case EXTENSION_CET:
+		return CATEGORY_CET
+	case EXTENSION_CLFLUSHOPT:
+		return CATEGORY_CLFLUSHOPT
+	case EXTENSION_CLWB:
+		return CATEGORY_CLWB
+	case EXTENSION_VTX:
+		return CATEGORY_VTX
+	}
+	return CATEGORY_MISC
+}
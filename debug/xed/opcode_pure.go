@@ -0,0 +1,496 @@
+//go:build !cgo || purego
+// +build !cgo purego
+
+package xed
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// An opcode table line has the fields
+//
+//	encoding | iclass | operands | isa set and attributes
+//
+// The encoding is written like in the Intel manual. A leading NP, 66, F3 or
+// F2 selects a mandatory prefix, NP and 66 don't allow an F2 or F3 prefix
+// while encodings without one ignore them. VEX encodings start with
+// VEX.<L>.<pp>.<map>.<W> where L is 128, 256, L for both, LIG or LZ. The opcode
+// byte is followed by the modrm constraints /0 to /7, /r, :xx for a
+// complete modrm byte and mem or reg for the form of the rm field, +r adds
+// a register number to the opcode.
+//
+// Operands are written as an addressing method and a width, like Eb or Vx,
+// then an optional action after a colon. The default action is read. A
+// leading ~ suppresses an operand. The addressing methods are the ones of
+// the Intel manual with these additions:
+//
+//	B	general purpose register in VEX.vvvv
+//	K	stack memory
+//	L	vector register in bits 7:4 of an immediate
+//	T	vector index memory, Tx with an xmm index whatever the length
+//	Z	general purpose register in the opcode
+//	DI	memory at rDI
+//	Mxlat	memory at rBX plus AL
+//	STi	x87 register in the rm field
+//	AGEN	address generation
+//	I1	the constant 1
+//	F	flags register
+//
+// Registers are written with their names or as rAX, eAX, aAX, sSP and mIP
+// for the ones sized by the operand size, the operand size without 64
+// bits, the address size, the stack address size and the machine mode.
+//
+// The attributes are:
+//
+//	lock	lockable with a memory destination
+//	rep	the REP_ iclass is used with an F3 prefix
+//	repcc	the REPE_ and REPNE_ iclasses are used with an F3 or F2 prefix
+//	d64	the operand size defaults to 64 bits in 64 bit mode
+//	f64	the operand size is 64 bits in 64 bit mode
+//	i64	invalid in 64 bit mode
+//	o64	only valid in 64 bit mode
+//	rmreg	the rm field is a register whatever the mod field is
+//	rexb0	the opcode register must not be extended by rex.b
+//	oszN	the operand size must be N bits
+//	aszN	the address size must be N bits
+//	w0, w1	the rex.w bit must be clear or set
+
+const (
+	pfxAny = iota
+	pfxNone
+	pfx66
+	pfxF3
+	pfxF2
+)
+
+const (
+	modAny = iota
+	modMem
+	modReg
+)
+
+const (
+	attrLock = 1 << iota
+	attrRep
+	attrRepcc
+	attrD64
+	attrF64
+	attrI64
+	attrO64
+	attrRmReg
+	attrRexB0
+)
+
+// widths of the operands, in the order of widthCodes
+const (
+	widthNone = iota
+	widthB
+	widthW
+	widthD
+	widthQ
+	widthV
+	widthZ
+	widthY
+	widthDQ
+	widthQQ
+	widthX
+	widthH
+	widthQT
+	widthOT
+	widthSS
+	widthSD
+	widthPS
+	widthPD
+	widthT
+	widthE
+	widthF
+	widthFX
+	widthXS
+	widthP
+	widthS
+	widthA
+	widthN
+)
+
+var widthCodes = []string{
+	"", "b", "w", "d", "q", "v", "z", "y", "dq", "qq", "x", "h", "qt", "ot",
+	"ss", "sd", "ps", "pd", "t", "e", "f", "fx", "xs", "p", "s", "a", "n",
+}
+
+// special addressing methods
+const (
+	kindReg   = 'f'
+	kindSized = 'r'
+	kindSTi   = 'i'
+	kindAgen  = 'g'
+	kindOne   = '1'
+	kindFlags = 'F'
+	kindDI    = 'd'
+	kindXlat  = 'l'
+)
+
+type opSpec struct {
+	kind   byte
+	width  int
+	size   byte
+	reg    Reg
+	action OperandAction
+	vis    OperandVisibility
+	signed bool
+}
+
+type opEntry struct {
+	vex    bool
+	mapn   int
+	op     byte
+	plusr  bool
+	pfx    int
+	modrm  bool
+	reg    int
+	mod    int
+	rmbyte int
+	vexl   int
+	w      int
+	osz    uint
+	asz    uint
+	attr   uint
+	iclass IClass
+	isa    [2]ISASet
+	ops    []opSpec
+}
+
+// opTable indexes the entries by VEX, map and opcode.
+var opTable [2][4][256][]*opEntry
+
+var opActions = map[string]OperandAction{
+	"r":   OPERAND_ACTION_R,
+	"w":   OPERAND_ACTION_W,
+	"rw":  OPERAND_ACTION_RW,
+	"cr":  OPERAND_ACTION_CR,
+	"cw":  OPERAND_ACTION_CW,
+	"crw": OPERAND_ACTION_CRW,
+	"rcw": OPERAND_ACTION_RCW,
+}
+
+var opAttrs = map[string]uint{
+	"lock":  attrLock,
+	"rep":   attrRep,
+	"repcc": attrRepcc,
+	"d64":   attrD64,
+	"f64":   attrF64,
+	"i64":   attrI64,
+	"o64":   attrO64,
+	"rmreg": attrRmReg,
+	"rexb0": attrRexB0,
+}
+
+func initTables() {
+	for _, t := range []string{oneByteTable, twoByteTable, threeByteTable, x87Table, vexTable} {
+		for _, l := range strings.Split(t, "\n") {
+			l = strings.TrimSpace(l)
+			if l == "" || strings.HasPrefix(l, "#") {
+				continue
+			}
+			e, err := parseEntry(l)
+			if err != nil {
+				panic(fmt.Errorf("xed: opcode table line %q: %v", l, err))
+			}
+			addEntry(e)
+		}
+	}
+}
+
+func addEntry(e *opEntry) {
+	v := 0
+	if e.vex {
+		v = 1
+	}
+	n := 1
+	if e.plusr {
+		n = 8
+	}
+	for i := 0; i < n; i++ {
+		slot := &opTable[v][e.mapn][int(e.op)+i]
+		if len(*slot) > 0 && (*slot)[0].modrm != e.modrm {
+			panic(fmt.Errorf("xed: opcode %#x in map %d mixes modrm and no modrm forms", int(e.op)+i, e.mapn))
+		}
+		*slot = append(*slot, e)
+	}
+}
+
+func parseEntry(l string) (*opEntry, error) {
+	f := strings.Split(l, "|")
+	if len(f) != 4 {
+		return nil, fmt.Errorf("expected 4 fields")
+	}
+	e := &opEntry{reg: -1, rmbyte: -1, vexl: -1, w: -1}
+	err := e.parseEncoding(strings.Fields(f[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	ic, ok := iclassByName[strings.TrimSpace(f[1])]
+	if !ok {
+		return nil, fmt.Errorf("unknown iclass")
+	}
+	e.iclass = ic
+
+	for _, s := range strings.Fields(f[2]) {
+		o, err := parseOperand(s)
+		if err != nil {
+			return nil, err
+		}
+		e.ops = append(e.ops, o)
+	}
+
+	a := strings.Fields(f[3])
+	if len(a) == 0 {
+		return nil, fmt.Errorf("missing isa set")
+	}
+	for i, s := range strings.Split(a[0], "/") {
+		isa, ok := isaSetByName[s]
+		if !ok || i > 1 {
+			return nil, fmt.Errorf("unknown isa set %q", s)
+		}
+		e.isa[i] = isa
+		e.isa[1] = isa
+	}
+	if n := strings.Split(a[0], "/"); len(n) == 2 {
+		e.isa[1] = isaSetByName[n[1]]
+	}
+	for _, s := range a[1:] {
+		switch {
+		case opAttrs[s] != 0:
+			e.attr |= opAttrs[s]
+		case s == "w0" || s == "w1":
+			e.w = int(s[1] - '0')
+		case strings.HasPrefix(s, "osz") || strings.HasPrefix(s, "asz"):
+			n, err := strconv.Atoi(s[3:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid attribute %q", s)
+			}
+			if s[0] == 'o' {
+				e.osz = uint(n)
+			} else {
+				e.asz = uint(n)
+			}
+		default:
+			return nil, fmt.Errorf("invalid attribute %q", s)
+		}
+	}
+
+	// the operands decide if there is a modrm byte and the form of the
+	// rm field when the encoding doesn't
+	for _, o := range e.ops {
+		switch o.kind {
+		case 'E', 'G', 'M', 'R', 'S', 'C', 'D', 'P', 'Q', 'N', 'V', 'W', 'U', 'T', kindAgen, kindSTi:
+			e.modrm = true
+		}
+		if e.mod == modAny && e.attr&attrRmReg == 0 {
+			switch o.kind {
+			case 'M', 'T', kindAgen:
+				e.mod = modMem
+			case 'R', 'U', 'N', kindSTi:
+				e.mod = modReg
+			}
+		}
+	}
+	return e, nil
+}
+
+func (e *opEntry) parseEncoding(t []string) error {
+	if len(t) == 0 {
+		return fmt.Errorf("missing encoding")
+	}
+	switch t[0] {
+	case "NP":
+		e.pfx = pfxNone
+	case "66":
+		e.pfx = pfx66
+	case "F3":
+		e.pfx = pfxF3
+	case "F2":
+		e.pfx = pfxF2
+	}
+	if e.pfx != pfxAny {
+		t = t[1:]
+	}
+
+	if len(t) > 0 && strings.HasPrefix(t[0], "VEX.") {
+		e.vex = true
+		e.pfx = pfxNone
+		for _, s := range strings.Split(t[0], ".")[1:] {
+			switch s {
+			case "128", "LZ":
+				e.vexl = 0
+			case "256":
+				e.vexl = 1
+			case "L", "LIG":
+			case "NP":
+			case "66":
+				e.pfx = pfx66
+			case "F3":
+				e.pfx = pfxF3
+			case "F2":
+				e.pfx = pfxF2
+			case "0F":
+				e.mapn = 1
+			case "0F38":
+				e.mapn = 2
+			case "0F3A":
+				e.mapn = 3
+			case "W0":
+				e.w = 0
+			case "W1":
+				e.w = 1
+			case "WIG":
+			default:
+				return fmt.Errorf("invalid vex field %q", s)
+			}
+		}
+		if e.mapn == 0 {
+			return fmt.Errorf("missing vex map")
+		}
+		t = t[1:]
+	} else if len(t) > 1 && t[0] == "0F" {
+		e.mapn = 1
+		t = t[1:]
+		if len(t) > 1 && (t[0] == "38" || t[0] == "3A") {
+			e.mapn = 2
+			if t[0] == "3A" {
+				e.mapn = 3
+			}
+			t = t[1:]
+		}
+	}
+
+	if len(t) == 0 {
+		return fmt.Errorf("missing opcode")
+	}
+	s := t[0]
+	if strings.HasSuffix(s, "+r") {
+		e.plusr = true
+		s = s[:len(s)-2]
+	}
+	op, err := strconv.ParseUint(s, 16, 8)
+	if err != nil {
+		return fmt.Errorf("invalid opcode %q", t[0])
+	}
+	e.op = byte(op)
+
+	for _, s := range t[1:] {
+		switch {
+		case s == "/r":
+			e.modrm = true
+		case len(s) == 2 && s[0] == '/' && '0' <= s[1] && s[1] <= '7':
+			e.modrm = true
+			e.reg = int(s[1] - '0')
+		case len(s) == 3 && s[0] == ':':
+			b, err := strconv.ParseUint(s[1:], 16, 8)
+			if err != nil {
+				return fmt.Errorf("invalid modrm %q", s)
+			}
+			e.modrm = true
+			e.rmbyte = int(b)
+		case s == "mem":
+			e.mod = modMem
+		case s == "reg":
+			e.mod = modReg
+		default:
+			return fmt.Errorf("invalid encoding %q", s)
+		}
+	}
+	return nil
+}
+
+func parseOperand(s string) (opSpec, error) {
+	o := opSpec{action: OPERAND_ACTION_R, vis: OPVIS_EXPLICIT}
+	if strings.HasPrefix(s, "~") {
+		o.vis = OPVIS_SUPPRESSED
+		s = s[1:]
+	}
+	if i := strings.IndexByte(s, ':'); i >= 0 {
+		a, ok := opActions[s[i+1:]]
+		if !ok {
+			return o, fmt.Errorf("invalid operand action %q", s)
+		}
+		o.action = a
+		s = s[:i]
+	}
+	implicit := func() {
+		if o.vis == OPVIS_EXPLICIT {
+			o.vis = OPVIS_IMPLICIT
+		}
+	}
+
+	switch {
+	case s == "STi":
+		o.kind = kindSTi
+		return o, nil
+	case s == "AGEN":
+		o.kind = kindAgen
+		return o, nil
+	case s == "I1":
+		o.kind = kindOne
+		implicit()
+		return o, nil
+	case s == "F":
+		o.kind = kindFlags
+		implicit()
+		return o, nil
+	case s == "Mxlat":
+		o.kind = kindXlat
+		o.width = widthB
+		implicit()
+		return o, nil
+	case strings.HasPrefix(s, "DI"):
+		o.kind = kindDI
+		implicit()
+		return o, parseWidth(&o, s[2:])
+	}
+	if r, ok := regByName[s]; ok && r != REG_INVALID {
+		o.kind = kindReg
+		o.reg = r
+		implicit()
+		return o, nil
+	}
+	if len(s) == 3 && strings.IndexByte("raesm", s[0]) >= 0 {
+		for i, n := range []string{"AX", "CX", "DX", "BX", "SP", "BP", "SI", "DI", "IP"} {
+			if s[1:] == n {
+				o.kind = kindSized
+				o.size = s[0]
+				o.reg = Reg(i)
+				implicit()
+				return o, nil
+			}
+		}
+	}
+	if strings.HasPrefix(s, "Tx") {
+		o.size = 'x'
+		s = "T" + s[2:]
+	}
+	if strings.HasPrefix(s, "sI") {
+		o.signed = true
+		s = s[1:]
+	}
+	if len(s) < 2 || s[0] < 'A' || s[0] > 'Z' {
+		return o, fmt.Errorf("invalid operand %q", s)
+	}
+	o.kind = s[0]
+	if o.kind == 'X' || o.kind == 'Y' {
+		implicit()
+	}
+	return o, parseWidth(&o, s[1:])
+}
+
+func parseWidth(o *opSpec, s string) error {
+	for i, w := range widthCodes {
+		if i > 0 && w == s {
+			o.width = i
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid operand width %q", s)
+}
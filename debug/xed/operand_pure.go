@@ -0,0 +1,190 @@
+//go:build !cgo || purego
+// +build !cgo purego
+
+package xed
+
+import "strings"
+
+type OperandValues DecodedInst
+
+type Inst struct {
+	iclass    IClass
+	noperands uint
+	operands  [maxOperands]Operand
+}
+
+type Operand struct {
+	name   OperandMode
+	width  OperandWidth
+	vis    OperandVisibility
+	action OperandAction
+	kind   byte
+	reg    Reg
+	bits   uint
+	elem   uint
+	mem    uint
+}
+
+func (c OperandWidth) String() string {
+	if c <= 0 || int(c) >= len(widthCodes) {
+		return "INVALID"
+	}
+	return strings.ToUpper(widthCodes[c])
+}
+
+func (c *Inst) NumOperands() uint {
+	return c.noperands
+}
+
+func (c *Inst) Operand(i uint) *Operand {
+	if i >= c.noperands {
+		return nil
+	}
+	return &c.operands[i]
+}
+
+func (c *Operand) Width() OperandWidth {
+	return c.width
+}
+
+func (c *Operand) Visibility() OperandVisibility {
+	return c.vis
+}
+
+func (c *Operand) isMem() bool {
+	switch c.name {
+	case OPERAND_MEM0, OPERAND_MEM1, OPERAND_AGEN:
+		return true
+	}
+	return false
+}
+
+func (c *OperandValues) EffectiveOperandWidth() uint32 {
+	return uint32(c.osz)
+}
+
+func (c *OperandValues) EffectiveAddressWidth() uint32 {
+	return uint32(c.asz)
+}
+
+func (c *OperandValues) StackAddressWidth() uint32 {
+	return uint32(c.ssz)
+}
+
+func (c *OperandValues) IClass() IClass {
+	return c.iclass
+}
+
+func (c *OperandValues) Init() {
+	*c = OperandValues{}
+}
+
+func (c *OperandValues) HasMemoryDisplacement() bool {
+	return c.dispWidth != 0
+}
+
+func (c *OperandValues) HasRepPrefix() bool {
+	return c.rep == 0xF3
+}
+
+func (c *OperandValues) HasRepnePrefix() bool {
+	return c.rep == 0xF2
+}
+
+func (c *OperandValues) HasRexwPrefix() bool {
+	return c.rex&8 != 0
+}
+
+func (c *OperandValues) HasSegmentPrefix() bool {
+	return c.seg != REG_INVALID
+}
+
+func (c *OperandValues) HasSibByte() bool {
+	return c.hasSib
+}
+
+func (c *OperandValues) IsNop() bool {
+	switch c.iclass {
+	case ICLASS_NOP, ICLASS_FNOP:
+		return true
+	}
+	return false
+}
+
+func (c *OperandValues) Lockable() bool {
+	return c.entry != nil && c.entry.attr&attrLock != 0
+}
+
+func (c *OperandValues) MemopWithoutModrm() bool {
+	return c.nomodr
+}
+
+func (c *OperandValues) HasRealRep() bool {
+	return c.rep != 0 && c.entry != nil && c.entry.attr&(attrRep|attrRepcc) != 0
+}
+
+func (c *OperandValues) HasAddressSizePrefix() bool {
+	return c.pfx67
+}
+
+// HasOperandSizePrefix reports if there is a 66 prefix that is not part of
+// the opcode.
+func (c *OperandValues) HasOperandSizePrefix() bool {
+	return c.pfx66 && c.entry != nil && c.entry.pfx != pfx66
+}
+
+func (c *OperandValues) Has66Prefix() bool {
+	return c.pfx66
+}
+
+func (c *OperandValues) HasBranchDisplacement() bool {
+	return c.brdispWidth != 0
+}
+
+func (c *OperandValues) ImmediateInt64() int64 {
+	return SignExtendArbitraryTo64(c.imm, c.immWidth*8)
+}
+
+func (c *OperandValues) ImmediateUint64() uint64 {
+	return c.imm
+}
+
+func (c *OperandValues) ImmediateIsSigned() bool {
+	return c.immSigned
+}
+
+func (c *OperandValues) ImmediateByte(i uint) uint8 {
+	return uint8(c.imm >> (8 * i))
+}
+
+func (c *OperandValues) SecondImmediate() uint8 {
+	return c.imm1
+}
+
+func (c *OperandValues) BranchDisplacementLength() uint32 {
+	return uint32(c.brdispWidth)
+}
+
+func (c *OperandValues) BranchDisplacementLengthBits() uint32 {
+	return uint32(c.brdispWidth * 8)
+}
+
+func (c *OperandValues) BranchDisplacementInt32() int32 {
+	return c.brdisp
+}
+
+func (c *OperandValues) BranchDisplacementByte(i uint) uint8 {
+	return uint8(c.brdisp >> (8 * i))
+}
+
+func (c *OperandValues) MemoryDisplacementInt64() int64 {
+	return c.disp
+}
+
+func (c *OperandValues) MemoryDisplacementInt64Raw() int64 {
+	return c.disp
+}
+
+func (c *OperandValues) MemoryDisplacementByte(i uint) uint8 {
+	return uint8(c.disp >> (8 * i))
+}
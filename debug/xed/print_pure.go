@@ -0,0 +1,353 @@
+//go:build !cgo || purego
+// +build !cgo purego
+
+package xed
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	Syntax              int
+	DisassemblyCallback func(address uint64, symbol_buffer []byte, offset *uint64)
+)
+
+type FormatOptions struct {
+	lowercase_hex bool
+}
+
+type PrintInfo struct {
+	syntax  Syntax
+	address uint64
+}
+
+const (
+	SYNTAX_INVALID Syntax = iota
+	SYNTAX_XED
+	SYNTAX_ATT
+	SYNTAX_INTEL
+	SYNTAX_LAST
+)
+
+var syntaxNames = [...]string{"INVALID", "XED", "ATT", "INTEL", "LAST"}
+
+func (c Syntax) String() string {
+	return enumString(syntaxNames[:], int(c))
+}
+
+func (c *PrintInfo) Init() {
+	*c = PrintInfo{syntax: SYNTAX_INTEL}
+}
+
+func (c *FormatOptions) SetLowerCaseHex(lowercase_hex bool) {
+	c.lowercase_hex = lowercase_hex
+}
+
+func (c *FormatOptions) LowerCase() bool {
+	return c.lowercase_hex
+}
+
+// FormatContext formats an instruction, the XED syntax is the same as the
+// Intel one.
+func FormatContext(syntax Syntax, xedd *DecodedInst, runtime_instruction_address uint64, symbolic_callback DisassemblyCallback) (string, error) {
+	if !xedd.valid {
+		return "", fmt.Errorf("failed to format instruction")
+	}
+	p := printer{
+		c:    xedd,
+		addr: runtime_instruction_address,
+		cb:   symbolic_callback,
+	}
+	switch syntax {
+	case SYNTAX_XED, SYNTAX_INTEL:
+		return p.intel(), nil
+	case SYNTAX_ATT:
+		return p.att(), nil
+	}
+	return "", fmt.Errorf("failed to format instruction")
+}
+
+type printer struct {
+	c    *DecodedInst
+	addr uint64
+	cb   DisassemblyCallback
+}
+
+var memSizeNames = map[uint]string{
+	8:   "byte",
+	16:  "word",
+	32:  "dword",
+	64:  "qword",
+	80:  "tbyte",
+	128: "xmmword",
+	256: "ymmword",
+}
+
+// mnemonic returns the lowercase mnemonic of an iclass with its rep and
+// lock prefixes.
+func mnemonic(ic IClass) string {
+	n := strings.ToLower(ic.String())
+	p := ""
+	for _, r := range []string{"rep_", "repe_", "repne_"} {
+		if strings.HasPrefix(n, r) {
+			p = r[:len(r)-1] + " "
+			n = n[len(r):]
+		}
+	}
+	if strings.HasSuffix(n, "_lock") {
+		p = "lock "
+		n = n[:len(n)-5]
+	}
+	switch n {
+	case "call_near":
+		n = "call"
+	case "call_far":
+		n = "call far"
+	case "jmp_far":
+		n = "jmp far"
+	case "ret_near":
+		n = "ret"
+	case "ret_far":
+		n = "ret far"
+	case "mov_cr", "mov_dr":
+		n = "mov"
+	case "syscall_amd":
+		n = "syscall"
+	case "sysret_amd":
+		n = "sysret"
+	default:
+		n = strings.TrimSuffix(n, "_xmm")
+		n = strings.TrimSuffix(n, "_sse4")
+	}
+	return p + n
+}
+
+func (p *printer) intel() string {
+	c := p.c
+	w := new(strings.Builder)
+	w.WriteString(mnemonic(c.iclass))
+	sep := " "
+	for i := uint(0); i < c.inst.noperands; i++ {
+		o := &c.inst.operands[i]
+		if o.vis == OPVIS_SUPPRESSED {
+			continue
+		}
+		w.WriteString(sep)
+		sep = ", "
+		switch {
+		case o.isMem():
+			w.WriteString(c.intelMem(o))
+		case o.name == OPERAND_RELBR:
+			p.target(w)
+		case o.name == OPERAND_PTR:
+			fmt.Fprintf(w, "%#x:%#x", c.imm, c.operandValue(o))
+		case o.name == OPERAND_IMM0 || o.name == OPERAND_IMM1:
+			fmt.Fprintf(w, "%#x", c.immValue(o))
+		default:
+			w.WriteString(strings.ToLower(o.reg.String()))
+		}
+	}
+	return w.String()
+}
+
+func (c *DecodedInst) intelMem(o *Operand) string {
+	m := &c.mem[o.mem]
+	w := new(strings.Builder)
+	if n, ok := memSizeNames[m.bits]; ok && !m.agen {
+		w.WriteString(n)
+		w.WriteString(" ")
+	}
+	w.WriteString("ptr ")
+	if m.seg != REG_INVALID && m.seg != c.defaultSegment(m) {
+		fmt.Fprintf(w, "%s:", strings.ToLower(m.seg.String()))
+	}
+	w.WriteString("[")
+	switch m.base {
+	case REG_STACKPUSH, REG_STACKPOP:
+		w.WriteString(strings.ToLower(gpr(c.ssz, 4, true).String()))
+	case REG_INVALID:
+	default:
+		w.WriteString(strings.ToLower(m.base.String()))
+	}
+	if m.index != REG_INVALID {
+		if m.base != REG_INVALID {
+			w.WriteString("+")
+		}
+		fmt.Fprintf(w, "%s*%d", strings.ToLower(m.index.String()), m.scale)
+	}
+	switch {
+	case m.base == REG_INVALID && m.index == REG_INVALID:
+		fmt.Fprintf(w, "%#x", uint64(m.disp)&mask(c.asz))
+	case m.disp < 0:
+		fmt.Fprintf(w, "-%#x", uint64(-m.disp))
+	case m.disp > 0:
+		fmt.Fprintf(w, "+%#x", uint64(m.disp))
+	}
+	w.WriteString("]")
+	return w.String()
+}
+
+// defaultSegment returns the segment a memory operand uses without an
+// override.
+func (c *DecodedInst) defaultSegment(m *memOp) Reg {
+	switch m.base {
+	case REG_STACKPUSH, REG_STACKPOP, REG_SP, REG_BP, REG_ESP, REG_EBP, REG_RSP, REG_RBP:
+		return REG_SS
+	}
+	return REG_DS
+}
+
+// immValue returns an immediate sign extended to the operand size if it is
+// signed.
+func (c *DecodedInst) immValue(o *Operand) uint64 {
+	v := c.operandValue(o)
+	if o.name == OPERAND_IMM0 && c.immSigned {
+		bits := c.osz
+		if c.inst.noperands > 0 && c.inst.operands[0].bits != 0 {
+			bits = c.inst.operands[0].bits
+		}
+		v = uint64(SignExtendArbitraryTo64(v, c.immWidth*8)) & mask(bits)
+	}
+	return v
+}
+
+func mask(bits uint) uint64 {
+	if bits >= 64 {
+		return ^uint64(0)
+	}
+	return 1<<bits - 1
+}
+
+// branchTarget returns the target of a relative branch.
+func (c *DecodedInst) branchTarget(addr uint64) uint64 {
+	bits := c.mode
+	if c.osz == 16 {
+		bits = 16
+	}
+	return (addr + uint64(c.length) + uint64(int64(c.brdisp))) & mask(bits)
+}
+
+func (p *printer) target(w *strings.Builder) {
+	t := p.c.branchTarget(p.addr)
+	fmt.Fprintf(w, "%#x", t)
+	if p.cb == nil {
+		return
+	}
+	var (
+		buf [256]byte
+		off uint64
+	)
+	p.cb(t, buf[:], &off)
+	n := 0
+	for n < len(buf) && buf[n] != 0 {
+		n++
+	}
+	if n == 0 {
+		return
+	}
+	if off != 0 {
+		fmt.Fprintf(w, " <%s+%#x>", buf[:n], off)
+	} else {
+		fmt.Fprintf(w, " <%s>", buf[:n])
+	}
+}
+
+var attSuffixes = map[uint]string{8: "b", 16: "w", 32: "l", 64: "q"}
+
+func (p *printer) att() string {
+	c := p.c
+	w := new(strings.Builder)
+	n := mnemonic(c.iclass)
+	if strings.HasSuffix(n, " far") {
+		n = "l" + n[:len(n)-4]
+	}
+
+	var ops []string
+	sized := false
+	msize := uint(0)
+	indirect := false
+	switch c.iclass {
+	case ICLASS_CALL_NEAR, ICLASS_JMP, ICLASS_CALL_FAR, ICLASS_JMP_FAR:
+		indirect = c.brdispWidth == 0
+	}
+	for i := uint(0); i < c.inst.noperands; i++ {
+		o := &c.inst.operands[i]
+		if o.vis == OPVIS_SUPPRESSED {
+			continue
+		}
+		var s string
+		switch {
+		case o.isMem():
+			s = c.attMem(o)
+			if !c.mem[o.mem].agen && o.vis == OPVIS_EXPLICIT {
+				msize = o.bits
+			}
+		case o.name == OPERAND_RELBR:
+			b := new(strings.Builder)
+			p.target(b)
+			s = b.String()
+		case o.name == OPERAND_PTR:
+			s = fmt.Sprintf("$%#x, $%#x", c.imm, c.operandValue(o))
+		case o.name == OPERAND_IMM0 || o.name == OPERAND_IMM1:
+			s = fmt.Sprintf("$%#x", c.immValue(o))
+		default:
+			s = "%" + strings.ToLower(o.reg.String())
+			if o.reg.Class() == REG_CLASS_X87 {
+				s = fmt.Sprintf("%%st(%d)", o.reg-REG_ST0)
+			}
+			if o.vis == OPVIS_EXPLICIT {
+				sized = true
+			}
+		}
+		if indirect && o.vis == OPVIS_EXPLICIT {
+			s = "*" + s
+		}
+		ops = append(ops, s)
+	}
+	if !sized && msize != 0 && c.Extension() == EXTENSION_BASE {
+		n += attSuffixes[msize]
+	}
+
+	w.WriteString(n)
+	for i := len(ops) - 1; i >= 0; i-- {
+		if i == len(ops)-1 {
+			w.WriteString(" ")
+		} else {
+			w.WriteString(", ")
+		}
+		w.WriteString(ops[i])
+	}
+	return w.String()
+}
+
+func (c *DecodedInst) attMem(o *Operand) string {
+	m := &c.mem[o.mem]
+	w := new(strings.Builder)
+	if m.seg != REG_INVALID && m.seg != c.defaultSegment(m) {
+		fmt.Fprintf(w, "%%%s:", strings.ToLower(m.seg.String()))
+	}
+	base := m.base
+	switch base {
+	case REG_STACKPUSH, REG_STACKPOP:
+		base = gpr(c.ssz, 4, true)
+	}
+	switch {
+	case base == REG_INVALID && m.index == REG_INVALID:
+		fmt.Fprintf(w, "%#x", uint64(m.disp)&mask(c.asz))
+		return w.String()
+	case m.disp < 0:
+		fmt.Fprintf(w, "-%#x", uint64(-m.disp))
+	case m.disp > 0:
+		fmt.Fprintf(w, "%#x", uint64(m.disp))
+	}
+	w.WriteString("(")
+	if base != REG_INVALID {
+		fmt.Fprintf(w, "%%%s", strings.ToLower(base.String()))
+	}
+	if m.index != REG_INVALID {
+		fmt.Fprintf(w, ",%%%s,%d", strings.ToLower(m.index.String()), m.scale)
+	}
+	w.WriteString(")")
+	return w.String()
+}
@@ -0,0 +1,127 @@
+//go:build !cgo || purego
+// +build !cgo purego
+
+package xed
+
+func (r Reg) Class() RegClass {
+	switch {
+	case r == REG_BNDCFGU:
+		return REG_CLASS_BNDCFG
+	case r == REG_BNDSTATUS:
+		return REG_CLASS_BNDSTAT
+	case REG_BOUND_FIRST <= r && r <= REG_BOUND_LAST:
+		return REG_CLASS_BOUND
+	case REG_CR_FIRST <= r && r <= REG_CR_LAST:
+		return REG_CLASS_CR
+	case REG_DR_FIRST <= r && r <= REG_DR_LAST:
+		return REG_CLASS_DR
+	case REG_FLAGS_FIRST <= r && r <= REG_FLAGS_LAST:
+		return REG_CLASS_FLAGS
+	case REG_GPR16_FIRST <= r && r <= REG_GPR8h_LAST:
+		return REG_CLASS_GPR
+	case REG_IP_FIRST <= r && r <= REG_IP_LAST:
+		return REG_CLASS_IP
+	case REG_MASK_FIRST <= r && r <= REG_MASK_LAST:
+		return REG_CLASS_MASK
+	case REG_MMX_FIRST <= r && r <= REG_MMX_LAST:
+		return REG_CLASS_MMX
+	case REG_MSR_FIRST <= r && r <= REG_MSR_LAST:
+		return REG_CLASS_MSR
+	case r == REG_MXCSR:
+		return REG_CLASS_MXCSR
+	case REG_PSEUDO_FIRST <= r && r <= REG_PSEUDO_LAST:
+		return REG_CLASS_PSEUDO
+	case REG_PSEUDOX87_FIRST <= r && r <= REG_PSEUDOX87_LAST:
+		return REG_CLASS_PSEUDOX87
+	case REG_SR_FIRST <= r && r <= REG_SR_LAST:
+		return REG_CLASS_SR
+	case REG_TMP_FIRST <= r && r <= REG_TMP_LAST:
+		return REG_CLASS_TMP
+	case REG_X87_FIRST <= r && r <= REG_X87_LAST:
+		return REG_CLASS_X87
+	case r == REG_XCR0:
+		return REG_CLASS_XCR
+	case REG_XMM_FIRST <= r && r <= REG_XMM_LAST:
+		return REG_CLASS_XMM
+	case REG_YMM_FIRST <= r && r <= REG_YMM_LAST:
+		return REG_CLASS_YMM
+	case REG_ZMM_FIRST <= r && r <= REG_ZMM_LAST:
+		return REG_CLASS_ZMM
+	}
+	return REG_CLASS_INVALID
+}
+
+func (r Reg) RegClass() RegClass {
+	return r.Class()
+}
+
+func (r Reg) GPRRegClass() RegClass {
+	switch {
+	case REG_GPR16_FIRST <= r && r <= REG_GPR16_LAST:
+		return REG_CLASS_GPR16
+	case REG_GPR32_FIRST <= r && r <= REG_GPR32_LAST:
+		return REG_CLASS_GPR32
+	case REG_GPR64_FIRST <= r && r <= REG_GPR64_LAST:
+		return REG_CLASS_GPR64
+	case REG_GPR8_FIRST <= r && r <= REG_GPR8h_LAST:
+		return REG_CLASS_GPR8
+	}
+	return REG_CLASS_INVALID
+}
+
+// bits returns the width of a register.
+func (r Reg) bits() uint {
+	switch r.Class() {
+	case REG_CLASS_GPR:
+		switch r.GPRRegClass() {
+		case REG_CLASS_GPR8:
+			return 8
+		case REG_CLASS_GPR16:
+			return 16
+		case REG_CLASS_GPR32:
+			return 32
+		}
+		return 64
+	case REG_CLASS_FLAGS, REG_CLASS_IP:
+		switch r {
+		case REG_FLAGS, REG_IP:
+			return 16
+		case REG_EFLAGS, REG_EIP:
+			return 32
+		}
+		return 64
+	case REG_CLASS_SR:
+		return 16
+	case REG_CLASS_X87:
+		return 80
+	case REG_CLASS_MMX:
+		return 64
+	case REG_CLASS_XMM:
+		return 128
+	case REG_CLASS_YMM:
+		return 256
+	case REG_CLASS_CR, REG_CLASS_DR:
+		return 64
+	}
+	return 0
+}
+
+// gpr returns the general purpose register of a size with an encoding
+// number, the high byte registers are used for 4 to 7 without a rex prefix.
+func gpr(bits uint, n int, rex bool) Reg {
+	switch bits {
+	case 8:
+		if !rex && 4 <= n && n < 8 {
+			return REG_AH + Reg(n-4)
+		}
+		return REG_AL + Reg(n)
+	case 16:
+		return REG_AX + Reg(n)
+	case 32:
+		return REG_EAX + Reg(n)
+	}
+	return REG_RAX + Reg(n)
+}
+
+// segment registers in encoding order
+var segRegs = [...]Reg{REG_ES, REG_CS, REG_SS, REG_DS, REG_FS, REG_GS}
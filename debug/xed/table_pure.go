@@ -0,0 +1,1462 @@
+//go:build !cgo || purego
+// +build !cgo purego
+
+package xed
+
+// The opcode tables follow the opcode maps of the Intel manual, the line
+// format is described in opcode_pure.go.
+
+// oneByteTable is the map without an escape byte.
+const oneByteTable = `
+00 /r     | ADD       | Eb:rw Gb                    | I86 lock
+01 /r     | ADD       | Ev:rw Gv                    | I86 lock
+02 /r     | ADD       | Gb:rw Eb                    | I86
+03 /r     | ADD       | Gv:rw Ev                    | I86
+04        | ADD       | AL:rw Ib                    | I86
+05        | ADD       | rAX:rw sIz                  | I86
+06        | PUSH      | ES ~Kv:w ~sSP:rw            | I86 i64
+07        | POP       | ES:w ~Kv ~sSP:rw            | I86 i64
+08 /r     | OR        | Eb:rw Gb                    | I86 lock
+09 /r     | OR        | Ev:rw Gv                    | I86 lock
+0A /r     | OR        | Gb:rw Eb                    | I86
+0B /r     | OR        | Gv:rw Ev                    | I86
+0C        | OR        | AL:rw Ib                    | I86
+0D        | OR        | rAX:rw sIz                  | I86
+0E        | PUSH      | CS ~Kv:w ~sSP:rw            | I86 i64
+10 /r     | ADC       | Eb:rw Gb                    | I86 lock
+11 /r     | ADC       | Ev:rw Gv                    | I86 lock
+12 /r     | ADC       | Gb:rw Eb                    | I86
+13 /r     | ADC       | Gv:rw Ev                    | I86
+14        | ADC       | AL:rw Ib                    | I86
+15        | ADC       | rAX:rw sIz                  | I86
+16        | PUSH      | SS ~Kv:w ~sSP:rw            | I86 i64
+17        | POP       | SS:w ~Kv ~sSP:rw            | I86 i64
+18 /r     | SBB       | Eb:rw Gb                    | I86 lock
+19 /r     | SBB       | Ev:rw Gv                    | I86 lock
+1A /r     | SBB       | Gb:rw Eb                    | I86
+1B /r     | SBB       | Gv:rw Ev                    | I86
+1C        | SBB       | AL:rw Ib                    | I86
+1D        | SBB       | rAX:rw sIz                  | I86
+1E        | PUSH      | DS ~Kv:w ~sSP:rw            | I86 i64
+1F        | POP       | DS:w ~Kv ~sSP:rw            | I86 i64
+20 /r     | AND       | Eb:rw Gb                    | I86 lock
+21 /r     | AND       | Ev:rw Gv                    | I86 lock
+22 /r     | AND       | Gb:rw Eb                    | I86
+23 /r     | AND       | Gv:rw Ev                    | I86
+24        | AND       | AL:rw Ib                    | I86
+25        | AND       | rAX:rw sIz                  | I86
+27        | DAA       | ~AL:rw                      | I86 i64
+28 /r     | SUB       | Eb:rw Gb                    | I86 lock
+29 /r     | SUB       | Ev:rw Gv                    | I86 lock
+2A /r     | SUB       | Gb:rw Eb                    | I86
+2B /r     | SUB       | Gv:rw Ev                    | I86
+2C        | SUB       | AL:rw Ib                    | I86
+2D        | SUB       | rAX:rw sIz                  | I86
+2F        | DAS       | ~AL:rw                      | I86 i64
+30 /r     | XOR       | Eb:rw Gb                    | I86 lock
+31 /r     | XOR       | Ev:rw Gv                    | I86 lock
+32 /r     | XOR       | Gb:rw Eb                    | I86
+33 /r     | XOR       | Gv:rw Ev                    | I86
+34        | XOR       | AL:rw Ib                    | I86
+35        | XOR       | rAX:rw sIz                  | I86
+37        | AAA       | ~AL:rw ~AH:rw               | I86 i64
+38 /r     | CMP       | Eb Gb                       | I86
+39 /r     | CMP       | Ev Gv                       | I86
+3A /r     | CMP       | Gb Eb                       | I86
+3B /r     | CMP       | Gv Ev                       | I86
+3C        | CMP       | AL Ib                       | I86
+3D        | CMP       | rAX sIz                     | I86
+3F        | AAS       | ~AL:rw ~AH:rw               | I86 i64
+40+r      | INC       | Zv:rw                       | I86 i64
+48+r      | DEC       | Zv:rw                       | I86 i64
+50+r      | PUSH      | Zv ~Kv:w ~sSP:rw            | I86 d64
+58+r      | POP       | Zv:w ~Kv ~sSP:rw            | I86 d64
+60        | PUSHA     | ~Kv:w ~sSP:rw               | I186 i64 osz16
+60        | PUSHAD    | ~Kv:w ~sSP:rw               | I386 i64
+61        | POPA      | ~Kv ~sSP:rw                 | I186 i64 osz16
+61        | POPAD     | ~Kv ~sSP:rw                 | I386 i64
+62 /r mem | BOUND     | Gv Ma                       | I186 i64
+63 /r     | ARPL      | Ew:rw Gw                    | I286PROTECTED i64
+63 /r     | MOVSXD    | Gv:w Ed                     | LONGMODE o64
+68        | PUSH      | sIz ~Kv:w ~sSP:rw           | I186 d64
+69 /r     | IMUL      | Gv:w Ev sIz                 | I186
+6A        | PUSH      | sIb ~Kv:w ~sSP:rw           | I186 d64
+6B /r     | IMUL      | Gv:w Ev sIb                 | I186
+6C        | INSB      | ~Yb:w ~DX ~aDI:rw           | I186 rep
+6D        | INSW      | ~Yw:w ~DX ~aDI:rw           | I186 rep osz16
+6D        | INSD      | ~Yd:w ~DX ~aDI:rw           | I386 rep
+6E        | OUTSB     | ~DX ~Xb ~aSI:rw             | I186 rep
+6F        | OUTSW     | ~DX ~Xw ~aSI:rw             | I186 rep osz16
+6F        | OUTSD     | ~DX ~Xd ~aSI:rw             | I386 rep
+70        | JO        | Jb ~mIP:rw                  | I86 f64
+71        | JNO       | Jb ~mIP:rw                  | I86 f64
+72        | JB        | Jb ~mIP:rw                  | I86 f64
+73        | JNB       | Jb ~mIP:rw                  | I86 f64
+74        | JZ        | Jb ~mIP:rw                  | I86 f64
+75        | JNZ       | Jb ~mIP:rw                  | I86 f64
+76        | JBE       | Jb ~mIP:rw                  | I86 f64
+77        | JNBE      | Jb ~mIP:rw                  | I86 f64
+78        | JS        | Jb ~mIP:rw                  | I86 f64
+79        | JNS       | Jb ~mIP:rw                  | I86 f64
+7A        | JP        | Jb ~mIP:rw                  | I86 f64
+7B        | JNP       | Jb ~mIP:rw                  | I86 f64
+7C        | JL        | Jb ~mIP:rw                  | I86 f64
+7D        | JNL       | Jb ~mIP:rw                  | I86 f64
+7E        | JLE       | Jb ~mIP:rw                  | I86 f64
+7F        | JNLE      | Jb ~mIP:rw                  | I86 f64
+80 /0     | ADD       | Eb:rw Ib                    | I86 lock
+80 /1     | OR        | Eb:rw Ib                    | I86 lock
+80 /2     | ADC       | Eb:rw Ib                    | I86 lock
+80 /3     | SBB       | Eb:rw Ib                    | I86 lock
+80 /4     | AND       | Eb:rw Ib                    | I86 lock
+80 /5     | SUB       | Eb:rw Ib                    | I86 lock
+80 /6     | XOR       | Eb:rw Ib                    | I86 lock
+80 /7     | CMP       | Eb Ib                       | I86
+81 /0     | ADD       | Ev:rw sIz                   | I86 lock
+81 /1     | OR        | Ev:rw sIz                   | I86 lock
+81 /2     | ADC       | Ev:rw sIz                   | I86 lock
+81 /3     | SBB       | Ev:rw sIz                   | I86 lock
+81 /4     | AND       | Ev:rw sIz                   | I86 lock
+81 /5     | SUB       | Ev:rw sIz                   | I86 lock
+81 /6     | XOR       | Ev:rw sIz                   | I86 lock
+81 /7     | CMP       | Ev sIz                      | I86
+82 /0     | ADD       | Eb:rw Ib                    | I86 lock i64
+82 /1     | OR        | Eb:rw Ib                    | I86 lock i64
+82 /2     | ADC       | Eb:rw Ib                    | I86 lock i64
+82 /3     | SBB       | Eb:rw Ib                    | I86 lock i64
+82 /4     | AND       | Eb:rw Ib                    | I86 lock i64
+82 /5     | SUB       | Eb:rw Ib                    | I86 lock i64
+82 /6     | XOR       | Eb:rw Ib                    | I86 lock i64
+82 /7     | CMP       | Eb Ib                       | I86 i64
+83 /0     | ADD       | Ev:rw sIb                   | I86 lock
+83 /1     | OR        | Ev:rw sIb                   | I86 lock
+83 /2     | ADC       | Ev:rw sIb                   | I86 lock
+83 /3     | SBB       | Ev:rw sIb                   | I86 lock
+83 /4     | AND       | Ev:rw sIb                   | I86 lock
+83 /5     | SUB       | Ev:rw sIb                   | I86 lock
+83 /6     | XOR       | Ev:rw sIb                   | I86 lock
+83 /7     | CMP       | Ev sIb                      | I86
+84 /r     | TEST      | Eb Gb                       | I86
+85 /r     | TEST      | Ev Gv                       | I86
+86 /r     | XCHG      | Eb:rw Gb:rw                 | I86 lock
+87 /r     | XCHG      | Ev:rw Gv:rw                 | I86 lock
+88 /r     | MOV       | Eb:w Gb                     | I86
+89 /r     | MOV       | Ev:w Gv                     | I86
+8A /r     | MOV       | Gb:w Eb                     | I86
+8B /r     | MOV       | Gv:w Ev                     | I86
+8C /r mem | MOV       | Mw:w Sw                     | I86
+8C /r reg | MOV       | Rv:w Sw                     | I86
+8D /r     | LEA       | Gv:w AGEN                   | I86
+8E /r     | MOV       | Sw:w Ew                     | I86
+8F /0     | POP       | Ev:w ~Kv ~sSP:rw            | I86 d64
+F3 90     | PAUSE     |                             | PAUSE
+90        | NOP       |                             | I86 rexb0
+90+r      | XCHG      | Zv:rw rAX:rw                | I86
+98        | CBW       | ~AX:w ~AL                   | I86 osz16
+98        | CWDE      | ~EAX:w ~AX                  | I386 osz32
+98        | CDQE      | ~RAX:w ~EAX                 | LONGMODE osz64
+99        | CWD       | ~DX:w ~AX                   | I86 osz16
+99        | CDQ       | ~EDX:w ~EAX                 | I386 osz32
+99        | CQO       | ~RDX:w ~RAX                 | LONGMODE osz64
+9A        | CALL_FAR  | Ap ~Kp:w ~sSP:rw            | I86 i64
+9B        | FWAIT     |                             | X87
+9C        | PUSHF     | ~F ~Kv:w ~sSP:rw            | I86 d64 osz16
+9C        | PUSHFD    | ~F ~Kv:w ~sSP:rw            | I386 i64
+9C        | PUSHFQ    | ~F ~Kv:w ~sSP:rw            | LONGMODE o64
+9D        | POPF      | ~F:w ~Kv ~sSP:rw            | I86 d64 osz16
+9D        | POPFD     | ~F:w ~Kv ~sSP:rw            | I386 i64
+9D        | POPFQ     | ~F:w ~Kv ~sSP:rw            | LONGMODE o64
+9E        | SAHF      | ~AH                         | LAHF
+9F        | LAHF      | ~AH:w                       | LAHF
+A0        | MOV       | AL:w Ob                     | I86
+A1        | MOV       | rAX:w Ov                    | I86
+A2        | MOV       | Ob:w AL                     | I86
+A3        | MOV       | Ov:w rAX                    | I86
+A4        | MOVSB     | Yb:w Xb ~aSI:rw ~aDI:rw     | I86 rep
+A5        | MOVSW     | Yw:w Xw ~aSI:rw ~aDI:rw     | I86 rep osz16
+A5        | MOVSD     | Yd:w Xd ~aSI:rw ~aDI:rw     | I386 rep osz32
+A5        | MOVSQ     | Yq:w Xq ~aSI:rw ~aDI:rw     | LONGMODE rep osz64
+A6        | CMPSB     | Xb Yb ~aSI:rw ~aDI:rw       | I86 repcc
+A7        | CMPSW     | Xw Yw ~aSI:rw ~aDI:rw       | I86 repcc osz16
+A7        | CMPSD     | Xd Yd ~aSI:rw ~aDI:rw       | I386 repcc osz32
+A7        | CMPSQ     | Xq Yq ~aSI:rw ~aDI:rw       | LONGMODE repcc osz64
+AA        | STOSB     | Yb:w ~AL ~aDI:rw            | I86 rep
+AB        | STOSW     | Yw:w ~AX ~aDI:rw            | I86 rep osz16
+AB        | STOSD     | Yd:w ~EAX ~aDI:rw           | I386 rep osz32
+AB        | STOSQ     | Yq:w ~RAX ~aDI:rw           | LONGMODE rep osz64
+AC        | LODSB     | ~AL:w Xb ~aSI:rw            | I86 rep
+AD        | LODSW     | ~AX:w Xw ~aSI:rw            | I86 rep osz16
+AD        | LODSD     | ~EAX:w Xd ~aSI:rw           | I386 rep osz32
+AD        | LODSQ     | ~RAX:w Xq ~aSI:rw           | LONGMODE rep osz64
+AE        | SCASB     | ~AL Yb ~aDI:rw              | I86 repcc
+AF        | SCASW     | ~AX Yw ~aDI:rw              | I86 repcc osz16
+AF        | SCASD     | ~EAX Yd ~aDI:rw             | I386 repcc osz32
+AF        | SCASQ     | ~RAX Yq ~aDI:rw             | LONGMODE repcc osz64
+A8        | TEST      | AL Ib                       | I86
+A9        | TEST      | rAX sIz                     | I86
+B0+r      | MOV       | Zb:w Ib                     | I86
+B8+r      | MOV       | Zv:w Iv                     | I86
+C0 /0     | ROL       | Eb:rw Ib                    | I186
+C0 /1     | ROR       | Eb:rw Ib                    | I186
+C0 /2     | RCL       | Eb:rw Ib                    | I186
+C0 /3     | RCR       | Eb:rw Ib                    | I186
+C0 /4     | SHL       | Eb:rw Ib                    | I186
+C0 /5     | SHR       | Eb:rw Ib                    | I186
+C0 /6     | SHL       | Eb:rw Ib                    | I186
+C0 /7     | SAR       | Eb:rw Ib                    | I186
+C1 /0     | ROL       | Ev:rw Ib                    | I186
+C1 /1     | ROR       | Ev:rw Ib                    | I186
+C1 /2     | RCL       | Ev:rw Ib                    | I186
+C1 /3     | RCR       | Ev:rw Ib                    | I186
+C1 /4     | SHL       | Ev:rw Ib                    | I186
+C1 /5     | SHR       | Ev:rw Ib                    | I186
+C1 /6     | SHL       | Ev:rw Ib                    | I186
+C1 /7     | SAR       | Ev:rw Ib                    | I186
+D0 /0     | ROL       | Eb:rw I1                    | I86
+D0 /1     | ROR       | Eb:rw I1                    | I86
+D0 /2     | RCL       | Eb:rw I1                    | I86
+D0 /3     | RCR       | Eb:rw I1                    | I86
+D0 /4     | SHL       | Eb:rw I1                    | I86
+D0 /5     | SHR       | Eb:rw I1                    | I86
+D0 /6     | SHL       | Eb:rw I1                    | I86
+D0 /7     | SAR       | Eb:rw I1                    | I86
+D1 /0     | ROL       | Ev:rw I1                    | I86
+D1 /1     | ROR       | Ev:rw I1                    | I86
+D1 /2     | RCL       | Ev:rw I1                    | I86
+D1 /3     | RCR       | Ev:rw I1                    | I86
+D1 /4     | SHL       | Ev:rw I1                    | I86
+D1 /5     | SHR       | Ev:rw I1                    | I86
+D1 /6     | SHL       | Ev:rw I1                    | I86
+D1 /7     | SAR       | Ev:rw I1                    | I86
+D2 /0     | ROL       | Eb:rw CL                    | I86
+D2 /1     | ROR       | Eb:rw CL                    | I86
+D2 /2     | RCL       | Eb:rw CL                    | I86
+D2 /3     | RCR       | Eb:rw CL                    | I86
+D2 /4     | SHL       | Eb:rw CL                    | I86
+D2 /5     | SHR       | Eb:rw CL                    | I86
+D2 /6     | SHL       | Eb:rw CL                    | I86
+D2 /7     | SAR       | Eb:rw CL                    | I86
+D3 /0     | ROL       | Ev:rw CL                    | I86
+D3 /1     | ROR       | Ev:rw CL                    | I86
+D3 /2     | RCL       | Ev:rw CL                    | I86
+D3 /3     | RCR       | Ev:rw CL                    | I86
+D3 /4     | SHL       | Ev:rw CL                    | I86
+D3 /5     | SHR       | Ev:rw CL                    | I86
+D3 /6     | SHL       | Ev:rw CL                    | I86
+D3 /7     | SAR       | Ev:rw CL                    | I86
+C2        | RET_NEAR  | Iw ~Kv ~sSP:rw ~mIP:w       | I86 f64
+C3        | RET_NEAR  | ~Kv ~sSP:rw ~mIP:w          | I86 f64
+C4 /r mem | LES       | Gz:w Mp ~ES:w               | I86 i64
+C5 /r mem | LDS       | Gz:w Mp ~DS:w               | I86 i64
+C6 :F8    | XABORT    | Ib ~EAX:rw                  | RTM
+C6 /0     | MOV       | Eb:w Ib                     | I86
+C7 :F8    | XBEGIN    | Jz ~mIP:rw ~EAX:rw          | RTM
+C7 /0     | MOV       | Ev:w sIz                    | I86
+C8        | ENTER     | Iw Ib ~Kv:w ~sSP:rw ~sBP:rw | I186 d64
+C9        | LEAVE     | ~Kv ~sSP:rw ~sBP:rw         | I186 d64
+CA        | RET_FAR   | Iw ~Kp ~sSP:rw              | I86
+CB        | RET_FAR   | ~Kp ~sSP:rw                 | I86
+CC        | INT3      |                             | I86
+CD        | INT       | Ib                          | I86
+CE        | INTO      |                             | I86 i64
+CF        | IRET      | ~Kv ~sSP:rw                 | I86 osz16
+CF        | IRETD     | ~Kv ~sSP:rw                 | I386 osz32
+CF        | IRETQ     | ~Kv ~sSP:rw                 | LONGMODE osz64
+D4        | AAM       | Ib ~AX:rw                   | I86 i64
+D5        | AAD       | Ib ~AX:rw                   | I86 i64
+D6        | SALC      | ~AL:w                       | I86 i64
+D7        | XLAT      | ~Mxlat ~AL:w                | I86
+E0        | LOOPNE    | Jb ~aCX:rw ~mIP:rw          | I86 f64
+E1        | LOOPE     | Jb ~aCX:rw ~mIP:rw          | I86 f64
+E2        | LOOP      | Jb ~aCX:rw ~mIP:rw          | I86 f64
+E3        | JCXZ      | Jb ~CX ~mIP:rw              | I86 f64 asz16
+E3        | JECXZ     | Jb ~ECX ~mIP:rw             | I386 f64 asz32
+E3        | JRCXZ     | Jb ~RCX ~mIP:rw             | LONGMODE f64 asz64
+E4        | IN        | AL:w Ib                     | I86
+E5        | IN        | eAX:w Ib                    | I86
+E6        | OUT       | Ib AL                       | I86
+E7        | OUT       | Ib eAX                      | I86
+E8        | CALL_NEAR | Jz ~Kv:w ~sSP:rw ~mIP:rw    | I86 f64
+E9        | JMP       | Jz ~mIP:rw                  | I86 f64
+EA        | JMP_FAR   | Ap ~mIP:w                   | I86 i64
+EB        | JMP       | Jb ~mIP:rw                  | I86 f64
+EC        | IN        | AL:w DX                     | I86
+ED        | IN        | eAX:w DX                    | I86
+EE        | OUT       | DX AL                       | I86
+EF        | OUT       | DX eAX                      | I86
+F1        | INT1      |                             | I386
+F4        | HLT       |                             | I86
+F5        | CMC       |                             | I86
+F6 /0     | TEST      | Eb Ib                       | I86
+F6 /1     | TEST      | Eb Ib                       | I86
+F6 /2     | NOT       | Eb:rw                       | I86 lock
+F6 /3     | NEG       | Eb:rw                       | I86 lock
+F6 /4     | MUL       | Eb ~AL ~AX:w                | I86
+F6 /5     | IMUL      | Eb ~AL ~AX:w                | I86
+F6 /6     | DIV       | Eb ~AX:rw                   | I86
+F6 /7     | IDIV      | Eb ~AX:rw                   | I86
+F7 /0     | TEST      | Ev sIz                      | I86
+F7 /1     | TEST      | Ev sIz                      | I86
+F7 /2     | NOT       | Ev:rw                       | I86 lock
+F7 /3     | NEG       | Ev:rw                       | I86 lock
+F7 /4     | MUL       | Ev ~rAX:rw ~rDX:w           | I86
+F7 /5     | IMUL      | Ev ~rAX:rw ~rDX:w           | I86
+F7 /6     | DIV       | Ev ~rAX:rw ~rDX:rw          | I86
+F7 /7     | IDIV      | Ev ~rAX:rw ~rDX:rw          | I86
+F8        | CLC       |                             | I86
+F9        | STC       |                             | I86
+FA        | CLI       |                             | I86
+FB        | STI       |                             | I86
+FC        | CLD       |                             | I86
+FD        | STD       |                             | I86
+FE /0     | INC       | Eb:rw                       | I86 lock
+FE /1     | DEC       | Eb:rw                       | I86 lock
+FF /0     | INC       | Ev:rw                       | I86 lock
+FF /1     | DEC       | Ev:rw                       | I86 lock
+FF /2     | CALL_NEAR | Ev ~Kv:w ~sSP:rw ~mIP:rw    | I86 f64
+FF /3 mem | CALL_FAR  | Mp ~Kp:w ~sSP:rw            | I86
+FF /4     | JMP       | Ev ~mIP:w                   | I86 f64
+FF /5 mem | JMP_FAR   | Mp ~mIP:w                   | I86
+FF /6     | PUSH      | Ev ~Kv:w ~sSP:rw            | I86 d64
+`
+
+// twoByteTable is the map after the 0F escape byte.
+const twoByteTable = `
+0F 00 /0 mem    | SLDT        | Mw:w                             | I286PROTECTED
+0F 00 /0 reg    | SLDT        | Rv:w                             | I286PROTECTED
+0F 00 /1 mem    | STR         | Mw:w                             | I286PROTECTED
+0F 00 /1 reg    | STR         | Rv:w                             | I286PROTECTED
+0F 00 /2        | LLDT        | Ew                               | I286PROTECTED
+0F 00 /3        | LTR         | Ew                               | I286PROTECTED
+0F 00 /4        | VERR        | Ew                               | I286PROTECTED
+0F 00 /5        | VERW        | Ew                               | I286PROTECTED
+0F 01 :C1       | VMCALL      |                                  | VTX
+0F 01 :C2       | VMLAUNCH    |                                  | VTX
+0F 01 :C3       | VMRESUME    |                                  | VTX
+0F 01 :C4       | VMXOFF      |                                  | VTX
+0F 01 :C8       | MONITOR     | ~aAX ~ECX ~EDX                   | MONITOR
+0F 01 :C9       | MWAIT       | ~EAX ~ECX                        | MONITOR
+0F 01 :CA       | CLAC        |                                  | SMAP
+0F 01 :CB       | STAC        |                                  | SMAP
+0F 01 :D0       | XGETBV      | ~ECX ~EDX:w ~EAX:w               | XSAVE
+0F 01 :D1       | XSETBV      | ~ECX ~EDX ~EAX                   | XSAVE
+0F 01 :D5       | XEND        |                                  | RTM
+0F 01 :D6       | XTEST       |                                  | RTM
+0F 01 :EE       | RDPKRU      | ~ECX ~EDX:w ~EAX:w               | PKU
+0F 01 :EF       | WRPKRU      | ~ECX ~EDX ~EAX                   | PKU
+0F 01 :F8       | SWAPGS      |                                  | LONGMODE o64
+0F 01 :F9       | RDTSCP      | ~EAX:w ~EDX:w ~ECX:w             | RDTSCP
+0F 01 /0 mem    | SGDT        | Ms:w                             | I286REAL
+0F 01 /1 mem    | SIDT        | Ms:w                             | I286REAL
+0F 01 /2 mem    | LGDT        | Ms                               | I286REAL
+0F 01 /3 mem    | LIDT        | Ms                               | I286REAL
+0F 01 /4 mem    | SMSW        | Mw:w                             | I286REAL
+0F 01 /4 reg    | SMSW        | Rv:w                             | I286REAL
+0F 01 /6        | LMSW        | Ew                               | I286REAL
+0F 01 /7 mem    | INVLPG      | AGEN                             | I486REAL
+0F 02 /r mem    | LAR         | Gv:w Mw                          | I286PROTECTED
+0F 02 /r reg    | LAR         | Gv:w Rz                          | I286PROTECTED
+0F 03 /r mem    | LSL         | Gv:w Mw                          | I286PROTECTED
+0F 03 /r reg    | LSL         | Gv:w Rz                          | I286PROTECTED
+0F 05           | SYSCALL     | ~RCX:w ~R11:w                    | LONGMODE o64
+0F 05           | SYSCALL_AMD |                                  | AMD i64
+0F 06           | CLTS        |                                  | I286REAL
+0F 07           | SYSRET      | ~RCX ~R11                        | LONGMODE o64
+0F 07           | SYSRET_AMD  |                                  | AMD i64
+0F 08           | INVD        |                                  | I486REAL
+F3 0F 09        | WBNOINVD    |                                  | WBNOINVD
+0F 09           | WBINVD      |                                  | I486REAL
+0F 0B           | UD2         |                                  | PPRO
+0F 0D /1 mem    | PREFETCHW   | Mb                               | PREFETCHW
+NP 0F 10 /r     | MOVUPS      | Vps:w Wps                        | SSE
+66 0F 10 /r     | MOVUPD      | Vpd:w Wpd                        | SSE2
+F3 0F 10 /r     | MOVSS       | Vss:w Wss                        | SSE
+F2 0F 10 /r     | MOVSD_XMM   | Vsd:w Wsd                        | SSE2
+NP 0F 11 /r     | MOVUPS      | Wps:w Vps                        | SSE
+66 0F 11 /r     | MOVUPD      | Wpd:w Vpd                        | SSE2
+F3 0F 11 /r     | MOVSS       | Wss:w Vss                        | SSE
+F2 0F 11 /r     | MOVSD_XMM   | Wsd:w Vsd                        | SSE2
+NP 0F 12 /r mem | MOVLPS      | Vq:w Mq                          | SSE
+NP 0F 12 /r reg | MOVHLPS     | Vq:w Uq                          | SSE
+66 0F 12 /r mem | MOVLPD      | Vq:w Mq                          | SSE2
+F3 0F 12 /r     | MOVSLDUP    | Vps:w Wps                        | SSE3
+F2 0F 12 /r     | MOVDDUP     | Vdq:w Wq                         | SSE3
+NP 0F 13 /r mem | MOVLPS      | Mq:w Vq                          | SSE
+66 0F 13 /r mem | MOVLPD      | Mq:w Vq                          | SSE2
+NP 0F 14 /r     | UNPCKLPS    | Vps:rw Wps                       | SSE
+66 0F 14 /r     | UNPCKLPD    | Vpd:rw Wpd                       | SSE2
+NP 0F 15 /r     | UNPCKHPS    | Vps:rw Wps                       | SSE
+66 0F 15 /r     | UNPCKHPD    | Vpd:rw Wpd                       | SSE2
+NP 0F 16 /r mem | MOVHPS      | Vq:w Mq                          | SSE
+NP 0F 16 /r reg | MOVLHPS     | Vq:w Uq                          | SSE
+66 0F 16 /r mem | MOVHPD      | Vq:w Mq                          | SSE2
+F3 0F 16 /r     | MOVSHDUP    | Vps:w Wps                        | SSE3
+NP 0F 17 /r mem | MOVHPS      | Mq:w Vq                          | SSE
+66 0F 17 /r mem | MOVHPD      | Mq:w Vq                          | SSE2
+0F 18 /0 mem    | PREFETCHNTA | Mb                               | SSE_PREFETCH
+0F 18 /1 mem    | PREFETCHT0  | Mb                               | SSE_PREFETCH
+0F 18 /2 mem    | PREFETCHT1  | Mb                               | SSE_PREFETCH
+0F 18 /3 mem    | PREFETCHT2  | Mb                               | SSE_PREFETCH
+F3 0F 1E :FA    | ENDBR64     |                                  | CET
+F3 0F 1E :FB    | ENDBR32     |                                  | CET
+0F 18 /r        | NOP         | Ev Gv                            | FAT_NOP
+0F 19 /r        | NOP         | Ev Gv                            | FAT_NOP
+0F 1A /r        | NOP         | Ev Gv                            | FAT_NOP
+0F 1B /r        | NOP         | Ev Gv                            | FAT_NOP
+0F 1C /r        | NOP         | Ev Gv                            | FAT_NOP
+0F 1D /r        | NOP         | Ev Gv                            | FAT_NOP
+0F 1E /r        | NOP         | Ev Gv                            | FAT_NOP
+0F 1F /r        | NOP         | Ev Gv                            | FAT_NOP
+0F 20 /r        | MOV_CR      | Rn:w Cn                          | I386 rmreg
+0F 21 /r        | MOV_DR      | Rn:w Dn                          | I386 rmreg
+0F 22 /r        | MOV_CR      | Cn:w Rn                          | I386 rmreg
+0F 23 /r        | MOV_DR      | Dn:w Rn                          | I386 rmreg
+NP 0F 28 /r     | MOVAPS      | Vps:w Wps                        | SSE
+66 0F 28 /r     | MOVAPD      | Vpd:w Wpd                        | SSE2
+NP 0F 29 /r     | MOVAPS      | Wps:w Vps                        | SSE
+66 0F 29 /r     | MOVAPD      | Wpd:w Vpd                        | SSE2
+NP 0F 2A /r     | CVTPI2PS    | Vq:w Qq                          | SSE
+66 0F 2A /r     | CVTPI2PD    | Vpd:w Qq                         | SSE2
+F3 0F 2A /r     | CVTSI2SS    | Vss:w Ey                         | SSE
+F2 0F 2A /r     | CVTSI2SD    | Vsd:w Ey                         | SSE2
+NP 0F 2B /r mem | MOVNTPS     | Mps:w Vps                        | SSE
+F3 0F 2B /r mem | MOVNTSS     | Md:w Vdq                         | SSE4A
+F2 0F 2B /r mem | MOVNTSD     | Mq:w Vdq                         | SSE4A
+66 0F 2B /r mem | MOVNTPD     | Mpd:w Vpd                        | SSE2
+NP 0F 2C /r     | CVTTPS2PI   | Pq:w Wq                          | SSE
+66 0F 2C /r     | CVTTPD2PI   | Pq:w Wpd                         | SSE2
+F3 0F 2C /r     | CVTTSS2SI   | Gy:w Wss                         | SSE
+F2 0F 2C /r     | CVTTSD2SI   | Gy:w Wsd                         | SSE2
+NP 0F 2D /r     | CVTPS2PI    | Pq:w Wq                          | SSE
+66 0F 2D /r     | CVTPD2PI    | Pq:w Wpd                         | SSE2
+F3 0F 2D /r     | CVTSS2SI    | Gy:w Wss                         | SSE
+F2 0F 2D /r     | CVTSD2SI    | Gy:w Wsd                         | SSE2
+NP 0F 2E /r     | UCOMISS     | Vss Wss                          | SSE
+66 0F 2E /r     | UCOMISD     | Vsd Wsd                          | SSE2
+NP 0F 2F /r     | COMISS      | Vss Wss                          | SSE
+66 0F 2F /r     | COMISD      | Vsd Wsd                          | SSE2
+0F 30           | WRMSR       | ~ECX ~EDX ~EAX                   | PENTIUMREAL
+0F 31           | RDTSC       | ~EAX:w ~EDX:w                    | PENTIUMREAL
+0F 32           | RDMSR       | ~ECX ~EDX:w ~EAX:w               | PENTIUMREAL
+0F 33           | RDPMC       | ~ECX ~EDX:w ~EAX:w               | RDPMC
+0F 34           | SYSENTER    |                                  | PPRO
+0F 35           | SYSEXIT     |                                  | PPRO
+0F 37           | GETSEC      | ~EAX:rw ~EBX                     | SMX
+0F 40 /r        | CMOVO       | Gv:cw Ev                         | CMOV
+0F 41 /r        | CMOVNO      | Gv:cw Ev                         | CMOV
+0F 42 /r        | CMOVB       | Gv:cw Ev                         | CMOV
+0F 43 /r        | CMOVNB      | Gv:cw Ev                         | CMOV
+0F 44 /r        | CMOVZ       | Gv:cw Ev                         | CMOV
+0F 45 /r        | CMOVNZ      | Gv:cw Ev                         | CMOV
+0F 46 /r        | CMOVBE      | Gv:cw Ev                         | CMOV
+0F 47 /r        | CMOVNBE     | Gv:cw Ev                         | CMOV
+0F 48 /r        | CMOVS       | Gv:cw Ev                         | CMOV
+0F 49 /r        | CMOVNS      | Gv:cw Ev                         | CMOV
+0F 4A /r        | CMOVP       | Gv:cw Ev                         | CMOV
+0F 4B /r        | CMOVNP      | Gv:cw Ev                         | CMOV
+0F 4C /r        | CMOVL       | Gv:cw Ev                         | CMOV
+0F 4D /r        | CMOVNL      | Gv:cw Ev                         | CMOV
+0F 4E /r        | CMOVLE      | Gv:cw Ev                         | CMOV
+0F 4F /r        | CMOVNLE     | Gv:cw Ev                         | CMOV
+NP 0F 50 /r reg | MOVMSKPS    | Gd:w Ups                         | SSE
+66 0F 50 /r reg | MOVMSKPD    | Gd:w Upd                         | SSE2
+NP 0F 51 /r     | SQRTPS      | Vps:w Wps                        | SSE
+66 0F 51 /r     | SQRTPD      | Vpd:w Wpd                        | SSE2
+F3 0F 51 /r     | SQRTSS      | Vss:w Wss                        | SSE
+F2 0F 51 /r     | SQRTSD      | Vsd:w Wsd                        | SSE2
+NP 0F 52 /r     | RSQRTPS     | Vps:w Wps                        | SSE
+F3 0F 52 /r     | RSQRTSS     | Vss:w Wss                        | SSE
+NP 0F 53 /r     | RCPPS       | Vps:w Wps                        | SSE
+F3 0F 53 /r     | RCPSS       | Vss:w Wss                        | SSE
+NP 0F 54 /r     | ANDPS       | Vps:rw Wps                       | SSE
+66 0F 54 /r     | ANDPD       | Vpd:rw Wpd                       | SSE2
+NP 0F 55 /r     | ANDNPS      | Vps:rw Wps                       | SSE
+66 0F 55 /r     | ANDNPD      | Vpd:rw Wpd                       | SSE2
+NP 0F 56 /r     | ORPS        | Vps:rw Wps                       | SSE
+66 0F 56 /r     | ORPD        | Vpd:rw Wpd                       | SSE2
+NP 0F 57 /r     | XORPS       | Vps:rw Wps                       | SSE
+66 0F 57 /r     | XORPD       | Vpd:rw Wpd                       | SSE2
+NP 0F 58 /r     | ADDPS       | Vps:rw Wps                       | SSE
+66 0F 58 /r     | ADDPD       | Vpd:rw Wpd                       | SSE2
+F3 0F 58 /r     | ADDSS       | Vss:rw Wss                       | SSE
+F2 0F 58 /r     | ADDSD       | Vsd:rw Wsd                       | SSE2
+NP 0F 59 /r     | MULPS       | Vps:rw Wps                       | SSE
+66 0F 59 /r     | MULPD       | Vpd:rw Wpd                       | SSE2
+F3 0F 59 /r     | MULSS       | Vss:rw Wss                       | SSE
+F2 0F 59 /r     | MULSD       | Vsd:rw Wsd                       | SSE2
+NP 0F 5A /r     | CVTPS2PD    | Vpd:w Wq                         | SSE2
+66 0F 5A /r     | CVTPD2PS    | Vps:w Wpd                        | SSE2
+F3 0F 5A /r     | CVTSS2SD    | Vsd:w Wss                        | SSE2
+F2 0F 5A /r     | CVTSD2SS    | Vss:w Wsd                        | SSE2
+NP 0F 5B /r     | CVTDQ2PS    | Vps:w Wdq                        | SSE2
+66 0F 5B /r     | CVTPS2DQ    | Vdq:w Wps                        | SSE2
+F3 0F 5B /r     | CVTTPS2DQ   | Vdq:w Wps                        | SSE2
+NP 0F 5C /r     | SUBPS       | Vps:rw Wps                       | SSE
+66 0F 5C /r     | SUBPD       | Vpd:rw Wpd                       | SSE2
+F3 0F 5C /r     | SUBSS       | Vss:rw Wss                       | SSE
+F2 0F 5C /r     | SUBSD       | Vsd:rw Wsd                       | SSE2
+NP 0F 5D /r     | MINPS       | Vps:rw Wps                       | SSE
+66 0F 5D /r     | MINPD       | Vpd:rw Wpd                       | SSE2
+F3 0F 5D /r     | MINSS       | Vss:rw Wss                       | SSE
+F2 0F 5D /r     | MINSD       | Vsd:rw Wsd                       | SSE2
+NP 0F 5E /r     | DIVPS       | Vps:rw Wps                       | SSE
+66 0F 5E /r     | DIVPD       | Vpd:rw Wpd                       | SSE2
+F3 0F 5E /r     | DIVSS       | Vss:rw Wss                       | SSE
+F2 0F 5E /r     | DIVSD       | Vsd:rw Wsd                       | SSE2
+NP 0F 5F /r     | MAXPS       | Vps:rw Wps                       | SSE
+66 0F 5F /r     | MAXPD       | Vpd:rw Wpd                       | SSE2
+F3 0F 5F /r     | MAXSS       | Vss:rw Wss                       | SSE
+F2 0F 5F /r     | MAXSD       | Vsd:rw Wsd                       | SSE2
+NP 0F 60 /r     | PUNPCKLBW   | Pq:rw Qd                         | PENTIUMMMX
+66 0F 60 /r     | PUNPCKLBW   | Vdq:rw Wdq                       | SSE2
+NP 0F 61 /r     | PUNPCKLWD   | Pq:rw Qd                         | PENTIUMMMX
+66 0F 61 /r     | PUNPCKLWD   | Vdq:rw Wdq                       | SSE2
+NP 0F 62 /r     | PUNPCKLDQ   | Pq:rw Qd                         | PENTIUMMMX
+66 0F 62 /r     | PUNPCKLDQ   | Vdq:rw Wdq                       | SSE2
+NP 0F 63 /r     | PACKSSWB    | Pq:rw Qq                         | PENTIUMMMX
+66 0F 63 /r     | PACKSSWB    | Vdq:rw Wdq                       | SSE2
+NP 0F 64 /r     | PCMPGTB     | Pq:rw Qq                         | PENTIUMMMX
+66 0F 64 /r     | PCMPGTB     | Vdq:rw Wdq                       | SSE2
+NP 0F 65 /r     | PCMPGTW     | Pq:rw Qq                         | PENTIUMMMX
+66 0F 65 /r     | PCMPGTW     | Vdq:rw Wdq                       | SSE2
+NP 0F 66 /r     | PCMPGTD     | Pq:rw Qq                         | PENTIUMMMX
+66 0F 66 /r     | PCMPGTD     | Vdq:rw Wdq                       | SSE2
+NP 0F 67 /r     | PACKUSWB    | Pq:rw Qq                         | PENTIUMMMX
+66 0F 67 /r     | PACKUSWB    | Vdq:rw Wdq                       | SSE2
+NP 0F 68 /r     | PUNPCKHBW   | Pq:rw Qq                         | PENTIUMMMX
+66 0F 68 /r     | PUNPCKHBW   | Vdq:rw Wdq                       | SSE2
+NP 0F 69 /r     | PUNPCKHWD   | Pq:rw Qq                         | PENTIUMMMX
+66 0F 69 /r     | PUNPCKHWD   | Vdq:rw Wdq                       | SSE2
+NP 0F 6A /r     | PUNPCKHDQ   | Pq:rw Qq                         | PENTIUMMMX
+66 0F 6A /r     | PUNPCKHDQ   | Vdq:rw Wdq                       | SSE2
+NP 0F 6B /r     | PACKSSDW    | Pq:rw Qq                         | PENTIUMMMX
+66 0F 6B /r     | PACKSSDW    | Vdq:rw Wdq                       | SSE2
+66 0F 6C /r     | PUNPCKLQDQ  | Vdq:rw Wdq                       | SSE2
+66 0F 6D /r     | PUNPCKHQDQ  | Vdq:rw Wdq                       | SSE2
+NP 0F 6E /r     | MOVQ        | Pq:w Eq                          | PENTIUMMMX w1 o64
+NP 0F 6E /r     | MOVD        | Pq:w Ed                          | PENTIUMMMX
+66 0F 6E /r     | MOVQ        | Vdq:w Eq                         | SSE2 w1 o64
+66 0F 6E /r     | MOVD        | Vdq:w Ed                         | SSE2
+NP 0F 6F /r     | MOVQ        | Pq:w Qq                          | PENTIUMMMX
+66 0F 6F /r     | MOVDQA      | Vdq:w Wdq                        | SSE2
+F3 0F 6F /r     | MOVDQU      | Vdq:w Wdq                        | SSE2
+NP 0F 70 /r     | PSHUFW      | Pq:w Qq Ib                       | SSE
+66 0F 70 /r     | PSHUFD      | Vdq:w Wdq Ib                     | SSE2
+F3 0F 70 /r     | PSHUFHW     | Vdq:w Wdq Ib                     | SSE2
+F2 0F 70 /r     | PSHUFLW     | Vdq:w Wdq Ib                     | SSE2
+NP 0F 71 /2 reg | PSRLW       | Nq:rw Ib                         | PENTIUMMMX
+66 0F 71 /2 reg | PSRLW       | Udq:rw Ib                        | SSE2
+NP 0F 71 /4 reg | PSRAW       | Nq:rw Ib                         | PENTIUMMMX
+66 0F 71 /4 reg | PSRAW       | Udq:rw Ib                        | SSE2
+NP 0F 71 /6 reg | PSLLW       | Nq:rw Ib                         | PENTIUMMMX
+66 0F 71 /6 reg | PSLLW       | Udq:rw Ib                        | SSE2
+NP 0F 72 /2 reg | PSRLD       | Nq:rw Ib                         | PENTIUMMMX
+66 0F 72 /2 reg | PSRLD       | Udq:rw Ib                        | SSE2
+NP 0F 72 /4 reg | PSRAD       | Nq:rw Ib                         | PENTIUMMMX
+66 0F 72 /4 reg | PSRAD       | Udq:rw Ib                        | SSE2
+NP 0F 72 /6 reg | PSLLD       | Nq:rw Ib                         | PENTIUMMMX
+66 0F 72 /6 reg | PSLLD       | Udq:rw Ib                        | SSE2
+NP 0F 73 /2 reg | PSRLQ       | Nq:rw Ib                         | PENTIUMMMX
+66 0F 73 /2 reg | PSRLQ       | Udq:rw Ib                        | SSE2
+66 0F 73 /3 reg | PSRLDQ      | Udq:rw Ib                        | SSE2
+NP 0F 73 /6 reg | PSLLQ       | Nq:rw Ib                         | PENTIUMMMX
+66 0F 73 /6 reg | PSLLQ       | Udq:rw Ib                        | SSE2
+66 0F 73 /7 reg | PSLLDQ      | Udq:rw Ib                        | SSE2
+NP 0F 74 /r     | PCMPEQB     | Pq:rw Qq                         | PENTIUMMMX
+66 0F 74 /r     | PCMPEQB     | Vdq:rw Wdq                       | SSE2
+NP 0F 75 /r     | PCMPEQW     | Pq:rw Qq                         | PENTIUMMMX
+66 0F 75 /r     | PCMPEQW     | Vdq:rw Wdq                       | SSE2
+NP 0F 76 /r     | PCMPEQD     | Pq:rw Qq                         | PENTIUMMMX
+66 0F 76 /r     | PCMPEQD     | Vdq:rw Wdq                       | SSE2
+NP 0F 77        | EMMS        |                                  | PENTIUMMMX
+NP 0F 78 /r     | VMREAD      | En:w Gn                          | VTX
+NP 0F 79 /r     | VMWRITE     | Gn En                            | VTX
+66 0F 7C /r     | HADDPD      | Vpd:rw Wpd                       | SSE3
+F2 0F 7C /r     | HADDPS      | Vps:rw Wps                       | SSE3
+66 0F 7D /r     | HSUBPD      | Vpd:rw Wpd                       | SSE3
+F2 0F 7D /r     | HSUBPS      | Vps:rw Wps                       | SSE3
+NP 0F 7E /r     | MOVQ        | Eq:w Pq                          | PENTIUMMMX w1 o64
+NP 0F 7E /r     | MOVD        | Ed:w Pq                          | PENTIUMMMX
+66 0F 7E /r     | MOVQ        | Eq:w Vdq                         | SSE2 w1 o64
+66 0F 7E /r     | MOVD        | Ed:w Vdq                         | SSE2
+F3 0F 7E /r     | MOVQ        | Vdq:w Wq                         | SSE2
+NP 0F 7F /r     | MOVQ        | Qq:w Pq                          | PENTIUMMMX
+66 0F 7F /r     | MOVDQA      | Wdq:w Vdq                        | SSE2
+F3 0F 7F /r     | MOVDQU      | Wdq:w Vdq                        | SSE2
+0F 80           | JO          | Jz ~mIP:rw                       | I386 f64
+0F 81           | JNO         | Jz ~mIP:rw                       | I386 f64
+0F 82           | JB          | Jz ~mIP:rw                       | I386 f64
+0F 83           | JNB         | Jz ~mIP:rw                       | I386 f64
+0F 84           | JZ          | Jz ~mIP:rw                       | I386 f64
+0F 85           | JNZ         | Jz ~mIP:rw                       | I386 f64
+0F 86           | JBE         | Jz ~mIP:rw                       | I386 f64
+0F 87           | JNBE        | Jz ~mIP:rw                       | I386 f64
+0F 88           | JS          | Jz ~mIP:rw                       | I386 f64
+0F 89           | JNS         | Jz ~mIP:rw                       | I386 f64
+0F 8A           | JP          | Jz ~mIP:rw                       | I386 f64
+0F 8B           | JNP         | Jz ~mIP:rw                       | I386 f64
+0F 8C           | JL          | Jz ~mIP:rw                       | I386 f64
+0F 8D           | JNL         | Jz ~mIP:rw                       | I386 f64
+0F 8E           | JLE         | Jz ~mIP:rw                       | I386 f64
+0F 8F           | JNLE        | Jz ~mIP:rw                       | I386 f64
+0F 90 /r        | SETO        | Eb:w                             | I386
+0F 91 /r        | SETNO       | Eb:w                             | I386
+0F 92 /r        | SETB        | Eb:w                             | I386
+0F 93 /r        | SETNB       | Eb:w                             | I386
+0F 94 /r        | SETZ        | Eb:w                             | I386
+0F 95 /r        | SETNZ       | Eb:w                             | I386
+0F 96 /r        | SETBE       | Eb:w                             | I386
+0F 97 /r        | SETNBE      | Eb:w                             | I386
+0F 98 /r        | SETS        | Eb:w                             | I386
+0F 99 /r        | SETNS       | Eb:w                             | I386
+0F 9A /r        | SETP        | Eb:w                             | I386
+0F 9B /r        | SETNP       | Eb:w                             | I386
+0F 9C /r        | SETL        | Eb:w                             | I386
+0F 9D /r        | SETNL       | Eb:w                             | I386
+0F 9E /r        | SETLE       | Eb:w                             | I386
+0F 9F /r        | SETNLE      | Eb:w                             | I386
+0F A0           | PUSH        | FS ~Kv:w ~sSP:rw                 | I386 d64
+0F A1           | POP         | FS:w ~Kv ~sSP:rw                 | I386 d64
+0F A2           | CPUID       | ~EAX:rw ~ECX:rw ~EBX:w ~EDX:w    | I486REAL
+0F A3 /r        | BT          | Ev Gv                            | I386
+0F A4 /r        | SHLD        | Ev:rw Gv Ib                      | I386
+0F A5 /r        | SHLD        | Ev:rw Gv CL                      | I386
+0F A8           | PUSH        | GS ~Kv:w ~sSP:rw                 | I386 d64
+0F A9           | POP         | GS:w ~Kv ~sSP:rw                 | I386 d64
+0F AA           | RSM         |                                  | I486REAL
+0F AB /r        | BTS         | Ev:rw Gv                         | I386 lock
+0F AC /r        | SHRD        | Ev:rw Gv Ib                      | I386
+0F AD /r        | SHRD        | Ev:rw Gv CL                      | I386
+NP 0F AE /0 mem | FXSAVE64    | Mfx:w                            | FXSAVE64 w1 o64
+NP 0F AE /0 mem | FXSAVE      | Mfx:w                            | FXSAVE
+NP 0F AE /1 mem | FXRSTOR64   | Mfx                              | FXSAVE64 w1 o64
+NP 0F AE /1 mem | FXRSTOR     | Mfx                              | FXSAVE
+NP 0F AE /2 mem | LDMXCSR     | Md ~MXCSR:w                      | SSEMXCSR
+NP 0F AE /3 mem | STMXCSR     | Md:w ~MXCSR                      | SSEMXCSR
+NP 0F AE /4 mem | XSAVE64     | Mxs:w ~EDX ~EAX                  | XSAVE w1 o64
+NP 0F AE /4 mem | XSAVE       | Mxs:w ~EDX ~EAX                  | XSAVE
+NP 0F AE /5 mem | XRSTOR64    | Mxs ~EDX ~EAX                    | XSAVE w1 o64
+NP 0F AE /5 mem | XRSTOR      | Mxs ~EDX ~EAX                    | XSAVE
+NP 0F AE /6 mem | XSAVEOPT64  | Mxs:w ~EDX ~EAX                  | XSAVEOPT w1 o64
+NP 0F AE /6 mem | XSAVEOPT    | Mxs:w ~EDX ~EAX                  | XSAVEOPT
+NP 0F AE /7 mem | CLFLUSH     | Mb                               | CLFSH
+66 0F AE /6 mem | CLWB        | Mb                               | CLWB
+66 0F AE /7 mem | CLFLUSHOPT  | Mb                               | CLFLUSHOPT
+NP 0F AE /5 reg | LFENCE      |                                  | SSE2
+NP 0F AE /6 reg | MFENCE      |                                  | SSE2
+NP 0F AE /7 reg | SFENCE      |                                  | SSE
+F3 0F AE /0 reg | RDFSBASE    | Ry:w                             | RDWRFSGS o64
+F3 0F AE /1 reg | RDGSBASE    | Ry:w                             | RDWRFSGS o64
+F3 0F AE /2 reg | WRFSBASE    | Ry                               | RDWRFSGS o64
+F3 0F AE /3 reg | WRGSBASE    | Ry                               | RDWRFSGS o64
+0F AF /r        | IMUL        | Gv:rw Ev                         | I386
+0F B0 /r        | CMPXCHG     | Eb:rw Gb ~AL:rw                  | I486REAL lock
+0F B1 /r        | CMPXCHG     | Ev:rw Gv ~rAX:rw                 | I486REAL lock
+0F B2 /r mem    | LSS         | Gv:w Mp ~SS:w                    | I386
+0F B3 /r        | BTR         | Ev:rw Gv                         | I386 lock
+0F B4 /r mem    | LFS         | Gv:w Mp ~FS:w                    | I386
+0F B5 /r mem    | LGS         | Gv:w Mp ~GS:w                    | I386
+0F B6 /r        | MOVZX       | Gv:w Eb                          | I386
+0F B7 /r        | MOVZX       | Gv:w Ew                          | I386
+F3 0F B8 /r     | POPCNT      | Gv:w Ev                          | POPCNT
+0F B9 /r        | UD1         | Gv Ev                            | PPRO
+0F BA /4        | BT          | Ev Ib                            | I386
+0F BA /5        | BTS         | Ev:rw Ib                         | I386 lock
+0F BA /6        | BTR         | Ev:rw Ib                         | I386 lock
+0F BA /7        | BTC         | Ev:rw Ib                         | I386 lock
+0F BB /r        | BTC         | Ev:rw Gv                         | I386 lock
+F3 0F BC /r     | TZCNT       | Gv:w Ev                          | BMI1
+0F BC /r        | BSF         | Gv:cw Ev                         | I386
+F3 0F BD /r     | LZCNT       | Gv:w Ev                          | LZCNT
+0F BD /r        | BSR         | Gv:cw Ev                         | I386
+0F BE /r        | MOVSX       | Gv:w Eb                          | I386
+0F BF /r        | MOVSX       | Gv:w Ew                          | I386
+0F C0 /r        | XADD        | Eb:rw Gb:rw                      | I486REAL lock
+0F C1 /r        | XADD        | Ev:rw Gv:rw                      | I486REAL lock
+NP 0F C2 /r     | CMPPS       | Vps:rw Wps Ib                    | SSE
+66 0F C2 /r     | CMPPD       | Vpd:rw Wpd Ib                    | SSE2
+F3 0F C2 /r     | CMPSS       | Vss:rw Wss Ib                    | SSE
+F2 0F C2 /r     | CMPSD_XMM   | Vsd:rw Wsd Ib                    | SSE2
+NP 0F C3 /r mem | MOVNTI      | My:w Gy                          | SSE2
+NP 0F C4 /r mem | PINSRW      | Pq:rw Mw Ib                      | SSE
+NP 0F C4 /r reg | PINSRW      | Pq:rw Rd Ib                      | SSE
+66 0F C4 /r mem | PINSRW      | Vdq:rw Mw Ib                     | SSE2
+66 0F C4 /r reg | PINSRW      | Vdq:rw Rd Ib                     | SSE2
+NP 0F C5 /r reg | PEXTRW      | Gd:w Nq Ib                       | SSE
+66 0F C5 /r reg | PEXTRW      | Gd:w Udq Ib                      | SSE2
+NP 0F C6 /r     | SHUFPS      | Vps:rw Wps Ib                    | SSE
+66 0F C6 /r     | SHUFPD      | Vpd:rw Wpd Ib                    | SSE2
+0F C7 /1 mem    | CMPXCHG16B  | Mdq:rw ~RDX:rw ~RAX:rw ~RCX ~RBX | CMPXCHG16B lock w1 o64
+0F C7 /1 mem    | CMPXCHG8B   | Mq:rw ~EDX:rw ~EAX:rw ~ECX ~EBX  | PENTIUMREAL lock
+NP 0F C7 /3 mem | XRSTORS64   | Mxs ~EDX ~EAX                    | XSAVES w1 o64
+NP 0F C7 /3 mem | XRSTORS     | Mxs ~EDX ~EAX                    | XSAVES
+NP 0F C7 /4 mem | XSAVEC64    | Mxs:w ~EDX ~EAX                  | XSAVEC w1 o64
+NP 0F C7 /4 mem | XSAVEC      | Mxs:w ~EDX ~EAX                  | XSAVEC
+NP 0F C7 /5 mem | XSAVES64    | Mxs:w ~EDX ~EAX                  | XSAVES w1 o64
+NP 0F C7 /5 mem | XSAVES      | Mxs:w ~EDX ~EAX                  | XSAVES
+NP 0F C7 /6 mem | VMPTRLD     | Mq                               | VTX
+66 0F C7 /6 mem | VMCLEAR     | Mq                               | VTX
+F3 0F C7 /6 mem | VMXON       | Mq                               | VTX
+NP 0F C7 /7 mem | VMPTRST     | Mq:w                             | VTX
+0F C7 /6 reg    | RDRAND      | Rv:w                             | RDRAND
+F3 0F C7 /7 reg | RDPID       | Rn:w                             | RDPID
+0F C7 /7 reg    | RDSEED      | Rv:w                             | RDSEED
+0F C8+r         | BSWAP       | Zv:rw                            | I486REAL
+66 0F D0 /r     | ADDSUBPD    | Vpd:rw Wpd                       | SSE3
+F2 0F D0 /r     | ADDSUBPS    | Vps:rw Wps                       | SSE3
+NP 0F D1 /r     | PSRLW       | Pq:rw Qq                         | PENTIUMMMX
+66 0F D1 /r     | PSRLW       | Vdq:rw Wdq                       | SSE2
+NP 0F D2 /r     | PSRLD       | Pq:rw Qq                         | PENTIUMMMX
+66 0F D2 /r     | PSRLD       | Vdq:rw Wdq                       | SSE2
+NP 0F D3 /r     | PSRLQ       | Pq:rw Qq                         | PENTIUMMMX
+66 0F D3 /r     | PSRLQ       | Vdq:rw Wdq                       | SSE2
+NP 0F D4 /r     | PADDQ       | Pq:rw Qq                         | SSE2MMX
+66 0F D4 /r     | PADDQ       | Vdq:rw Wdq                       | SSE2
+NP 0F D5 /r     | PMULLW      | Pq:rw Qq                         | PENTIUMMMX
+66 0F D5 /r     | PMULLW      | Vdq:rw Wdq                       | SSE2
+66 0F D6 /r     | MOVQ        | Wq:w Vq                          | SSE2
+F3 0F D6 /r reg | MOVQ2DQ     | Vdq:w Nq                         | SSE2
+F2 0F D6 /r reg | MOVDQ2Q     | Pq:w Uq                          | SSE2
+NP 0F D7 /r reg | PMOVMSKB    | Gd:w Nq                          | SSE
+66 0F D7 /r reg | PMOVMSKB    | Gd:w Udq                         | SSE2
+NP 0F D8 /r     | PSUBUSB     | Pq:rw Qq                         | PENTIUMMMX
+66 0F D8 /r     | PSUBUSB     | Vdq:rw Wdq                       | SSE2
+NP 0F D9 /r     | PSUBUSW     | Pq:rw Qq                         | PENTIUMMMX
+66 0F D9 /r     | PSUBUSW     | Vdq:rw Wdq                       | SSE2
+NP 0F DA /r     | PMINUB      | Pq:rw Qq                         | SSE
+66 0F DA /r     | PMINUB      | Vdq:rw Wdq                       | SSE2
+NP 0F DB /r     | PAND        | Pq:rw Qq                         | PENTIUMMMX
+66 0F DB /r     | PAND        | Vdq:rw Wdq                       | SSE2
+NP 0F DC /r     | PADDUSB     | Pq:rw Qq                         | PENTIUMMMX
+66 0F DC /r     | PADDUSB     | Vdq:rw Wdq                       | SSE2
+NP 0F DD /r     | PADDUSW     | Pq:rw Qq                         | PENTIUMMMX
+66 0F DD /r     | PADDUSW     | Vdq:rw Wdq                       | SSE2
+NP 0F DE /r     | PMAXUB      | Pq:rw Qq                         | SSE
+66 0F DE /r     | PMAXUB      | Vdq:rw Wdq                       | SSE2
+NP 0F DF /r     | PANDN       | Pq:rw Qq                         | PENTIUMMMX
+66 0F DF /r     | PANDN       | Vdq:rw Wdq                       | SSE2
+NP 0F E0 /r     | PAVGB       | Pq:rw Qq                         | SSE
+66 0F E0 /r     | PAVGB       | Vdq:rw Wdq                       | SSE2
+NP 0F E1 /r     | PSRAW       | Pq:rw Qq                         | PENTIUMMMX
+66 0F E1 /r     | PSRAW       | Vdq:rw Wdq                       | SSE2
+NP 0F E2 /r     | PSRAD       | Pq:rw Qq                         | PENTIUMMMX
+66 0F E2 /r     | PSRAD       | Vdq:rw Wdq                       | SSE2
+NP 0F E3 /r     | PAVGW       | Pq:rw Qq                         | SSE
+66 0F E3 /r     | PAVGW       | Vdq:rw Wdq                       | SSE2
+NP 0F E4 /r     | PMULHUW     | Pq:rw Qq                         | SSE
+66 0F E4 /r     | PMULHUW     | Vdq:rw Wdq                       | SSE2
+NP 0F E5 /r     | PMULHW      | Pq:rw Qq                         | PENTIUMMMX
+66 0F E5 /r     | PMULHW      | Vdq:rw Wdq                       | SSE2
+66 0F E6 /r     | CVTTPD2DQ   | Vdq:w Wpd                        | SSE2
+F3 0F E6 /r     | CVTDQ2PD    | Vpd:w Wq                         | SSE2
+F2 0F E6 /r     | CVTPD2DQ    | Vdq:w Wpd                        | SSE2
+NP 0F E7 /r mem | MOVNTQ      | Mq:w Pq                          | SSE
+66 0F E7 /r mem | MOVNTDQ     | Mdq:w Vdq                        | SSE2
+NP 0F E8 /r     | PSUBSB      | Pq:rw Qq                         | PENTIUMMMX
+66 0F E8 /r     | PSUBSB      | Vdq:rw Wdq                       | SSE2
+NP 0F E9 /r     | PSUBSW      | Pq:rw Qq                         | PENTIUMMMX
+66 0F E9 /r     | PSUBSW      | Vdq:rw Wdq                       | SSE2
+NP 0F EA /r     | PMINSW      | Pq:rw Qq                         | SSE
+66 0F EA /r     | PMINSW      | Vdq:rw Wdq                       | SSE2
+NP 0F EB /r     | POR         | Pq:rw Qq                         | PENTIUMMMX
+66 0F EB /r     | POR         | Vdq:rw Wdq                       | SSE2
+NP 0F EC /r     | PADDSB      | Pq:rw Qq                         | PENTIUMMMX
+66 0F EC /r     | PADDSB      | Vdq:rw Wdq                       | SSE2
+NP 0F ED /r     | PADDSW      | Pq:rw Qq                         | PENTIUMMMX
+66 0F ED /r     | PADDSW      | Vdq:rw Wdq                       | SSE2
+NP 0F EE /r     | PMAXSW      | Pq:rw Qq                         | SSE
+66 0F EE /r     | PMAXSW      | Vdq:rw Wdq                       | SSE2
+NP 0F EF /r     | PXOR        | Pq:rw Qq                         | PENTIUMMMX
+66 0F EF /r     | PXOR        | Vdq:rw Wdq                       | SSE2
+F2 0F F0 /r mem | LDDQU       | Vdq:w Mdq                        | SSE3
+NP 0F F1 /r     | PSLLW       | Pq:rw Qq                         | PENTIUMMMX
+66 0F F1 /r     | PSLLW       | Vdq:rw Wdq                       | SSE2
+NP 0F F2 /r     | PSLLD       | Pq:rw Qq                         | PENTIUMMMX
+66 0F F2 /r     | PSLLD       | Vdq:rw Wdq                       | SSE2
+NP 0F F3 /r     | PSLLQ       | Pq:rw Qq                         | PENTIUMMMX
+66 0F F3 /r     | PSLLQ       | Vdq:rw Wdq                       | SSE2
+NP 0F F4 /r     | PMULUDQ     | Pq:rw Qq                         | SSE2MMX
+66 0F F4 /r     | PMULUDQ     | Vdq:rw Wdq                       | SSE2
+NP 0F F5 /r     | PMADDWD     | Pq:rw Qq                         | PENTIUMMMX
+66 0F F5 /r     | PMADDWD     | Vdq:rw Wdq                       | SSE2
+NP 0F F6 /r     | PSADBW      | Pq:rw Qq                         | SSE
+66 0F F6 /r     | PSADBW      | Vdq:rw Wdq                       | SSE2
+NP 0F F7 /r reg | MASKMOVQ    | Pq Nq ~DIq:w                     | SSE
+66 0F F7 /r reg | MASKMOVDQU  | Vdq Udq ~DIdq:w                  | SSE2
+NP 0F F8 /r     | PSUBB       | Pq:rw Qq                         | PENTIUMMMX
+66 0F F8 /r     | PSUBB       | Vdq:rw Wdq                       | SSE2
+NP 0F F9 /r     | PSUBW       | Pq:rw Qq                         | PENTIUMMMX
+66 0F F9 /r     | PSUBW       | Vdq:rw Wdq                       | SSE2
+NP 0F FA /r     | PSUBD       | Pq:rw Qq                         | PENTIUMMMX
+66 0F FA /r     | PSUBD       | Vdq:rw Wdq                       | SSE2
+NP 0F FB /r     | PSUBQ       | Pq:rw Qq                         | SSE2MMX
+66 0F FB /r     | PSUBQ       | Vdq:rw Wdq                       | SSE2
+NP 0F FC /r     | PADDB       | Pq:rw Qq                         | PENTIUMMMX
+66 0F FC /r     | PADDB       | Vdq:rw Wdq                       | SSE2
+NP 0F FD /r     | PADDW       | Pq:rw Qq                         | PENTIUMMMX
+66 0F FD /r     | PADDW       | Vdq:rw Wdq                       | SSE2
+NP 0F FE /r     | PADDD       | Pq:rw Qq                         | PENTIUMMMX
+66 0F FE /r     | PADDD       | Vdq:rw Wdq                       | SSE2
+0F FF /r        | UD0         | Gd Ed                            | PPRO
+`
+
+// threeByteTable is the maps after the 0F 38 and 0F 3A escape bytes.
+const threeByteTable = `
+NP 0F 38 00 /r     | PSHUFB          | Pq:rw Qq                     | SSSE3MMX
+66 0F 38 00 /r     | PSHUFB          | Vdq:rw Wdq                   | SSSE3
+NP 0F 38 01 /r     | PHADDW          | Pq:rw Qq                     | SSSE3MMX
+66 0F 38 01 /r     | PHADDW          | Vdq:rw Wdq                   | SSSE3
+NP 0F 38 02 /r     | PHADDD          | Pq:rw Qq                     | SSSE3MMX
+66 0F 38 02 /r     | PHADDD          | Vdq:rw Wdq                   | SSSE3
+NP 0F 38 03 /r     | PHADDSW         | Pq:rw Qq                     | SSSE3MMX
+66 0F 38 03 /r     | PHADDSW         | Vdq:rw Wdq                   | SSSE3
+NP 0F 38 04 /r     | PMADDUBSW       | Pq:rw Qq                     | SSSE3MMX
+66 0F 38 04 /r     | PMADDUBSW       | Vdq:rw Wdq                   | SSSE3
+NP 0F 38 05 /r     | PHSUBW          | Pq:rw Qq                     | SSSE3MMX
+66 0F 38 05 /r     | PHSUBW          | Vdq:rw Wdq                   | SSSE3
+NP 0F 38 06 /r     | PHSUBD          | Pq:rw Qq                     | SSSE3MMX
+66 0F 38 06 /r     | PHSUBD          | Vdq:rw Wdq                   | SSSE3
+NP 0F 38 07 /r     | PHSUBSW         | Pq:rw Qq                     | SSSE3MMX
+66 0F 38 07 /r     | PHSUBSW         | Vdq:rw Wdq                   | SSSE3
+NP 0F 38 08 /r     | PSIGNB          | Pq:rw Qq                     | SSSE3MMX
+66 0F 38 08 /r     | PSIGNB          | Vdq:rw Wdq                   | SSSE3
+NP 0F 38 09 /r     | PSIGNW          | Pq:rw Qq                     | SSSE3MMX
+66 0F 38 09 /r     | PSIGNW          | Vdq:rw Wdq                   | SSSE3
+NP 0F 38 0A /r     | PSIGND          | Pq:rw Qq                     | SSSE3MMX
+66 0F 38 0A /r     | PSIGND          | Vdq:rw Wdq                   | SSSE3
+NP 0F 38 0B /r     | PMULHRSW        | Pq:rw Qq                     | SSSE3MMX
+66 0F 38 0B /r     | PMULHRSW        | Vdq:rw Wdq                   | SSSE3
+NP 0F 38 1C /r     | PABSB           | Pq:w Qq                      | SSSE3MMX
+66 0F 38 1C /r     | PABSB           | Vdq:w Wdq                    | SSSE3
+NP 0F 38 1D /r     | PABSW           | Pq:w Qq                      | SSSE3MMX
+66 0F 38 1D /r     | PABSW           | Vdq:w Wdq                    | SSSE3
+NP 0F 38 1E /r     | PABSD           | Pq:w Qq                      | SSSE3MMX
+66 0F 38 1E /r     | PABSD           | Vdq:w Wdq                    | SSSE3
+66 0F 38 10 /r     | PBLENDVB        | Vdq:rw Wdq XMM0              | SSE4
+66 0F 38 14 /r     | BLENDVPS        | Vps:rw Wps XMM0              | SSE4
+66 0F 38 15 /r     | BLENDVPD        | Vpd:rw Wpd XMM0              | SSE4
+66 0F 38 17 /r     | PTEST           | Vdq Wdq                      | SSE4
+66 0F 38 20 /r     | PMOVSXBW        | Vdq:w Wq                     | SSE4
+66 0F 38 21 /r     | PMOVSXBD        | Vdq:w Wd                     | SSE4
+66 0F 38 22 /r     | PMOVSXBQ        | Vdq:w Ww                     | SSE4
+66 0F 38 23 /r     | PMOVSXWD        | Vdq:w Wq                     | SSE4
+66 0F 38 24 /r     | PMOVSXWQ        | Vdq:w Wd                     | SSE4
+66 0F 38 25 /r     | PMOVSXDQ        | Vdq:w Wq                     | SSE4
+66 0F 38 28 /r     | PMULDQ          | Vdq:rw Wdq                   | SSE4
+66 0F 38 29 /r     | PCMPEQQ         | Vdq:rw Wdq                   | SSE4
+66 0F 38 2A /r mem | MOVNTDQA        | Vdq:w Mdq                    | SSE4
+66 0F 38 2B /r     | PACKUSDW        | Vdq:rw Wdq                   | SSE4
+66 0F 38 30 /r     | PMOVZXBW        | Vdq:w Wq                     | SSE4
+66 0F 38 31 /r     | PMOVZXBD        | Vdq:w Wd                     | SSE4
+66 0F 38 32 /r     | PMOVZXBQ        | Vdq:w Ww                     | SSE4
+66 0F 38 33 /r     | PMOVZXWD        | Vdq:w Wq                     | SSE4
+66 0F 38 34 /r     | PMOVZXWQ        | Vdq:w Wd                     | SSE4
+66 0F 38 35 /r     | PMOVZXDQ        | Vdq:w Wq                     | SSE4
+66 0F 38 37 /r     | PCMPGTQ         | Vdq:rw Wdq                   | SSE42
+66 0F 38 38 /r     | PMINSB          | Vdq:rw Wdq                   | SSE4
+66 0F 38 39 /r     | PMINSD          | Vdq:rw Wdq                   | SSE4
+66 0F 38 3A /r     | PMINUW          | Vdq:rw Wdq                   | SSE4
+66 0F 38 3B /r     | PMINUD          | Vdq:rw Wdq                   | SSE4
+66 0F 38 3C /r     | PMAXSB          | Vdq:rw Wdq                   | SSE4
+66 0F 38 3D /r     | PMAXSD          | Vdq:rw Wdq                   | SSE4
+66 0F 38 3E /r     | PMAXUW          | Vdq:rw Wdq                   | SSE4
+66 0F 38 3F /r     | PMAXUD          | Vdq:rw Wdq                   | SSE4
+66 0F 38 40 /r     | PMULLD          | Vdq:rw Wdq                   | SSE4
+66 0F 38 41 /r     | PHMINPOSUW      | Vdq:w Wdq                    | SSE4
+66 0F 38 82 /r mem | INVPCID         | Gn Mdq                       | INVPCID
+NP 0F 38 C8 /r     | SHA1NEXTE       | Vdq:rw Wdq                   | SHA
+NP 0F 38 C9 /r     | SHA1MSG1        | Vdq:rw Wdq                   | SHA
+NP 0F 38 CA /r     | SHA1MSG2        | Vdq:rw Wdq                   | SHA
+NP 0F 38 CB /r     | SHA256RNDS2     | Vdq:rw Wdq XMM0              | SHA
+NP 0F 38 CC /r     | SHA256MSG1      | Vdq:rw Wdq                   | SHA
+NP 0F 38 CD /r     | SHA256MSG2      | Vdq:rw Wdq                   | SHA
+66 0F 38 DB /r     | AESIMC          | Vdq:w Wdq                    | AES
+66 0F 38 DC /r     | AESENC          | Vdq:rw Wdq                   | AES
+66 0F 38 DD /r     | AESENCLAST      | Vdq:rw Wdq                   | AES
+66 0F 38 DE /r     | AESDEC          | Vdq:rw Wdq                   | AES
+66 0F 38 DF /r     | AESDECLAST      | Vdq:rw Wdq                   | AES
+F2 0F 38 F0 /r     | CRC32           | Gy:rw Eb                     | SSE42
+0F 38 F0 /r mem    | MOVBE           | Gv:w Mv                      | MOVBE
+F2 0F 38 F1 /r     | CRC32           | Gy:rw Ev                     | SSE42
+0F 38 F1 /r mem    | MOVBE           | Mv:w Gv                      | MOVBE
+66 0F 38 F6 /r     | ADCX            | Gy:rw Ey                     | ADOX_ADCX
+F3 0F 38 F6 /r     | ADOX            | Gy:rw Ey                     | ADOX_ADCX
+NP 0F 3A 0F /r     | PALIGNR         | Pq:rw Qq Ib                  | SSSE3MMX
+66 0F 3A 0F /r     | PALIGNR         | Vdq:rw Wdq Ib                | SSSE3
+66 0F 3A 08 /r     | ROUNDPS         | Vps:w Wps Ib                 | SSE4
+66 0F 3A 09 /r     | ROUNDPD         | Vpd:w Wpd Ib                 | SSE4
+66 0F 3A 0A /r     | ROUNDSS         | Vss:rw Wss Ib                | SSE4
+66 0F 3A 0B /r     | ROUNDSD         | Vsd:rw Wsd Ib                | SSE4
+66 0F 3A 0C /r     | BLENDPS         | Vps:rw Wps Ib                | SSE4
+66 0F 3A 0D /r     | BLENDPD         | Vpd:rw Wpd Ib                | SSE4
+66 0F 3A 0E /r     | PBLENDW         | Vdq:rw Wdq Ib                | SSE4
+66 0F 3A 14 /r mem | PEXTRB          | Mb:w Vdq Ib                  | SSE4
+66 0F 3A 14 /r reg | PEXTRB          | Ry:w Vdq Ib                  | SSE4
+66 0F 3A 15 /r mem | PEXTRW          | Mw:w Vdq Ib                  | SSE4
+66 0F 3A 15 /r reg | PEXTRW_SSE4     | Ry:w Vdq Ib                  | SSE4
+66 0F 3A 16 /r     | PEXTRQ          | Eq:w Vdq Ib                  | SSE4 w1 o64
+66 0F 3A 16 /r     | PEXTRD          | Ed:w Vdq Ib                  | SSE4
+66 0F 3A 17 /r     | EXTRACTPS       | Ed:w Vdq Ib                  | SSE4
+66 0F 3A 20 /r mem | PINSRB          | Vdq:rw Mb Ib                 | SSE4
+66 0F 3A 20 /r reg | PINSRB          | Vdq:rw Rd Ib                 | SSE4
+66 0F 3A 21 /r mem | INSERTPS        | Vdq:rw Md Ib                 | SSE4
+66 0F 3A 21 /r reg | INSERTPS        | Vdq:rw Udq Ib                | SSE4
+66 0F 3A 22 /r     | PINSRQ          | Vdq:rw Eq Ib                 | SSE4 w1 o64
+66 0F 3A 22 /r     | PINSRD          | Vdq:rw Ed Ib                 | SSE4
+66 0F 3A 40 /r     | DPPS            | Vps:rw Wps Ib                | SSE4
+66 0F 3A 41 /r     | DPPD            | Vpd:rw Wpd Ib                | SSE4
+66 0F 3A 42 /r     | MPSADBW         | Vdq:rw Wdq Ib                | SSE4
+66 0F 3A 44 /r     | PCLMULQDQ       | Vdq:rw Wdq Ib                | PCLMULQDQ
+66 0F 3A 60 /r     | PCMPESTRM       | Vdq Wdq Ib ~XMM0:w ~EAX ~EDX | SSE42
+66 0F 3A 61 /r     | PCMPESTRI       | Vdq Wdq Ib ~ECX:w ~EAX ~EDX  | SSE42
+66 0F 3A 62 /r     | PCMPISTRM       | Vdq Wdq Ib ~XMM0:w           | SSE42
+66 0F 3A 63 /r     | PCMPISTRI       | Vdq Wdq Ib ~ECX:w            | SSE42
+NP 0F 3A CC /r     | SHA1RNDS4       | Vdq:rw Wdq Ib                | SHA
+66 0F 3A DF /r     | AESKEYGENASSIST | Vdq:w Wdq Ib                 | AES
+`
+
+// x87Table is the x87 escape opcodes D8 to DF.
+const x87Table = `
+D8 /0 mem | FADD     | ST0:rw Md     | X87
+D8 /1 mem | FMUL     | ST0:rw Md     | X87
+D8 /2 mem | FCOM     | ST0 Md        | X87
+D8 /3 mem | FCOMP    | ST0 Md        | X87
+D8 /4 mem | FSUB     | ST0:rw Md     | X87
+D8 /5 mem | FSUBR    | ST0:rw Md     | X87
+D8 /6 mem | FDIV     | ST0:rw Md     | X87
+D8 /7 mem | FDIVR    | ST0:rw Md     | X87
+DC /0 mem | FADD     | ST0:rw Mq     | X87
+DC /1 mem | FMUL     | ST0:rw Mq     | X87
+DC /2 mem | FCOM     | ST0 Mq        | X87
+DC /3 mem | FCOMP    | ST0 Mq        | X87
+DC /4 mem | FSUB     | ST0:rw Mq     | X87
+DC /5 mem | FSUBR    | ST0:rw Mq     | X87
+DC /6 mem | FDIV     | ST0:rw Mq     | X87
+DC /7 mem | FDIVR    | ST0:rw Mq     | X87
+DA /0 mem | FIADD    | ST0:rw Md     | X87
+DA /1 mem | FIMUL    | ST0:rw Md     | X87
+DA /2 mem | FICOM    | ST0 Md        | X87
+DA /3 mem | FICOMP   | ST0 Md        | X87
+DA /4 mem | FISUB    | ST0:rw Md     | X87
+DA /5 mem | FISUBR   | ST0:rw Md     | X87
+DA /6 mem | FIDIV    | ST0:rw Md     | X87
+DA /7 mem | FIDIVR   | ST0:rw Md     | X87
+DE /0 mem | FIADD    | ST0:rw Mw     | X87
+DE /1 mem | FIMUL    | ST0:rw Mw     | X87
+DE /2 mem | FICOM    | ST0 Mw        | X87
+DE /3 mem | FICOMP   | ST0 Mw        | X87
+DE /4 mem | FISUB    | ST0:rw Mw     | X87
+DE /5 mem | FISUBR   | ST0:rw Mw     | X87
+DE /6 mem | FIDIV    | ST0:rw Mw     | X87
+DE /7 mem | FIDIVR   | ST0:rw Mw     | X87
+D8 /0 reg | FADD     | ST0:rw STi    | X87
+D8 /1 reg | FMUL     | ST0:rw STi    | X87
+D8 /2 reg | FCOM     | ST0 STi       | X87
+D8 /3 reg | FCOMP    | ST0 STi       | X87
+D8 /4 reg | FSUB     | ST0:rw STi    | X87
+D8 /5 reg | FSUBR    | ST0:rw STi    | X87
+D8 /6 reg | FDIV     | ST0:rw STi    | X87
+D8 /7 reg | FDIVR    | ST0:rw STi    | X87
+D9 /0 mem | FLD      | ST0:w Md      | X87
+D9 /2 mem | FST      | Md:w ST0      | X87
+D9 /3 mem | FSTP     | Md:w ST0      | X87
+D9 /4 mem | FLDENV   | Me            | X87
+D9 /5 mem | FLDCW    | Mw            | X87
+D9 /6 mem | FNSTENV  | Me:w          | X87
+D9 /7 mem | FNSTCW   | Mw:w          | X87
+D9 /0 reg | FLD      | ST0:w STi     | X87
+D9 /1 reg | FXCH     | ST0:rw STi:rw | X87
+D9 :D0    | FNOP     |               | X87
+D9 :E0    | FCHS     | ST0:rw        | X87
+D9 :E1    | FABS     | ST0:rw        | X87
+D9 :E4    | FTST     | ST0           | X87
+D9 :E5    | FXAM     | ST0           | X87
+D9 :E8    | FLD1     | ST0:w         | X87
+D9 :E9    | FLDL2T   | ST0:w         | X87
+D9 :EA    | FLDL2E   | ST0:w         | X87
+D9 :EB    | FLDPI    | ST0:w         | X87
+D9 :EC    | FLDLG2   | ST0:w         | X87
+D9 :ED    | FLDLN2   | ST0:w         | X87
+D9 :EE    | FLDZ     | ST0:w         | X87
+D9 :F0    | F2XM1    | ST0:rw        | X87
+D9 :F1    | FYL2X    | ST0 ST1:rw    | X87
+D9 :F2    | FPTAN    | ST0:rw ST1:w  | X87
+D9 :F3    | FPATAN   | ST0 ST1:rw    | X87
+D9 :F4    | FXTRACT  | ST0:rw ST1:w  | X87
+D9 :F5    | FPREM1   | ST0:rw ST1    | X87
+D9 :F6    | FDECSTP  |               | X87
+D9 :F7    | FINCSTP  |               | X87
+D9 :F8    | FPREM    | ST0:rw ST1    | X87
+D9 :F9    | FYL2XP1  | ST0 ST1:rw    | X87
+D9 :FA    | FSQRT    | ST0:rw        | X87
+D9 :FB    | FSINCOS  | ST0:rw ST1:w  | X87
+D9 :FC    | FRNDINT  | ST0:rw        | X87
+D9 :FD    | FSCALE   | ST0:rw ST1    | X87
+D9 :FE    | FSIN     | ST0:rw        | X87
+D9 :FF    | FCOS     | ST0:rw        | X87
+DA /0 reg | FCMOVB   | ST0:cw STi    | FCMOV
+DA /1 reg | FCMOVE   | ST0:cw STi    | FCMOV
+DA /2 reg | FCMOVBE  | ST0:cw STi    | FCMOV
+DA /3 reg | FCMOVU   | ST0:cw STi    | FCMOV
+DA :E9    | FUCOMPP  | ST0 ST1       | X87
+DB /0 mem | FILD     | ST0:w Md      | X87
+DB /1 mem | FISTTP   | Md:w ST0      | SSE3X87
+DB /2 mem | FIST     | Md:w ST0      | X87
+DB /3 mem | FISTP    | Md:w ST0      | X87
+DB /5 mem | FLD      | ST0:w Mt      | X87
+DB /7 mem | FSTP     | Mt:w ST0      | X87
+DB /0 reg | FCMOVNB  | ST0:cw STi    | FCMOV
+DB /1 reg | FCMOVNE  | ST0:cw STi    | FCMOV
+DB /2 reg | FCMOVNBE | ST0:cw STi    | FCMOV
+DB /3 reg | FCMOVNU  | ST0:cw STi    | FCMOV
+DB :E2    | FNCLEX   |               | X87
+DB :E3    | FNINIT   |               | X87
+DB /5 reg | FUCOMI   | ST0 STi       | FCMOV
+DB /6 reg | FCOMI    | ST0 STi       | FCMOV
+DC /0 reg | FADD     | STi:rw ST0    | X87
+DC /1 reg | FMUL     | STi:rw ST0    | X87
+DC /4 reg | FSUBR    | STi:rw ST0    | X87
+DC /5 reg | FSUB     | STi:rw ST0    | X87
+DC /6 reg | FDIVR    | STi:rw ST0    | X87
+DC /7 reg | FDIV     | STi:rw ST0    | X87
+DD /0 mem | FLD      | ST0:w Mq      | X87
+DD /1 mem | FISTTP   | Mq:w ST0      | SSE3X87
+DD /2 mem | FST      | Mq:w ST0      | X87
+DD /3 mem | FSTP     | Mq:w ST0      | X87
+DD /4 mem | FRSTOR   | Mf            | X87
+DD /6 mem | FNSAVE   | Mf:w          | X87
+DD /7 mem | FNSTSW   | Mw:w          | X87
+DD /0 reg | FFREE    | STi           | X87
+DD /2 reg | FST      | STi:w ST0     | X87
+DD /3 reg | FSTP     | STi:w ST0     | X87
+DD /4 reg | FUCOM    | ST0 STi       | X87
+DD /5 reg | FUCOMP   | ST0 STi       | X87
+DE /0 reg | FADDP    | STi:rw ST0    | X87
+DE /1 reg | FMULP    | STi:rw ST0    | X87
+DE /4 reg | FSUBRP   | STi:rw ST0    | X87
+DE /5 reg | FSUBP    | STi:rw ST0    | X87
+DE /6 reg | FDIVRP   | STi:rw ST0    | X87
+DE /7 reg | FDIVP    | STi:rw ST0    | X87
+DE :D9    | FCOMPP   | ST0 ST1       | X87
+DF /0 mem | FILD     | ST0:w Mw      | X87
+DF /1 mem | FISTTP   | Mw:w ST0      | SSE3X87
+DF /2 mem | FIST     | Mw:w ST0      | X87
+DF /3 mem | FISTP    | Mw:w ST0      | X87
+DF /4 mem | FBLD     | ST0:w Mt      | X87
+DF /5 mem | FILD     | ST0:w Mq      | X87
+DF /6 mem | FBSTP    | Mt:w ST0      | X87
+DF /7 mem | FISTP    | Mq:w ST0      | X87
+DF /0 reg | FFREEP   | STi           | X87
+DF :E0    | FNSTSW   | AX:w          | X87
+DF /5 reg | FUCOMIP  | ST0 STi       | FCMOV
+DF /6 reg | FCOMIP   | ST0 STi       | FCMOV
+`
+
+// vexTable is the AVX, AVX2, FMA, F16C and BMI instructions.
+const vexTable = `
+VEX.LIG.F3.0F 10 /r mem      | VMOVSS           | Vdq:w Md                     | AVX
+VEX.LIG.F3.0F 10 /r reg      | VMOVSS           | Vdq:w Hdq Udq                | AVX
+VEX.LIG.F2.0F 10 /r mem      | VMOVSD           | Vdq:w Mq                     | AVX
+VEX.LIG.F2.0F 10 /r reg      | VMOVSD           | Vdq:w Hdq Udq                | AVX
+VEX.L.NP.0F 10 /r            | VMOVUPS          | Vx:w Wx                      | AVX
+VEX.L.66.0F 10 /r            | VMOVUPD          | Vx:w Wx                      | AVX
+VEX.LIG.F3.0F 11 /r mem      | VMOVSS           | Md:w Vdq                     | AVX
+VEX.LIG.F3.0F 11 /r reg      | VMOVSS           | Udq:w Hdq Vdq                | AVX
+VEX.LIG.F2.0F 11 /r mem      | VMOVSD           | Mq:w Vdq                     | AVX
+VEX.LIG.F2.0F 11 /r reg      | VMOVSD           | Udq:w Hdq Vdq                | AVX
+VEX.L.NP.0F 11 /r            | VMOVUPS          | Wx:w Vx                      | AVX
+VEX.L.66.0F 11 /r            | VMOVUPD          | Wx:w Vx                      | AVX
+VEX.128.NP.0F 12 /r mem      | VMOVLPS          | Vdq:w Hdq Mq                 | AVX
+VEX.128.NP.0F 12 /r reg      | VMOVHLPS         | Vdq:w Hdq Udq                | AVX
+VEX.128.66.0F 12 /r mem      | VMOVLPD          | Vdq:w Hdq Mq                 | AVX
+VEX.L.F3.0F 12 /r            | VMOVSLDUP        | Vx:w Wx                      | AVX
+VEX.128.F2.0F 12 /r          | VMOVDDUP         | Vdq:w Wq                     | AVX
+VEX.256.F2.0F 12 /r          | VMOVDDUP         | Vqq:w Wqq                    | AVX
+VEX.128.NP.0F 13 /r mem      | VMOVLPS          | Mq:w Vdq                     | AVX
+VEX.128.66.0F 13 /r mem      | VMOVLPD          | Mq:w Vdq                     | AVX
+VEX.L.NP.0F 14 /r            | VUNPCKLPS        | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F 14 /r            | VUNPCKLPD        | Vx:w Hx Wx                   | AVX
+VEX.L.NP.0F 15 /r            | VUNPCKHPS        | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F 15 /r            | VUNPCKHPD        | Vx:w Hx Wx                   | AVX
+VEX.128.NP.0F 16 /r mem      | VMOVHPS          | Vdq:w Hdq Mq                 | AVX
+VEX.128.NP.0F 16 /r reg      | VMOVLHPS         | Vdq:w Hdq Udq                | AVX
+VEX.128.66.0F 16 /r mem      | VMOVHPD          | Vdq:w Hdq Mq                 | AVX
+VEX.L.F3.0F 16 /r            | VMOVSHDUP        | Vx:w Wx                      | AVX
+VEX.128.NP.0F 17 /r mem      | VMOVHPS          | Mq:w Vdq                     | AVX
+VEX.128.66.0F 17 /r mem      | VMOVHPD          | Mq:w Vdq                     | AVX
+VEX.L.NP.0F 28 /r            | VMOVAPS          | Vx:w Wx                      | AVX
+VEX.L.66.0F 28 /r            | VMOVAPD          | Vx:w Wx                      | AVX
+VEX.L.NP.0F 29 /r            | VMOVAPS          | Wx:w Vx                      | AVX
+VEX.L.66.0F 29 /r            | VMOVAPD          | Wx:w Vx                      | AVX
+VEX.LIG.F3.0F 2A /r          | VCVTSI2SS        | Vdq:w Hdq Ey                 | AVX
+VEX.LIG.F2.0F 2A /r          | VCVTSI2SD        | Vdq:w Hdq Ey                 | AVX
+VEX.L.NP.0F 2B /r mem        | VMOVNTPS         | Mx:w Vx                      | AVX
+VEX.L.66.0F 2B /r mem        | VMOVNTPD         | Mx:w Vx                      | AVX
+VEX.LIG.F3.0F 2C /r          | VCVTTSS2SI       | Gy:w Wss                     | AVX
+VEX.LIG.F2.0F 2C /r          | VCVTTSD2SI       | Gy:w Wsd                     | AVX
+VEX.LIG.F3.0F 2D /r          | VCVTSS2SI        | Gy:w Wss                     | AVX
+VEX.LIG.F2.0F 2D /r          | VCVTSD2SI        | Gy:w Wsd                     | AVX
+VEX.LIG.NP.0F 2E /r          | VUCOMISS         | Vdq Wss                      | AVX
+VEX.LIG.66.0F 2E /r          | VUCOMISD         | Vdq Wsd                      | AVX
+VEX.LIG.NP.0F 2F /r          | VCOMISS          | Vdq Wss                      | AVX
+VEX.LIG.66.0F 2F /r          | VCOMISD          | Vdq Wsd                      | AVX
+VEX.L.NP.0F 50 /r reg        | VMOVMSKPS        | Gd:w Ux                      | AVX
+VEX.L.66.0F 50 /r reg        | VMOVMSKPD        | Gd:w Ux                      | AVX
+VEX.L.NP.0F 51 /r            | VSQRTPS          | Vx:w Wx                      | AVX
+VEX.L.66.0F 51 /r            | VSQRTPD          | Vx:w Wx                      | AVX
+VEX.LIG.F3.0F 51 /r          | VSQRTSS          | Vdq:w Hdq Wss                | AVX
+VEX.LIG.F2.0F 51 /r          | VSQRTSD          | Vdq:w Hdq Wsd                | AVX
+VEX.L.NP.0F 52 /r            | VRSQRTPS         | Vx:w Wx                      | AVX
+VEX.LIG.F3.0F 52 /r          | VRSQRTSS         | Vdq:w Hdq Wss                | AVX
+VEX.L.NP.0F 53 /r            | VRCPPS           | Vx:w Wx                      | AVX
+VEX.LIG.F3.0F 53 /r          | VRCPSS           | Vdq:w Hdq Wss                | AVX
+VEX.L.NP.0F 54 /r            | VANDPS           | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F 54 /r            | VANDPD           | Vx:w Hx Wx                   | AVX
+VEX.L.NP.0F 55 /r            | VANDNPS          | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F 55 /r            | VANDNPD          | Vx:w Hx Wx                   | AVX
+VEX.L.NP.0F 56 /r            | VORPS            | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F 56 /r            | VORPD            | Vx:w Hx Wx                   | AVX
+VEX.L.NP.0F 57 /r            | VXORPS           | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F 57 /r            | VXORPD           | Vx:w Hx Wx                   | AVX
+VEX.L.NP.0F 58 /r            | VADDPS           | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F 58 /r            | VADDPD           | Vx:w Hx Wx                   | AVX
+VEX.LIG.F3.0F 58 /r          | VADDSS           | Vdq:w Hdq Wss                | AVX
+VEX.LIG.F2.0F 58 /r          | VADDSD           | Vdq:w Hdq Wsd                | AVX
+VEX.L.NP.0F 59 /r            | VMULPS           | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F 59 /r            | VMULPD           | Vx:w Hx Wx                   | AVX
+VEX.LIG.F3.0F 59 /r          | VMULSS           | Vdq:w Hdq Wss                | AVX
+VEX.LIG.F2.0F 59 /r          | VMULSD           | Vdq:w Hdq Wsd                | AVX
+VEX.L.NP.0F 5A /r            | VCVTPS2PD        | Vx:w Wh                      | AVX
+VEX.L.66.0F 5A /r            | VCVTPD2PS        | Vdq:w Wx                     | AVX
+VEX.LIG.F3.0F 5A /r          | VCVTSS2SD        | Vdq:w Hdq Wss                | AVX
+VEX.LIG.F2.0F 5A /r          | VCVTSD2SS        | Vdq:w Hdq Wsd                | AVX
+VEX.L.NP.0F 5B /r            | VCVTDQ2PS        | Vx:w Wx                      | AVX
+VEX.L.66.0F 5B /r            | VCVTPS2DQ        | Vx:w Wx                      | AVX
+VEX.L.F3.0F 5B /r            | VCVTTPS2DQ       | Vx:w Wx                      | AVX
+VEX.L.NP.0F 5C /r            | VSUBPS           | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F 5C /r            | VSUBPD           | Vx:w Hx Wx                   | AVX
+VEX.LIG.F3.0F 5C /r          | VSUBSS           | Vdq:w Hdq Wss                | AVX
+VEX.LIG.F2.0F 5C /r          | VSUBSD           | Vdq:w Hdq Wsd                | AVX
+VEX.L.NP.0F 5D /r            | VMINPS           | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F 5D /r            | VMINPD           | Vx:w Hx Wx                   | AVX
+VEX.LIG.F3.0F 5D /r          | VMINSS           | Vdq:w Hdq Wss                | AVX
+VEX.LIG.F2.0F 5D /r          | VMINSD           | Vdq:w Hdq Wsd                | AVX
+VEX.L.NP.0F 5E /r            | VDIVPS           | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F 5E /r            | VDIVPD           | Vx:w Hx Wx                   | AVX
+VEX.LIG.F3.0F 5E /r          | VDIVSS           | Vdq:w Hdq Wss                | AVX
+VEX.LIG.F2.0F 5E /r          | VDIVSD           | Vdq:w Hdq Wsd                | AVX
+VEX.L.NP.0F 5F /r            | VMAXPS           | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F 5F /r            | VMAXPD           | Vx:w Hx Wx                   | AVX
+VEX.LIG.F3.0F 5F /r          | VMAXSS           | Vdq:w Hdq Wss                | AVX
+VEX.LIG.F2.0F 5F /r          | VMAXSD           | Vdq:w Hdq Wsd                | AVX
+VEX.L.66.0F 60 /r            | VPUNPCKLBW       | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 61 /r            | VPUNPCKLWD       | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 62 /r            | VPUNPCKLDQ       | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 63 /r            | VPACKSSWB        | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 64 /r            | VPCMPGTB         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 65 /r            | VPCMPGTW         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 66 /r            | VPCMPGTD         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 67 /r            | VPACKUSWB        | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 68 /r            | VPUNPCKHBW       | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 69 /r            | VPUNPCKHWD       | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 6A /r            | VPUNPCKHDQ       | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 6B /r            | VPACKSSDW        | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 6C /r            | VPUNPCKLQDQ      | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 6D /r            | VPUNPCKHQDQ      | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 74 /r            | VPCMPEQB         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 75 /r            | VPCMPEQW         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F 76 /r            | VPCMPEQD         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.128.66.0F 6E /r          | VMOVQ            | Vdq:w Eq                     | AVX w1 o64
+VEX.128.66.0F 6E /r          | VMOVD            | Vdq:w Ed                     | AVX
+VEX.L.66.0F 6F /r            | VMOVDQA          | Vx:w Wx                      | AVX
+VEX.L.F3.0F 6F /r            | VMOVDQU          | Vx:w Wx                      | AVX
+VEX.L.66.0F 70 /r            | VPSHUFD          | Vx:w Wx Ib                   | AVX/AVX2
+VEX.L.F3.0F 70 /r            | VPSHUFHW         | Vx:w Wx Ib                   | AVX/AVX2
+VEX.L.F2.0F 70 /r            | VPSHUFLW         | Vx:w Wx Ib                   | AVX/AVX2
+VEX.L.66.0F 71 /2 reg        | VPSRLW           | Hx:w Ux Ib                   | AVX/AVX2
+VEX.L.66.0F 71 /4 reg        | VPSRAW           | Hx:w Ux Ib                   | AVX/AVX2
+VEX.L.66.0F 71 /6 reg        | VPSLLW           | Hx:w Ux Ib                   | AVX/AVX2
+VEX.L.66.0F 72 /2 reg        | VPSRLD           | Hx:w Ux Ib                   | AVX/AVX2
+VEX.L.66.0F 72 /4 reg        | VPSRAD           | Hx:w Ux Ib                   | AVX/AVX2
+VEX.L.66.0F 72 /6 reg        | VPSLLD           | Hx:w Ux Ib                   | AVX/AVX2
+VEX.L.66.0F 73 /2 reg        | VPSRLQ           | Hx:w Ux Ib                   | AVX/AVX2
+VEX.L.66.0F 73 /3 reg        | VPSRLDQ          | Hx:w Ux Ib                   | AVX/AVX2
+VEX.L.66.0F 73 /6 reg        | VPSLLQ           | Hx:w Ux Ib                   | AVX/AVX2
+VEX.L.66.0F 73 /7 reg        | VPSLLDQ          | Hx:w Ux Ib                   | AVX/AVX2
+VEX.128.NP.0F 77             | VZEROUPPER       |                              | AVX
+VEX.256.NP.0F 77             | VZEROALL         |                              | AVX
+VEX.L.66.0F 7C /r            | VHADDPD          | Vx:w Hx Wx                   | AVX
+VEX.L.F2.0F 7C /r            | VHADDPS          | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F 7D /r            | VHSUBPD          | Vx:w Hx Wx                   | AVX
+VEX.L.F2.0F 7D /r            | VHSUBPS          | Vx:w Hx Wx                   | AVX
+VEX.128.66.0F 7E /r          | VMOVQ            | Eq:w Vdq                     | AVX w1 o64
+VEX.128.66.0F 7E /r          | VMOVD            | Ed:w Vdq                     | AVX
+VEX.128.F3.0F 7E /r          | VMOVQ            | Vdq:w Wq                     | AVX
+VEX.L.66.0F 7F /r            | VMOVDQA          | Wx:w Vx                      | AVX
+VEX.L.F3.0F 7F /r            | VMOVDQU          | Wx:w Vx                      | AVX
+VEX.LZ.NP.0F AE /2 mem       | VLDMXCSR         | Md ~MXCSR:w                  | AVX
+VEX.LZ.NP.0F AE /3 mem       | VSTMXCSR         | Md:w ~MXCSR                  | AVX
+VEX.L.NP.0F C2 /r            | VCMPPS           | Vx:w Hx Wx Ib                | AVX
+VEX.L.66.0F C2 /r            | VCMPPD           | Vx:w Hx Wx Ib                | AVX
+VEX.LIG.F3.0F C2 /r          | VCMPSS           | Vdq:w Hdq Wss Ib             | AVX
+VEX.LIG.F2.0F C2 /r          | VCMPSD           | Vdq:w Hdq Wsd Ib             | AVX
+VEX.128.66.0F C4 /r mem      | VPINSRW          | Vdq:w Hdq Mw Ib              | AVX
+VEX.128.66.0F C4 /r reg      | VPINSRW          | Vdq:w Hdq Rd Ib              | AVX
+VEX.128.66.0F C5 /r reg      | VPEXTRW          | Gd:w Udq Ib                  | AVX
+VEX.L.NP.0F C6 /r            | VSHUFPS          | Vx:w Hx Wx Ib                | AVX
+VEX.L.66.0F C6 /r            | VSHUFPD          | Vx:w Hx Wx Ib                | AVX
+VEX.L.66.0F D0 /r            | VADDSUBPD        | Vx:w Hx Wx                   | AVX
+VEX.L.F2.0F D0 /r            | VADDSUBPS        | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F D1 /r            | VPSRLW           | Vx:w Hx Wdq                  | AVX/AVX2
+VEX.L.66.0F D2 /r            | VPSRLD           | Vx:w Hx Wdq                  | AVX/AVX2
+VEX.L.66.0F D3 /r            | VPSRLQ           | Vx:w Hx Wdq                  | AVX/AVX2
+VEX.L.66.0F D4 /r            | VPADDQ           | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F D5 /r            | VPMULLW          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.128.66.0F D6 /r          | VMOVQ            | Wq:w Vdq                     | AVX
+VEX.L.66.0F D7 /r reg        | VPMOVMSKB        | Gd:w Ux                      | AVX/AVX2
+VEX.L.66.0F D8 /r            | VPSUBUSB         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F D9 /r            | VPSUBUSW         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F DA /r            | VPMINUB          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F DB /r            | VPAND            | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F DC /r            | VPADDUSB         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F DD /r            | VPADDUSW         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F DE /r            | VPMAXUB          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F DF /r            | VPANDN           | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F E0 /r            | VPAVGB           | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F E1 /r            | VPSRAW           | Vx:w Hx Wdq                  | AVX/AVX2
+VEX.L.66.0F E2 /r            | VPSRAD           | Vx:w Hx Wdq                  | AVX/AVX2
+VEX.L.66.0F E3 /r            | VPAVGW           | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F E4 /r            | VPMULHUW         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F E5 /r            | VPMULHW          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F E6 /r            | VCVTTPD2DQ       | Vdq:w Wx                     | AVX
+VEX.L.F3.0F E6 /r            | VCVTDQ2PD        | Vx:w Wh                      | AVX
+VEX.L.F2.0F E6 /r            | VCVTPD2DQ        | Vdq:w Wx                     | AVX
+VEX.L.66.0F E7 /r mem        | VMOVNTDQ         | Mx:w Vx                      | AVX
+VEX.L.66.0F E8 /r            | VPSUBSB          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F E9 /r            | VPSUBSW          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F EA /r            | VPMINSW          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F EB /r            | VPOR             | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F EC /r            | VPADDSB          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F ED /r            | VPADDSW          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F EE /r            | VPMAXSW          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F EF /r            | VPXOR            | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.F2.0F F0 /r mem        | VLDDQU           | Vx:w Mx                      | AVX
+VEX.L.66.0F F1 /r            | VPSLLW           | Vx:w Hx Wdq                  | AVX/AVX2
+VEX.L.66.0F F2 /r            | VPSLLD           | Vx:w Hx Wdq                  | AVX/AVX2
+VEX.L.66.0F F3 /r            | VPSLLQ           | Vx:w Hx Wdq                  | AVX/AVX2
+VEX.L.66.0F F4 /r            | VPMULUDQ         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F F5 /r            | VPMADDWD         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F F6 /r            | VPSADBW          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.128.66.0F F7 /r reg      | VMASKMOVDQU      | Vdq Udq ~DIdq:w              | AVX
+VEX.L.66.0F F8 /r            | VPSUBB           | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F F9 /r            | VPSUBW           | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F FA /r            | VPSUBD           | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F FB /r            | VPSUBQ           | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F FC /r            | VPADDB           | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F FD /r            | VPADDW           | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F FE /r            | VPADDD           | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 00 /r          | VPSHUFB          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 01 /r          | VPHADDW          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 02 /r          | VPHADDD          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 03 /r          | VPHADDSW         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 04 /r          | VPMADDUBSW       | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 05 /r          | VPHSUBW          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 06 /r          | VPHSUBD          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 07 /r          | VPHSUBSW         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 08 /r          | VPSIGNB          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 09 /r          | VPSIGNW          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 0A /r          | VPSIGND          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 0B /r          | VPMULHRSW        | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38.W0 0C /r       | VPERMILPS        | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F38.W0 0D /r       | VPERMILPD        | Vx:w Hx Wx                   | AVX
+VEX.L.66.0F38.W0 0E /r       | VTESTPS          | Vx Wx                        | AVX
+VEX.L.66.0F38.W0 0F /r       | VTESTPD          | Vx Wx                        | AVX
+VEX.L.66.0F38.W0 13 /r       | VCVTPH2PS        | Vx:w Wh                      | F16C
+VEX.256.66.0F38.W0 16 /r     | VPERMPS          | Vqq:w Hqq Wqq                | AVX2
+VEX.L.66.0F38 17 /r          | VPTEST           | Vx Wx                        | AVX
+VEX.L.66.0F38.W0 18 /r mem   | VBROADCASTSS     | Vx:w Md                      | AVX
+VEX.L.66.0F38.W0 18 /r reg   | VBROADCASTSS     | Vx:w Udq                     | AVX2
+VEX.256.66.0F38.W0 19 /r mem | VBROADCASTSD     | Vqq:w Mq                     | AVX
+VEX.256.66.0F38.W0 19 /r reg | VBROADCASTSD     | Vqq:w Udq                    | AVX2
+VEX.256.66.0F38.W0 1A /r mem | VBROADCASTF128   | Vqq:w Mdq                    | AVX
+VEX.L.66.0F38 1C /r          | VPABSB           | Vx:w Wx                      | AVX/AVX2
+VEX.L.66.0F38 1D /r          | VPABSW           | Vx:w Wx                      | AVX/AVX2
+VEX.L.66.0F38 1E /r          | VPABSD           | Vx:w Wx                      | AVX/AVX2
+VEX.L.66.0F38 20 /r          | VPMOVSXBW        | Vx:w Wh                      | AVX/AVX2
+VEX.L.66.0F38 21 /r          | VPMOVSXBD        | Vx:w Wqt                     | AVX/AVX2
+VEX.L.66.0F38 22 /r          | VPMOVSXBQ        | Vx:w Wot                     | AVX/AVX2
+VEX.L.66.0F38 23 /r          | VPMOVSXWD        | Vx:w Wh                      | AVX/AVX2
+VEX.L.66.0F38 24 /r          | VPMOVSXWQ        | Vx:w Wqt                     | AVX/AVX2
+VEX.L.66.0F38 25 /r          | VPMOVSXDQ        | Vx:w Wh                      | AVX/AVX2
+VEX.L.66.0F38 28 /r          | VPMULDQ          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 29 /r          | VPCMPEQQ         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 2A /r mem      | VMOVNTDQA        | Vx:w Mx                      | AVX/AVX2
+VEX.L.66.0F38 2B /r          | VPACKUSDW        | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38.W0 2C /r mem   | VMASKMOVPS       | Vx:w Hx Mx                   | AVX
+VEX.L.66.0F38.W0 2D /r mem   | VMASKMOVPD       | Vx:w Hx Mx                   | AVX
+VEX.L.66.0F38.W0 2E /r mem   | VMASKMOVPS       | Mx:w Hx Vx                   | AVX
+VEX.L.66.0F38.W0 2F /r mem   | VMASKMOVPD       | Mx:w Hx Vx                   | AVX
+VEX.L.66.0F38 30 /r          | VPMOVZXBW        | Vx:w Wh                      | AVX/AVX2
+VEX.L.66.0F38 31 /r          | VPMOVZXBD        | Vx:w Wqt                     | AVX/AVX2
+VEX.L.66.0F38 32 /r          | VPMOVZXBQ        | Vx:w Wot                     | AVX/AVX2
+VEX.L.66.0F38 33 /r          | VPMOVZXWD        | Vx:w Wh                      | AVX/AVX2
+VEX.L.66.0F38 34 /r          | VPMOVZXWQ        | Vx:w Wqt                     | AVX/AVX2
+VEX.L.66.0F38 35 /r          | VPMOVZXDQ        | Vx:w Wh                      | AVX/AVX2
+VEX.256.66.0F38.W0 36 /r     | VPERMD           | Vqq:w Hqq Wqq                | AVX2
+VEX.L.66.0F38 37 /r          | VPCMPGTQ         | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 38 /r          | VPMINSB          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 39 /r          | VPMINSD          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 3A /r          | VPMINUW          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 3B /r          | VPMINUD          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 3C /r          | VPMAXSB          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 3D /r          | VPMAXSD          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 3E /r          | VPMAXUW          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 3F /r          | VPMAXUD          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.L.66.0F38 40 /r          | VPMULLD          | Vx:w Hx Wx                   | AVX/AVX2
+VEX.128.66.0F38 41 /r        | VPHMINPOSUW      | Vdq:w Wdq                    | AVX
+VEX.L.66.0F38.W0 45 /r       | VPSRLVD          | Vx:w Hx Wx                   | AVX2
+VEX.L.66.0F38.W1 45 /r       | VPSRLVQ          | Vx:w Hx Wx                   | AVX2
+VEX.L.66.0F38.W0 46 /r       | VPSRAVD          | Vx:w Hx Wx                   | AVX2
+VEX.L.66.0F38.W0 47 /r       | VPSLLVD          | Vx:w Hx Wx                   | AVX2
+VEX.L.66.0F38.W1 47 /r       | VPSLLVQ          | Vx:w Hx Wx                   | AVX2
+VEX.L.66.0F38.W0 58 /r       | VPBROADCASTD     | Vx:w Wd                      | AVX2
+VEX.L.66.0F38.W0 59 /r       | VPBROADCASTQ     | Vx:w Wq                      | AVX2
+VEX.256.66.0F38.W0 5A /r mem | VBROADCASTI128   | Vqq:w Mdq                    | AVX2
+VEX.L.66.0F38.W0 78 /r       | VPBROADCASTB     | Vx:w Wb                      | AVX2
+VEX.L.66.0F38.W0 79 /r       | VPBROADCASTW     | Vx:w Ww                      | AVX2
+VEX.L.66.0F38.W0 8C /r mem   | VPMASKMOVD       | Vx:w Hx Mx                   | AVX2
+VEX.L.66.0F38.W1 8C /r mem   | VPMASKMOVQ       | Vx:w Hx Mx                   | AVX2
+VEX.L.66.0F38.W0 8E /r mem   | VPMASKMOVD       | Mx:w Hx Vx                   | AVX2
+VEX.L.66.0F38.W1 8E /r mem   | VPMASKMOVQ       | Mx:w Hx Vx                   | AVX2
+VEX.L.66.0F38.W0 90 /r mem   | VPGATHERDD       | Vx:crw Td Hx:rw              | AVX2GATHER
+VEX.L.66.0F38.W1 90 /r mem   | VPGATHERDQ       | Vx:crw Txq Hx:rw             | AVX2GATHER
+VEX.L.66.0F38.W0 91 /r mem   | VPGATHERQD       | Vdq:crw Td Hdq:rw            | AVX2GATHER
+VEX.L.66.0F38.W1 91 /r mem   | VPGATHERQQ       | Vx:crw Tq Hx:rw              | AVX2GATHER
+VEX.L.66.0F38.W0 92 /r mem   | VGATHERDPS       | Vx:crw Td Hx:rw              | AVX2GATHER
+VEX.L.66.0F38.W1 92 /r mem   | VGATHERDPD       | Vx:crw Txq Hx:rw             | AVX2GATHER
+VEX.L.66.0F38.W0 93 /r mem   | VGATHERQPS       | Vdq:crw Td Hdq:rw            | AVX2GATHER
+VEX.L.66.0F38.W1 93 /r mem   | VGATHERQPD       | Vx:crw Tq Hx:rw              | AVX2GATHER
+VEX.L.66.0F38.W0 96 /r       | VFMADDSUB132PS   | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 96 /r       | VFMADDSUB132PD   | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W0 97 /r       | VFMSUBADD132PS   | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 97 /r       | VFMSUBADD132PD   | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W0 98 /r       | VFMADD132PS      | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 98 /r       | VFMADD132PD      | Vx:rw Hx Wx                  | FMA
+VEX.LIG.66.0F38.W0 99 /r     | VFMADD132SS      | Vdq:rw Hdq Wss               | FMA
+VEX.LIG.66.0F38.W1 99 /r     | VFMADD132SD      | Vdq:rw Hdq Wsd               | FMA
+VEX.L.66.0F38.W0 9A /r       | VFMSUB132PS      | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 9A /r       | VFMSUB132PD      | Vx:rw Hx Wx                  | FMA
+VEX.LIG.66.0F38.W0 9B /r     | VFMSUB132SS      | Vdq:rw Hdq Wss               | FMA
+VEX.LIG.66.0F38.W1 9B /r     | VFMSUB132SD      | Vdq:rw Hdq Wsd               | FMA
+VEX.L.66.0F38.W0 9C /r       | VFNMADD132PS     | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 9C /r       | VFNMADD132PD     | Vx:rw Hx Wx                  | FMA
+VEX.LIG.66.0F38.W0 9D /r     | VFNMADD132SS     | Vdq:rw Hdq Wss               | FMA
+VEX.LIG.66.0F38.W1 9D /r     | VFNMADD132SD     | Vdq:rw Hdq Wsd               | FMA
+VEX.L.66.0F38.W0 9E /r       | VFNMSUB132PS     | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 9E /r       | VFNMSUB132PD     | Vx:rw Hx Wx                  | FMA
+VEX.LIG.66.0F38.W0 9F /r     | VFNMSUB132SS     | Vdq:rw Hdq Wss               | FMA
+VEX.LIG.66.0F38.W1 9F /r     | VFNMSUB132SD     | Vdq:rw Hdq Wsd               | FMA
+VEX.L.66.0F38.W0 A6 /r       | VFMADDSUB213PS   | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 A6 /r       | VFMADDSUB213PD   | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W0 A7 /r       | VFMSUBADD213PS   | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 A7 /r       | VFMSUBADD213PD   | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W0 A8 /r       | VFMADD213PS      | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 A8 /r       | VFMADD213PD      | Vx:rw Hx Wx                  | FMA
+VEX.LIG.66.0F38.W0 A9 /r     | VFMADD213SS      | Vdq:rw Hdq Wss               | FMA
+VEX.LIG.66.0F38.W1 A9 /r     | VFMADD213SD      | Vdq:rw Hdq Wsd               | FMA
+VEX.L.66.0F38.W0 AA /r       | VFMSUB213PS      | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 AA /r       | VFMSUB213PD      | Vx:rw Hx Wx                  | FMA
+VEX.LIG.66.0F38.W0 AB /r     | VFMSUB213SS      | Vdq:rw Hdq Wss               | FMA
+VEX.LIG.66.0F38.W1 AB /r     | VFMSUB213SD      | Vdq:rw Hdq Wsd               | FMA
+VEX.L.66.0F38.W0 AC /r       | VFNMADD213PS     | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 AC /r       | VFNMADD213PD     | Vx:rw Hx Wx                  | FMA
+VEX.LIG.66.0F38.W0 AD /r     | VFNMADD213SS     | Vdq:rw Hdq Wss               | FMA
+VEX.LIG.66.0F38.W1 AD /r     | VFNMADD213SD     | Vdq:rw Hdq Wsd               | FMA
+VEX.L.66.0F38.W0 AE /r       | VFNMSUB213PS     | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 AE /r       | VFNMSUB213PD     | Vx:rw Hx Wx                  | FMA
+VEX.LIG.66.0F38.W0 AF /r     | VFNMSUB213SS     | Vdq:rw Hdq Wss               | FMA
+VEX.LIG.66.0F38.W1 AF /r     | VFNMSUB213SD     | Vdq:rw Hdq Wsd               | FMA
+VEX.L.66.0F38.W0 B6 /r       | VFMADDSUB231PS   | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 B6 /r       | VFMADDSUB231PD   | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W0 B7 /r       | VFMSUBADD231PS   | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 B7 /r       | VFMSUBADD231PD   | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W0 B8 /r       | VFMADD231PS      | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 B8 /r       | VFMADD231PD      | Vx:rw Hx Wx                  | FMA
+VEX.LIG.66.0F38.W0 B9 /r     | VFMADD231SS      | Vdq:rw Hdq Wss               | FMA
+VEX.LIG.66.0F38.W1 B9 /r     | VFMADD231SD      | Vdq:rw Hdq Wsd               | FMA
+VEX.L.66.0F38.W0 BA /r       | VFMSUB231PS      | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 BA /r       | VFMSUB231PD      | Vx:rw Hx Wx                  | FMA
+VEX.LIG.66.0F38.W0 BB /r     | VFMSUB231SS      | Vdq:rw Hdq Wss               | FMA
+VEX.LIG.66.0F38.W1 BB /r     | VFMSUB231SD      | Vdq:rw Hdq Wsd               | FMA
+VEX.L.66.0F38.W0 BC /r       | VFNMADD231PS     | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 BC /r       | VFNMADD231PD     | Vx:rw Hx Wx                  | FMA
+VEX.LIG.66.0F38.W0 BD /r     | VFNMADD231SS     | Vdq:rw Hdq Wss               | FMA
+VEX.LIG.66.0F38.W1 BD /r     | VFNMADD231SD     | Vdq:rw Hdq Wsd               | FMA
+VEX.L.66.0F38.W0 BE /r       | VFNMSUB231PS     | Vx:rw Hx Wx                  | FMA
+VEX.L.66.0F38.W1 BE /r       | VFNMSUB231PD     | Vx:rw Hx Wx                  | FMA
+VEX.LIG.66.0F38.W0 BF /r     | VFNMSUB231SS     | Vdq:rw Hdq Wss               | FMA
+VEX.LIG.66.0F38.W1 BF /r     | VFNMSUB231SD     | Vdq:rw Hdq Wsd               | FMA
+VEX.128.66.0F38 DB /r        | VAESIMC          | Vdq:w Wdq                    | AVXAES
+VEX.128.66.0F38 DC /r        | VAESENC          | Vdq:w Hdq Wdq                | AVXAES
+VEX.128.66.0F38 DD /r        | VAESENCLAST      | Vdq:w Hdq Wdq                | AVXAES
+VEX.128.66.0F38 DE /r        | VAESDEC          | Vdq:w Hdq Wdq                | AVXAES
+VEX.128.66.0F38 DF /r        | VAESDECLAST      | Vdq:w Hdq Wdq                | AVXAES
+VEX.LZ.NP.0F38 F2 /r         | ANDN             | Gy:w By Ey                   | BMI1
+VEX.LZ.NP.0F38 F3 /1         | BLSR             | By:w Ey                      | BMI1
+VEX.LZ.NP.0F38 F3 /2         | BLSMSK           | By:w Ey                      | BMI1
+VEX.LZ.NP.0F38 F3 /3         | BLSI             | By:w Ey                      | BMI1
+VEX.LZ.NP.0F38 F5 /r         | BZHI             | Gy:w Ey By                   | BMI2
+VEX.LZ.F3.0F38 F5 /r         | PEXT             | Gy:w By Ey                   | BMI2
+VEX.LZ.F2.0F38 F5 /r         | PDEP             | Gy:w By Ey                   | BMI2
+VEX.LZ.F2.0F38 F6 /r         | MULX             | Gy:w By:w Ey ~rDX            | BMI2
+VEX.LZ.NP.0F38 F7 /r         | BEXTR            | Gy:w Ey By                   | BMI1
+VEX.LZ.66.0F38 F7 /r         | SHLX             | Gy:w Ey By                   | BMI2
+VEX.LZ.F3.0F38 F7 /r         | SARX             | Gy:w Ey By                   | BMI2
+VEX.LZ.F2.0F38 F7 /r         | SHRX             | Gy:w Ey By                   | BMI2
+VEX.256.66.0F3A.W1 00 /r     | VPERMQ           | Vqq:w Wqq Ib                 | AVX2
+VEX.256.66.0F3A.W1 01 /r     | VPERMPD          | Vqq:w Wqq Ib                 | AVX2
+VEX.L.66.0F3A.W0 02 /r       | VPBLENDD         | Vx:w Hx Wx Ib                | AVX2
+VEX.L.66.0F3A.W0 04 /r       | VPERMILPS        | Vx:w Wx Ib                   | AVX
+VEX.L.66.0F3A.W0 05 /r       | VPERMILPD        | Vx:w Wx Ib                   | AVX
+VEX.256.66.0F3A.W0 06 /r     | VPERM2F128       | Vqq:w Hqq Wqq Ib             | AVX
+VEX.L.66.0F3A 08 /r          | VROUNDPS         | Vx:w Wx Ib                   | AVX
+VEX.L.66.0F3A 09 /r          | VROUNDPD         | Vx:w Wx Ib                   | AVX
+VEX.LIG.66.0F3A 0A /r        | VROUNDSS         | Vdq:w Hdq Wss Ib             | AVX
+VEX.LIG.66.0F3A 0B /r        | VROUNDSD         | Vdq:w Hdq Wsd Ib             | AVX
+VEX.L.66.0F3A 0C /r          | VBLENDPS         | Vx:w Hx Wx Ib                | AVX
+VEX.L.66.0F3A 0D /r          | VBLENDPD         | Vx:w Hx Wx Ib                | AVX
+VEX.L.66.0F3A 0E /r          | VPBLENDW         | Vx:w Hx Wx Ib                | AVX/AVX2
+VEX.L.66.0F3A 0F /r          | VPALIGNR         | Vx:w Hx Wx Ib                | AVX/AVX2
+VEX.128.66.0F3A 14 /r mem    | VPEXTRB          | Mb:w Vdq Ib                  | AVX
+VEX.128.66.0F3A 14 /r reg    | VPEXTRB          | Ry:w Vdq Ib                  | AVX
+VEX.128.66.0F3A 15 /r mem    | VPEXTRW          | Mw:w Vdq Ib                  | AVX
+VEX.128.66.0F3A 15 /r reg    | VPEXTRW          | Ry:w Vdq Ib                  | AVX
+VEX.128.66.0F3A 16 /r        | VPEXTRQ          | Eq:w Vdq Ib                  | AVX w1 o64
+VEX.128.66.0F3A 16 /r        | VPEXTRD          | Ed:w Vdq Ib                  | AVX
+VEX.128.66.0F3A 17 /r        | VEXTRACTPS       | Ed:w Vdq Ib                  | AVX
+VEX.256.66.0F3A.W0 18 /r     | VINSERTF128      | Vqq:w Hqq Wdq Ib             | AVX
+VEX.256.66.0F3A.W0 19 /r     | VEXTRACTF128     | Wdq:w Vqq Ib                 | AVX
+VEX.L.66.0F3A.W0 1D /r       | VCVTPS2PH        | Wh:w Vx Ib                   | F16C
+VEX.128.66.0F3A 20 /r mem    | VPINSRB          | Vdq:w Hdq Mb Ib              | AVX
+VEX.128.66.0F3A 20 /r reg    | VPINSRB          | Vdq:w Hdq Rd Ib              | AVX
+VEX.128.66.0F3A 21 /r mem    | VINSERTPS        | Vdq:w Hdq Md Ib              | AVX
+VEX.128.66.0F3A 21 /r reg    | VINSERTPS        | Vdq:w Hdq Udq Ib             | AVX
+VEX.128.66.0F3A 22 /r        | VPINSRQ          | Vdq:w Hdq Eq Ib              | AVX w1 o64
+VEX.128.66.0F3A 22 /r        | VPINSRD          | Vdq:w Hdq Ed Ib              | AVX
+VEX.256.66.0F3A.W0 38 /r     | VINSERTI128      | Vqq:w Hqq Wdq Ib             | AVX2
+VEX.256.66.0F3A.W0 39 /r     | VEXTRACTI128     | Wdq:w Vqq Ib                 | AVX2
+VEX.L.66.0F3A 40 /r          | VDPPS            | Vx:w Hx Wx Ib                | AVX
+VEX.128.66.0F3A 41 /r        | VDPPD            | Vdq:w Hdq Wdq Ib             | AVX
+VEX.L.66.0F3A 42 /r          | VMPSADBW         | Vx:w Hx Wx Ib                | AVX/AVX2
+VEX.128.66.0F3A 44 /r        | VPCLMULQDQ       | Vdq:w Hdq Wdq Ib             | AVX
+VEX.256.66.0F3A.W0 46 /r     | VPERM2I128       | Vqq:w Hqq Wqq Ib             | AVX2
+VEX.L.66.0F3A.W0 4A /r       | VBLENDVPS        | Vx:w Hx Wx Lx                | AVX
+VEX.L.66.0F3A.W0 4B /r       | VBLENDVPD        | Vx:w Hx Wx Lx                | AVX
+VEX.L.66.0F3A.W0 4C /r       | VPBLENDVB        | Vx:w Hx Wx Lx                | AVX/AVX2
+VEX.128.66.0F3A 60 /r        | VPCMPESTRM       | Vdq Wdq Ib ~XMM0:w ~EAX ~EDX | AVX
+VEX.128.66.0F3A 61 /r        | VPCMPESTRI       | Vdq Wdq Ib ~ECX:w ~EAX ~EDX  | AVX
+VEX.128.66.0F3A 62 /r        | VPCMPISTRM       | Vdq Wdq Ib ~XMM0:w           | AVX
+VEX.128.66.0F3A 63 /r        | VPCMPISTRI       | Vdq Wdq Ib ~ECX:w            | AVX
+VEX.128.66.0F3A DF /r        | VAESKEYGENASSIST | Vdq:w Wdq Ib                 | AVXAES
+VEX.LZ.F2.0F3A F0 /r         | RORX             | Gy:w Ey Ib                   | BMI2
+`
@@ -1,3 +1,6 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
 package xed
 
 /*
@@ -2497,6 +2500,41 @@ const (
 	ICLASS_VZEROALL           IClass = C.XED_ICLASS_VZEROALL
 	ICLASS_VZEROUPPER         IClass = C.XED_ICLASS_VZEROUPPER
 	ICLASS_WBINVD             IClass = C.XED_ICLASS_WBINVD
+	ICLASS_WBNOINVD           IClass = C.XED_ICLASS_WBNOINVD
+	ICLASS_WRFSBASE           IClass = C.XED_ICLASS_WRFSBASE
+	ICLASS_WRGSBASE           IClass = C.XED_ICLASS_WRGSBASE
+	ICLASS_WRMSR              IClass = C.XED_ICLASS_WRMSR
+	ICLASS_WRPKRU             IClass = C.XED_ICLASS_WRPKRU
+	ICLASS_WRSSD              IClass = C.XED_ICLASS_WRSSD
+	ICLASS_WRSSQ              IClass = C.XED_ICLASS_WRSSQ
+	ICLASS_WRUSSD             IClass = C.XED_ICLASS_WRUSSD
+	ICLASS_WRUSSQ             IClass = C.XED_ICLASS_WRUSSQ
+	ICLASS_XABORT             IClass = C.XED_ICLASS_XABORT
+	ICLASS_XADD               IClass = C.XED_ICLASS_XADD
+	ICLASS_XADD_LOCK          IClass = C.XED_ICLASS_XADD_LOCK
+	ICLASS_XBEGIN             IClass = C.XED_ICLASS_XBEGIN
+	ICLASS_XCHG               IClass = C.XED_ICLASS_XCHG
+	ICLASS_XEND               IClass = C.XED_ICLASS_XEND
+	ICLASS_XGETBV             IClass = C.XED_ICLASS_XGETBV
+	ICLASS_XLAT               IClass = C.XED_ICLASS_XLAT
+	ICLASS_XOR                IClass = C.XED_ICLASS_XOR
+	ICLASS_XORPD              IClass = C.XED_ICLASS_XORPD
+	ICLASS_XORPS              IClass = C.XED_ICLASS_XORPS
+	ICLASS_XOR_LOCK           IClass = C.XED_ICLASS_XOR_LOCK
+	ICLASS_XRSTOR             IClass = C.XED_ICLASS_XRSTOR
+	ICLASS_XRSTOR64           IClass = C.XED_ICLASS_XRSTOR64
+	ICLASS_XRSTORS            IClass = C.XED_ICLASS_XRSTORS
+	ICLASS_XRSTORS64          IClass = C.XED_ICLASS_XRSTORS64
+	ICLASS_XSAVE              IClass = C.XED_ICLASS_XSAVE
+	ICLASS_XSAVE64            IClass = C.XED_ICLASS_XSAVE64
+	ICLASS_XSAVEC             IClass = C.XED_ICLASS_XSAVEC
+	ICLASS_XSAVEC64           IClass = C.XED_ICLASS_XSAVEC64
+	ICLASS_XSAVEOPT           IClass = C.XED_ICLASS_XSAVEOPT
+	ICLASS_XSAVEOPT64         IClass = C.XED_ICLASS_XSAVEOPT64
+	ICLASS_XSAVES             IClass = C.XED_ICLASS_XSAVES
+	ICLASS_XSAVES64           IClass = C.XED_ICLASS_XSAVES64
+	ICLASS_XSETBV             IClass = C.XED_ICLASS_XSETBV
+	ICLASS_XTEST              IClass = C.XED_ICLASS_XTEST
 	ICLASS_LAST               IClass = C.XED_ICLASS_LAST
 )
 
@@ -0,0 +1,180 @@
+//go:build !cgo || purego
+// +build !cgo purego
+
+package xed
+
+import "strings"
+
+// Without cgo or with the purego tag the package uses a decoder written in
+// Go instead of the XED library. It decodes the base, x87, MMX, SSE up to
+// SSE4.2, AVX, AVX2, FMA, F16C and BMI instructions in every machine mode
+// and formats them in Intel or AT&T syntax. The encoder, the CPUID, chip,
+// iform and flag information are only available with XED.
+
+type (
+	AddressWidth      int
+	Category          int
+	Error             int
+	Extension         int
+	IClass            int
+	ISASet            int
+	MachineMode       int
+	OperandAction     int
+	OperandMode       int
+	OperandVisibility int
+	OperandWidth      int
+	RegClass          int
+	Reg               int
+)
+
+type State struct {
+	mmode            MachineMode
+	stack_addr_width AddressWidth
+}
+
+var (
+	iclassByName = make(map[string]IClass)
+	isaSetByName = make(map[string]ISASet)
+	regByName    = make(map[string]Reg)
+)
+
+func init() {
+	for i, n := range iclassNames {
+		iclassByName[n] = IClass(i)
+	}
+	for i, n := range isaSetNames {
+		isaSetByName[n] = ISASet(i)
+	}
+	for i, n := range regNames {
+		regByName[n] = Reg(i)
+	}
+	initTables()
+	initInfo()
+}
+
+func enumString(names []string, i int) string {
+	if i < 0 || i >= len(names) {
+		return "INVALID"
+	}
+	return names[i]
+}
+
+func (c IClass) String() string {
+	return enumString(iclassNames[:], int(c))
+}
+
+func (c ISASet) String() string {
+	return enumString(isaSetNames[:], int(c))
+}
+
+func (a AddressWidth) String() string {
+	return enumString(addressWidthNames[:], int(a))
+}
+
+func (m MachineMode) String() string {
+	return enumString(machineModeNames[:], int(m))
+}
+
+func (r RegClass) String() string {
+	return enumString(regClassNames[:], int(r))
+}
+
+func (r Reg) String() string {
+	return enumString(regNames[:], int(r))
+}
+
+func (c Category) String() string {
+	return enumString(categoryNames[:], int(c))
+}
+
+func (c Extension) String() string {
+	return enumString(extensionNames[:], int(c))
+}
+
+func (c OperandAction) String() string {
+	return enumString(operandActionNames[:], int(c))
+}
+
+func (c OperandVisibility) String() string {
+	return enumString(opvisNames[:], int(c))
+}
+
+func (c OperandMode) String() string {
+	return enumString(operandNames[:], int(c))
+}
+
+func (e Error) Error() string {
+	return enumString(errorNames[:], int(e))
+}
+
+func (c *State) Zero() {
+	*c = State{}
+}
+
+func (c *State) SetMachineMode(mmode MachineMode) {
+	c.mmode = mmode
+}
+
+func (c *State) SetStackAddrWidth(stack_addr_width AddressWidth) {
+	c.stack_addr_width = stack_addr_width
+}
+
+func (c *State) MachineMode() MachineMode {
+	return c.mmode
+}
+
+func (c *State) StackAddrWidth() AddressWidth {
+	return c.stack_addr_width
+}
+
+func (w AddressWidth) Bits() int {
+	switch w {
+	case ADDRESS_WIDTH_64b:
+		return 64
+	case ADDRESS_WIDTH_32b:
+		return 32
+	case ADDRESS_WIDTH_16b:
+		return 16
+	}
+	return 0
+}
+
+func SignExtendArbitraryTo64(x uint64, bits uint) int64 {
+	if bits == 0 || bits >= 64 {
+		return int64(x)
+	}
+	s := 64 - bits
+	return int64(x<<s) >> s
+}
+
+func ZeroExtend16_32(x uint16) uint32 {
+	return uint32(x)
+}
+
+func ZeroExtend16_64(x uint16) uint64 {
+	return uint64(x)
+}
+
+func ZeroExtend32_64(x uint32) uint64 {
+	return uint64(x)
+}
+
+// RepRemove returns the iclass without its rep prefix.
+func RepRemove(x IClass) IClass {
+	n := x.String()
+	for _, p := range []string{"REP_", "REPE_", "REPNE_"} {
+		if strings.HasPrefix(n, p) {
+			if y, ok := iclassByName[n[len(p):]]; ok {
+				return y
+			}
+		}
+	}
+	return x
+}
+
+func xederror(e Error) error {
+	if e == ERROR_NONE {
+		return nil
+	}
+	return e
+}
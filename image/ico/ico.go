@@ -51,6 +51,7 @@ func Encode(w io.Writer, f *File) error {
 		direntLen = 16
 	)
 
+	var data []*bytes.Buffer
 	off := headerLen + direntLen*uint32(h.Entries)
 	for i, m := range f.Image {
 		p := new(bytes.Buffer)
@@ -63,14 +64,16 @@ func Encode(w io.Writer, f *File) error {
 			return fmt.Errorf("ico: image %d is too big", i)
 		}
 
+		// a dimension of 256 is stored as 0
 		r := m.Bounds()
-		if r.Dx() >= math.MaxUint8 || r.Dy() >= math.MaxUint8 {
+		if r.Dx() > 256 || r.Dy() > 256 {
 			return fmt.Errorf("ico: image %d with dimension %dx%d is too big", i, r.Dx(), r.Dy())
 		}
 
 		d := dirent{
 			Width:  uint8(r.Dx()),
 			Height: uint8(r.Dy()),
+			Planes: 1,
 			Bpp:    32,
 			Size:   uint32(p.Len()),
 			Off:    off,
@@ -81,6 +84,11 @@ func Encode(w io.Writer, f *File) error {
 			return fmt.Errorf("ico: too many images")
 		}
 		off += uint32(p.Len())
+		data = append(data, p)
+	}
+
+	for _, p := range data {
+		b.Write(p.Bytes())
 	}
 
 	return b.Flush()
@@ -149,10 +157,10 @@ func decodeBMP(n int, d *dirent, b []byte) (image.Image, error) {
 
 	b = append([]uint8{
 		'B', 'M',
-		uint8(sz), uint8(sz >> 8), uint8(sz >> 16), uint8(sz >> 32),
+		uint8(sz), uint8(sz >> 8), uint8(sz >> 16), uint8(sz >> 24),
 		0, 0,
 		0, 0,
-		uint8(off), uint8(off >> 8), uint8(off >> 16), uint8(off >> 32),
+		uint8(off), uint8(off >> 8), uint8(off >> 16), uint8(off >> 24),
 	}, b...)
 
 	return bmp.Decode(bytes.NewReader(b))
@@ -0,0 +1,637 @@
+package aiff
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/qeedquan/go-media/math/mathutil"
+	"github.com/qeedquan/go-media/snd"
+	"github.com/qeedquan/go-media/snd/wav"
+)
+
+const (
+	aifcVersion1 = 0xa2805140
+	maxChunkSize = 1 << 28
+)
+
+var ErrFormat = errors.New("aiff: invalid format")
+
+type Comm struct {
+	Channels        int16
+	SampleFrames    uint32
+	SampleSize      int16
+	SampleRate      float64
+	CompressionType [4]byte
+	CompressionName string
+}
+
+type Marker struct {
+	ID       int16
+	Position uint32
+	Name     string
+}
+
+type Loop struct {
+	PlayMode int16
+	Begin    int16
+	End      int16
+}
+
+type Instrument struct {
+	BaseNote     int8
+	Detune       int8
+	LowNote      int8
+	HighNote     int8
+	LowVelocity  int8
+	HighVelocity int8
+	Gain         int16
+	SustainLoop  Loop
+	ReleaseLoop  Loop
+}
+
+const (
+	NoLooping              = 0
+	ForwardLooping         = 1
+	ForwardBackwardLooping = 2
+)
+
+type decoder struct {
+	r       io.Reader
+	aifc    bool
+	comm    *Comm
+	markers []Marker
+	inst    *Instrument
+	f       *wav.File
+	data    []byte
+}
+
+func Decode(r io.Reader) (*wav.File, error) {
+	d := &decoder{r: r, f: &wav.File{}}
+	if err := d.decode(false); err != nil {
+		return nil, err
+	}
+	return d.f, nil
+}
+
+func DecodeConfig(r io.Reader) (wav.Format, error) {
+	d := &decoder{r: r, f: &wav.File{}}
+	if err := d.decode(true); err != nil {
+		return wav.Format{}, err
+	}
+	return d.f.Format, nil
+}
+
+func (d *decoder) decode(config bool) error {
+	var hdr [12]byte
+	if _, err := io.ReadFull(d.r, hdr[:]); err != nil {
+		return fmt.Errorf("aiff: failed to read form header: %v", err)
+	}
+	if string(hdr[:4]) != "FORM" {
+		return ErrFormat
+	}
+	switch string(hdr[8:]) {
+	case "AIFF":
+	case "AIFC":
+		d.aifc = true
+	default:
+		return ErrFormat
+	}
+
+	for {
+		var ch [8]byte
+		_, err := io.ReadFull(d.r, ch[:])
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// tolerate trailing garbage once the sound data was found
+			if d.data != nil {
+				break
+			}
+			return fmt.Errorf("aiff: failed to read chunk header: %v", err)
+		}
+
+		id := string(ch[:4])
+		size := int64(binary.BigEndian.Uint32(ch[4:]))
+		switch id {
+		case "SSND":
+			err = d.readSound(size, config)
+		case "COMM", "MARK", "INST", "NAME", "AUTH", "(c) ", "ANNO":
+			err = d.readChunk(id, size)
+			if err == nil && id == "COMM" && config {
+				return d.setFormat()
+			}
+		default:
+			if err = d.skip(size + size&1); err != nil {
+				err = fmt.Errorf("aiff: failed to skip %q chunk: %v", id, err)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.comm == nil {
+		return fmt.Errorf("aiff: missing COMM chunk")
+	}
+	if err := d.setFormat(); err != nil {
+		return err
+	}
+	if err := d.convert(); err != nil {
+		return err
+	}
+	d.setMeta()
+	return nil
+}
+
+func (d *decoder) skip(n int64) error {
+	_, err := io.CopyN(io.Discard, d.r, n)
+	return err
+}
+
+// skipPad skips the pad byte of an odd sized chunk, a missing pad byte at
+// the end of the file is tolerated.
+func (d *decoder) skipPad(size int64) {
+	if size&1 != 0 {
+		d.skip(1)
+	}
+}
+
+// readSound reads the SSND chunk through a limit instead of allocating the
+// size given in the header up front.
+func (d *decoder) readSound(size int64, config bool) error {
+	if config {
+		if err := d.skip(size + size&1); err != nil {
+			return fmt.Errorf("aiff: failed to skip SSND chunk: %v", err)
+		}
+		return nil
+	}
+	if size < 8 {
+		return fmt.Errorf("aiff: SSND chunk too short")
+	}
+	var hdr [8]byte
+	if _, err := io.ReadFull(d.r, hdr[:]); err != nil {
+		return fmt.Errorf("aiff: failed to read SSND chunk: %v", err)
+	}
+	off := int64(binary.BigEndian.Uint32(hdr[:]))
+	if off > size-8 {
+		return fmt.Errorf("aiff: SSND offset %d out of range", off)
+	}
+	if err := d.skip(off); err != nil {
+		return fmt.Errorf("aiff: failed to read SSND chunk: %v", err)
+	}
+
+	n := size - 8 - off
+	b, err := io.ReadAll(io.LimitReader(d.r, n))
+	if err == nil && int64(len(b)) < n {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return fmt.Errorf("aiff: failed to read SSND chunk: %v", err)
+	}
+	d.data = b
+	d.skipPad(size)
+	return nil
+}
+
+func (d *decoder) readChunk(id string, size int64) error {
+	if size > maxChunkSize {
+		return fmt.Errorf("aiff: %q chunk too large (%d bytes)", id, size)
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		return fmt.Errorf("aiff: failed to read %q chunk: %v", id, err)
+	}
+	d.skipPad(size)
+
+	var err error
+	switch id {
+	case "COMM":
+		err = d.decodeComm(b)
+	case "MARK":
+		err = d.decodeMark(b)
+	case "INST":
+		err = d.decodeInst(b)
+	case "NAME":
+		d.f.SetInfo("INAM", string(b))
+	case "AUTH":
+		d.f.SetInfo("IART", string(b))
+	case "(c) ":
+		d.f.SetInfo("ICOP", string(b))
+	case "ANNO":
+		d.f.SetInfo("ICMT", string(b))
+	}
+	return err
+}
+
+func (d *decoder) decodeComm(b []byte) error {
+	if len(b) < 18 {
+		return fmt.Errorf("aiff: COMM chunk too short")
+	}
+	be := binary.BigEndian
+	c := &Comm{
+		Channels:        int16(be.Uint16(b[0:])),
+		SampleFrames:    be.Uint32(b[2:]),
+		SampleSize:      int16(be.Uint16(b[6:])),
+		SampleRate:      ExtendedToFloat64(b[8:18]),
+		CompressionType: [4]byte{'N', 'O', 'N', 'E'},
+	}
+	if d.aifc {
+		if len(b) < 22 {
+			return fmt.Errorf("aiff: AIFC COMM chunk too short")
+		}
+		copy(c.CompressionType[:], b[18:22])
+		c.CompressionName = pstring(b[22:])
+	}
+	if c.Channels <= 0 {
+		return fmt.Errorf("aiff: invalid channel count %d", c.Channels)
+	}
+	if c.SampleSize <= 0 || c.SampleSize > 64 {
+		return fmt.Errorf("aiff: invalid sample size %d", c.SampleSize)
+	}
+	d.comm = c
+	return nil
+}
+
+func (d *decoder) decodeMark(b []byte) error {
+	if len(b) < 2 {
+		return fmt.Errorf("aiff: MARK chunk too short")
+	}
+	be := binary.BigEndian
+	n := int(be.Uint16(b))
+	b = b[2:]
+	for i := 0; i < n; i++ {
+		if len(b) < 7 {
+			return fmt.Errorf("aiff: MARK chunk truncated at marker %d", i)
+		}
+		m := Marker{
+			ID:       int16(be.Uint16(b)),
+			Position: be.Uint32(b[2:]),
+			Name:     pstring(b[6:]),
+		}
+		l := 1 + int(b[6])
+		b = b[mathutil.Min(len(b), 6+l+l&1):]
+		d.markers = append(d.markers, m)
+	}
+	return nil
+}
+
+func (d *decoder) decodeInst(b []byte) error {
+	if len(b) < 20 {
+		return fmt.Errorf("aiff: INST chunk too short")
+	}
+	be := binary.BigEndian
+	loop := func(p []byte) Loop {
+		return Loop{int16(be.Uint16(p)), int16(be.Uint16(p[2:])), int16(be.Uint16(p[4:]))}
+	}
+	d.inst = &Instrument{
+		BaseNote:     int8(b[0]),
+		Detune:       int8(b[1]),
+		LowNote:      int8(b[2]),
+		HighNote:     int8(b[3]),
+		LowVelocity:  int8(b[4]),
+		HighVelocity: int8(b[5]),
+		Gain:         int16(be.Uint16(b[6:])),
+		SustainLoop:  loop(b[8:]),
+		ReleaseLoop:  loop(b[14:]),
+	}
+	return nil
+}
+
+func (d *decoder) setFormat() error {
+	c := d.comm
+	ch := int(c.Channels)
+	rate := int(math.Round(c.SampleRate))
+	bits := int(c.SampleSize)
+
+	var ft wav.Format
+	switch string(c.CompressionType[:]) {
+	case "NONE", "twos", "sowt", "raw ", "in24", "in32":
+		ft = wav.NewPCMFormat(ch, rate, bits)
+	case "fl32", "FL32":
+		ft = wav.NewFloatFormat(ch, rate, 32)
+	case "fl64", "FL64":
+		ft = wav.NewFloatFormat(ch, rate, 64)
+	case "alaw", "ALAW":
+		ft = wav.NewALawFormat(ch, rate)
+	case "ulaw", "ULAW":
+		ft = wav.NewMuLawFormat(ch, rate)
+	case "ima4":
+		ft = wav.NewPCMFormat(ch, rate, 16)
+	default:
+		return fmt.Errorf("aiff: unsupported compression type %q (%s)", c.CompressionType, c.CompressionName)
+	}
+	d.f.Format = ft
+	return nil
+}
+
+func (d *decoder) convert() error {
+	c := d.comm
+	b := d.data
+	f := d.f
+
+	ct := string(c.CompressionType[:])
+	if ct == "sowt" && f.Format.SampleBytes() == 1 {
+		// byte order doesn't apply to 8-bit samples
+		ct = "twos"
+	}
+	switch ct {
+	case "ima4":
+		s, err := decodeIMA4(b, int(c.Channels))
+		if err != nil {
+			return err
+		}
+		if n := int(c.SampleFrames) * int(c.Channels); n < len(s) {
+			s = s[:n]
+		}
+		return f.SetSamples(s)
+
+	case "sowt", "alaw", "ALAW", "ulaw", "ULAW":
+		f.Data = append([]byte{}, b...)
+
+	case "raw ":
+		f.Data = append([]byte{}, b...)
+
+	default:
+		size := f.Format.SampleBytes()
+		f.Data = make([]byte, len(b)-len(b)%size)
+		for i := 0; i < len(f.Data); i += size {
+			for j := 0; j < size; j++ {
+				f.Data[i+j] = b[i+size-1-j]
+			}
+		}
+		if size == 1 {
+			for i := range f.Data {
+				f.Data[i] ^= 0x80
+			}
+		}
+	}
+
+	if n := int(c.SampleFrames) * int(f.Format.BlockAlign); n < len(f.Data) {
+		f.Data = f.Data[:n]
+	}
+	return nil
+}
+
+func (d *decoder) setMeta() {
+	f := d.f
+	pos := make(map[int16]uint32)
+	for _, m := range d.markers {
+		pos[m.ID] = m.Position
+		f.Cues = append(f.Cues, wav.CuePoint{
+			ID:           uint32(uint16(m.ID)),
+			Position:     m.Position,
+			ChunkID:      [4]byte{'d', 'a', 't', 'a'},
+			SampleOffset: m.Position,
+			Label:        m.Name,
+		})
+	}
+
+	i := d.inst
+	if i == nil {
+		return
+	}
+	s := &wav.Sampler{
+		MIDIUnityNote: uint32(uint8(i.BaseNote)),
+	}
+	if i.Detune > 0 {
+		s.MIDIPitchFraction = uint32(i.Detune) * (1 << 32 / 100)
+	}
+	if f.Format.SampleRate != 0 {
+		s.SamplePeriod = uint32(1e9 / float64(f.Format.SampleRate))
+	}
+	for _, l := range []Loop{i.SustainLoop, i.ReleaseLoop} {
+		if l.PlayMode == NoLooping {
+			continue
+		}
+		b, ok1 := pos[l.Begin]
+		e, ok2 := pos[l.End]
+		if !ok1 || !ok2 || e <= b {
+			continue
+		}
+		typ := uint32(wav.LOOP_FORWARD)
+		if l.PlayMode == ForwardBackwardLooping {
+			typ = wav.LOOP_PINGPONG
+		}
+		s.Loops = append(s.Loops, wav.SampleLoop{
+			CuePointID: uint32(uint16(l.Begin)),
+			Type:       typ,
+			Start:      b,
+			End:        e - 1,
+		})
+	}
+	f.Sampler = s
+}
+
+// Encode writes f as AIFF, or AIFC when the samples are floating point or
+// G.711 encoded. ADPCM data is decoded and written as 16-bit PCM.
+func Encode(f *wav.File, w io.Writer) error {
+	ft := f.Format
+	data := f.Data
+
+	var comp [4]byte
+	aifc := false
+	switch ft.Tag() {
+	case wav.FORMAT_PCM:
+	case wav.FORMAT_IEEE_FLOAT:
+		aifc = true
+		comp = [4]byte{'f', 'l', '3', '2'}
+		if ft.SampleBytes() == 8 {
+			comp = [4]byte{'f', 'l', '6', '4'}
+		}
+	case wav.FORMAT_ALAW:
+		aifc = true
+		comp = [4]byte{'a', 'l', 'a', 'w'}
+	case wav.FORMAT_MULAW:
+		aifc = true
+		comp = [4]byte{'u', 'l', 'a', 'w'}
+	case wav.FORMAT_IMA_ADPCM, wav.FORMAT_ADPCM:
+		s, err := f.Int16()
+		if err != nil {
+			return err
+		}
+		ft = wav.NewPCMFormat(int(ft.Channels), int(ft.SampleRate), 16)
+		data, _ = wav.EncodeSamples(&ft, s)
+	default:
+		return fmt.Errorf("aiff: cannot encode wav format tag %#x", ft.Tag())
+	}
+
+	size := ft.SampleBytes()
+	if size == 0 {
+		return fmt.Errorf("aiff: invalid block align %d", ft.BlockAlign)
+	}
+	frames := len(data) / int(ft.BlockAlign)
+	pcm := make([]byte, frames*int(ft.BlockAlign))
+	if aifc && ft.Tag() != wav.FORMAT_IEEE_FLOAT {
+		copy(pcm, data)
+	} else {
+		for i := 0; i < len(pcm); i += size {
+			for j := 0; j < size; j++ {
+				pcm[i+j] = data[i+size-1-j]
+			}
+		}
+		if size == 1 {
+			for i := range pcm {
+				pcm[i] ^= 0x80
+			}
+		}
+	}
+
+	be := binary.BigEndian
+	body := new(bytes.Buffer)
+	if aifc {
+		var ver [4]byte
+		be.PutUint32(ver[:], aifcVersion1)
+		writeChunk(body, "FVER", ver[:])
+	}
+
+	comm := new(bytes.Buffer)
+	binary.Write(comm, be, int16(ft.Channels))
+	binary.Write(comm, be, uint32(frames))
+	bits := int16(ft.BitsPerSample)
+	if bits == 0 || ft.Format == wav.FORMAT_EXTENSIBLE && ft.ValidBits != 0 {
+		bits = int16(ft.ValidBits)
+	}
+	if bits == 0 || int(bits) > size*8 {
+		bits = int16(size * 8)
+	}
+	binary.Write(comm, be, bits)
+	ext := Float64ToExtended(float64(ft.SampleRate))
+	comm.Write(ext[:])
+	if aifc {
+		comm.Write(comp[:])
+		name := map[string]string{"fl32": "32-bit floating point", "fl64": "64-bit floating point", "alaw": "ALaw 2:1", "ulaw": "µLaw 2:1"}[string(comp[:])]
+		comm.Write(pstringBytes(name))
+	}
+	writeChunk(body, "COMM", comm.Bytes())
+
+	for _, c := range []struct{ id, info string }{
+		{"NAME", "INAM"},
+		{"AUTH", "IART"},
+		{"(c) ", "ICOP"},
+		{"ANNO", "ICMT"},
+	} {
+		if v := f.InfoValue(c.info); v != "" {
+			writeChunk(body, c.id, []byte(v))
+		}
+	}
+
+	markers, inst := encodeMeta(f)
+	if len(markers) > 0 {
+		writeChunk(body, "MARK", markers)
+	}
+	if len(inst) > 0 {
+		writeChunk(body, "INST", inst)
+	}
+
+	ssnd := make([]byte, 8+len(pcm))
+	copy(ssnd[8:], pcm)
+	writeChunk(body, "SSND", ssnd)
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("FORM")
+	binary.Write(bw, be, uint32(4+body.Len()))
+	if aifc {
+		bw.WriteString("AIFC")
+	} else {
+		bw.WriteString("AIFF")
+	}
+	bw.Write(body.Bytes())
+	return bw.Flush()
+}
+
+func encodeMeta(f *wav.File) (markers, inst []byte) {
+	be := binary.BigEndian
+
+	type mark struct {
+		id   int16
+		pos  uint32
+		name string
+	}
+	var marks []mark
+	for _, c := range f.Cues {
+		marks = append(marks, mark{int16(c.ID), c.Position, c.Label})
+	}
+
+	var loops [2]Loop
+	var base int8 = 60
+	if s := f.Sampler; s != nil {
+		base = int8(s.MIDIUnityNote)
+		next := int16(len(marks) + 1)
+		for i, l := range s.Loops {
+			if i >= len(loops) {
+				break
+			}
+			mode := int16(ForwardLooping)
+			if l.Type == wav.LOOP_PINGPONG {
+				mode = ForwardBackwardLooping
+			}
+			loops[i] = Loop{mode, next, next + 1}
+			marks = append(marks,
+				mark{next, l.Start, fmt.Sprintf("loop %d start", i)},
+				mark{next + 1, l.End + 1, fmt.Sprintf("loop %d end", i)})
+			next += 2
+		}
+
+		b := new(bytes.Buffer)
+		b.Write([]byte{byte(base), 0, 0, 127, 1, 127})
+		binary.Write(b, be, int16(0))
+		for _, l := range loops {
+			binary.Write(b, be, &l)
+		}
+		inst = b.Bytes()
+	}
+
+	if len(marks) > 0 {
+		b := new(bytes.Buffer)
+		binary.Write(b, be, uint16(len(marks)))
+		for _, m := range marks {
+			binary.Write(b, be, m.id)
+			binary.Write(b, be, m.pos)
+			b.Write(pstringBytes(m.name))
+		}
+		markers = b.Bytes()
+	}
+	return
+}
+
+func writeChunk(w *bytes.Buffer, id string, b []byte) {
+	w.WriteString(id)
+	binary.Write(w, binary.BigEndian, uint32(len(b)))
+	w.Write(b)
+	if len(b)&1 != 0 {
+		w.WriteByte(0)
+	}
+}
+
+func pstring(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	n := int(b[0])
+	if n > len(b)-1 {
+		n = len(b) - 1
+	}
+	return string(b[1 : 1+n])
+}
+
+func pstringBytes(s string) []byte {
+	if len(s) > 255 {
+		s = s[:255]
+	}
+	b := append([]byte{byte(len(s))}, s...)
+	if len(b)&1 != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+func init() {
+	snd.RegisterFormat("aiff", "FORM????AIFF", Decode, DecodeConfig)
+	snd.RegisterFormat("aiff", "FORM????AIFC", Decode, DecodeConfig)
+}
@@ -0,0 +1,68 @@
+package aiff
+
+import "math"
+
+// ExtendedToFloat64 converts an 80-bit IEEE 754 extended precision big
+// endian number, as used for the AIFF sample rate, to a float64.
+func ExtendedToFloat64(b []byte) float64 {
+	if len(b) < 10 {
+		return 0
+	}
+	sign := b[0] & 0x80
+	exp := int(b[0]&0x7f)<<8 | int(b[1])
+	var mant uint64
+	for i := 0; i < 8; i++ {
+		mant = mant<<8 | uint64(b[2+i])
+	}
+
+	var f float64
+	switch {
+	case exp == 0 && mant == 0:
+		f = 0
+	case exp == 0x7fff:
+		if mant<<1 == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(float64(mant), exp-16383-63)
+	}
+	if sign != 0 {
+		f = -f
+	}
+	return f
+}
+
+func Float64ToExtended(f float64) [10]byte {
+	var b [10]byte
+	if f < 0 {
+		b[0] = 0x80
+		f = -f
+	}
+
+	switch {
+	case f == 0:
+		return b
+	case math.IsInf(f, 0):
+		b[0] |= 0x7f
+		b[1] = 0xff
+		b[2] = 0x80
+		return b
+	case math.IsNaN(f):
+		b[0] |= 0x7f
+		b[1] = 0xff
+		b[2] = 0xc0
+		return b
+	}
+
+	frac, exp := math.Frexp(f)
+	exp += 16382
+	mant := uint64(math.Ldexp(frac, 64))
+	b[0] |= byte(exp >> 8 & 0x7f)
+	b[1] = byte(exp)
+	for i := 0; i < 8; i++ {
+		b[2+i] = byte(mant >> uint(56-8*i))
+	}
+	return b
+}
@@ -0,0 +1,45 @@
+package aiff
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/qeedquan/go-media/snd/wav"
+)
+
+const (
+	ima4PacketSize    = 34
+	ima4PacketSamples = 64
+)
+
+// decodeIMA4 decodes Apple IMA4, where each channel has its own 34 byte
+// packets of 64 samples that are interleaved channel by channel.
+func decodeIMA4(b []byte, channels int) ([]int16, error) {
+	stride := ima4PacketSize * channels
+	if len(b)%stride != 0 {
+		return nil, fmt.Errorf("aiff: ima4 data size %d is not a multiple of %d", len(b), stride)
+	}
+
+	n := len(b) / stride
+	s := make([]int16, n*ima4PacketSamples*channels)
+	for p := 0; p < n; p++ {
+		for c := 0; c < channels; c++ {
+			pk := b[p*stride+c*ima4PacketSize:]
+			h := binary.BigEndian.Uint16(pk)
+			st := wav.IMAState{
+				Predictor: int(int16(h & 0xff80)),
+				Index:     int(h & 0x7f),
+			}
+			if st.Index > 88 {
+				st.Index = 88
+			}
+
+			out := s[p*ima4PacketSamples*channels:]
+			for i := 0; i < ima4PacketSamples; i++ {
+				nib := pk[2+i/2] >> (4 * uint(i&1)) & 0xf
+				out[i*channels+c] = st.Decode(nib)
+			}
+		}
+	}
+	return s, nil
+}
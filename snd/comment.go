@@ -0,0 +1,34 @@
+package snd
+
+import (
+	"strings"
+
+	"github.com/qeedquan/go-media/snd/wav"
+)
+
+var commentInfo = map[string]string{
+	"TITLE":       "INAM",
+	"ARTIST":      "IART",
+	"ALBUM":       "IPRD",
+	"DATE":        "ICRD",
+	"GENRE":       "IGNR",
+	"COMMENT":     "ICMT",
+	"DESCRIPTION": "ICMT",
+	"COPYRIGHT":   "ICOP",
+	"TRACKNUMBER": "ITRK",
+	"ENCODER":     "ISFT",
+}
+
+// SetComments maps Vorbis style KEY=value comments, as used by FLAC and Ogg
+// Vorbis, onto the equivalent RIFF INFO tags of f.
+func SetComments(f *wav.File, comments []string) {
+	for _, c := range comments {
+		i := strings.IndexByte(c, '=')
+		if i < 0 {
+			continue
+		}
+		if id, ok := commentInfo[strings.ToUpper(c[:i])]; ok && f.InfoValue(id) == "" {
+			f.SetInfo(id, c[i+1:])
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package dsp
+
+import (
+	"fmt"
+	"math"
+)
+
+// Matrix maps input channels to output channels, the gain of input i
+// in output o is stored at Matrix[o][i].
+type Matrix [][]float32
+
+func NewMatrix(in, out int) Matrix {
+	m := make(Matrix, out)
+	for i := range m {
+		m[i] = make([]float32, in)
+	}
+	return m
+}
+
+func IdentityMatrix(n int) Matrix {
+	m := NewMatrix(n, n)
+	for i := range m {
+		m[i][i] = 1
+	}
+	return m
+}
+
+// DefaultMatrix returns the up/down mix matrix for the wav channel order
+// (FL, FR, FC, LFE, BL, BR, ...), using ITU-R BS.775 coefficients for
+// 5.1 to stereo.
+func DefaultMatrix(in, out int) Matrix {
+	const k = math.Sqrt2 / 2
+
+	switch {
+	case in == out:
+		return IdentityMatrix(in)
+
+	case in == 1:
+		m := NewMatrix(in, out)
+		m[0][0] = 1
+		if out >= 2 {
+			m[1][0] = 1
+		}
+		return m
+
+	case in == 2 && out == 1:
+		return Matrix{{0.5, 0.5}}
+
+	case in == 2:
+		m := NewMatrix(in, out)
+		m[0][0] = 1
+		m[1][1] = 1
+		return m
+
+	case in == 6 && out == 2:
+		n := float32(1 / (1 + 2*k))
+		return Matrix{
+			{n, 0, k * n, 0, k * n, 0},
+			{0, n, k * n, 0, 0, k * n},
+		}
+
+	case in == 6 && out == 1:
+		s := DefaultMatrix(6, 2)
+		m := NewMatrix(6, 1)
+		for i := range m[0] {
+			m[0][i] = (s[0][i] + s[1][i]) / 2
+		}
+		return m
+	}
+
+	m := NewMatrix(in, out)
+	cnt := make([]int, out)
+	for i := 0; i < in; i++ {
+		cnt[i%out]++
+	}
+	for i := 0; i < in; i++ {
+		m[i%out][i] = 1 / float32(cnt[i%out])
+	}
+	return m
+}
+
+func (m Matrix) Inputs() int {
+	if len(m) == 0 {
+		return 0
+	}
+	return len(m[0])
+}
+
+func (m Matrix) Outputs() int {
+	return len(m)
+}
+
+func (m Matrix) Apply(p []float32) []float32 {
+	in := m.Inputs()
+	out := m.Outputs()
+	if in == 0 || out == 0 {
+		return nil
+	}
+
+	n := len(p) / in
+	q := make([]float32, n*out)
+	for f := 0; f < n; f++ {
+		x := p[f*in : f*in+in]
+		y := q[f*out : f*out+out]
+		for o := range y {
+			var s float32
+			for i, g := range m[o] {
+				s += g * x[i]
+			}
+			y[o] = s
+		}
+	}
+	return q
+}
+
+func Remix(p []float32, in, out int) ([]float32, error) {
+	if in <= 0 || out <= 0 {
+		return nil, fmt.Errorf("dsp: invalid channel count %d -> %d", in, out)
+	}
+	if in == out {
+		return append([]float32{}, p...), nil
+	}
+	return DefaultMatrix(in, out).Apply(p), nil
+}
+
+func Interleave(c [][]float32) []float32 {
+	if len(c) == 0 {
+		return nil
+	}
+	n := len(c[0])
+	for _, x := range c {
+		if len(x) < n {
+			n = len(x)
+		}
+	}
+
+	p := make([]float32, n*len(c))
+	for i := 0; i < n; i++ {
+		for j := range c {
+			p[i*len(c)+j] = c[j][i]
+		}
+	}
+	return p
+}
+
+func Deinterleave(p []float32, channels int) [][]float32 {
+	n := len(p) / channels
+	c := make([][]float32, channels)
+	for j := range c {
+		c[j] = make([]float32, n)
+		for i := 0; i < n; i++ {
+			c[j][i] = p[i*channels+j]
+		}
+	}
+	return c
+}
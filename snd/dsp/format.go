@@ -0,0 +1,189 @@
+package dsp
+
+import (
+	"fmt"
+	"math"
+)
+
+type SampleFormat int
+
+const (
+	U8 SampleFormat = iota
+	S8
+	S16
+	S24
+	S32
+	F32
+	F64
+)
+
+func (f SampleFormat) String() string {
+	switch f {
+	case U8:
+		return "u8"
+	case S8:
+		return "s8"
+	case S16:
+		return "s16"
+	case S24:
+		return "s24"
+	case S32:
+		return "s32"
+	case F32:
+		return "f32"
+	case F64:
+		return "f64"
+	}
+	return fmt.Sprintf("SampleFormat(%d)", int(f))
+}
+
+func (f SampleFormat) Bytes() int {
+	switch f {
+	case U8, S8:
+		return 1
+	case S16:
+		return 2
+	case S24:
+		return 3
+	case S32, F32:
+		return 4
+	case F64:
+		return 8
+	}
+	return 0
+}
+
+// FormatOf maps a wav style sample slice and container width to a format,
+// []int32 holds both 24-bit and 32-bit data so the width disambiguates it.
+func FormatOf(s interface{}, bytes int) (SampleFormat, error) {
+	switch s.(type) {
+	case []uint8:
+		return U8, nil
+	case []int8:
+		return S8, nil
+	case []int16:
+		return S16, nil
+	case []int32:
+		if bytes == 3 {
+			return S24, nil
+		}
+		return S32, nil
+	case []float32:
+		return F32, nil
+	case []float64:
+		return F64, nil
+	}
+	return 0, fmt.Errorf("dsp: unsupported sample type %T", s)
+}
+
+func ToFloat(s interface{}, f SampleFormat) ([]float32, error) {
+	var p []float32
+	switch s := s.(type) {
+	case []uint8:
+		p = make([]float32, len(s))
+		for i := range s {
+			p[i] = (float32(s[i]) - 128) / 128
+		}
+	case []int8:
+		p = make([]float32, len(s))
+		for i := range s {
+			p[i] = float32(s[i]) / 128
+		}
+	case []int16:
+		p = make([]float32, len(s))
+		for i := range s {
+			p[i] = float32(s[i]) / 32768
+		}
+	case []int32:
+		scale := float32(1 << 31)
+		if f == S24 {
+			scale = 1 << 23
+		}
+		p = make([]float32, len(s))
+		for i := range s {
+			p[i] = float32(s[i]) / scale
+		}
+	case []float32:
+		p = append([]float32{}, s...)
+	case []float64:
+		p = make([]float32, len(s))
+		for i := range s {
+			p[i] = float32(s[i])
+		}
+	default:
+		return nil, fmt.Errorf("dsp: unsupported sample type %T", s)
+	}
+	return p, nil
+}
+
+func FromFloat(p []float32, f SampleFormat) (interface{}, error) {
+	switch f {
+	case U8:
+		s := make([]uint8, len(p))
+		for i := range p {
+			s[i] = uint8(quantize(p[i], 127) + 128)
+		}
+		return s, nil
+	case S8:
+		s := make([]int8, len(p))
+		for i := range p {
+			s[i] = int8(quantize(p[i], 127))
+		}
+		return s, nil
+	case S16:
+		s := make([]int16, len(p))
+		for i := range p {
+			s[i] = int16(quantize(p[i], 32767))
+		}
+		return s, nil
+	case S24:
+		s := make([]int32, len(p))
+		for i := range p {
+			s[i] = int32(quantize(p[i], 1<<23-1))
+		}
+		return s, nil
+	case S32:
+		s := make([]int32, len(p))
+		for i := range p {
+			s[i] = int32(quantize(p[i], math.MaxInt32))
+		}
+		return s, nil
+	case F32:
+		return append([]float32{}, p...), nil
+	case F64:
+		s := make([]float64, len(p))
+		for i := range p {
+			s[i] = float64(p[i])
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("dsp: unsupported sample format %v", f)
+}
+
+func Convert(s interface{}, from, to SampleFormat) (interface{}, error) {
+	p, err := ToFloat(s, from)
+	if err != nil {
+		return nil, err
+	}
+	return FromFloat(p, to)
+}
+
+func quantize(x float32, scale float64) int64 {
+	v := math.Round(float64(x) * scale)
+	if v > scale {
+		v = scale
+	} else if v < -scale-1 {
+		v = -scale - 1
+	}
+	return int64(v)
+}
+
+func Clip(p []float32) {
+	for i := range p {
+		if p[i] > 1 {
+			p[i] = 1
+		} else if p[i] < -1 {
+			p[i] = -1
+		}
+	}
+}
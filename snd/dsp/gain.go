@@ -0,0 +1,75 @@
+package dsp
+
+import "math"
+
+func DB(db float64) float32 {
+	return float32(math.Pow(10, db/20))
+}
+
+func ToDB(g float32) float64 {
+	if g <= 0 {
+		return math.Inf(-1)
+	}
+	return 20 * math.Log10(float64(g))
+}
+
+func Gain(p []float32, g float32) {
+	for i := range p {
+		p[i] *= g
+	}
+}
+
+// PanGains returns constant power left/right gains for pan in [-1, 1].
+func PanGains(pan float32) (l, r float32) {
+	if pan < -1 {
+		pan = -1
+	} else if pan > 1 {
+		pan = 1
+	}
+	a := (float64(pan) + 1) * math.Pi / 4
+	return float32(math.Cos(a)), float32(math.Sin(a))
+}
+
+// Pan applies a stereo pan to interleaved two channel data, scaled so
+// that a centered pan leaves the signal unchanged.
+func Pan(p []float32, pan float32) {
+	l, r := PanGains(pan)
+	l *= math.Sqrt2
+	r *= math.Sqrt2
+	for i := 0; i+1 < len(p); i += 2 {
+		p[i] *= l
+		p[i+1] *= r
+	}
+}
+
+func Peak(p []float32) float32 {
+	var m float32
+	for _, x := range p {
+		if x < 0 {
+			x = -x
+		}
+		if x > m {
+			m = x
+		}
+	}
+	return m
+}
+
+func Normalize(p []float32, peak float32) {
+	if m := Peak(p); m > 0 {
+		Gain(p, peak/m)
+	}
+}
+
+func Fade(p []float32, channels int, from, to float32) {
+	n := len(p) / channels
+	if n == 0 {
+		return
+	}
+	for i := 0; i < n; i++ {
+		g := from + (to-from)*float32(i)/float32(n)
+		for c := 0; c < channels; c++ {
+			p[i*channels+c] *= g
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package dsp
+
+import (
+	"github.com/qeedquan/go-media/snd/wav"
+)
+
+// Load decodes a wav file into float samples with the given channel count
+// and sample rate, ready to be played by a Mixer.
+func Load(f *wav.File, channels, sampleRate int, q Quality) ([]float32, error) {
+	p, err := f.Float32()
+	if err != nil {
+		return nil, err
+	}
+
+	if ch := int(f.Format.Channels); ch != channels {
+		p, err = Remix(p, ch, channels)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rate := int(f.Format.SampleRate); rate != sampleRate {
+		p, err = Resample(p, channels, rate, sampleRate, q)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func (m *Mixer) Load(f *wav.File, q Quality) ([]float32, error) {
+	return Load(f, m.Channels, m.SampleRate, q)
+}
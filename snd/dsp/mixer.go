@@ -0,0 +1,275 @@
+package dsp
+
+import (
+	"math"
+	"sync"
+)
+
+type Envelope struct {
+	Attack  float64
+	Decay   float64
+	Sustain float32
+	Release float64
+}
+
+const (
+	stageAttack = iota
+	stageDecay
+	stageSustain
+	stageRelease
+	stageDone
+)
+
+type Voice struct {
+	Data      []float32
+	Volume    float32
+	Pan       float32
+	Loop      bool
+	LoopStart int
+	LoopEnd   int
+	Envelope  *Envelope
+
+	m      *Mixer
+	pos    int
+	stage  int
+	level  float32
+	rlevel float32
+	t      int
+}
+
+type Mixer struct {
+	Channels   int
+	SampleRate int
+	Gain       float32
+
+	mu     sync.Mutex
+	voices []*Voice
+	buf    []float32
+}
+
+func NewMixer(channels, sampleRate int) *Mixer {
+	return &Mixer{
+		Channels:   channels,
+		SampleRate: sampleRate,
+		Gain:       1,
+	}
+}
+
+// Play starts a voice, data must already be interleaved in the mixer
+// channel count and sample rate.
+func (m *Mixer) Play(data []float32, volume, pan float32, env *Envelope) *Voice {
+	v := &Voice{
+		Data:     data,
+		Volume:   volume,
+		Pan:      pan,
+		Envelope: env,
+	}
+	m.Add(v)
+	return v
+}
+
+func (m *Mixer) Add(v *Voice) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v.m = m
+	v.pos = 0
+	v.t = 0
+	v.stage = stageAttack
+	v.level = 0
+	if v.Envelope == nil {
+		v.stage = stageSustain
+		v.level = 1
+	}
+	m.voices = append(m.voices, v)
+}
+
+func (m *Mixer) Voices() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.voices)
+}
+
+func (m *Mixer) StopAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, v := range m.voices {
+		v.stage = stageDone
+	}
+	m.voices = m.voices[:0]
+}
+
+func (v *Voice) SetVolume(volume float32) {
+	v.lock(func() { v.Volume = volume })
+}
+
+func (v *Voice) SetPan(pan float32) {
+	v.lock(func() { v.Pan = pan })
+}
+
+// Release moves the voice into the release stage of its envelope, voices
+// without an envelope stop immediately.
+func (v *Voice) Release() {
+	v.lock(func() {
+		if v.stage >= stageRelease {
+			return
+		}
+		if v.Envelope == nil || v.Envelope.Release <= 0 {
+			v.stage = stageDone
+			return
+		}
+		v.stage = stageRelease
+		v.rlevel = v.level
+		v.t = 0
+	})
+}
+
+func (v *Voice) Stop() {
+	v.lock(func() { v.stage = stageDone })
+}
+
+func (v *Voice) Playing() bool {
+	playing := false
+	v.lock(func() { playing = v.stage != stageDone })
+	return playing
+}
+
+func (v *Voice) lock(f func()) {
+	if v.m != nil {
+		v.m.mu.Lock()
+		defer v.m.mu.Unlock()
+	}
+	f()
+}
+
+func (v *Voice) envelope(rate int) float32 {
+	e := v.Envelope
+	if e == nil {
+		if v.stage == stageDone {
+			return 0
+		}
+		return 1
+	}
+
+	frames := func(s float64) int {
+		return int(math.Round(s * float64(rate)))
+	}
+
+	for {
+		switch v.stage {
+		case stageAttack:
+			n := frames(e.Attack)
+			if v.t >= n {
+				v.stage, v.t = stageDecay, 0
+				continue
+			}
+			v.level = float32(v.t+1) / float32(n)
+		case stageDecay:
+			n := frames(e.Decay)
+			if v.t >= n {
+				v.stage, v.t = stageSustain, 0
+				continue
+			}
+			v.level = 1 - (1-e.Sustain)*float32(v.t+1)/float32(n)
+		case stageSustain:
+			v.level = e.Sustain
+			return v.level
+		case stageRelease:
+			n := frames(e.Release)
+			if v.t >= n {
+				v.stage, v.level = stageDone, 0
+				return 0
+			}
+			v.level = v.rlevel * (1 - float32(v.t+1)/float32(n))
+		case stageDone:
+			return 0
+		}
+		v.t++
+		return v.level
+	}
+}
+
+// Mix renders len(out)/Channels frames of all active voices into out,
+// finished voices are removed.
+func (m *Mixer) Mix(out []float32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mix(out)
+}
+
+func (m *Mixer) mix(out []float32) {
+	for i := range out {
+		out[i] = 0
+	}
+
+	ch := m.Channels
+	frames := len(out) / ch
+	n := 0
+	for _, v := range m.voices {
+		gl, gr := float32(1), float32(1)
+		if ch == 2 {
+			gl, gr = PanGains(v.Pan)
+			gl *= math.Sqrt2
+			gr *= math.Sqrt2
+		}
+
+		end := len(v.Data) / ch
+		if v.Loop && v.LoopEnd > 0 && v.LoopEnd < end {
+			end = v.LoopEnd
+		}
+
+		for f := 0; f < frames && v.stage != stageDone; f++ {
+			if v.pos >= end {
+				if !v.Loop || v.LoopStart >= end {
+					v.stage = stageDone
+					break
+				}
+				v.pos = v.LoopStart
+			}
+
+			g := v.envelope(m.SampleRate) * v.Volume
+			x := v.Data[v.pos*ch : v.pos*ch+ch]
+			y := out[f*ch : f*ch+ch]
+			if ch == 2 {
+				y[0] += x[0] * g * gl
+				y[1] += x[1] * g * gr
+			} else {
+				for c := range y {
+					y[c] += x[c] * g
+				}
+			}
+			v.pos++
+		}
+
+		if v.stage != stageDone {
+			m.voices[n], n = v, n+1
+		}
+	}
+	for i := n; i < len(m.voices); i++ {
+		m.voices[i] = nil
+	}
+	m.voices = m.voices[:n]
+
+	if m.Gain != 1 {
+		Gain(out, m.Gain)
+	}
+}
+
+func (m *Mixer) MixInt16(out []int16) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cap(m.buf) < len(out) {
+		m.buf = make([]float32, len(out))
+	}
+	p := m.buf[:len(out)]
+	m.mix(p)
+	for i := range p {
+		out[i] = int16(quantize(p[i], 32767))
+	}
+}
+
+func (m *Mixer) Render(frames int) []float32 {
+	p := make([]float32, frames*m.Channels)
+	m.Mix(p)
+	return p
+}
@@ -0,0 +1,207 @@
+package dsp
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/qeedquan/go-media/math/mathutil"
+)
+
+type Quality int
+
+const (
+	Fast Quality = iota
+	Medium
+	Best
+)
+
+type filterSpec struct {
+	half   int
+	phases int
+	beta   float64
+}
+
+var filterSpecs = [...]filterSpec{
+	Fast:   {8, 64, 6},
+	Medium: {16, 256, 8.6},
+	Best:   {64, 1024, 12},
+}
+
+type Resampler struct {
+	channels int
+	in, out  int
+	half     int
+	phases   int
+	table    []float32
+
+	buf   []float32
+	idx   int
+	frac  int
+	nin   int64
+	nout  int64
+	flush bool
+}
+
+func NewResampler(channels, inRate, outRate int, q Quality) (*Resampler, error) {
+	if channels <= 0 || inRate <= 0 || outRate <= 0 {
+		return nil, fmt.Errorf("dsp: invalid resampler parameters %d channels %d -> %d Hz", channels, inRate, outRate)
+	}
+	if q < Fast || q > Best {
+		return nil, fmt.Errorf("dsp: invalid resampler quality %d", q)
+	}
+
+	g := mathutil.GCD(inRate, outRate)
+	spec := filterSpecs[q]
+	r := &Resampler{
+		channels: channels,
+		in:       inRate / g,
+		out:      outRate / g,
+		half:     spec.half,
+		phases:   spec.phases,
+	}
+
+	// widen the filter when decimating so the cutoff drops with the ratio
+	fc := 1.0
+	if r.out < r.in {
+		fc = float64(r.out) / float64(r.in)
+		r.half = int(math.Ceil(float64(r.half) / fc))
+	}
+	r.table = makeSincTable(r.half, r.phases, fc, spec.beta)
+	r.Reset()
+	return r, nil
+}
+
+func (r *Resampler) Reset() {
+	r.buf = make([]float32, r.half*r.channels)
+	r.idx = r.half
+	r.frac = 0
+	r.nin = 0
+	r.nout = 0
+	r.flush = false
+}
+
+// makeSincTable builds phases+1 rows of 2*half Kaiser windowed sinc taps,
+// the extra row lets the kernel be interpolated between adjacent phases.
+func makeSincTable(half, phases int, fc, beta float64) []float32 {
+	n := 2 * half
+	t := make([]float32, (phases+1)*n)
+	ib := besselI0(beta)
+	for p := 0; p <= phases; p++ {
+		for j := 0; j < n; j++ {
+			x := float64(j-half+1) - float64(p)/float64(phases)
+			w := 0.0
+			if r := x / float64(half); r > -1 && r < 1 {
+				w = besselI0(beta*math.Sqrt(1-r*r)) / ib
+			}
+			t[p*n+j] = float32(fc * sinc(fc*x) * w)
+		}
+	}
+	return t
+}
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	x *= math.Pi
+	return math.Sin(x) / x
+}
+
+func besselI0(x float64) float64 {
+	s, t := 1.0, 1.0
+	for k := 1; k < 64; k++ {
+		t *= (x / 2) / float64(k)
+		s += t * t
+		if t*t < 1e-12*s {
+			break
+		}
+	}
+	return s
+}
+
+func (r *Resampler) Ratio() (in, out int) {
+	return r.in, r.out
+}
+
+// Process consumes interleaved input frames and returns all output frames
+// that can be produced without looking past the end of the input so far.
+func (r *Resampler) Process(p []float32) []float32 {
+	r.nin += int64(len(p) / r.channels)
+	return r.run(p)
+}
+
+func (r *Resampler) run(p []float32) []float32 {
+	ch := r.channels
+	r.buf = append(r.buf, p[:len(p)-len(p)%ch]...)
+
+	var q []float32
+	n := 2 * r.half
+	frames := len(r.buf) / ch
+	acc := make([]float32, ch)
+	for r.idx+r.half < frames {
+		if r.flush && r.nout >= r.expected() {
+			break
+		}
+
+		pf := r.frac * r.phases
+		ph := pf / r.out
+		mu := float32(pf%r.out) / float32(r.out)
+		t0 := r.table[ph*n : ph*n+n]
+		t1 := r.table[(ph+1)*n : (ph+1)*n+n]
+
+		for c := range acc {
+			acc[c] = 0
+		}
+		base := (r.idx - r.half + 1) * ch
+		for j := 0; j < n; j++ {
+			h := t0[j] + (t1[j]-t0[j])*mu
+			x := r.buf[base+j*ch : base+j*ch+ch]
+			for c := range acc {
+				acc[c] += h * x[c]
+			}
+		}
+		q = append(q, acc...)
+		r.nout++
+
+		r.frac += r.in
+		r.idx += r.frac / r.out
+		r.frac %= r.out
+	}
+
+	if drop := r.idx - r.half; drop > 0 {
+		r.buf = append(r.buf[:0], r.buf[drop*ch:]...)
+		r.idx -= drop
+	}
+	return q
+}
+
+// Flush pads the input with silence to drain the filter and returns the
+// remaining output, the total output length is ceil(input * out / in).
+func (r *Resampler) Flush() []float32 {
+	r.flush = true
+	var q []float32
+	pad := make([]float32, r.half*r.channels)
+	for r.nout < r.expected() {
+		p := r.run(pad)
+		if len(p) == 0 && r.nout < r.expected() {
+			break
+		}
+		q = append(q, p...)
+	}
+	return q
+}
+
+func (r *Resampler) expected() int64 {
+	return (r.nin*int64(r.out) + int64(r.in) - 1) / int64(r.in)
+}
+
+func Resample(p []float32, channels, inRate, outRate int, q Quality) ([]float32, error) {
+	if inRate == outRate {
+		return append([]float32{}, p...), nil
+	}
+	r, err := NewResampler(channels, inRate, outRate, q)
+	if err != nil {
+		return nil, err
+	}
+	return append(r.Process(p), r.Flush()...), nil
+}
@@ -0,0 +1,172 @@
+package flac
+
+import (
+	"bufio"
+	"io"
+)
+
+var crc8Table, crc16Table = makeCRCTables()
+
+func makeCRCTables() (t8 [256]uint8, t16 [256]uint16) {
+	for i := range t8 {
+		c := uint8(i)
+		for j := 0; j < 8; j++ {
+			if c&0x80 != 0 {
+				c = c<<1 ^ 0x07
+			} else {
+				c <<= 1
+			}
+		}
+		t8[i] = c
+	}
+	for i := range t16 {
+		c := uint16(i) << 8
+		for j := 0; j < 8; j++ {
+			if c&0x8000 != 0 {
+				c = c<<1 ^ 0x8005
+			} else {
+				c <<= 1
+			}
+		}
+		t16[i] = c
+	}
+	return
+}
+
+type bitReader struct {
+	r     *bufio.Reader
+	off   int64
+	bits  uint64
+	nbits uint
+	crc8  uint8
+	crc16 uint16
+	err   error
+}
+
+func newBitReader(r io.Reader, off int64) *bitReader {
+	return &bitReader{r: bufio.NewReaderSize(r, 1<<16), off: off}
+}
+
+func (b *bitReader) reset(r io.Reader, off int64) {
+	b.r.Reset(r)
+	b.off = off
+	b.bits = 0
+	b.nbits = 0
+	b.err = nil
+}
+
+func (b *bitReader) resetCRC(p ...byte) {
+	b.crc8 = 0
+	b.crc16 = 0
+	for _, c := range p {
+		b.crc8 = crc8Table[b.crc8^c]
+		b.crc16 = b.crc16<<8 ^ crc16Table[uint8(b.crc16>>8)^c]
+	}
+}
+
+func (b *bitReader) readByte() uint8 {
+	if b.err != nil {
+		return 0
+	}
+	c, err := b.r.ReadByte()
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		b.err = err
+		return 0
+	}
+	b.off++
+	b.crc8 = crc8Table[b.crc8^c]
+	b.crc16 = b.crc16<<8 ^ crc16Table[uint8(b.crc16>>8)^c]
+	return c
+}
+
+// read returns the next n bits, n must not exceed 56.
+func (b *bitReader) read(n uint) uint64 {
+	if n == 0 {
+		return 0
+	}
+	for b.nbits < n {
+		b.bits = b.bits<<8 | uint64(b.readByte())
+		b.nbits += 8
+	}
+	b.nbits -= n
+	v := b.bits >> b.nbits & (1<<n - 1)
+	return v
+}
+
+func (b *bitReader) readSigned(n uint) int64 {
+	if n == 0 {
+		return 0
+	}
+	v := b.read(n)
+	return int64(v<<(64-n)) >> (64 - n)
+}
+
+func (b *bitReader) readBit() bool {
+	return b.read(1) != 0
+}
+
+func (b *bitReader) readUnary() uint64 {
+	var n uint64
+	for {
+		if b.nbits == 0 {
+			b.bits = uint64(b.readByte())
+			b.nbits = 8
+			if b.err != nil {
+				return n
+			}
+		}
+		m := b.bits & (1<<b.nbits - 1)
+		if m == 0 {
+			n += uint64(b.nbits)
+			b.nbits = 0
+			continue
+		}
+		for m>>(b.nbits-1)&1 == 0 {
+			b.nbits--
+			n++
+		}
+		b.nbits--
+		return n
+	}
+}
+
+func (b *bitReader) align() {
+	b.nbits -= b.nbits % 8
+}
+
+// readUTF8 reads the extended UTF-8 coding used for frame and sample
+// numbers, which allows up to 36-bit values.
+func (b *bitReader) readUTF8() (uint64, bool) {
+	c := b.read(8)
+	var n int
+	var v uint64
+	switch {
+	case c&0x80 == 0:
+		return c, true
+	case c&0xe0 == 0xc0:
+		n, v = 1, c&0x1f
+	case c&0xf0 == 0xe0:
+		n, v = 2, c&0x0f
+	case c&0xf8 == 0xf0:
+		n, v = 3, c&0x07
+	case c&0xfc == 0xf8:
+		n, v = 4, c&0x03
+	case c&0xfe == 0xfc:
+		n, v = 5, c&0x01
+	case c == 0xfe:
+		n, v = 6, 0
+	default:
+		return 0, false
+	}
+	for i := 0; i < n; i++ {
+		c = b.read(8)
+		if c&0xc0 != 0x80 {
+			return 0, false
+		}
+		v = v<<6 | c&0x3f
+	}
+	return v, true
+}
@@ -0,0 +1,689 @@
+package flac
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/qeedquan/go-media/snd"
+	"github.com/qeedquan/go-media/snd/wav"
+)
+
+const (
+	BLOCK_STREAMINFO     = 0
+	BLOCK_PADDING        = 1
+	BLOCK_APPLICATION    = 2
+	BLOCK_SEEKTABLE      = 3
+	BLOCK_VORBIS_COMMENT = 4
+	BLOCK_CUESHEET       = 5
+	BLOCK_PICTURE        = 6
+)
+
+const placeholderPoint = 0xffffffffffffffff
+
+var (
+	ErrFormat   = errors.New("flac: invalid format")
+	ErrChecksum = errors.New("flac: md5 checksum mismatch")
+	ErrSeek     = errors.New("flac: underlying stream is not seekable")
+)
+
+type StreamInfo struct {
+	MinBlockSize  uint16
+	MaxBlockSize  uint16
+	MinFrameSize  uint32
+	MaxFrameSize  uint32
+	SampleRate    uint32
+	Channels      uint8
+	BitsPerSample uint8
+	TotalSamples  uint64
+	MD5           [16]byte
+}
+
+type SeekPoint struct {
+	Sample  uint64
+	Offset  uint64
+	Samples uint16
+}
+
+type Block struct {
+	Type uint8
+	Data []byte
+}
+
+type Decoder struct {
+	Info      StreamInfo
+	SeekTable []SeekPoint
+	Vendor    string
+	Comments  []string
+	Blocks    []Block
+
+	r          io.Reader
+	br         *bitReader
+	start      int64
+	firstFrame int64
+	pos        uint64
+	pending    [][]int32
+}
+
+type FrameHeader struct {
+	VariableBlockSize bool
+	BlockSize         int
+	SampleRate        uint32
+	Channels          int
+	ChannelAssignment uint8
+	BitsPerSample     uint8
+	Number            uint64
+}
+
+type Frame struct {
+	FrameHeader
+	Samples [][]int32
+}
+
+func NewDecoder(r io.Reader) (*Decoder, error) {
+	d := &Decoder{r: r}
+	if s, ok := r.(io.Seeker); ok {
+		if off, err := s.Seek(0, io.SeekCurrent); err == nil {
+			d.start = off
+		}
+	}
+	d.br = newBitReader(r, 0)
+
+	var magic [4]byte
+	if err := d.readFull(magic[:]); err != nil {
+		return nil, fmt.Errorf("flac: failed to read magic: %v", err)
+	}
+	if string(magic[:]) != "fLaC" {
+		return nil, ErrFormat
+	}
+
+	seenInfo := false
+	for last := false; !last; {
+		var hdr [4]byte
+		if err := d.readFull(hdr[:]); err != nil {
+			return nil, fmt.Errorf("flac: failed to read metadata block header: %v", err)
+		}
+		last = hdr[0]&0x80 != 0
+		typ := hdr[0] & 0x7f
+		size := int(hdr[1])<<16 | int(hdr[2])<<8 | int(hdr[3])
+		b := make([]byte, size)
+		if err := d.readFull(b); err != nil {
+			return nil, fmt.Errorf("flac: failed to read metadata block %d: %v", typ, err)
+		}
+
+		var err error
+		switch typ {
+		case BLOCK_STREAMINFO:
+			err = d.decodeStreamInfo(b)
+			seenInfo = true
+		case BLOCK_SEEKTABLE:
+			d.decodeSeekTable(b)
+		case BLOCK_VORBIS_COMMENT:
+			err = d.decodeComments(b)
+		case BLOCK_PADDING:
+		default:
+			d.Blocks = append(d.Blocks, Block{typ, b})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !seenInfo {
+		return nil, fmt.Errorf("flac: missing STREAMINFO block")
+	}
+	d.firstFrame = d.br.off
+	return d, nil
+}
+
+func (d *Decoder) readFull(b []byte) error {
+	for i := range b {
+		b[i] = d.br.readByte()
+	}
+	return d.br.err
+}
+
+func (d *Decoder) decodeStreamInfo(b []byte) error {
+	if len(b) < 34 {
+		return fmt.Errorf("flac: STREAMINFO block too short")
+	}
+	be := binary.BigEndian
+	i := &d.Info
+	i.MinBlockSize = be.Uint16(b[0:])
+	i.MaxBlockSize = be.Uint16(b[2:])
+	i.MinFrameSize = uint32(b[4])<<16 | uint32(b[5])<<8 | uint32(b[6])
+	i.MaxFrameSize = uint32(b[7])<<16 | uint32(b[8])<<8 | uint32(b[9])
+	v := be.Uint64(b[10:])
+	i.SampleRate = uint32(v >> 44)
+	i.Channels = uint8(v>>41&7) + 1
+	i.BitsPerSample = uint8(v>>36&0x1f) + 1
+	i.TotalSamples = v & (1<<36 - 1)
+	copy(i.MD5[:], b[18:34])
+	if i.SampleRate == 0 {
+		return fmt.Errorf("flac: invalid sample rate 0")
+	}
+	return nil
+}
+
+func (d *Decoder) decodeSeekTable(b []byte) {
+	be := binary.BigEndian
+	for ; len(b) >= 18; b = b[18:] {
+		p := SeekPoint{be.Uint64(b), be.Uint64(b[8:]), be.Uint16(b[16:])}
+		if p.Sample != placeholderPoint {
+			d.SeekTable = append(d.SeekTable, p)
+		}
+	}
+}
+
+func (d *Decoder) decodeComments(b []byte) error {
+	le := binary.LittleEndian
+	str := func() (string, bool) {
+		if len(b) < 4 {
+			return "", false
+		}
+		n := int(le.Uint32(b))
+		if n < 0 || 4+n > len(b) {
+			return "", false
+		}
+		s := string(b[4 : 4+n])
+		b = b[4+n:]
+		return s, true
+	}
+
+	var ok bool
+	if d.Vendor, ok = str(); !ok || len(b) < 4 {
+		return fmt.Errorf("flac: invalid VORBIS_COMMENT block")
+	}
+	n := int(le.Uint32(b))
+	b = b[4:]
+	for i := 0; i < n; i++ {
+		s, ok := str()
+		if !ok {
+			return fmt.Errorf("flac: VORBIS_COMMENT block truncated at comment %d", i)
+		}
+		d.Comments = append(d.Comments, s)
+	}
+	return nil
+}
+
+// Tell returns the index of the next sample to be returned by Read.
+func (d *Decoder) Tell() uint64 {
+	return d.pos
+}
+
+// Read returns the next block of samples, one slice per channel.
+func (d *Decoder) Read() ([][]int32, error) {
+	if d.pending != nil {
+		p := d.pending
+		d.pending = nil
+		d.pos += uint64(len(p[0]))
+		return p, nil
+	}
+	f, err := d.ReadFrame()
+	if err != nil {
+		return nil, err
+	}
+	d.pos = f.Number + uint64(f.BlockSize)
+	return f.Samples, nil
+}
+
+// Seek positions the decoder at the given sample using the seek table
+// when present, falling back to a linear scan from the first frame.
+func (d *Decoder) Seek(sample uint64) error {
+	if d.Info.TotalSamples != 0 && sample >= d.Info.TotalSamples {
+		return fmt.Errorf("flac: seek to sample %d past end of stream (%d)", sample, d.Info.TotalSamples)
+	}
+
+	s, ok := d.r.(io.Seeker)
+	if !ok {
+		if sample < d.pos {
+			return ErrSeek
+		}
+	} else {
+		off := uint64(0)
+		for _, p := range d.SeekTable {
+			if p.Sample <= sample {
+				off = p.Offset
+			}
+		}
+		if _, err := s.Seek(d.start+d.firstFrame+int64(off), io.SeekStart); err != nil {
+			return err
+		}
+		d.br.reset(d.r, d.firstFrame+int64(off))
+		d.pending = nil
+	}
+
+	for {
+		f, err := d.ReadFrame()
+		if err != nil {
+			return err
+		}
+		end := f.Number + uint64(f.BlockSize)
+		if sample < f.Number {
+			return fmt.Errorf("flac: seek target %d precedes frame at %d", sample, f.Number)
+		}
+		if sample < end {
+			i := sample - f.Number
+			d.pending = make([][]int32, len(f.Samples))
+			for c := range f.Samples {
+				d.pending[c] = f.Samples[c][i:]
+			}
+			d.pos = sample
+			return nil
+		}
+	}
+}
+
+func (d *Decoder) ReadFrame() (*Frame, error) {
+	br := d.br
+	if err := d.sync(); err != nil {
+		return nil, err
+	}
+
+	h, err := d.readFrameHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([][]int32, h.Channels)
+	for c := range samples {
+		bps := uint(h.BitsPerSample)
+		switch {
+		case h.ChannelAssignment == 8 && c == 1,
+			h.ChannelAssignment == 9 && c == 0,
+			h.ChannelAssignment == 10 && c == 1:
+			bps++
+		}
+		samples[c] = make([]int32, h.BlockSize)
+		if err := d.readSubframe(samples[c], bps); err != nil {
+			return nil, fmt.Errorf("flac: frame %d channel %d: %v", h.Number, c, err)
+		}
+	}
+
+	br.align()
+	crc := br.crc16
+	if want := uint16(br.read(16)); br.err == nil && want != crc {
+		return nil, fmt.Errorf("flac: frame %d crc-16 mismatch %#x != %#x", h.Number, want, crc)
+	}
+	if br.err != nil {
+		return nil, br.err
+	}
+
+	decorrelate(h.ChannelAssignment, samples)
+	return &Frame{h, samples}, nil
+}
+
+func (d *Decoder) sync() error {
+	br := d.br
+	br.align()
+	br.nbits = 0
+	for {
+		c := br.readByte()
+		if br.err != nil {
+			if br.err == io.ErrUnexpectedEOF {
+				return io.EOF
+			}
+			return br.err
+		}
+		for c == 0xff {
+			c = br.readByte()
+			if br.err != nil {
+				return br.err
+			}
+			if c&0xfe == 0xf8 {
+				br.resetCRC(0xff, c)
+				br.bits = uint64(c)
+				br.nbits = 1
+				return nil
+			}
+		}
+	}
+}
+
+var blockSizes = [16]int{0, 192, 576, 1152, 2304, 4608, -8, -16, 256, 512, 1024, 2048, 4096, 8192, 16384, 32768}
+var sampleRates = [12]uint32{0, 88200, 176400, 192000, 8000, 16000, 22050, 24000, 32000, 44100, 48000, 96000}
+var sampleSizes = [8]uint8{0, 8, 12, 0, 16, 20, 24, 32}
+
+func (d *Decoder) readFrameHeader() (FrameHeader, error) {
+	br := d.br
+	var h FrameHeader
+	h.VariableBlockSize = br.readBit()
+	bs := br.read(4)
+	sr := br.read(4)
+	ca := uint8(br.read(4))
+	ss := br.read(3)
+	br.read(1)
+
+	num, ok := br.readUTF8()
+	if !ok {
+		return h, fmt.Errorf("flac: invalid frame number coding")
+	}
+
+	switch n := blockSizes[bs]; {
+	case n == -8:
+		h.BlockSize = int(br.read(8)) + 1
+	case n == -16:
+		h.BlockSize = int(br.read(16)) + 1
+	case n == 0:
+		return h, fmt.Errorf("flac: reserved block size")
+	default:
+		h.BlockSize = n
+	}
+
+	switch {
+	case sr == 0:
+		h.SampleRate = d.Info.SampleRate
+	case sr < 12:
+		h.SampleRate = sampleRates[sr]
+	case sr == 12:
+		h.SampleRate = uint32(br.read(8)) * 1000
+	case sr == 13:
+		h.SampleRate = uint32(br.read(16))
+	case sr == 14:
+		h.SampleRate = uint32(br.read(16)) * 10
+	default:
+		return h, fmt.Errorf("flac: invalid sample rate code")
+	}
+
+	switch {
+	case ca < 8:
+		h.Channels = int(ca) + 1
+	case ca <= 10:
+		h.Channels = 2
+	default:
+		return h, fmt.Errorf("flac: reserved channel assignment %d", ca)
+	}
+	h.ChannelAssignment = ca
+
+	if ss == 0 {
+		h.BitsPerSample = d.Info.BitsPerSample
+	} else if h.BitsPerSample = sampleSizes[ss]; h.BitsPerSample == 0 {
+		return h, fmt.Errorf("flac: reserved sample size")
+	}
+
+	crc := br.crc8
+	if want := uint8(br.read(8)); br.err == nil && want != crc {
+		return h, fmt.Errorf("flac: frame header crc-8 mismatch %#x != %#x", want, crc)
+	}
+	if br.err != nil {
+		return h, br.err
+	}
+
+	h.Number = num
+	if !h.VariableBlockSize {
+		h.Number = num * uint64(d.Info.MaxBlockSize)
+		if d.Info.MinBlockSize != d.Info.MaxBlockSize || d.Info.MaxBlockSize == 0 {
+			h.Number = num * uint64(h.BlockSize)
+		}
+	}
+	return h, nil
+}
+
+func (d *Decoder) readSubframe(s []int32, bps uint) error {
+	br := d.br
+	if br.readBit() {
+		return fmt.Errorf("invalid subframe padding")
+	}
+	typ := br.read(6)
+
+	wasted := uint(0)
+	if br.readBit() {
+		wasted = uint(br.readUnary()) + 1
+		if wasted >= bps {
+			return fmt.Errorf("invalid wasted bits %d", wasted)
+		}
+		bps -= wasted
+	}
+
+	var err error
+	switch {
+	case typ == 0:
+		v := int32(br.readSigned(bps))
+		for i := range s {
+			s[i] = v
+		}
+	case typ == 1:
+		for i := range s {
+			s[i] = int32(br.readSigned(bps))
+		}
+	case typ >= 8 && typ <= 12:
+		err = d.readFixed(s, bps, int(typ-8))
+	case typ >= 32:
+		err = d.readLPC(s, bps, int(typ-31))
+	default:
+		err = fmt.Errorf("reserved subframe type %d", typ)
+	}
+	if err != nil {
+		return err
+	}
+	if br.err != nil {
+		return br.err
+	}
+
+	if wasted > 0 {
+		for i := range s {
+			s[i] <<= wasted
+		}
+	}
+	return nil
+}
+
+func (d *Decoder) readFixed(s []int32, bps uint, order int) error {
+	if order > len(s) {
+		return fmt.Errorf("fixed predictor order %d exceeds block size %d", order, len(s))
+	}
+	for i := 0; i < order; i++ {
+		s[i] = int32(d.br.readSigned(bps))
+	}
+	if err := d.readResidual(s, order); err != nil {
+		return err
+	}
+
+	switch order {
+	case 1:
+		for i := 1; i < len(s); i++ {
+			s[i] += s[i-1]
+		}
+	case 2:
+		for i := 2; i < len(s); i++ {
+			s[i] += 2*s[i-1] - s[i-2]
+		}
+	case 3:
+		for i := 3; i < len(s); i++ {
+			s[i] += 3*s[i-1] - 3*s[i-2] + s[i-3]
+		}
+	case 4:
+		for i := 4; i < len(s); i++ {
+			s[i] += 4*s[i-1] - 6*s[i-2] + 4*s[i-3] - s[i-4]
+		}
+	}
+	return nil
+}
+
+func (d *Decoder) readLPC(s []int32, bps uint, order int) error {
+	br := d.br
+	if order > len(s) {
+		return fmt.Errorf("lpc order %d exceeds block size %d", order, len(s))
+	}
+	for i := 0; i < order; i++ {
+		s[i] = int32(br.readSigned(bps))
+	}
+
+	prec := uint(br.read(4)) + 1
+	if prec == 16 {
+		return fmt.Errorf("invalid lpc coefficient precision")
+	}
+	shift := br.readSigned(5)
+	if shift < 0 {
+		return fmt.Errorf("negative lpc shift %d", shift)
+	}
+	coefs := make([]int64, order)
+	for i := range coefs {
+		coefs[i] = br.readSigned(prec)
+	}
+
+	if err := d.readResidual(s, order); err != nil {
+		return err
+	}
+
+	for i := order; i < len(s); i++ {
+		var sum int64
+		for j, c := range coefs {
+			sum += c * int64(s[i-1-j])
+		}
+		s[i] += int32(sum >> uint(shift))
+	}
+	return nil
+}
+
+func (d *Decoder) readResidual(s []int32, order int) error {
+	br := d.br
+	method := br.read(2)
+	if method > 1 {
+		return fmt.Errorf("reserved residual coding method %d", method)
+	}
+	pbits, escape := uint(4), uint64(0xf)
+	if method == 1 {
+		pbits, escape = 5, 0x1f
+	}
+
+	porder := uint(br.read(4))
+	parts := 1 << porder
+	if len(s)%parts != 0 || len(s)>>porder < order {
+		return fmt.Errorf("invalid residual partition order %d", porder)
+	}
+
+	i := order
+	for p := 0; p < parts; p++ {
+		n := len(s) >> porder
+		if p == 0 {
+			n -= order
+		}
+
+		k := br.read(pbits)
+		if k == escape {
+			nb := uint(br.read(5))
+			for j := 0; j < n; j++ {
+				s[i] = int32(br.readSigned(nb))
+				i++
+			}
+			continue
+		}
+
+		for j := 0; j < n; j++ {
+			q := br.readUnary()
+			v := q<<k | br.read(uint(k))
+			s[i] = int32(v>>1) ^ -int32(v&1)
+			i++
+		}
+		if br.err != nil {
+			return br.err
+		}
+	}
+	return nil
+}
+
+func decorrelate(ca uint8, s [][]int32) {
+	switch ca {
+	case 8:
+		for i := range s[0] {
+			s[1][i] = s[0][i] - s[1][i]
+		}
+	case 9:
+		for i := range s[0] {
+			s[0][i] += s[1][i]
+		}
+	case 10:
+		for i := range s[0] {
+			m := int64(s[0][i])<<1 | int64(s[1][i])&1
+			side := int64(s[1][i])
+			s[0][i] = int32((m + side) >> 1)
+			s[1][i] = int32((m - side) >> 1)
+		}
+	}
+}
+
+func (d *Decoder) Format() wav.Format {
+	return wav.NewPCMFormat(int(d.Info.Channels), int(d.Info.SampleRate), int(d.Info.BitsPerSample))
+}
+
+func Decode(r io.Reader) (*wav.File, error) {
+	d, err := NewDecoder(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &wav.File{Format: d.Format()}
+	snd.SetComments(f, d.Comments)
+
+	bps := uint(d.Info.BitsPerSample)
+	size := (bps + 7) / 8
+	shift := size*8 - bps
+	ch := int(d.Info.Channels)
+	verify := d.Info.MD5 != [16]byte{}
+
+	h := md5.New()
+	data := new(bytes.Buffer)
+	if d.Info.TotalSamples != 0 {
+		data.Grow(int(d.Info.TotalSamples) * ch * int(size))
+	}
+	var frame, sum []byte
+	for {
+		s, err := d.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(s) != ch {
+			return nil, fmt.Errorf("flac: frame has %d channels, stream has %d", len(s), ch)
+		}
+
+		n := len(s[0]) * ch * int(size)
+		if cap(frame) < n {
+			frame = make([]byte, n)
+			sum = make([]byte, n)
+		}
+		frame, sum = frame[:n], sum[:n]
+		p := 0
+		for i := range s[0] {
+			for c := 0; c < ch; c++ {
+				v := s[c][i]
+				u := uint32(v) << shift
+				for j := uint(0); j < size; j++ {
+					sum[p] = byte(v >> (8 * j))
+					frame[p] = byte(u >> (8 * j))
+					p++
+				}
+				if size == 1 {
+					frame[p-1] ^= 0x80
+				}
+			}
+		}
+		if verify {
+			h.Write(sum)
+		}
+		data.Write(frame)
+	}
+
+	if verify && !bytes.Equal(h.Sum(nil), d.Info.MD5[:]) {
+		return nil, ErrChecksum
+	}
+	f.Data = data.Bytes()
+	return f, nil
+}
+
+func DecodeConfig(r io.Reader) (wav.Format, error) {
+	d, err := NewDecoder(r)
+	if err != nil {
+		return wav.Format{}, err
+	}
+	return d.Format(), nil
+}
+
+func init() {
+	snd.RegisterFormat("flac", "fLaC", Decode, DecodeConfig)
+}
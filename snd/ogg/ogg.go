@@ -0,0 +1,219 @@
+package ogg
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	PAGE_CONTINUED = 1 << 0
+	PAGE_BOS       = 1 << 1
+	PAGE_EOS       = 1 << 2
+)
+
+const maxPageSize = 27 + 255 + 255*255
+
+var (
+	ErrFormat   = errors.New("ogg: invalid format")
+	ErrChecksum = errors.New("ogg: page checksum mismatch")
+)
+
+var crcTable = makeCRCTable()
+
+func makeCRCTable() (t [256]uint32) {
+	for i := range t {
+		c := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if c&0x80000000 != 0 {
+				c = c<<1 ^ 0x04c11db7
+			} else {
+				c <<= 1
+			}
+		}
+		t[i] = c
+	}
+	return
+}
+
+func CRC(crc uint32, b []byte) uint32 {
+	for _, c := range b {
+		crc = crc<<8 ^ crcTable[uint8(crc>>24)^c]
+	}
+	return crc
+}
+
+type Page struct {
+	Version  uint8
+	Type     uint8
+	Granule  int64
+	Serial   uint32
+	Sequence uint32
+	CRC      uint32
+	Segments []uint8
+	Data     []byte
+}
+
+type Packet struct {
+	Data []byte
+
+	// Granule is the granule position of the page the packet ended on if
+	// it is the last packet completed on that page, otherwise -1.
+	Granule int64
+	EOS     bool
+}
+
+// Reader reads pages and packets of the first logical bitstream in
+// a physical Ogg stream, pages of other multiplexed streams are skipped.
+type Reader struct {
+	r      *bufio.Reader
+	serial uint32
+	seen   bool
+	page   *Page
+	seg    int
+	off    int
+	eos    bool
+	buf    [maxPageSize]byte
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+func (r *Reader) ReadPage() (*Page, error) {
+	h := r.buf[:27]
+	if _, err := io.ReadFull(r.r, h); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("ogg: truncated page header")
+		}
+		return nil, err
+	}
+	if string(h[:4]) != "OggS" {
+		return nil, ErrFormat
+	}
+
+	le := binary.LittleEndian
+	p := &Page{
+		Version:  h[4],
+		Type:     h[5],
+		Granule:  int64(le.Uint64(h[6:])),
+		Serial:   le.Uint32(h[14:]),
+		Sequence: le.Uint32(h[18:]),
+		CRC:      le.Uint32(h[22:]),
+	}
+	if p.Version != 0 {
+		return nil, fmt.Errorf("ogg: unsupported stream structure version %d", p.Version)
+	}
+
+	nseg := int(h[26])
+	seg := r.buf[27 : 27+nseg]
+	if _, err := io.ReadFull(r.r, seg); err != nil {
+		return nil, fmt.Errorf("ogg: truncated segment table: %v", err)
+	}
+	size := 0
+	for _, n := range seg {
+		size += int(n)
+	}
+	data := r.buf[27+nseg : 27+nseg+size]
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return nil, fmt.Errorf("ogg: truncated page data: %v", err)
+	}
+
+	h[22], h[23], h[24], h[25] = 0, 0, 0, 0
+	if CRC(0, r.buf[:27+nseg+size]) != p.CRC {
+		return nil, ErrChecksum
+	}
+
+	p.Segments = append([]uint8(nil), seg...)
+	p.Data = append([]byte(nil), data...)
+	return p, nil
+}
+
+func (r *Reader) nextPage() error {
+	for {
+		p, err := r.ReadPage()
+		if err != nil {
+			return err
+		}
+		if !r.seen {
+			r.seen = true
+			r.serial = p.Serial
+		}
+		if p.Serial != r.serial {
+			continue
+		}
+		r.page, r.seg, r.off = p, 0, 0
+		return nil
+	}
+}
+
+// ReadPacket returns the next complete packet, joining packets that span
+// multiple pages.
+func (r *Reader) ReadPacket() (Packet, error) {
+	var b []byte
+	started := false
+	for {
+		if r.page == nil || r.seg >= len(r.page.Segments) {
+			if r.eos {
+				return Packet{}, io.EOF
+			}
+			if err := r.nextPage(); err != nil {
+				if err == io.EOF && started {
+					err = io.ErrUnexpectedEOF
+				}
+				return Packet{}, err
+			}
+			if !started && r.page.Type&PAGE_CONTINUED != 0 {
+				r.skipContinued()
+			}
+			if r.page.Type&PAGE_EOS != 0 {
+				r.eos = true
+			}
+			continue
+		}
+
+		p := r.page
+		for r.seg < len(p.Segments) {
+			n := int(p.Segments[r.seg])
+			b = append(b, p.Data[r.off:r.off+n]...)
+			r.seg++
+			r.off += n
+			started = true
+			if n < 255 {
+				pk := Packet{Data: b, Granule: -1}
+				if r.seg == len(p.Segments) || !r.completesAfter() {
+					pk.Granule = p.Granule
+					pk.EOS = r.eos && r.seg == len(p.Segments)
+				}
+				return pk, nil
+			}
+		}
+	}
+}
+
+// completesAfter reports whether another packet ends on the current page
+// after the current segment.
+func (r *Reader) completesAfter() bool {
+	for _, n := range r.page.Segments[r.seg:] {
+		if n < 255 {
+			return true
+		}
+	}
+	return false
+}
+
+// skipContinued drops the tail of a packet whose start was never seen,
+// which happens when reading begins mid-stream.
+func (r *Reader) skipContinued() {
+	p := r.page
+	for r.seg < len(p.Segments) {
+		n := int(p.Segments[r.seg])
+		r.seg++
+		r.off += n
+		if n < 255 {
+			break
+		}
+	}
+}
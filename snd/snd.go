@@ -0,0 +1,95 @@
+package snd
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/qeedquan/go-media/snd/wav"
+)
+
+var ErrFormat = errors.New("snd: unknown format")
+
+type format struct {
+	name, magic  string
+	decode       func(io.Reader) (*wav.File, error)
+	decodeConfig func(io.Reader) (wav.Format, error)
+}
+
+var (
+	formatsMu     sync.Mutex
+	atomicFormats atomic.Value
+)
+
+// RegisterFormat registers an audio format for use by Decode, the magic
+// string may contain ? wildcards that match any one byte. Decoders return
+// the samples as a wav.File so all formats share one representation.
+func RegisterFormat(name, magic string, decode func(io.Reader) (*wav.File, error), decodeConfig func(io.Reader) (wav.Format, error)) {
+	formatsMu.Lock()
+	formats, _ := atomicFormats.Load().([]format)
+	atomicFormats.Store(append(formats, format{name, magic, decode, decodeConfig}))
+	formatsMu.Unlock()
+}
+
+type reader interface {
+	io.Reader
+	Peek(int) ([]byte, error)
+}
+
+func asReader(r io.Reader) reader {
+	if rr, ok := r.(reader); ok {
+		return rr
+	}
+	return bufio.NewReader(r)
+}
+
+func match(magic string, b []byte) bool {
+	if len(magic) != len(b) {
+		return false
+	}
+	for i, c := range b {
+		if magic[i] != c && magic[i] != '?' {
+			return false
+		}
+	}
+	return true
+}
+
+func sniff(r reader) format {
+	formats, _ := atomicFormats.Load().([]format)
+	for _, f := range formats {
+		b, err := r.Peek(len(f.magic))
+		if err == nil && match(f.magic, b) {
+			return f
+		}
+	}
+	return format{}
+}
+
+func Decode(r io.Reader) (*wav.File, string, error) {
+	rr := asReader(r)
+	f := sniff(rr)
+	if f.decode == nil {
+		return nil, "", ErrFormat
+	}
+	m, err := f.decode(rr)
+	return m, f.name, err
+}
+
+func DecodeConfig(r io.Reader) (wav.Format, string, error) {
+	rr := asReader(r)
+	f := sniff(rr)
+	if f.decodeConfig == nil {
+		return wav.Format{}, "", ErrFormat
+	}
+	c, err := f.decodeConfig(rr)
+	return c, f.name, err
+}
+
+func init() {
+	for _, magic := range []string{"RIFF????WAVE", "RF64????WAVE", "BW64????WAVE"} {
+		RegisterFormat("wav", magic, wav.Decode, wav.DecodeConfig)
+	}
+}
@@ -0,0 +1,49 @@
+package vorbis
+
+import "math/bits"
+
+// bitReader reads a packet least significant bit first, reads past the end
+// return zero and set eop.
+type bitReader struct {
+	b   []byte
+	pos int
+	bit uint
+	eop bool
+}
+
+func (r *bitReader) read(n uint) uint32 {
+	var v uint32
+	for i := uint(0); i < n; {
+		if r.pos >= len(r.b) {
+			r.eop = true
+			return 0
+		}
+		k := 8 - r.bit
+		if k > n-i {
+			k = n - i
+		}
+		c := uint32(r.b[r.pos]) >> r.bit & (1<<k - 1)
+		v |= c << i
+		i += k
+		if r.bit += k; r.bit == 8 {
+			r.bit = 0
+			r.pos++
+		}
+	}
+	return v
+}
+
+func (r *bitReader) readBit() bool {
+	return r.read(1) != 0
+}
+
+func (r *bitReader) readInt(n uint) int {
+	return int(r.read(n))
+}
+
+func ilog(x int) uint {
+	if x <= 0 {
+		return 0
+	}
+	return uint(bits.Len32(uint32(x)))
+}
@@ -0,0 +1,213 @@
+package vorbis
+
+import (
+	"fmt"
+	"math"
+)
+
+type codebook struct {
+	dims    int
+	entries int
+	lengths []uint8
+
+	// tree holds the huffman decode tree, a child is a node index when
+	// positive, -(entry+1) when negative and missing when zero.
+	tree [][2]int32
+
+	// lookup holds the dims wide VQ vector of every entry.
+	lookup []float32
+}
+
+func (d *Decoder) readCodebook(r *bitReader) (codebook, error) {
+	var c codebook
+	if r.read(24) != 0x564342 {
+		return c, fmt.Errorf("vorbis: invalid codebook sync pattern")
+	}
+	c.dims = r.readInt(16)
+	c.entries = r.readInt(24)
+	if c.dims == 0 {
+		return c, fmt.Errorf("vorbis: codebook has zero dimensions")
+	}
+
+	c.lengths = make([]uint8, c.entries)
+	if ordered := r.readBit(); !ordered {
+		sparse := r.readBit()
+		for i := range c.lengths {
+			if !sparse || r.readBit() {
+				c.lengths[i] = uint8(r.read(5)) + 1
+			}
+		}
+	} else {
+		length := r.readInt(5) + 1
+		for i := 0; i < c.entries; length++ {
+			n := r.readInt(ilog(c.entries - i))
+			if i+n > c.entries || length > 32 {
+				return c, fmt.Errorf("vorbis: invalid ordered codebook lengths")
+			}
+			for j := 0; j < n; j++ {
+				c.lengths[i+j] = uint8(length)
+			}
+			i += n
+		}
+	}
+
+	switch typ := r.read(4); typ {
+	case 0:
+	case 1, 2:
+		min := float32Unpack(r.read(32))
+		delta := float32Unpack(r.read(32))
+		bits := uint(r.read(4)) + 1
+		seq := r.readBit()
+
+		n := c.entries * c.dims
+		if typ == 1 {
+			n = lookup1Values(c.entries, c.dims)
+		}
+		mult := make([]float32, n)
+		for i := range mult {
+			mult[i] = float32(r.read(bits))
+		}
+
+		c.lookup = make([]float32, c.entries*c.dims)
+		for e := 0; e < c.entries; e++ {
+			last := float32(0)
+			div := 1
+			for i := 0; i < c.dims; i++ {
+				off := e*c.dims + i
+				if typ == 1 {
+					off = e / div % n
+					div *= n
+				}
+				v := mult[off]*delta + min + last
+				if seq {
+					last = v
+				}
+				c.lookup[e*c.dims+i] = v
+			}
+		}
+	default:
+		return c, fmt.Errorf("vorbis: invalid codebook lookup type %d", typ)
+	}
+
+	if r.eop {
+		return c, fmt.Errorf("vorbis: setup header truncated in codebook")
+	}
+	return c, c.build()
+}
+
+func (c *codebook) build() error {
+	var marker [33]uint32
+	codes := make([]uint32, c.entries)
+	used, last := 0, 0
+	for i, l := range c.lengths {
+		if l == 0 {
+			continue
+		}
+		e := marker[l]
+		if l < 32 && e>>l != 0 {
+			return fmt.Errorf("vorbis: overspecified huffman tree")
+		}
+		codes[i] = e
+		used, last = used+1, i
+
+		for j := int(l); j > 0; j-- {
+			if marker[j]&1 != 0 {
+				if j == 1 {
+					marker[1]++
+				} else {
+					marker[j] = marker[j-1] << 1
+				}
+				break
+			}
+			marker[j]++
+		}
+		for j := int(l) + 1; j < 33; j++ {
+			if marker[j]>>1 != e {
+				break
+			}
+			e = marker[j]
+			marker[j] = marker[j-1] << 1
+		}
+	}
+
+	c.tree = make([][2]int32, 1)
+	if used == 1 {
+		c.tree[0] = [2]int32{-int32(last) - 1, -int32(last) - 1}
+		return nil
+	}
+	for i, l := range c.lengths {
+		if l == 0 {
+			continue
+		}
+		node := int32(0)
+		for b := int(l) - 1; b > 0; b-- {
+			bit := codes[i] >> uint(b) & 1
+			next := c.tree[node][bit]
+			if next < 0 {
+				return fmt.Errorf("vorbis: ambiguous huffman code")
+			}
+			if next == 0 {
+				next = int32(len(c.tree))
+				c.tree = append(c.tree, [2]int32{})
+				c.tree[node][bit] = next
+			}
+			node = next
+		}
+		bit := codes[i] & 1
+		if c.tree[node][bit] != 0 {
+			return fmt.Errorf("vorbis: ambiguous huffman code")
+		}
+		c.tree[node][bit] = -int32(i) - 1
+	}
+	return nil
+}
+
+// decode returns the next entry or -1 at end of packet or on an invalid code.
+func (c *codebook) decode(r *bitReader) int {
+	node := int32(0)
+	for {
+		b := r.read(1)
+		if r.eop {
+			return -1
+		}
+		next := c.tree[node][b]
+		if next < 0 {
+			return int(-next - 1)
+		}
+		if next == 0 {
+			return -1
+		}
+		node = next
+	}
+}
+
+func (c *codebook) vector(e int) []float32 {
+	return c.lookup[e*c.dims : (e+1)*c.dims]
+}
+
+func float32Unpack(x uint32) float32 {
+	m := float64(x & 0x1fffff)
+	if x&0x80000000 != 0 {
+		m = -m
+	}
+	return float32(math.Ldexp(m, int(x>>21&0x3ff)-788))
+}
+
+// lookup1Values returns the largest integer r with r^dims <= entries.
+func lookup1Values(entries, dims int) int {
+	r := int(math.Floor(math.Pow(float64(entries), 1/float64(dims))))
+	pow := func(r int) int {
+		p := 1
+		for i := 0; i < dims && p <= entries; i++ {
+			p *= r
+		}
+		return p
+	}
+	for pow(r+1) <= entries {
+		r++
+	}
+	for r > 0 && pow(r) > entries {
+		r--
+	}
+	return r
+}
@@ -0,0 +1,370 @@
+package vorbis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+type floor interface {
+	// decode reads the floor of one channel and renders its curve into out,
+	// returning false if the channel is unused in this packet.
+	decode(d *Decoder, r *bitReader, out []float32) bool
+}
+
+type floor0 struct {
+	order       int
+	rate        int
+	barkMapSize int
+	ampBits     uint
+	ampOffset   int
+	books       []int
+	maps        map[int][]int
+}
+
+type floor1 struct {
+	partClass []int
+	classDims []int
+	classSub  []uint
+	classBook []int
+	subBooks  [][]int
+	mult      int
+	xs        []int
+	order     []int
+	low       []int
+	high      []int
+}
+
+var floor1Ranges = [4]int{256, 128, 86, 64}
+
+var invDB = makeInvDB()
+
+// makeInvDB returns the floor1 inverse dB table, which spans 140 dB in
+// 256 equal steps up to unity.
+func makeInvDB() (t [256]float32) {
+	for i := range t {
+		t[i] = float32(math.Exp(math.Log(1.0649863e-07) * float64(255-i) / 255))
+	}
+	return
+}
+
+func (d *Decoder) readFloor(r *bitReader) (floor, error) {
+	switch typ := r.read(16); typ {
+	case 0:
+		f := &floor0{
+			order:       r.readInt(8),
+			rate:        r.readInt(16),
+			barkMapSize: r.readInt(16),
+			ampBits:     uint(r.read(6)),
+			ampOffset:   r.readInt(8),
+			maps:        make(map[int][]int),
+		}
+		f.books = make([]int, r.readInt(4)+1)
+		for i := range f.books {
+			if f.books[i] = r.readInt(8); f.books[i] >= len(d.books) || d.books[f.books[i]].lookup == nil {
+				return nil, fmt.Errorf("vorbis: floor0 references invalid codebook %d", f.books[i])
+			}
+		}
+		if f.order < 1 || f.rate == 0 || f.barkMapSize == 0 {
+			return nil, fmt.Errorf("vorbis: invalid floor0 parameters")
+		}
+		return f, nil
+
+	case 1:
+		f := &floor1{}
+		f.partClass = make([]int, r.readInt(5))
+		maxClass := -1
+		for i := range f.partClass {
+			f.partClass[i] = r.readInt(4)
+			if f.partClass[i] > maxClass {
+				maxClass = f.partClass[i]
+			}
+		}
+
+		book := func() (int, error) {
+			n := r.readInt(8)
+			if n >= len(d.books) {
+				return 0, fmt.Errorf("vorbis: floor1 references invalid codebook %d", n)
+			}
+			return n, nil
+		}
+		n := maxClass + 1
+		f.classDims = make([]int, n)
+		f.classSub = make([]uint, n)
+		f.classBook = make([]int, n)
+		f.subBooks = make([][]int, n)
+		for i := 0; i < n; i++ {
+			f.classDims[i] = r.readInt(3) + 1
+			f.classSub[i] = uint(r.read(2))
+			var err error
+			if f.classSub[i] != 0 {
+				if f.classBook[i], err = book(); err != nil {
+					return nil, err
+				}
+			}
+			f.subBooks[i] = make([]int, 1<<f.classSub[i])
+			for j := range f.subBooks[i] {
+				if f.subBooks[i][j] = r.readInt(8) - 1; f.subBooks[i][j] >= len(d.books) {
+					return nil, fmt.Errorf("vorbis: floor1 references invalid codebook %d", f.subBooks[i][j])
+				}
+			}
+		}
+
+		f.mult = r.readInt(2) + 1
+		bits := uint(r.read(4))
+		f.xs = []int{0, 1 << bits}
+		for _, c := range f.partClass {
+			for j := 0; j < f.classDims[c]; j++ {
+				f.xs = append(f.xs, r.readInt(bits))
+			}
+		}
+		if len(f.xs) > 65 {
+			return nil, fmt.Errorf("vorbis: floor1 has %d values", len(f.xs))
+		}
+
+		f.order = make([]int, len(f.xs))
+		for i := range f.order {
+			f.order[i] = i
+		}
+		sort.SliceStable(f.order, func(i, j int) bool { return f.xs[f.order[i]] < f.xs[f.order[j]] })
+		for i := 1; i < len(f.order); i++ {
+			if f.xs[f.order[i]] == f.xs[f.order[i-1]] {
+				return nil, fmt.Errorf("vorbis: floor1 has duplicate x value %d", f.xs[f.order[i]])
+			}
+		}
+
+		f.low = make([]int, len(f.xs))
+		f.high = make([]int, len(f.xs))
+		for i := 2; i < len(f.xs); i++ {
+			lo, hi := 0, 1
+			for j := 0; j < i; j++ {
+				if x := f.xs[j]; x < f.xs[i] && x > f.xs[lo] {
+					lo = j
+				}
+				if x := f.xs[j]; x > f.xs[i] && x < f.xs[hi] {
+					hi = j
+				}
+			}
+			f.low[i], f.high[i] = lo, hi
+		}
+		return f, nil
+
+	default:
+		return nil, fmt.Errorf("vorbis: invalid floor type %d", typ)
+	}
+}
+
+func (f *floor0) decode(d *Decoder, r *bitReader, out []float32) bool {
+	amp := r.read(f.ampBits)
+	if amp == 0 {
+		return false
+	}
+	n := r.readInt(ilog(len(f.books)))
+	if n >= len(f.books) {
+		return false
+	}
+	cb := &d.books[f.books[n]]
+
+	coefs := make([]float64, 0, f.order+cb.dims)
+	last := 0.0
+	for len(coefs) < f.order {
+		e := cb.decode(r)
+		if e < 0 {
+			return false
+		}
+		for _, v := range cb.vector(e) {
+			coefs = append(coefs, float64(v)+last)
+		}
+		last = coefs[len(coefs)-1]
+	}
+	for i := range coefs {
+		coefs[i] = math.Cos(coefs[i])
+	}
+
+	m := f.barkMap(len(out))
+	lin := float64(amp) * float64(f.ampOffset) / float64(uint64(1)<<f.ampBits-1)
+	for i := 0; i < len(out); {
+		w := math.Cos(math.Pi * float64(m[i]) / float64(f.barkMapSize))
+		p, q := 1.0, 1.0
+		if f.order%2 == 1 {
+			p = 1 - w*w
+			q = 0.25
+		} else {
+			p = (1 - w) / 2
+			q = (1 + w) / 2
+		}
+		for j := 0; j < f.order; j++ {
+			t := 4 * (coefs[j] - w) * (coefs[j] - w)
+			if j%2 == 1 {
+				p *= t
+			} else {
+				q *= t
+			}
+		}
+		v := float32(math.Exp(0.11512925 * (lin/math.Sqrt(p+q) - float64(f.ampOffset))))
+		for c := m[i]; i < len(out) && m[i] == c; i++ {
+			out[i] = v
+		}
+	}
+	return true
+}
+
+func (f *floor0) barkMap(n int) []int {
+	if m, ok := f.maps[n]; ok {
+		return m
+	}
+	bark := func(x float64) float64 {
+		return 13.1*math.Atan(.00074*x) + 2.24*math.Atan(.0000000185*x*x) + .0001*x
+	}
+	m := make([]int, n)
+	scale := float64(f.barkMapSize) / bark(.5*float64(f.rate))
+	for i := range m {
+		v := int(math.Floor(bark(float64(f.rate*i)/float64(2*n)) * scale))
+		if v > f.barkMapSize-1 {
+			v = f.barkMapSize - 1
+		}
+		m[i] = v
+	}
+	f.maps[n] = m
+	return m
+}
+
+func (f *floor1) decode(d *Decoder, r *bitReader, out []float32) bool {
+	if !r.readBit() {
+		return false
+	}
+	rng := floor1Ranges[f.mult-1]
+	bits := ilog(rng - 1)
+
+	y := make([]int, len(f.xs))
+	y[0] = r.readInt(bits)
+	y[1] = r.readInt(bits)
+	off := 2
+	for _, c := range f.partClass {
+		cdim := f.classDims[c]
+		cbits := f.classSub[c]
+		csub := 1<<cbits - 1
+		cval := 0
+		if cbits > 0 {
+			if cval = d.books[f.classBook[c]].decode(r); cval < 0 {
+				return false
+			}
+		}
+		for j := 0; j < cdim; j++ {
+			if b := f.subBooks[c][cval&csub]; b >= 0 {
+				if y[off+j] = d.books[b].decode(r); y[off+j] < 0 {
+					return false
+				}
+			}
+			cval >>= cbits
+		}
+		off += cdim
+	}
+	if r.eop {
+		return false
+	}
+
+	step2 := make([]bool, len(f.xs))
+	step2[0], step2[1] = true, true
+	for i := 2; i < len(f.xs); i++ {
+		lo, hi := f.low[i], f.high[i]
+		pred := renderPoint(f.xs[lo], y[lo], f.xs[hi], y[hi], f.xs[i])
+		v := y[i]
+		highroom, lowroom := rng-pred, pred
+		room := lowroom
+		if highroom < lowroom {
+			room = highroom
+		}
+		room *= 2
+
+		switch {
+		case v == 0:
+			y[i] = pred
+			continue
+		case v >= room && highroom > lowroom:
+			y[i] = v - lowroom + pred
+		case v >= room:
+			y[i] = pred - v + highroom - 1
+		case v&1 != 0:
+			y[i] = pred - (v+1)/2
+		default:
+			y[i] = pred + v/2
+		}
+		step2[lo], step2[hi], step2[i] = true, true, true
+	}
+
+	lx, ly := 0, y[f.order[0]]*f.mult
+	for _, i := range f.order[1:] {
+		if !step2[i] {
+			continue
+		}
+		hx, hy := f.xs[i], y[i]*f.mult
+		renderLine(lx, ly, hx, hy, out)
+		lx, ly = hx, hy
+	}
+	if lx < len(out) {
+		renderLine(lx, ly, len(out), ly, out)
+	}
+	return true
+}
+
+func renderPoint(x0, y0, x1, y1, x int) int {
+	dy := y1 - y0
+	adx := x1 - x0
+	ady := dy
+	if ady < 0 {
+		ady = -ady
+	}
+	off := ady * (x - x0) / adx
+	if dy < 0 {
+		return y0 - off
+	}
+	return y0 + off
+}
+
+func renderLine(x0, y0, x1, y1 int, out []float32) {
+	dy := y1 - y0
+	adx := x1 - x0
+	base := dy / adx
+	ady := dy
+	if ady < 0 {
+		ady = -ady
+	}
+	sy := base + 1
+	if dy < 0 {
+		sy = base - 1
+	}
+	if base < 0 {
+		ady -= -base * adx
+	} else {
+		ady -= base * adx
+	}
+
+	put := func(x, y int) {
+		if x < len(out) {
+			out[x] = invDB[clampY(y)]
+		}
+	}
+	y, err := y0, 0
+	put(x0, y)
+	for x := x0 + 1; x < x1; x++ {
+		err += ady
+		if err >= adx {
+			err -= adx
+			y += sy
+		} else {
+			y += base
+		}
+		put(x, y)
+	}
+}
+
+func clampY(y int) int {
+	if y < 0 {
+		return 0
+	}
+	if y > 255 {
+		return 255
+	}
+	return y
+}
@@ -0,0 +1,60 @@
+package vorbis
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/qeedquan/go-media/math/f64"
+)
+
+// imdct computes the inverse MDCT of an n point block through a size n/2
+// DCT-IV, which in turn is evaluated with an n/4 point complex FFT.
+type imdct struct {
+	n    int
+	pre  []complex128
+	post []complex128
+	z    []complex128
+	zf   []complex128
+	u    []float64
+}
+
+func newIMDCT(n int) *imdct {
+	k, m := n/2, n/4
+	t := &imdct{
+		n:    n,
+		pre:  make([]complex128, m),
+		post: make([]complex128, m),
+		z:    make([]complex128, m),
+		zf:   make([]complex128, m),
+		u:    make([]float64, k),
+	}
+	for i := 0; i < m; i++ {
+		t.pre[i] = cmplx.Rect(1, -math.Pi*float64(i)/float64(k))
+		t.post[i] = cmplx.Rect(1, -math.Pi*(float64(i)+0.25)/float64(k))
+	}
+	return t
+}
+
+func (t *imdct) transform(out, in []float32) {
+	k, m := t.n/2, t.n/4
+	for i := 0; i < m; i++ {
+		t.z[i] = complex(float64(in[2*i]), float64(in[k-1-2*i])) * t.pre[i]
+	}
+	f64.FFT1DC(t.zf, t.z)
+	for i := 0; i < m; i++ {
+		s := t.zf[i] * t.post[i]
+		t.u[2*i] = real(s)
+		t.u[k-1-2*i] = -imag(s)
+	}
+
+	for i := range out[:t.n] {
+		switch j := i + k/2; {
+		case j < k:
+			out[i] = float32(t.u[j])
+		case j < 2*k:
+			out[i] = float32(-t.u[2*k-1-j])
+		default:
+			out[i] = float32(-t.u[j-2*k])
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package vorbis
+
+import "fmt"
+
+type residue struct {
+	typ       int
+	begin     int
+	end       int
+	partSize  int
+	classes   int
+	classBook int
+	books     [][8]int
+}
+
+func (d *Decoder) readResidue(r *bitReader) (residue, error) {
+	var rs residue
+	rs.typ = r.readInt(16)
+	if rs.typ > 2 {
+		return rs, fmt.Errorf("vorbis: invalid residue type %d", rs.typ)
+	}
+	rs.begin = r.readInt(24)
+	rs.end = r.readInt(24)
+	rs.partSize = r.readInt(24) + 1
+	rs.classes = r.readInt(6) + 1
+	rs.classBook = r.readInt(8)
+	if rs.classBook >= len(d.books) {
+		return rs, fmt.Errorf("vorbis: residue references invalid codebook %d", rs.classBook)
+	}
+
+	cascade := make([]int, rs.classes)
+	for i := range cascade {
+		cascade[i] = r.readInt(3)
+		if r.readBit() {
+			cascade[i] |= r.readInt(5) << 3
+		}
+	}
+	rs.books = make([][8]int, rs.classes)
+	for i := range rs.books {
+		for j := range rs.books[i] {
+			rs.books[i][j] = -1
+			if cascade[i]>>uint(j)&1 == 0 {
+				continue
+			}
+			b := r.readInt(8)
+			if b >= len(d.books) || d.books[b].lookup == nil {
+				return rs, fmt.Errorf("vorbis: residue references invalid codebook %d", b)
+			}
+			rs.books[i][j] = b
+		}
+	}
+	return rs, nil
+}
+
+// decode adds the residue of the channels in v, channels marked in skip
+// are not coded in the packet and left as is.
+func (rs *residue) decode(d *Decoder, r *bitReader, v [][]float32, skip []bool) {
+	if rs.typ != 2 {
+		rs.decodeVectors(d, r, v, skip, rs.typ)
+		return
+	}
+
+	all := true
+	for _, s := range skip {
+		all = all && s
+	}
+	if all {
+		return
+	}
+	ch, n := len(v), len(v[0])
+	tmp := make([]float32, ch*n)
+	rs.decodeVectors(d, r, [][]float32{tmp}, []bool{false}, 1)
+	for i := 0; i < n; i++ {
+		for c := range v {
+			v[c][i] += tmp[i*ch+c]
+		}
+	}
+}
+
+func (rs *residue) decodeVectors(d *Decoder, r *bitReader, v [][]float32, skip []bool, format int) {
+	size := len(v[0])
+	begin, end := rs.begin, rs.end
+	if begin > size {
+		begin = size
+	}
+	if end > size {
+		end = size
+	}
+	if end <= begin {
+		return
+	}
+	nparts := (end - begin) / rs.partSize
+	if nparts == 0 {
+		return
+	}
+
+	cb := &d.books[rs.classBook]
+	cpw := cb.dims
+	classes := make([][]int, len(v))
+	for i := range classes {
+		classes[i] = make([]int, nparts+cpw)
+	}
+
+	for pass := 0; pass < 8; pass++ {
+		for p := 0; p < nparts; {
+			if pass == 0 {
+				for j := range v {
+					if skip[j] {
+						continue
+					}
+					t := cb.decode(r)
+					if t < 0 {
+						return
+					}
+					for i := cpw - 1; i >= 0; i-- {
+						classes[j][p+i] = t % rs.classes
+						t /= rs.classes
+					}
+				}
+			}
+			for i := 0; i < cpw && p < nparts; i, p = i+1, p+1 {
+				for j := range v {
+					if skip[j] {
+						continue
+					}
+					b := rs.books[classes[j][p]][pass]
+					if b < 0 {
+						continue
+					}
+					off := begin + p*rs.partSize
+					if !d.books[b].decodePartition(r, v[j][off:off+rs.partSize], format) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+func (c *codebook) decodePartition(r *bitReader, out []float32, format int) bool {
+	if format == 0 {
+		step := len(out) / c.dims
+		for i := 0; i < step; i++ {
+			e := c.decode(r)
+			if e < 0 {
+				return false
+			}
+			for j, x := range c.vector(e) {
+				out[i+j*step] += x
+			}
+		}
+		return true
+	}
+
+	for i := 0; i < len(out); {
+		e := c.decode(r)
+		if e < 0 {
+			return false
+		}
+		for _, x := range c.vector(e) {
+			if i >= len(out) {
+				break
+			}
+			out[i] += x
+			i++
+		}
+	}
+	return true
+}
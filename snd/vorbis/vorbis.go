@@ -0,0 +1,492 @@
+package vorbis
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/qeedquan/go-media/snd"
+	"github.com/qeedquan/go-media/snd/ogg"
+	"github.com/qeedquan/go-media/snd/wav"
+)
+
+var ErrFormat = errors.New("vorbis: invalid format")
+
+type mapping struct {
+	mux     []int
+	floor   []int
+	residue []int
+	mag     []int
+	ang     []int
+}
+
+type mode struct {
+	long    bool
+	mapping int
+}
+
+type Decoder struct {
+	Channels       int
+	SampleRate     int
+	MaxBitrate     int
+	NominalBitrate int
+	MinBitrate     int
+	Vendor         string
+	Comments       []string
+
+	r         *ogg.Reader
+	blocksize [2]int
+	books     []codebook
+	floors    []floor
+	residues  []residue
+	maps      []mapping
+	modes     []mode
+
+	imdct  [2]*imdct
+	slope  [2][]float32
+	prev   [][]float32
+	floor  [][]float32
+	vec    [][]float32
+	block  [][]float32
+	pos    int64
+	primed bool
+}
+
+func NewDecoder(r io.Reader) (*Decoder, error) {
+	d := &Decoder{r: ogg.NewReader(r)}
+	for i, h := range []func(*bitReader) error{d.readIdent, d.readComments, d.readSetup} {
+		p, err := d.r.ReadPacket()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, fmt.Errorf("vorbis: failed to read header %d: %v", i, err)
+		}
+		br := &bitReader{b: p.Data}
+		if typ := br.readInt(8); typ != 2*i+1 {
+			if i == 0 {
+				return nil, ErrFormat
+			}
+			return nil, fmt.Errorf("vorbis: expected header packet type %d, got %d", 2*i+1, typ)
+		}
+		if len(p.Data) < 7 || string(p.Data[1:7]) != "vorbis" {
+			return nil, ErrFormat
+		}
+		br.pos = 7
+		if err := h(br); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, n := range d.blocksize {
+		d.imdct[i] = newIMDCT(n)
+		d.slope[i] = make([]float32, n/2)
+		for j := range d.slope[i] {
+			s := math.Sin((float64(j) + .5) / float64(n/2) * math.Pi / 2)
+			d.slope[i][j] = float32(math.Sin(math.Pi / 2 * s * s))
+		}
+	}
+	alloc := func(n int) [][]float32 {
+		p := make([][]float32, d.Channels)
+		for i := range p {
+			p[i] = make([]float32, n)
+		}
+		return p
+	}
+	d.floor = alloc(d.blocksize[1] / 2)
+	d.vec = alloc(d.blocksize[1] / 2)
+	d.block = alloc(d.blocksize[1])
+	return d, nil
+}
+
+func (d *Decoder) readIdent(r *bitReader) error {
+	if v := r.read(32); v != 0 {
+		return fmt.Errorf("vorbis: unsupported version %d", v)
+	}
+	d.Channels = r.readInt(8)
+	d.SampleRate = int(r.read(32))
+	d.MaxBitrate = int(int32(r.read(32)))
+	d.NominalBitrate = int(int32(r.read(32)))
+	d.MinBitrate = int(int32(r.read(32)))
+	d.blocksize[0] = 1 << r.read(4)
+	d.blocksize[1] = 1 << r.read(4)
+	if !r.readBit() || r.eop {
+		return fmt.Errorf("vorbis: invalid identification header")
+	}
+	if d.Channels == 0 || d.SampleRate == 0 {
+		return fmt.Errorf("vorbis: invalid channel count %d or sample rate %d", d.Channels, d.SampleRate)
+	}
+	if b := d.blocksize; b[0] < 64 || b[1] > 8192 || b[0] > b[1] {
+		return fmt.Errorf("vorbis: invalid block sizes %d and %d", b[0], b[1])
+	}
+	return nil
+}
+
+func (d *Decoder) readComments(r *bitReader) error {
+	str := func() (string, bool) {
+		n := int(r.read(32))
+		if n < 0 || r.pos+n > len(r.b) {
+			return "", false
+		}
+		s := string(r.b[r.pos : r.pos+n])
+		r.pos += n
+		return s, true
+	}
+
+	var ok bool
+	if d.Vendor, ok = str(); !ok {
+		return fmt.Errorf("vorbis: invalid comment header")
+	}
+	n := int(r.read(32))
+	for i := 0; i < n; i++ {
+		s, ok := str()
+		if !ok {
+			return fmt.Errorf("vorbis: comment header truncated at comment %d", i)
+		}
+		d.Comments = append(d.Comments, s)
+	}
+	return nil
+}
+
+func (d *Decoder) readSetup(r *bitReader) error {
+	d.books = make([]codebook, r.readInt(8)+1)
+	for i := range d.books {
+		var err error
+		if d.books[i], err = d.readCodebook(r); err != nil {
+			return err
+		}
+	}
+
+	for i, n := 0, r.readInt(6)+1; i < n; i++ {
+		if r.read(16) != 0 {
+			return fmt.Errorf("vorbis: invalid time domain transform")
+		}
+	}
+
+	d.floors = make([]floor, r.readInt(6)+1)
+	for i := range d.floors {
+		var err error
+		if d.floors[i], err = d.readFloor(r); err != nil {
+			return err
+		}
+	}
+
+	d.residues = make([]residue, r.readInt(6)+1)
+	for i := range d.residues {
+		var err error
+		if d.residues[i], err = d.readResidue(r); err != nil {
+			return err
+		}
+	}
+
+	d.maps = make([]mapping, r.readInt(6)+1)
+	for i := range d.maps {
+		var err error
+		if d.maps[i], err = d.readMapping(r); err != nil {
+			return err
+		}
+	}
+
+	d.modes = make([]mode, r.readInt(6)+1)
+	for i := range d.modes {
+		m := &d.modes[i]
+		m.long = r.readBit()
+		wt, tt := r.read(16), r.read(16)
+		m.mapping = r.readInt(8)
+		if wt != 0 || tt != 0 || m.mapping >= len(d.maps) {
+			return fmt.Errorf("vorbis: invalid mode %d", i)
+		}
+	}
+
+	if !r.readBit() || r.eop {
+		return fmt.Errorf("vorbis: setup header truncated")
+	}
+	return nil
+}
+
+func (d *Decoder) readMapping(r *bitReader) (mapping, error) {
+	var m mapping
+	if typ := r.read(16); typ != 0 {
+		return m, fmt.Errorf("vorbis: invalid mapping type %d", typ)
+	}
+	submaps := 1
+	if r.readBit() {
+		submaps = r.readInt(4) + 1
+	}
+	if r.readBit() {
+		n := r.readInt(8) + 1
+		bits := ilog(d.Channels - 1)
+		m.mag = make([]int, n)
+		m.ang = make([]int, n)
+		for i := 0; i < n; i++ {
+			m.mag[i] = r.readInt(bits)
+			m.ang[i] = r.readInt(bits)
+			if m.mag[i] == m.ang[i] || m.mag[i] >= d.Channels || m.ang[i] >= d.Channels {
+				return m, fmt.Errorf("vorbis: invalid channel coupling %d/%d", m.mag[i], m.ang[i])
+			}
+		}
+	}
+	if r.read(2) != 0 {
+		return m, fmt.Errorf("vorbis: invalid mapping reserved field")
+	}
+
+	m.mux = make([]int, d.Channels)
+	if submaps > 1 {
+		for i := range m.mux {
+			if m.mux[i] = r.readInt(4); m.mux[i] >= submaps {
+				return m, fmt.Errorf("vorbis: invalid mapping mux %d", m.mux[i])
+			}
+		}
+	}
+	m.floor = make([]int, submaps)
+	m.residue = make([]int, submaps)
+	for i := 0; i < submaps; i++ {
+		r.read(8)
+		m.floor[i] = r.readInt(8)
+		m.residue[i] = r.readInt(8)
+		if m.floor[i] >= len(d.floors) || m.residue[i] >= len(d.residues) {
+			return m, fmt.Errorf("vorbis: mapping references invalid floor or residue")
+		}
+	}
+	return m, nil
+}
+
+// Tell returns the index of the next sample to be returned by Read.
+func (d *Decoder) Tell() int64 {
+	return d.pos
+}
+
+// Read returns the next block of decoded samples, one slice per channel.
+func (d *Decoder) Read() ([][]float32, error) {
+	for {
+		p, err := d.r.ReadPacket()
+		if err != nil {
+			return nil, err
+		}
+		out, err := d.decodePacket(p.Data)
+		if err != nil {
+			return nil, err
+		}
+		if out == nil {
+			continue
+		}
+
+		n := int64(len(out[0]))
+		if p.EOS && p.Granule >= 0 && d.pos+n > p.Granule {
+			if n = p.Granule - d.pos; n < 0 {
+				n = 0
+			}
+			for c := range out {
+				out[c] = out[c][:n]
+			}
+		}
+		d.pos += n
+		if n > 0 {
+			return out, nil
+		}
+	}
+}
+
+func (d *Decoder) decodePacket(p []byte) ([][]float32, error) {
+	r := &bitReader{b: p}
+	if r.readBit() {
+		return nil, nil
+	}
+	mi := r.readInt(ilog(len(d.modes) - 1))
+	if mi >= len(d.modes) || r.eop {
+		return nil, fmt.Errorf("vorbis: invalid audio packet mode")
+	}
+	m := &d.modes[mi]
+	mp := &d.maps[m.mapping]
+
+	bf := 0
+	prevLong, nextLong := false, false
+	if m.long {
+		bf = 1
+		prevLong = r.readBit()
+		nextLong = r.readBit()
+	}
+	n := d.blocksize[bf]
+	half := n / 2
+
+	used := make([]bool, d.Channels)
+	for c := range used {
+		f := d.floors[mp.floor[mp.mux[c]]]
+		used[c] = f.decode(d, r, d.floor[c][:half])
+	}
+
+	skip := make([]bool, d.Channels)
+	for c := range skip {
+		skip[c] = !used[c]
+	}
+	for i := range mp.mag {
+		if used[mp.mag[i]] || used[mp.ang[i]] {
+			skip[mp.mag[i]] = false
+			skip[mp.ang[i]] = false
+		}
+	}
+
+	for c := range d.vec {
+		d.vec[c] = d.vec[c][:half]
+		for i := range d.vec[c] {
+			d.vec[c][i] = 0
+		}
+	}
+	for s := range mp.residue {
+		var v [][]float32
+		var sk []bool
+		for c, x := range mp.mux {
+			if x == s {
+				v = append(v, d.vec[c])
+				sk = append(sk, skip[c])
+			}
+		}
+		if len(v) > 0 {
+			d.residues[mp.residue[s]].decode(d, r, v, sk)
+		}
+	}
+
+	for i := len(mp.mag) - 1; i >= 0; i-- {
+		mv, av := d.vec[mp.mag[i]], d.vec[mp.ang[i]]
+		for j := range mv {
+			m, a := mv[j], av[j]
+			switch {
+			case m > 0 && a > 0:
+				mv[j], av[j] = m, m-a
+			case m > 0:
+				mv[j], av[j] = m+a, m
+			case a > 0:
+				mv[j], av[j] = m, m+a
+			default:
+				mv[j], av[j] = m-a, m
+			}
+		}
+	}
+
+	for c := range d.vec {
+		v := d.vec[c]
+		if !used[c] {
+			for i := range v {
+				v[i] = 0
+			}
+		} else {
+			for i, f := range d.floor[c][:half] {
+				v[i] *= f
+			}
+		}
+		b := d.block[c][:n]
+		d.imdct[bf].transform(b, v)
+		d.window(b, bf, prevLong, nextLong)
+	}
+
+	return d.overlap(n), nil
+}
+
+func (d *Decoder) window(b []float32, bf int, prevLong, nextLong bool) {
+	n := len(b)
+	left, right := d.slope[bf], d.slope[bf]
+	if bf == 1 && !prevLong {
+		left = d.slope[0]
+	}
+	if bf == 1 && !nextLong {
+		right = d.slope[0]
+	}
+
+	ls := n/4 - len(left)/2
+	for i := 0; i < ls; i++ {
+		b[i] = 0
+	}
+	for i, w := range left {
+		b[ls+i] *= w
+	}
+	rs := n*3/4 - len(right)/2
+	for i := range right {
+		b[rs+i] *= right[len(right)-1-i]
+	}
+	for i := rs + len(right); i < n; i++ {
+		b[i] = 0
+	}
+}
+
+// overlap adds the left half of the current block to the saved right half
+// of the previous one, returning the finished samples between the centers
+// of the two blocks.
+func (d *Decoder) overlap(n int) [][]float32 {
+	if !d.primed {
+		d.primed = true
+		d.prev = make([][]float32, d.Channels)
+		for c := range d.prev {
+			d.prev[c] = append(d.prev[c][:0], d.block[c][n/2:n]...)
+		}
+		return nil
+	}
+
+	pn := len(d.prev[0]) * 2
+	size := pn/4 + n/4
+	out := make([][]float32, d.Channels)
+	for c := range out {
+		o := make([]float32, size)
+		prev, cur := d.prev[c], d.block[c]
+		for j := range o {
+			if j < len(prev) {
+				o[j] = prev[j]
+			}
+			if i := j + n/4 - pn/4; i >= 0 && i < n/2 {
+				o[j] += cur[i]
+			}
+		}
+		out[c] = o
+		d.prev[c] = append(d.prev[c][:0], cur[n/2:n]...)
+	}
+	return out
+}
+
+func (d *Decoder) Format() wav.Format {
+	return wav.NewFloatFormat(d.Channels, d.SampleRate, 32)
+}
+
+func Decode(r io.Reader) (*wav.File, error) {
+	d, err := NewDecoder(r)
+	if err != nil {
+		return nil, err
+	}
+	f := &wav.File{Format: d.Format()}
+	snd.SetComments(f, d.Comments)
+
+	var s []float32
+	for {
+		p, err := d.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i := range p[0] {
+			for c := range p {
+				s = append(s, p[c][i])
+			}
+		}
+	}
+	if err := f.SetSamples(s); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func DecodeConfig(r io.Reader) (wav.Format, error) {
+	d, err := NewDecoder(r)
+	if err != nil {
+		return wav.Format{}, err
+	}
+	return d.Format(), nil
+}
+
+// oggMagic matches the first page of a vorbis stream, it holds only the
+// identification header so the page has a single segment.
+const oggMagic = "OggS" + "??????????????????????" + "\x01?\x01vorbis"
+
+func init() {
+	snd.RegisterFormat("vorbis", oggMagic, Decode, DecodeConfig)
+}
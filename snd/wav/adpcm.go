@@ -0,0 +1,441 @@
+package wav
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/qeedquan/go-media/math/mathutil"
+)
+
+var imaStepTable = [89]int{
+	7, 8, 9, 10, 11, 12, 13, 14, 16, 17,
+	19, 21, 23, 25, 28, 31, 34, 37, 41, 45,
+	50, 55, 60, 66, 73, 80, 88, 97, 107, 118,
+	130, 143, 157, 173, 190, 209, 230, 253, 279, 307,
+	337, 371, 408, 449, 494, 544, 598, 658, 724, 796,
+	876, 963, 1060, 1166, 1282, 1411, 1552, 1707, 1878, 2066,
+	2272, 2499, 2749, 3024, 3327, 3660, 4026, 4428, 4871, 5358,
+	5894, 6484, 7132, 7845, 8630, 9493, 10442, 11487, 12635, 13899,
+	15289, 16818, 18500, 20350, 22385, 24623, 27086, 29794, 32767,
+}
+
+var imaIndexTable = [16]int{
+	-1, -1, -1, -1, 2, 4, 6, 8,
+	-1, -1, -1, -1, 2, 4, 6, 8,
+}
+
+var msAdaptTable = [16]int{
+	230, 230, 230, 230, 307, 409, 512, 614,
+	768, 614, 512, 409, 307, 230, 230, 230,
+}
+
+var MSADPCMCoefs = [7][2]int16{
+	{256, 0},
+	{512, -256},
+	{0, 0},
+	{192, 64},
+	{240, 0},
+	{460, -208},
+	{392, -232},
+}
+
+func NewIMAADPCMFormat(channels, sampleRate, blockAlign int) Format {
+	if blockAlign <= 0 {
+		blockAlign = defaultBlockAlign(channels, sampleRate)
+	}
+	f := Format{
+		Format:        FORMAT_IMA_ADPCM,
+		Channels:      uint16(channels),
+		SampleRate:    uint32(sampleRate),
+		BlockAlign:    uint16(blockAlign),
+		BitsPerSample: 4,
+	}
+	f.ValidBits = uint16(f.FramesPerBlock())
+	f.ByteRate = uint32(sampleRate * blockAlign / int(f.ValidBits))
+	return f
+}
+
+func NewMSADPCMFormat(channels, sampleRate, blockAlign int) Format {
+	if blockAlign <= 0 {
+		blockAlign = defaultBlockAlign(channels, sampleRate)
+	}
+	f := Format{
+		Format:        FORMAT_ADPCM,
+		Channels:      uint16(channels),
+		SampleRate:    uint32(sampleRate),
+		BlockAlign:    uint16(blockAlign),
+		BitsPerSample: 4,
+	}
+	f.ValidBits = uint16(f.FramesPerBlock())
+	f.ByteRate = uint32(sampleRate * blockAlign / int(f.ValidBits))
+
+	f.Extra = make([]byte, 2+4*len(MSADPCMCoefs))
+	binary.LittleEndian.PutUint16(f.Extra, uint16(len(MSADPCMCoefs)))
+	for i, c := range MSADPCMCoefs {
+		binary.LittleEndian.PutUint16(f.Extra[2+i*4:], uint16(c[0]))
+		binary.LittleEndian.PutUint16(f.Extra[4+i*4:], uint16(c[1]))
+	}
+	return f
+}
+
+func defaultBlockAlign(channels, sampleRate int) int {
+	n := sampleRate / 11025
+	if n < 1 {
+		n = 1
+	}
+	return 256 * channels * n
+}
+
+// FramesPerBlock returns how many sample frames a block of BlockAlign bytes
+// holds, this is 1 for all formats except the ADPCM variants.
+func (f *Format) FramesPerBlock() int {
+	ch := int(f.Channels)
+	ba := int(f.BlockAlign)
+	if ch == 0 {
+		return 1
+	}
+
+	switch f.Tag() {
+	case FORMAT_IMA_ADPCM:
+		if ba < 4*ch {
+			return 1
+		}
+		return (ba-4*ch)*8/(4*ch) + 1
+	case FORMAT_ADPCM:
+		if ba < 7*ch {
+			return 1
+		}
+		return (ba-7*ch)*8/(4*ch) + 2
+	}
+	return 1
+}
+
+func (f *Format) msCoefs() ([][2]int16, error) {
+	if len(f.Extra) < 2 {
+		return MSADPCMCoefs[:], nil
+	}
+	n := int(binary.LittleEndian.Uint16(f.Extra))
+	if 2+n*4 > len(f.Extra) {
+		return nil, fmt.Errorf("wav: ms adpcm coefficient table truncated")
+	}
+	c := make([][2]int16, n)
+	for i := range c {
+		c[i][0] = int16(binary.LittleEndian.Uint16(f.Extra[2+i*4:]))
+		c[i][1] = int16(binary.LittleEndian.Uint16(f.Extra[4+i*4:]))
+	}
+	return c, nil
+}
+
+type IMAState struct {
+	Predictor int
+	Index     int
+}
+
+func (s *IMAState) Decode(n uint8) int16 {
+	step := imaStepTable[s.Index]
+	diff := step >> 3
+	if n&1 != 0 {
+		diff += step >> 2
+	}
+	if n&2 != 0 {
+		diff += step >> 1
+	}
+	if n&4 != 0 {
+		diff += step
+	}
+	if n&8 != 0 {
+		diff = -diff
+	}
+	s.Predictor = clamp16(s.Predictor + diff)
+	s.Index = clampIndex(s.Index + imaIndexTable[n])
+	return int16(s.Predictor)
+}
+
+func (s *IMAState) Encode(v int16) uint8 {
+	step := imaStepTable[s.Index]
+	diff := int(v) - s.Predictor
+
+	var n uint8
+	if diff < 0 {
+		n = 8
+		diff = -diff
+	}
+	for mask := uint8(4); mask != 0; mask >>= 1 {
+		if diff >= step {
+			n |= mask
+			diff -= step
+		}
+		step >>= 1
+	}
+	s.Decode(n)
+	return n
+}
+
+func decodeIMAADPCM(ft *Format, b []byte) ([]int16, error) {
+	ch := int(ft.Channels)
+	ba := int(ft.BlockAlign)
+	if ch == 0 || ba < 4*ch {
+		return nil, fmt.Errorf("wav: invalid ima adpcm block align %d for %d channels", ba, ch)
+	}
+	spb := ft.FramesPerBlock()
+
+	var out []int16
+	st := make([]IMAState, ch)
+	for ; len(b) >= 4*ch; b = b[mathutil.Min(ba, len(b)):] {
+		blk := b[:mathutil.Min(ba, len(b))]
+		for c := range st {
+			st[c].Predictor = int(int16(binary.LittleEndian.Uint16(blk[c*4:])))
+			st[c].Index = clampIndex(int(blk[c*4+2]))
+		}
+
+		n := 1 + (len(blk)-4*ch)/(4*ch)*8
+		if n > spb {
+			n = spb
+		}
+		p := len(out)
+		out = append(out, make([]int16, n*ch)...)
+		frame := out[p:]
+		for c := range st {
+			frame[c] = int16(st[c].Predictor)
+		}
+
+		data := blk[4*ch:]
+		for i := 1; i < n; i += 8 {
+			for c := range st {
+				g := data[c*4 : c*4+4]
+				for j := 0; j < 8 && i+j < n; j++ {
+					nib := g[j/2] >> (4 * uint(j&1)) & 0xf
+					frame[(i+j)*ch+c] = st[c].Decode(nib)
+				}
+			}
+			data = data[4*ch:]
+		}
+	}
+	return out, nil
+}
+
+func encodeIMAADPCM(ft *Format, s []int16) ([]byte, error) {
+	ch := int(ft.Channels)
+	ba := int(ft.BlockAlign)
+	if ch == 0 || ba < 4*ch || (ba-4*ch)%(4*ch) != 0 {
+		return nil, fmt.Errorf("wav: invalid ima adpcm block align %d for %d channels", ba, ch)
+	}
+	spb := ft.FramesPerBlock()
+	frames := len(s) / ch
+
+	var out []byte
+	st := make([]IMAState, ch)
+	for f := 0; f < frames; f += spb {
+		blk := make([]byte, ba)
+		for c := range st {
+			st[c].Predictor = int(s[f*ch+c])
+			binary.LittleEndian.PutUint16(blk[c*4:], uint16(s[f*ch+c]))
+			blk[c*4+2] = uint8(st[c].Index)
+		}
+
+		data := blk[4*ch:]
+		for i := 1; i < spb; i += 8 {
+			for c := range st {
+				g := data[c*4 : c*4+4]
+				for j := 0; j < 8; j++ {
+					var v int16
+					if k := f + i + j; k < frames {
+						v = s[k*ch+c]
+					}
+					g[j/2] |= st[c].Encode(v) << (4 * uint(j&1))
+				}
+			}
+			data = data[4*ch:]
+		}
+		out = append(out, blk...)
+	}
+	return out, nil
+}
+
+type msState struct {
+	c1, c2 int
+	delta  int
+	s1, s2 int
+}
+
+func (s *msState) decode(n uint8) int16 {
+	pred := (s.s1*s.c1 + s.s2*s.c2) >> 8
+	v := int(n)
+	if v >= 8 {
+		v -= 16
+	}
+	x := clamp16(pred + v*s.delta)
+	s.s2, s.s1 = s.s1, x
+	s.delta = (msAdaptTable[n] * s.delta) >> 8
+	if s.delta < 16 {
+		s.delta = 16
+	}
+	return int16(x)
+}
+
+func (s *msState) encode(v int16) uint8 {
+	pred := (s.s1*s.c1 + s.s2*s.c2) >> 8
+	d := int(v) - pred
+	bias := s.delta / 2
+	if d < 0 {
+		bias = -bias
+	}
+	n := (d + bias) / s.delta
+	if n < -8 {
+		n = -8
+	} else if n > 7 {
+		n = 7
+	}
+	u := uint8(n) & 0xf
+	s.decode(u)
+	return u
+}
+
+func decodeMSADPCM(ft *Format, b []byte) ([]int16, error) {
+	ch := int(ft.Channels)
+	ba := int(ft.BlockAlign)
+	if ch == 0 || ch > 2 || ba < 7*ch {
+		return nil, fmt.Errorf("wav: invalid ms adpcm block align %d for %d channels", ba, ch)
+	}
+	coefs, err := ft.msCoefs()
+	if err != nil {
+		return nil, err
+	}
+	spb := ft.FramesPerBlock()
+
+	le := binary.LittleEndian
+	var out []int16
+	st := make([]msState, ch)
+	for ; len(b) >= 7*ch; b = b[mathutil.Min(ba, len(b)):] {
+		blk := b[:mathutil.Min(ba, len(b))]
+		for c := range st {
+			p := int(blk[c])
+			if p >= len(coefs) {
+				return nil, fmt.Errorf("wav: ms adpcm predictor index %d out of range", p)
+			}
+			st[c].c1 = int(coefs[p][0])
+			st[c].c2 = int(coefs[p][1])
+			st[c].delta = int(int16(le.Uint16(blk[ch+c*2:])))
+			st[c].s1 = int(int16(le.Uint16(blk[3*ch+c*2:])))
+			st[c].s2 = int(int16(le.Uint16(blk[5*ch+c*2:])))
+		}
+
+		n := 2 + (len(blk)-7*ch)*2/ch
+		if n > spb {
+			n = spb
+		}
+		p := len(out)
+		out = append(out, make([]int16, n*ch)...)
+		frame := out[p:]
+		for c := range st {
+			frame[c] = int16(st[c].s2)
+			frame[ch+c] = int16(st[c].s1)
+		}
+
+		data := blk[7*ch:]
+		for i := 2 * ch; i < n*ch; i++ {
+			nib := data[(i-2*ch)/2]
+			if i&1 == 0 {
+				nib >>= 4
+			}
+			frame[i] = st[i%ch].decode(nib & 0xf)
+		}
+	}
+	return out, nil
+}
+
+func encodeMSADPCM(ft *Format, s []int16) ([]byte, error) {
+	ch := int(ft.Channels)
+	ba := int(ft.BlockAlign)
+	if ch == 0 || ch > 2 || ba < 7*ch {
+		return nil, fmt.Errorf("wav: invalid ms adpcm block align %d for %d channels", ba, ch)
+	}
+	coefs, err := ft.msCoefs()
+	if err != nil {
+		return nil, err
+	}
+	spb := ft.FramesPerBlock()
+	frames := len(s) / ch
+
+	le := binary.LittleEndian
+	var out []byte
+	delta := make([]int, ch)
+	for f := 0; f < frames; f += spb {
+		at := func(i, c int) int16 {
+			if i += f; i < frames {
+				return s[i*ch+c]
+			}
+			return 0
+		}
+
+		blk := make([]byte, ba)
+		st := make([]msState, ch)
+		for c := range st {
+			best, berr := 0, -1
+			for p := range coefs {
+				t := msState{
+					c1:    int(coefs[p][0]),
+					c2:    int(coefs[p][1]),
+					delta: mathutil.Max(delta[c], 16),
+					s1:    int(at(1, c)),
+					s2:    int(at(0, c)),
+				}
+				e := 0
+				for i := 2; i < spb; i++ {
+					v := int(at(i, c))
+					t.encode(int16(v))
+					e += mathutil.Abs(v - t.s1)
+				}
+				if berr < 0 || e < berr {
+					best, berr = p, e
+				}
+			}
+
+			st[c] = msState{
+				c1:    int(coefs[best][0]),
+				c2:    int(coefs[best][1]),
+				delta: mathutil.Max(delta[c], 16),
+				s1:    int(at(1, c)),
+				s2:    int(at(0, c)),
+			}
+			blk[c] = uint8(best)
+			le.PutUint16(blk[ch+c*2:], uint16(st[c].delta))
+			le.PutUint16(blk[3*ch+c*2:], uint16(st[c].s1))
+			le.PutUint16(blk[5*ch+c*2:], uint16(st[c].s2))
+		}
+
+		data := blk[7*ch:]
+		for i := 2 * ch; i < spb*ch; i++ {
+			nib := st[i%ch].encode(at(i/ch, i%ch))
+			if i&1 == 0 {
+				nib <<= 4
+			}
+			data[(i-2*ch)/2] |= nib
+		}
+		for c := range st {
+			delta[c] = st[c].delta
+		}
+		out = append(out, blk...)
+	}
+	return out, nil
+}
+
+func clamp16(v int) int {
+	if v < -32768 {
+		return -32768
+	}
+	if v > 32767 {
+		return 32767
+	}
+	return v
+}
+
+func clampIndex(i int) int {
+	if i < 0 {
+		return 0
+	}
+	if i > 88 {
+		return 88
+	}
+	return i
+}
@@ -0,0 +1,204 @@
+package wav
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	ds64Size     = 28
+	unknownSize  = math.MaxUint64
+	maxChunkSize = 1 << 28
+)
+
+type ds64 struct {
+	RIFFSize    uint64
+	DataSize    uint64
+	SampleCount uint64
+	Table       map[[4]byte]uint64
+}
+
+type reader struct {
+	r    io.Reader
+	f    *File
+	ds64 *ds64
+	off  int64
+}
+
+func (d *reader) read(b []byte) error {
+	n, err := io.ReadFull(d.r, b)
+	d.off += int64(n)
+	return err
+}
+
+func (d *reader) skip(n uint64) error {
+	m, err := io.CopyN(io.Discard, d.r, int64(n))
+	d.off += m
+	return err
+}
+
+func (d *reader) readChunkHeader() (id [4]byte, size uint64, err error) {
+	var b [8]byte
+	if err = d.read(b[:]); err != nil {
+		return
+	}
+	copy(id[:], b[:4])
+	size = uint64(binary.LittleEndian.Uint32(b[4:]))
+	if size == math.MaxUint32 && d.ds64 != nil {
+		if id == [4]byte{'d', 'a', 't', 'a'} {
+			size = d.ds64.DataSize
+		} else if n, ok := d.ds64.Table[id]; ok {
+			size = n
+		}
+	}
+	return
+}
+
+// readHeader walks the RIFF chunk list up to the data chunk and returns the
+// size of the data payload, the reader is left positioned at its first byte.
+func (d *reader) readHeader() (uint64, error) {
+	var b [12]byte
+	if err := d.read(b[:]); err != nil {
+		return 0, fmt.Errorf("wav: failed to read riff header: %v", err)
+	}
+
+	d.f = &File{}
+	f := d.f
+	copy(f.Container[:], b[:4])
+	if f.Container != RIFF && f.Container != RF64 && f.Container != BW64 {
+		return 0, fmt.Errorf("wav: invalid riff header %q", b[:4])
+	}
+	if string(b[8:12]) != "WAVE" {
+		return 0, fmt.Errorf("wav: invalid wave header %q", b[8:12])
+	}
+
+	fmtSeen := false
+	for {
+		id, size, err := d.readChunkHeader()
+		if err != nil {
+			return 0, fmt.Errorf("wav: failed to read chunk header: %v", err)
+		}
+
+		switch string(id[:]) {
+		case "ds64":
+			if f.Container == RIFF {
+				return 0, fmt.Errorf("wav: ds64 chunk in riff file")
+			}
+			err = d.readDS64(size)
+		case "data":
+			if !fmtSeen {
+				return 0, fmt.Errorf("wav: data chunk before fmt chunk")
+			}
+			if f.Container != RIFF && d.ds64 == nil {
+				return 0, fmt.Errorf("wav: missing ds64 chunk")
+			}
+			if f.Container == RIFF && size == math.MaxUint32 {
+				// streaming writers that never patched the header
+				size = unknownSize
+			}
+			// the fact chunk of an RF64 file holds a placeholder when the
+			// count doesn't fit, the real one is in ds64
+			if d.ds64 != nil && (f.SampleLength == 0 || f.SampleLength == math.MaxUint32) {
+				f.SampleLength = d.ds64.SampleCount
+			}
+			return size, nil
+		case "fmt ":
+			fmtSeen = true
+			fallthrough
+		default:
+			err = d.readChunk(id, size)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (d *reader) readDS64(size uint64) error {
+	if size < ds64Size || size > maxChunkSize {
+		return fmt.Errorf("wav: invalid ds64 chunk size %d", size)
+	}
+	b := make([]byte, pad(size))
+	if err := d.read(b); err != nil {
+		return fmt.Errorf("wav: failed to read ds64 chunk: %v", err)
+	}
+
+	le := binary.LittleEndian
+	d.ds64 = &ds64{
+		RIFFSize:    le.Uint64(b[0:]),
+		DataSize:    le.Uint64(b[8:]),
+		SampleCount: le.Uint64(b[16:]),
+		Table:       make(map[[4]byte]uint64),
+	}
+	n := int(le.Uint32(b[24:]))
+	b = b[ds64Size:size]
+	for i := 0; i < n && len(b) >= 12; i++ {
+		var id [4]byte
+		copy(id[:], b)
+		d.ds64.Table[id] = le.Uint64(b[4:])
+		b = b[12:]
+	}
+	return nil
+}
+
+func (d *reader) readChunk(id [4]byte, size uint64) error {
+	switch string(id[:]) {
+	case "JUNK", "junk", "PAD ", "pad ", "FLLR":
+		if err := d.skip(pad(size)); err != nil {
+			return fmt.Errorf("wav: failed to skip %q chunk: %v", id, err)
+		}
+		return nil
+	}
+
+	if size > maxChunkSize {
+		return fmt.Errorf("wav: %q chunk too large (%d bytes)", id, size)
+	}
+	b := make([]byte, size)
+	if err := d.read(b); err != nil {
+		return fmt.Errorf("wav: failed to read %q chunk: %v", id, err)
+	}
+	if size&1 != 0 {
+		d.skip(1)
+	}
+
+	f := d.f
+	switch string(id[:]) {
+	case "fmt ":
+		return f.Format.decode(b)
+	case "fact":
+		if len(b) >= 4 {
+			f.SampleLength = uint64(binary.LittleEndian.Uint32(b))
+		}
+	case "LIST":
+		return f.decodeList(b)
+	case "cue ":
+		return f.decodeCue(b)
+	case "smpl":
+		return f.decodeSampler(b)
+	default:
+		f.Chunks = append(f.Chunks, Chunk{id, b})
+	}
+	return nil
+}
+
+func writeChunkHeader(w *bufio.Writer, id [4]byte, size uint32) {
+	w.Write(id[:])
+	binary.Write(w, binary.LittleEndian, size)
+}
+
+func writeChunk(w *bufio.Writer, id [4]byte, b []byte) {
+	writeChunkHeader(w, id, uint32(len(b)))
+	w.Write(b)
+	if len(b)&1 != 0 {
+		w.WriteByte(0)
+	}
+}
+
+func writeDS64(w *bufio.Writer, riffSize, dataSize, sampleCount uint64) {
+	writeChunkHeader(w, [4]byte{'d', 's', '6', '4'}, ds64Size)
+	binary.Write(w, binary.LittleEndian, []uint64{riffSize, dataSize, sampleCount})
+	binary.Write(w, binary.LittleEndian, uint32(0))
+}
@@ -0,0 +1,110 @@
+package wav
+
+func NewALawFormat(channels, sampleRate int) Format {
+	return newFormat(FORMAT_ALAW, channels, sampleRate, 8)
+}
+
+func NewMuLawFormat(channels, sampleRate int) Format {
+	return newFormat(FORMAT_MULAW, channels, sampleRate, 8)
+}
+
+var (
+	alawSegEnd  = [8]int{0x1f, 0x3f, 0x7f, 0xff, 0x1ff, 0x3ff, 0x7ff, 0xfff}
+	mulawSegEnd = [8]int{0x3f, 0x7f, 0xff, 0x1ff, 0x3ff, 0x7ff, 0xfff, 0x1fff}
+)
+
+func segment(v int, tab *[8]int) int {
+	for i, e := range tab {
+		if v <= e {
+			return i
+		}
+	}
+	return len(tab)
+}
+
+func ALawDecode(a uint8) int16 {
+	a ^= 0x55
+	t := int(a&0x0f) << 4
+	seg := int(a&0x70) >> 4
+	switch seg {
+	case 0:
+		t += 8
+	case 1:
+		t += 0x108
+	default:
+		t += 0x108
+		t <<= seg - 1
+	}
+	if a&0x80 != 0 {
+		return int16(t)
+	}
+	return int16(-t)
+}
+
+func ALawEncode(s int16) uint8 {
+	v := int(s) >> 3
+	mask := 0xd5
+	if v < 0 {
+		mask = 0x55
+		v = -v - 1
+	}
+
+	seg := segment(v, &alawSegEnd)
+	if seg >= 8 {
+		return uint8(0x7f ^ mask)
+	}
+
+	a := seg << 4
+	if seg < 2 {
+		a |= (v >> 1) & 0x0f
+	} else {
+		a |= (v >> uint(seg)) & 0x0f
+	}
+	return uint8(a ^ mask)
+}
+
+func MuLawDecode(u uint8) int16 {
+	u = ^u
+	t := (int(u&0x0f) << 3) + 0x84
+	t <<= (u & 0x70) >> 4
+	if u&0x80 != 0 {
+		return int16(0x84 - t)
+	}
+	return int16(t - 0x84)
+}
+
+func MuLawEncode(s int16) uint8 {
+	v := int(s) >> 2
+	mask := 0xff
+	if v < 0 {
+		v = -v
+		mask = 0x7f
+	}
+	if v > 8159 {
+		v = 8159
+	}
+	v += 0x84 >> 2
+
+	seg := segment(v, &mulawSegEnd)
+	if seg >= 8 {
+		return uint8(0x7f ^ mask)
+	}
+	u := seg<<4 | (v>>uint(seg+1))&0x0f
+	return uint8(u ^ mask)
+}
+
+func decodeG711(b []byte, dec func(uint8) int16) []int16 {
+	s := make([]int16, len(b))
+	for i := range b {
+		s[i] = dec(b[i])
+	}
+	return s
+}
+
+func encodeG711(s []int16, enc func(int16) uint8) []byte {
+	b := make([]byte, len(s))
+	for i := range s {
+		b[i] = enc(s[i])
+	}
+	return b
+}
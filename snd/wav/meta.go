@@ -0,0 +1,272 @@
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+type InfoItem struct {
+	ID    [4]byte
+	Value string
+}
+
+type CuePoint struct {
+	ID           uint32
+	Position     uint32
+	ChunkID      [4]byte
+	ChunkStart   uint32
+	BlockStart   uint32
+	SampleOffset uint32
+	Label        string
+	Note         string
+}
+
+type Sampler struct {
+	Manufacturer      uint32
+	Product           uint32
+	SamplePeriod      uint32
+	MIDIUnityNote     uint32
+	MIDIPitchFraction uint32
+	SMPTEFormat       uint32
+	SMPTEOffset       uint32
+	Loops             []SampleLoop
+	Data              []byte
+}
+
+type SampleLoop struct {
+	CuePointID uint32
+	Type       uint32
+	Start      uint32
+	End        uint32
+	Fraction   uint32
+	PlayCount  uint32
+}
+
+const (
+	LOOP_FORWARD  = 0
+	LOOP_PINGPONG = 1
+	LOOP_BACKWARD = 2
+)
+
+func (f *File) InfoValue(id string) string {
+	for _, i := range f.Info {
+		if string(i.ID[:]) == id {
+			return i.Value
+		}
+	}
+	return ""
+}
+
+func (f *File) SetInfo(id, value string) {
+	var key [4]byte
+	copy(key[:], id)
+	for i := range f.Info {
+		if f.Info[i].ID == key {
+			f.Info[i].Value = value
+			return
+		}
+	}
+	f.Info = append(f.Info, InfoItem{key, value})
+}
+
+func (f *File) Cue(id uint32) *CuePoint {
+	for i := range f.Cues {
+		if f.Cues[i].ID == id {
+			return &f.Cues[i]
+		}
+	}
+	return nil
+}
+
+func (f *File) decodeList(b []byte) error {
+	if len(b) < 4 {
+		return fmt.Errorf("wav: LIST chunk too short")
+	}
+
+	typ := string(b[:4])
+	switch typ {
+	case "INFO", "adtl":
+	default:
+		f.Chunks = append(f.Chunks, Chunk{[4]byte{'L', 'I', 'S', 'T'}, b})
+		return nil
+	}
+
+	le := binary.LittleEndian
+	for p := b[4:]; len(p) >= 8; {
+		var id [4]byte
+		copy(id[:], p)
+		n := int(le.Uint32(p[4:]))
+		p = p[8:]
+		if n > len(p) {
+			return fmt.Errorf("wav: LIST %s subchunk %q overflows chunk", typ, id)
+		}
+		v := p[:n]
+		if n&1 != 0 && n < len(p) {
+			n++
+		}
+		p = p[n:]
+
+		switch {
+		case typ == "INFO":
+			f.Info = append(f.Info, InfoItem{id, cstring(v)})
+		case string(id[:]) == "labl" && len(v) >= 4:
+			f.cuePoint(le.Uint32(v)).Label = cstring(v[4:])
+		case string(id[:]) == "note" && len(v) >= 4:
+			f.cuePoint(le.Uint32(v)).Note = cstring(v[4:])
+		}
+	}
+	return nil
+}
+
+func (f *File) cuePoint(id uint32) *CuePoint {
+	c := f.Cue(id)
+	if c == nil {
+		f.Cues = append(f.Cues, CuePoint{ID: id})
+		c = &f.Cues[len(f.Cues)-1]
+	}
+	return c
+}
+
+func (f *File) decodeCue(b []byte) error {
+	if len(b) < 4 {
+		return fmt.Errorf("wav: cue chunk too short")
+	}
+	le := binary.LittleEndian
+	n := int(le.Uint32(b))
+	b = b[4:]
+	if n*24 > len(b) {
+		return fmt.Errorf("wav: cue chunk has %d points but only %d bytes", n, len(b))
+	}
+	for i := 0; i < n; i++ {
+		p := b[i*24:]
+		c := f.cuePoint(le.Uint32(p))
+		c.Position = le.Uint32(p[4:])
+		copy(c.ChunkID[:], p[8:12])
+		c.ChunkStart = le.Uint32(p[12:])
+		c.BlockStart = le.Uint32(p[16:])
+		c.SampleOffset = le.Uint32(p[20:])
+	}
+	return nil
+}
+
+func (f *File) decodeSampler(b []byte) error {
+	if len(b) < 36 {
+		return fmt.Errorf("wav: smpl chunk too short")
+	}
+	le := binary.LittleEndian
+	s := &Sampler{
+		Manufacturer:      le.Uint32(b[0:]),
+		Product:           le.Uint32(b[4:]),
+		SamplePeriod:      le.Uint32(b[8:]),
+		MIDIUnityNote:     le.Uint32(b[12:]),
+		MIDIPitchFraction: le.Uint32(b[16:]),
+		SMPTEFormat:       le.Uint32(b[20:]),
+		SMPTEOffset:       le.Uint32(b[24:]),
+	}
+	n := int(le.Uint32(b[28:]))
+	m := int(le.Uint32(b[32:]))
+	b = b[36:]
+	if n*24 > len(b) {
+		return fmt.Errorf("wav: smpl chunk has %d loops but only %d bytes", n, len(b))
+	}
+	for i := 0; i < n; i++ {
+		p := b[i*24:]
+		s.Loops = append(s.Loops, SampleLoop{
+			CuePointID: le.Uint32(p[0:]),
+			Type:       le.Uint32(p[4:]),
+			Start:      le.Uint32(p[8:]),
+			End:        le.Uint32(p[12:]),
+			Fraction:   le.Uint32(p[16:]),
+			PlayCount:  le.Uint32(p[20:]),
+		})
+	}
+	b = b[n*24:]
+	if m > len(b) {
+		m = len(b)
+	}
+	if m > 0 {
+		s.Data = append([]byte{}, b[:m]...)
+	}
+	f.Sampler = s
+	return nil
+}
+
+func (f *File) metaChunks() []Chunk {
+	var chunks []Chunk
+	le := binary.LittleEndian
+
+	if len(f.Info) > 0 {
+		w := new(bytes.Buffer)
+		w.WriteString("INFO")
+		for _, i := range f.Info {
+			writeSubchunk(w, i.ID, append([]byte(i.Value), 0))
+		}
+		chunks = append(chunks, Chunk{[4]byte{'L', 'I', 'S', 'T'}, w.Bytes()})
+	}
+
+	if len(f.Cues) > 0 {
+		w := new(bytes.Buffer)
+		binary.Write(w, le, uint32(len(f.Cues)))
+		for _, c := range f.Cues {
+			id := c.ChunkID
+			if id == [4]byte{} {
+				id = [4]byte{'d', 'a', 't', 'a'}
+			}
+			binary.Write(w, le, []uint32{c.ID, c.Position})
+			w.Write(id[:])
+			binary.Write(w, le, []uint32{c.ChunkStart, c.BlockStart, c.SampleOffset})
+		}
+		chunks = append(chunks, Chunk{[4]byte{'c', 'u', 'e', ' '}, w.Bytes()})
+
+		w = new(bytes.Buffer)
+		w.WriteString("adtl")
+		for _, c := range f.Cues {
+			var id [4]byte
+			binary.LittleEndian.PutUint32(id[:], c.ID)
+			if c.Label != "" {
+				writeSubchunk(w, [4]byte{'l', 'a', 'b', 'l'}, append(append(id[:], c.Label...), 0))
+			}
+			if c.Note != "" {
+				writeSubchunk(w, [4]byte{'n', 'o', 't', 'e'}, append(append(id[:], c.Note...), 0))
+			}
+		}
+		if w.Len() > 4 {
+			chunks = append(chunks, Chunk{[4]byte{'L', 'I', 'S', 'T'}, w.Bytes()})
+		}
+	}
+
+	if s := f.Sampler; s != nil {
+		w := new(bytes.Buffer)
+		binary.Write(w, le, []uint32{
+			s.Manufacturer, s.Product, s.SamplePeriod,
+			s.MIDIUnityNote, s.MIDIPitchFraction,
+			s.SMPTEFormat, s.SMPTEOffset,
+			uint32(len(s.Loops)), uint32(len(s.Data)),
+		})
+		for _, l := range s.Loops {
+			binary.Write(w, le, &l)
+		}
+		w.Write(s.Data)
+		chunks = append(chunks, Chunk{[4]byte{'s', 'm', 'p', 'l'}, w.Bytes()})
+	}
+
+	return chunks
+}
+
+func writeSubchunk(w *bytes.Buffer, id [4]byte, b []byte) {
+	w.Write(id[:])
+	binary.Write(w, binary.LittleEndian, uint32(len(b)))
+	w.Write(b)
+	if len(b)&1 != 0 {
+		w.WriteByte(0)
+	}
+}
+
+func cstring(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return strings.TrimRight(string(b), " ")
+}
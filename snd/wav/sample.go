@@ -0,0 +1,259 @@
+package wav
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+func (f *File) Samples() (interface{}, error) {
+	s, err := DecodeSamples(&f.Format, f.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	// block codecs pad the last block, the fact chunk has the real length
+	if p, ok := s.([]int16); ok && f.Format.FramesPerBlock() > 1 && f.SampleLength != 0 {
+		if n := f.SampleLength * uint64(f.Format.Channels); n < uint64(len(p)) {
+			s = p[:n]
+		}
+	}
+	return s, nil
+}
+
+func (f *File) SetSamples(s interface{}) error {
+	b, err := EncodeSamples(&f.Format, s)
+	if err != nil {
+		return err
+	}
+	f.Data = b
+	if p, ok := s.([]int16); ok && f.Format.FramesPerBlock() > 1 {
+		f.SampleLength = uint64(len(p) / int(f.Format.Channels))
+	}
+	return nil
+}
+
+func (f *File) Uint8() ([]uint8, error) {
+	s, err := f.samples(FORMAT_PCM, 1)
+	if err != nil {
+		return nil, err
+	}
+	return s.([]uint8), nil
+}
+
+// Int16 returns 16-bit PCM data as is and decodes the G.711 and ADPCM codecs.
+func (f *File) Int16() ([]int16, error) {
+	var (
+		s   interface{}
+		err error
+	)
+	switch f.Format.Tag() {
+	case FORMAT_ALAW, FORMAT_MULAW, FORMAT_IMA_ADPCM, FORMAT_ADPCM:
+		s, err = f.Samples()
+	default:
+		s, err = f.samples(FORMAT_PCM, 2)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.([]int16), nil
+}
+
+func (f *File) Int24() ([]int32, error) {
+	s, err := f.samples(FORMAT_PCM, 3)
+	if err != nil {
+		return nil, err
+	}
+	return s.([]int32), nil
+}
+
+func (f *File) Int32() ([]int32, error) {
+	s, err := f.samples(FORMAT_PCM, 4)
+	if err != nil {
+		return nil, err
+	}
+	return s.([]int32), nil
+}
+
+func (f *File) Float64() ([]float64, error) {
+	s, err := f.samples(FORMAT_IEEE_FLOAT, 8)
+	if err != nil {
+		return nil, err
+	}
+	return s.([]float64), nil
+}
+
+// Float32 converts any supported sample format to float32 in [-1, 1].
+func (f *File) Float32() ([]float32, error) {
+	s, err := f.Samples()
+	if err != nil {
+		return nil, err
+	}
+	return ToFloat32(s, f.Format.SampleBytes()), nil
+}
+
+func (f *File) samples(tag uint16, size int) (interface{}, error) {
+	if f.Format.Tag() != tag || f.Format.SampleBytes() != size {
+		return nil, fmt.Errorf("wav: sample format is %d-bit tag %#x, not %d-bit tag %#x",
+			f.Format.SampleBytes()*8, f.Format.Tag(), size*8, tag)
+	}
+	return f.Samples()
+}
+
+func DecodeSamples(ft *Format, b []byte) (interface{}, error) {
+	le := binary.LittleEndian
+	size := ft.SampleBytes()
+	if size == 0 {
+		return nil, ErrFormat
+	}
+	n := len(b) / size
+
+	switch ft.Tag() {
+	case FORMAT_ALAW:
+		return decodeG711(b, ALawDecode), nil
+	case FORMAT_MULAW:
+		return decodeG711(b, MuLawDecode), nil
+	case FORMAT_IMA_ADPCM:
+		return decodeIMAADPCM(ft, b)
+	case FORMAT_ADPCM:
+		return decodeMSADPCM(ft, b)
+	case FORMAT_PCM:
+		switch size {
+		case 1:
+			return append([]uint8{}, b[:n]...), nil
+		case 2:
+			s := make([]int16, n)
+			for i := range s {
+				s[i] = int16(le.Uint16(b[i*2:]))
+			}
+			return s, nil
+		case 3:
+			s := make([]int32, n)
+			for i := range s {
+				p := b[i*3:]
+				s[i] = int32(uint32(p[0])<<8|uint32(p[1])<<16|uint32(p[2])<<24) >> 8
+			}
+			return s, nil
+		case 4:
+			s := make([]int32, n)
+			for i := range s {
+				s[i] = int32(le.Uint32(b[i*4:]))
+			}
+			return s, nil
+		}
+
+	case FORMAT_IEEE_FLOAT:
+		switch size {
+		case 4:
+			s := make([]float32, n)
+			for i := range s {
+				s[i] = math.Float32frombits(le.Uint32(b[i*4:]))
+			}
+			return s, nil
+		case 8:
+			s := make([]float64, n)
+			for i := range s {
+				s[i] = math.Float64frombits(le.Uint64(b[i*8:]))
+			}
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%v: %d-bit tag %#x", ErrUnsupported, size*8, ft.Tag())
+}
+
+func EncodeSamples(ft *Format, s interface{}) ([]byte, error) {
+	le := binary.LittleEndian
+	size := ft.SampleBytes()
+	tag := ft.Tag()
+
+	var b []byte
+	switch s := s.(type) {
+	case []uint8:
+		if tag == FORMAT_PCM && size == 1 {
+			b = append(make([]byte, 0, len(s)), s...)
+		}
+	case []int16:
+		switch {
+		case tag == FORMAT_PCM && size == 2:
+			b = make([]byte, len(s)*2)
+			for i := range s {
+				le.PutUint16(b[i*2:], uint16(s[i]))
+			}
+		case tag == FORMAT_ALAW:
+			b = encodeG711(s, ALawEncode)
+		case tag == FORMAT_MULAW:
+			b = encodeG711(s, MuLawEncode)
+		case tag == FORMAT_IMA_ADPCM:
+			return encodeIMAADPCM(ft, s)
+		case tag == FORMAT_ADPCM:
+			return encodeMSADPCM(ft, s)
+		}
+	case []int32:
+		if tag == FORMAT_PCM && size == 3 {
+			b = make([]byte, len(s)*3)
+			for i := range s {
+				b[i*3], b[i*3+1], b[i*3+2] = byte(s[i]), byte(s[i]>>8), byte(s[i]>>16)
+			}
+		} else if tag == FORMAT_PCM && size == 4 {
+			b = make([]byte, len(s)*4)
+			for i := range s {
+				le.PutUint32(b[i*4:], uint32(s[i]))
+			}
+		}
+	case []float32:
+		if tag == FORMAT_IEEE_FLOAT && size == 4 {
+			b = make([]byte, len(s)*4)
+			for i := range s {
+				le.PutUint32(b[i*4:], math.Float32bits(s[i]))
+			}
+		}
+	case []float64:
+		if tag == FORMAT_IEEE_FLOAT && size == 8 {
+			b = make([]byte, len(s)*8)
+			for i := range s {
+				le.PutUint64(b[i*8:], math.Float64bits(s[i]))
+			}
+		}
+	}
+
+	if b == nil {
+		return nil, fmt.Errorf("%v: cannot store %T as %d-bit tag %#x", ErrUnsupported, s, size*8, tag)
+	}
+	return b, nil
+}
+
+// ToFloat32 normalizes a sample slice returned by DecodeSamples, size is the
+// container width in bytes to distinguish 24-bit from 32-bit integer data.
+func ToFloat32(s interface{}, size int) []float32 {
+	var p []float32
+	switch s := s.(type) {
+	case []uint8:
+		p = make([]float32, len(s))
+		for i := range s {
+			p[i] = (float32(s[i]) - 128) / 128
+		}
+	case []int16:
+		p = make([]float32, len(s))
+		for i := range s {
+			p[i] = float32(s[i]) / (1 << 15)
+		}
+	case []int32:
+		scale := float32(1 << 31)
+		if size == 3 {
+			scale = 1 << 23
+		}
+		p = make([]float32, len(s))
+		for i := range s {
+			p[i] = float32(s[i]) / scale
+		}
+	case []float32:
+		p = append(p, s...)
+	case []float64:
+		p = make([]float32, len(s))
+		for i := range s {
+			p[i] = float32(s[i])
+		}
+	}
+	return p
+}
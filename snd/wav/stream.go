@@ -0,0 +1,356 @@
+package wav
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/qeedquan/go-media/math/mathutil"
+)
+
+var (
+	ErrSeek   = errors.New("wav: underlying stream is not seekable")
+	ErrClosed = errors.New("wav: encoder is closed")
+)
+
+type Decoder struct {
+	f       *File
+	r       *reader
+	dataOff int64
+	size    uint64
+	pos     uint64
+}
+
+func NewDecoder(r io.Reader) (*Decoder, error) {
+	rd := &reader{r: r}
+	size, err := rd.readHeader()
+	if err != nil {
+		return nil, err
+	}
+	return &Decoder{
+		f:       rd.f,
+		r:       rd,
+		dataOff: rd.off,
+		size:    size,
+	}, nil
+}
+
+func (d *Decoder) Header() *File {
+	return d.f
+}
+
+func (d *Decoder) Format() *Format {
+	return &d.f.Format
+}
+
+func (d *Decoder) Frames() uint64 {
+	if d.f.SampleLength != 0 {
+		return d.f.SampleLength
+	}
+	if d.size == unknownSize || d.f.Format.BlockAlign == 0 {
+		return 0
+	}
+	return d.size / uint64(d.f.Format.BlockAlign) * uint64(d.f.Format.FramesPerBlock())
+}
+
+func (d *Decoder) Tell() uint64 {
+	if d.f.Format.BlockAlign == 0 {
+		return 0
+	}
+	return d.pos / uint64(d.f.Format.BlockAlign) * uint64(d.f.Format.FramesPerBlock())
+}
+
+func (d *Decoder) Read(p []byte) (int, error) {
+	if d.size != unknownSize {
+		if d.pos >= d.size {
+			return 0, io.EOF
+		}
+		if n := d.size - d.pos; uint64(len(p)) > n {
+			p = p[:n]
+		}
+	}
+	n, err := d.r.r.Read(p)
+	d.pos += uint64(n)
+	d.r.off += int64(n)
+	if err == io.EOF && d.size != unknownSize && d.pos < d.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// ReadSamples reads up to n frames and returns them in the same
+// representation as DecodeSamples, returning io.EOF once the data is
+// exhausted. Block codecs are read in whole blocks, so n is rounded up
+// to a multiple of Format.FramesPerBlock.
+func (d *Decoder) ReadSamples(n int) (interface{}, error) {
+	ba := int(d.f.Format.BlockAlign)
+	if ba == 0 {
+		return nil, ErrFormat
+	}
+
+	fpb := d.f.Format.FramesPerBlock()
+	start := d.Tell()
+	b := make([]byte, (n+fpb-1)/fpb*ba)
+	m, err := io.ReadFull(d, b)
+	if m == 0 && err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return nil, err
+	}
+	if fpb == 1 {
+		m -= m % ba
+	}
+
+	s, err := DecodeSamples(&d.f.Format, b[:m])
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := s.([]int16); ok && fpb > 1 && d.f.SampleLength != 0 {
+		ch := uint64(d.f.Format.Channels)
+		if start+uint64(len(p))/ch > d.f.SampleLength {
+			s = p[:(d.f.SampleLength-mathutil.Min64(start, d.f.SampleLength))*ch]
+		}
+	}
+	return s, nil
+}
+
+// Seek positions the decoder at a sample frame, not a byte offset. Block
+// codecs can only seek to the start of a block, the frame actually reached
+// is returned.
+func (d *Decoder) Seek(offset int64, whence int) (int64, error) {
+	s, ok := d.r.r.(io.Seeker)
+	if !ok {
+		return 0, ErrSeek
+	}
+
+	ba := int64(d.f.Format.BlockAlign)
+	if ba == 0 {
+		return 0, ErrFormat
+	}
+	fpb := int64(d.f.Format.FramesPerBlock())
+
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += int64(d.Tell())
+	case io.SeekEnd:
+		if d.size == unknownSize {
+			return 0, fmt.Errorf("wav: cannot seek from end of stream with unknown length")
+		}
+		offset += int64(d.Frames())
+	default:
+		return 0, fmt.Errorf("wav: invalid seek whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("wav: seek to negative frame %d", offset)
+	}
+
+	pos := uint64(offset / fpb * ba)
+	if d.size != unknownSize && pos > d.size {
+		pos = d.size - d.size%uint64(ba)
+	}
+	off, err := s.Seek(d.dataOff+int64(pos), io.SeekStart)
+	if err != nil {
+		return 0, err
+	}
+	d.r.off = off
+	d.pos = pos
+	return int64(pos) / ba * fpb, nil
+}
+
+type Encoder struct {
+	w         io.Writer
+	b         *bufio.Writer
+	f         *File
+	container [4]byte
+	factOff   int64
+	dataOff   int64
+	size      uint64
+	frames    uint64
+	written   uint64
+	pending   []int16
+	err       error
+	closed    bool
+}
+
+// NewEncoder writes the header of f and returns an encoder that appends
+// samples to its data chunk. Sizes are patched on Close when w is an
+// io.WriteSeeker, a JUNK chunk is reserved so the file can be upgraded to
+// RF64 if the data grows past 4GB.
+func NewEncoder(w io.Writer, f *File) (*Encoder, error) {
+	e := &Encoder{
+		w:         w,
+		b:         bufio.NewWriter(w),
+		f:         f,
+		container: f.Container,
+	}
+	if e.container != RF64 && e.container != BW64 {
+		e.container = RIFF
+	}
+
+	b := e.b
+	writeChunkHeader(b, e.container, math.MaxUint32)
+	b.WriteString("WAVE")
+	if e.container == RIFF {
+		writeChunk(b, [4]byte{'J', 'U', 'N', 'K'}, make([]byte, ds64Size))
+	} else {
+		writeDS64(b, 0, 0, 0)
+	}
+
+	off := int64(12 + 8 + ds64Size)
+	for _, c := range f.headerChunks(0) {
+		if string(c.ID[:]) == "fact" {
+			e.factOff = off + 8
+		}
+		writeChunk(b, c.ID, c.Data)
+		off += 8 + int64(pad(uint64(len(c.Data))))
+	}
+	writeChunkHeader(b, [4]byte{'d', 'a', 't', 'a'}, math.MaxUint32)
+	e.dataOff = off + 8
+
+	if err := b.Flush(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *Encoder) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, ErrClosed
+	}
+	if e.err != nil {
+		return 0, e.err
+	}
+	n, err := e.b.Write(p)
+	e.size += uint64(n)
+	e.err = err
+	return n, err
+}
+
+func (e *Encoder) WriteSamples(s interface{}) error {
+	ft := &e.f.Format
+	fpb := ft.FramesPerBlock()
+	if p, ok := s.([]int16); ok && fpb > 1 {
+		// hold back partial blocks until more samples arrive or Close
+		e.written += uint64(len(p)) / uint64(ft.Channels)
+		e.pending = append(e.pending, p...)
+		n := len(e.pending) / (fpb * int(ft.Channels)) * fpb * int(ft.Channels)
+		if n == 0 {
+			return nil
+		}
+		b, err := EncodeSamples(ft, e.pending[:n])
+		if err != nil {
+			return err
+		}
+		e.pending = append(e.pending[:0], e.pending[n:]...)
+		_, err = e.Write(b)
+		return err
+	}
+
+	b, err := EncodeSamples(ft, s)
+	if err != nil {
+		return err
+	}
+	_, err = e.Write(b)
+	return err
+}
+
+// SetFrames overrides the frame count recorded in the fact chunk, needed
+// for compressed formats where it is not a function of the data size.
+func (e *Encoder) SetFrames(n uint64) {
+	e.frames = n
+}
+
+func (e *Encoder) Close() error {
+	if e.closed {
+		return nil
+	}
+	if len(e.pending) > 0 {
+		b, err := EncodeSamples(&e.f.Format, e.pending)
+		if err != nil {
+			return err
+		}
+		e.pending = nil
+		e.Write(b)
+	}
+	e.closed = true
+	if e.err != nil {
+		return e.err
+	}
+
+	if e.size&1 != 0 {
+		e.b.WriteByte(0)
+	}
+	if err := e.b.Flush(); err != nil {
+		return err
+	}
+
+	s, ok := e.w.(io.WriteSeeker)
+	if !ok {
+		return nil
+	}
+
+	frames := e.frames
+	if frames == 0 {
+		frames = e.written
+	}
+	if frames == 0 && e.f.Format.BlockAlign != 0 {
+		frames = e.size / uint64(e.f.Format.BlockAlign)
+	}
+	riffSize := uint64(e.dataOff) - 8 + pad(e.size)
+
+	le := binary.LittleEndian
+	b := make([]byte, 8+ds64Size)
+	if e.container == RIFF && riffSize <= math.MaxUint32 {
+		le.PutUint32(b, uint32(riffSize))
+		if err := writeAt(s, b[:4], 4); err != nil {
+			return err
+		}
+		le.PutUint32(b, uint32(e.size))
+		if err := writeAt(s, b[:4], e.dataOff-4); err != nil {
+			return err
+		}
+	} else {
+		container := e.container
+		if container == RIFF {
+			container = RF64
+		}
+		if err := writeAt(s, container[:], 0); err != nil {
+			return err
+		}
+		copy(b, "ds64")
+		le.PutUint32(b[4:], ds64Size)
+		le.PutUint64(b[8:], riffSize)
+		le.PutUint64(b[16:], e.size)
+		le.PutUint64(b[24:], frames)
+		le.PutUint32(b[32:], 0)
+		if err := writeAt(s, b, 12); err != nil {
+			return err
+		}
+	}
+
+	if e.factOff != 0 {
+		if frames > math.MaxUint32 {
+			frames = math.MaxUint32
+		}
+		le.PutUint32(b, uint32(frames))
+		if err := writeAt(s, b[:4], e.factOff); err != nil {
+			return err
+		}
+	}
+
+	_, err := s.Seek(0, io.SeekEnd)
+	return err
+}
+
+func writeAt(s io.WriteSeeker, b []byte, off int64) error {
+	if _, err := s.Seek(off, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := s.Write(b)
+	return err
+}
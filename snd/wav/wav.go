@@ -2,9 +2,12 @@ package wav
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 )
 
 const (
@@ -31,64 +34,293 @@ const (
 	FORMAT_EXTENSIBLE        = 0xfffe
 )
 
-type Header struct {
-	RIFF          [4]byte
-	Size          uint32
-	WAVE          [4]byte
-	Fmt           [4]byte
-	FmtSize       uint32
+var (
+	ErrFormat      = errors.New("wav: invalid format")
+	ErrUnsupported = errors.New("wav: unsupported sample format")
+)
+
+var (
+	RIFF = [4]byte{'R', 'I', 'F', 'F'}
+	RF64 = [4]byte{'R', 'F', '6', '4'}
+	BW64 = [4]byte{'B', 'W', '6', '4'}
+)
+
+type GUID [16]byte
+
+type Format struct {
 	Format        uint16
 	Channels      uint16
 	SampleRate    uint32
 	ByteRate      uint32
 	BlockAlign    uint16
 	BitsPerSample uint16
-	Data          [4]byte
-	DataSize      uint32
+
+	// WAVE_FORMAT_EXTENSIBLE fields, ValidBits doubles as
+	// SamplesPerBlock for compressed formats
+	ValidBits   uint16
+	ChannelMask uint32
+	SubFormat   GUID
+
+	// codec specific bytes following the standard cbSize fields
+	Extra []byte
+}
+
+type Chunk struct {
+	ID   [4]byte
+	Data []byte
 }
 
 type File struct {
-	Header  Header
-	Samples []uint8
+	Container    [4]byte
+	Format       Format
+	SampleLength uint64
+	Info         []InfoItem
+	Cues         []CuePoint
+	Sampler      *Sampler
+	Chunks       []Chunk
+	Data         []byte
+}
+
+func NewPCMFormat(channels, sampleRate, bits int) Format {
+	return newFormat(FORMAT_PCM, channels, sampleRate, bits)
+}
+
+func NewFloatFormat(channels, sampleRate, bits int) Format {
+	return newFormat(FORMAT_IEEE_FLOAT, channels, sampleRate, bits)
+}
+
+func newFormat(tag, channels, sampleRate, bits int) Format {
+	f := Format{
+		Format:        uint16(tag),
+		Channels:      uint16(channels),
+		SampleRate:    uint32(sampleRate),
+		BitsPerSample: uint16(bits),
+		BlockAlign:    uint16(channels * ((bits + 7) / 8)),
+	}
+	f.ByteRate = f.SampleRate * uint32(f.BlockAlign)
+	return f
+}
+
+func FormatGUID(tag uint16) GUID {
+	return GUID{
+		byte(tag), byte(tag >> 8), 0x00, 0x00,
+		0x00, 0x00, 0x10, 0x00,
+		0x80, 0x00, 0x00, 0xaa, 0x00, 0x38, 0x9b, 0x71,
+	}
+}
+
+func (f *Format) Tag() uint16 {
+	if f.Format == FORMAT_EXTENSIBLE {
+		return binary.LittleEndian.Uint16(f.SubFormat[:])
+	}
+	return f.Format
+}
+
+func (f *Format) SampleBytes() int {
+	if f.Channels == 0 {
+		return 0
+	}
+	return int(f.BlockAlign) / int(f.Channels)
+}
+
+func (f *Format) decode(b []byte) error {
+	if len(b) < 16 {
+		return fmt.Errorf("wav: fmt chunk too short (%d bytes)", len(b))
+	}
+	le := binary.LittleEndian
+	f.Format = le.Uint16(b[0:])
+	f.Channels = le.Uint16(b[2:])
+	f.SampleRate = le.Uint32(b[4:])
+	f.ByteRate = le.Uint32(b[8:])
+	f.BlockAlign = le.Uint16(b[12:])
+	f.BitsPerSample = le.Uint16(b[14:])
+	if f.Format == FORMAT_PCM && f.BitsPerSample == 0 && f.Channels != 0 {
+		f.BitsPerSample = f.BlockAlign / f.Channels * 8
+	}
+	if len(b) < 18 {
+		return nil
+	}
+
+	n := int(le.Uint16(b[16:]))
+	b = b[18:]
+	if n > len(b) {
+		n = len(b)
+	}
+	b = b[:n]
+
+	if f.Format == FORMAT_EXTENSIBLE {
+		if len(b) < 22 {
+			return fmt.Errorf("wav: extensible fmt chunk too short (%d bytes)", len(b))
+		}
+		f.ValidBits = le.Uint16(b[0:])
+		f.ChannelMask = le.Uint32(b[2:])
+		copy(f.SubFormat[:], b[6:22])
+		b = b[22:]
+	} else if len(b) >= 2 && f.Format != FORMAT_PCM && f.Format != FORMAT_IEEE_FLOAT {
+		f.ValidBits = le.Uint16(b[0:])
+		b = b[2:]
+	}
+	if len(b) > 0 {
+		f.Extra = append([]byte{}, b...)
+	}
+	return nil
+}
+
+func (f *Format) encode() []byte {
+	w := new(bytes.Buffer)
+	le := binary.LittleEndian
+	binary.Write(w, le, []uint16{f.Format, f.Channels})
+	binary.Write(w, le, []uint32{f.SampleRate, f.ByteRate})
+	binary.Write(w, le, []uint16{f.BlockAlign, f.BitsPerSample})
+
+	switch {
+	case f.Format == FORMAT_EXTENSIBLE:
+		binary.Write(w, le, uint16(22+len(f.Extra)))
+		binary.Write(w, le, f.ValidBits)
+		binary.Write(w, le, f.ChannelMask)
+		w.Write(f.SubFormat[:])
+		w.Write(f.Extra)
+	case f.Format == FORMAT_PCM:
+	case f.Format == FORMAT_IEEE_FLOAT:
+		binary.Write(w, le, uint16(len(f.Extra)))
+		w.Write(f.Extra)
+	default:
+		binary.Write(w, le, uint16(2+len(f.Extra)))
+		binary.Write(w, le, f.ValidBits)
+		w.Write(f.Extra)
+	}
+	return w.Bytes()
+}
+
+// Frames returns the number of sample frames, preferring the fact chunk
+// length for compressed formats where it cannot be derived from the data size.
+func (f *File) Frames() uint64 {
+	if f.SampleLength != 0 {
+		return f.SampleLength
+	}
+	if f.Format.BlockAlign == 0 {
+		return 0
+	}
+	return uint64(len(f.Data)) / uint64(f.Format.BlockAlign) * uint64(f.Format.FramesPerBlock())
 }
 
 func Decode(r io.Reader) (*File, error) {
-	f := &File{}
-	h := &f.Header
-	err := binary.Read(r, binary.LittleEndian, h)
+	d := &reader{r: bufio.NewReader(r)}
+	size, err := d.readHeader()
 	if err != nil {
 		return nil, err
 	}
-	if h.RIFF != [4]byte{'R', 'I', 'F', 'F'} {
-		return nil, fmt.Errorf("invalid riff header")
+	f := d.f
+
+	// the size comes from the header, so read through a limit instead of
+	// allocating it up front
+	if size == unknownSize {
+		f.Data, err = io.ReadAll(d.r)
+	} else {
+		n := int64(math.MaxInt64)
+		if size < math.MaxInt64 {
+			n = int64(size)
+		}
+		f.Data, err = io.ReadAll(io.LimitReader(d.r, n))
+		if err == nil && uint64(len(f.Data)) < size {
+			err = io.ErrUnexpectedEOF
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("wav: failed to read data chunk: %v", err)
 	}
-	if h.WAVE != [4]byte{'W', 'A', 'V', 'E'} {
-		return nil, fmt.Errorf("invalid wave header")
+	if size != unknownSize && size&1 != 0 {
+		d.skip(1)
 	}
 
-	f.Samples, err = io.ReadAll(r)
-	if err != nil {
-		return nil, err
+	// metadata chunks are allowed after the data chunk, but many writers
+	// leave truncated trailers, so errors here are not fatal
+	for {
+		id, size, err := d.readChunkHeader()
+		if err != nil {
+			break
+		}
+		if err := d.readChunk(id, size); err != nil {
+			break
+		}
 	}
 
 	return f, nil
 }
 
+func DecodeConfig(r io.Reader) (Format, error) {
+	d := &reader{r: r}
+	if _, err := d.readHeader(); err != nil {
+		return Format{}, err
+	}
+	return d.f.Format, nil
+}
+
 func Encode(f *File, w io.Writer) error {
-	h := f.Header
-	h.RIFF = [4]byte{'R', 'I', 'F', 'F'}
-	h.WAVE = [4]byte{'W', 'A', 'V', 'E'}
-	h.Fmt = [4]byte{'f', 'm', 't', ' '}
-	h.Data = [4]byte{'d', 'a', 't', 'a'}
-
-	h.FmtSize = 16
-	h.DataSize = uint32(len(f.Samples))
-	h.Size = 4 + (8 + h.FmtSize) + (8 + h.DataSize)
-	h.ByteRate = h.SampleRate * uint32(h.Channels) * uint32(h.BitsPerSample/8)
-	h.BlockAlign = h.Channels * (h.BitsPerSample / 8)
+	chunks := f.headerChunks(f.Frames())
+
+	size := uint64(4)
+	for _, c := range chunks {
+		size += 8 + pad(uint64(len(c.Data)))
+	}
+	size += 8 + pad(uint64(len(f.Data)))
+
+	container := f.Container
+	if container != RF64 && container != BW64 {
+		container = RIFF
+	}
+	if size+8+ds64Size > math.MaxUint32 && container == RIFF {
+		container = RF64
+	}
 
 	b := bufio.NewWriter(w)
-	binary.Write(b, binary.LittleEndian, &h)
-	binary.Write(b, binary.LittleEndian, f.Samples)
+	if container == RIFF {
+		writeChunkHeader(b, RIFF, uint32(size))
+		b.WriteString("WAVE")
+	} else {
+		size += 8 + ds64Size
+		writeChunkHeader(b, container, math.MaxUint32)
+		b.WriteString("WAVE")
+		writeDS64(b, size, uint64(len(f.Data)), f.Frames())
+	}
+
+	for _, c := range chunks {
+		writeChunk(b, c.ID, c.Data)
+	}
+
+	n := uint32(len(f.Data))
+	if container != RIFF {
+		n = math.MaxUint32
+	}
+	writeChunkHeader(b, [4]byte{'d', 'a', 't', 'a'}, n)
+	b.Write(f.Data)
+	if len(f.Data)&1 != 0 {
+		b.WriteByte(0)
+	}
+
 	return b.Flush()
 }
+
+func (f *File) headerChunks(frames uint64) []Chunk {
+	var chunks []Chunk
+
+	fc := Chunk{ID: [4]byte{'f', 'm', 't', ' '}, Data: f.Format.encode()}
+	chunks = append(chunks, fc)
+
+	tag := f.Format.Tag()
+	if f.SampleLength != 0 || (tag != FORMAT_PCM && tag != FORMAT_IEEE_FLOAT) {
+		if frames > math.MaxUint32 {
+			frames = math.MaxUint32
+		}
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, uint32(frames))
+		chunks = append(chunks, Chunk{ID: [4]byte{'f', 'a', 'c', 't'}, Data: b})
+	}
+	chunks = append(chunks, f.metaChunks()...)
+	chunks = append(chunks, f.Chunks...)
+	return chunks
+}
+
+func pad(n uint64) uint64 {
+	return n + n&1
+}